@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,16 +12,32 @@ import (
 	"passive-rec/internal/core/analysis"
 )
 
+// errStopStream corta la lectura cuando el consumidor del iterador termina.
+var errStopStream = errors.New("stop")
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <directory>\n", os.Args[0])
+	writeCSV := flag.Bool("csv", false, "Escribe también artifacts.csv junto a los informes")
+	validate := flag.Bool("validate", false, "Solo comprueba que artifacts.jsonl cumple el formato v2 (sale con código 1 si no)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-csv] [-validate] <directory>\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Example: %s rezasa_com/\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	dir := os.Args[1]
+	dir := flag.Arg(0)
 	artifactsPath := filepath.Join(dir, "artifacts.jsonl")
 
+	if *validate {
+		if err := validateArtifacts(artifactsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "%s no es válido:\n%v\n", artifactsPath, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ %s es válido\n", artifactsPath)
+		return
+	}
+
 	// Leer artifacts
 	fmt.Printf("Reading artifacts from: %s\n", artifactsPath)
 
@@ -36,34 +54,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Obtener header (si está disponible)
+	// Obtener header (si está disponible). Podemos inferir el target del
+	// nombre del directorio.
 	var header artifacts.HeaderV2
+	header.Target = filepath.Base(dir)
 
-	// Leer todos los artifacts
+	// Leer los artifacts en streaming: el analizador los recibe de uno en uno
+	// sin un slice intermedio. Solo se acumulan si hay que escribir el CSV.
+	var loaded int
 	var arts []artifacts.Artifact
-	for {
-		art, err := reader.ReadArtifact()
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
+	stream := func(yield func(artifacts.Artifact) bool) {
+		err := reader.Each(func(art artifacts.Artifact) error {
+			loaded++
+			if *writeCSV {
+				arts = append(arts, art)
+			}
+			if !yield(art) {
+				return errStopStream
 			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopStream) {
 			fmt.Fprintf(os.Stderr, "Error reading artifact: %v\n", err)
-			break
 		}
-		arts = append(arts, art)
 	}
 
-	// Intentar obtener información básica del primer artifact
-	if len(arts) > 0 {
-		// Podemos inferir el target del nombre del directorio
-		header.Target = filepath.Base(dir)
-	}
-
-	fmt.Printf("Loaded %d artifacts\n\n", len(arts))
-
 	// Crear analizador con opciones por defecto
 	opts := analysis.DefaultAnalysisOptions()
-	analyzer := analysis.NewAnalyzer(arts, header, opts)
+	analyzer := analysis.NewAnalyzerFromIterator(stream, header, opts)
+
+	fmt.Printf("Loaded %d artifacts\n\n", loaded)
 
 	// Ejecutar análisis
 	fmt.Println("Running analysis...")
@@ -99,6 +119,15 @@ func main() {
 		}
 	}
 
+	if *writeCSV {
+		csvPath := filepath.Join(dir, "artifacts.csv")
+		if err := writeArtifactsCSV(csvPath, arts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		} else {
+			fmt.Printf("✓ CSV saved to: %s\n", csvPath)
+		}
+	}
+
 	// Imprimir resumen
 	fmt.Println("\n=== SUMMARY ===")
 	fmt.Printf("Total Artifacts: %d\n", report.Summary.TotalArtifacts)
@@ -144,3 +173,26 @@ func main() {
 
 	fmt.Println("\nDone!")
 }
+
+// validateArtifacts comprueba el formato de path con artifacts.Validate.
+func validateArtifacts(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return artifacts.Validate(f)
+}
+
+// writeArtifactsCSV vuelca los artefactos en path con artifacts.WriteCSV.
+func writeArtifactsCSV(path string, arts []artifacts.Artifact) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := artifacts.WriteCSV(f, arts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
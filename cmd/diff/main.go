@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run compara dos artifacts.jsonl y escribe en stdout los artefactos nuevos,
+// eliminados y modificados, como resumen legible o como JSON (-json).
+func run(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	oldPath := fs.String("old", "", "Manifiesto anterior (artifacts.jsonl o directorio que lo contiene)")
+	newPath := fs.String("new", "", "Manifiesto reciente (artifacts.jsonl o directorio que lo contiene)")
+	asJSON := fs.Bool("json", false, "Escribe la diferencia como JSON en vez del resumen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	before := manifestPath(*oldPath)
+	after := manifestPath(*newPath)
+	if before == "" || after == "" {
+		return errors.New("-old y -new son obligatorios")
+	}
+
+	diff, err := artifacts.DiffFiles(before, after)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return diff.WriteJSON(os.Stdout)
+	}
+	return diff.WriteSummary(os.Stdout)
+}
+
+// manifestPath admite tanto el fichero como el directorio de salida de una
+// ejecución.
+func manifestPath(raw string) string {
+	path := strings.TrimSpace(raw)
+	if path == "" {
+		return ""
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, "artifacts.jsonl")
+	}
+	return path
+}
@@ -27,7 +27,7 @@ func main() {
 		os.Exit(1)
 	}
 	if cfg.Proxy != "" {
-		logx.Info("Proxy configurado", logx.Fields{"proxy": cfg.Proxy})
+		logx.Info("Proxy configurado", logx.Fields{"proxy": config.RedactProxy(cfg.Proxy)})
 	}
 	if err := config.ConfigureRootCAs(cfg.ProxyCACert); err != nil {
 		logx.Error("Error configurando certificado CA", logx.Fields{"error": err.Error()})
@@ -36,17 +36,21 @@ func main() {
 	if cfg.ProxyCACert != "" {
 		logx.Info("Certificado CA cargado", logx.Fields{"path": cfg.ProxyCACert})
 	}
-	logx.Info("Iniciando passive-rec", logx.Fields{
+	startFields := logx.Fields{
 		"target":  cfg.Target,
 		"outdir":  cfg.OutDir,
 		"tools":   cfg.Tools,
 		"workers": cfg.Workers,
 		"active":  cfg.Active,
 		"report":  cfg.Report,
-	})
+	}
+	if cfg.TargetsFile != "" {
+		startFields["targets_file"] = cfg.TargetsFile
+	}
+	logx.Info("Iniciando passive-rec", startFields)
 
-	if cfg.Target == "" {
-		fmt.Fprintln(os.Stderr, "uso: -target example.com")
+	if cfg.Target == "" && cfg.TargetsFile == "" {
+		fmt.Fprintln(os.Stderr, "uso: -target example.com | -targets-file targets.txt")
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
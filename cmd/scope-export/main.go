@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run filtra un artifacts.jsonl por scope y escribe el resultado en -out, como
+// manifiesto v2 (-format jsonl), como sitemap XML de Burp Suite (-format burp)
+// o como lista plana de URLs de rutas (-format urls).
+func run(args []string) error {
+	fs := flag.NewFlagSet("scope-export", flag.ContinueOnError)
+	in := fs.String("in", "", "Manifiesto de entrada (artifacts.jsonl o directorio que lo contiene)")
+	out := fs.String("out", "", "Ruta del manifiesto filtrado")
+	target := fs.String("target", "", "Dominio o IP que define el scope")
+	scopeMode := fs.String("scope", "subdomains", "Modo de scope: 'subdomains' o 'domain'")
+	scopeFile := fs.String("scope-file", "", "Fichero con un dominio, IP o CIDR por línea (sustituye a -target)")
+	format := fs.String("format", "jsonl", "Formato de salida: 'jsonl' (manifiesto v2), 'burp' (sitemap XML de Burp Suite con las rutas) o 'urls' (urls.txt con las URLs únicas de las rutas)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	src := strings.TrimSpace(*in)
+	dst := strings.TrimSpace(*out)
+	if src == "" || dst == "" {
+		return errors.New("-in y -out son obligatorios")
+	}
+	if info, err := os.Stat(src); err == nil && info.IsDir() {
+		src = filepath.Join(src, "artifacts.jsonl")
+	}
+
+	var scope *netutil.Scope
+	switch {
+	case strings.TrimSpace(*scopeFile) != "":
+		loaded, err := netutil.NewScopeFromFile(strings.TrimSpace(*scopeFile), *scopeMode)
+		if err != nil {
+			return err
+		}
+		scope = loaded
+	case strings.TrimSpace(*target) != "":
+		scope = netutil.NewScope(*target, *scopeMode)
+		if scope == nil {
+			return fmt.Errorf("target inválido %q", *target)
+		}
+	default:
+		return errors.New("indica -target o -scope-file")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(*format)) {
+	case "jsonl":
+	case "burp":
+		count, err := artifacts.ExportBurpSitemap(src, dst, scope)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d rutas exportadas a %s (sitemap de Burp Suite)\n", count, dst)
+		return nil
+	case "urls":
+		count, err := artifacts.ExportURLList(src, dst, scope)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d URLs exportadas a %s\n", count, dst)
+		return nil
+	default:
+		return fmt.Errorf("formato desconocido %q (jsonl, burp o urls)", *format)
+	}
+
+	kept, dropped, err := artifacts.ExportInScope(src, dst, scope)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d artefactos exportados a %s (%d fuera de scope descartados)\n", kept, dst, dropped)
+	return nil
+}
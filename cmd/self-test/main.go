@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"passive-rec/internal/adapters/sources"
+	"passive-rec/internal/core/runner"
+)
+
+var (
+	// Permite inyección en tests.
+	findBin = runner.FindBin
+	runCmd  = runner.RunCommandWithDir
+)
+
+// versionArgs son los argumentos con los que cada herramienta imprime su
+// versión. Las que no tienen ese flag (waybackurls, assetfinder, subjs,
+// GoLinkfinderEVO) no aparecen: basta con encontrar el binario, porque
+// ejecutarlas sin argumentos válidos acaba con código de salida distinto de 0.
+var versionArgs = map[string][]string{
+	"amass":     {"--version"},
+	"subfinder": {"--version"},
+	"gau":       {"--version"},
+	"httpx":     {"--version"},
+	"dnsx":      {"--version"},
+}
+
+const defaultTools = "amass,subfinder,assetfinder,rdap,crtsh,dedupe,dnsx,waybackurls,gau,httpx,subjs,linkfinderevo"
+
+type checkStatus string
+
+const (
+	statusPass    checkStatus = "PASS"
+	statusFail    checkStatus = "FAIL"
+	statusBuiltin checkStatus = "BUILTIN"
+)
+
+type checkResult struct {
+	Tool   string
+	Binary string
+	Status checkStatus
+	Detail string
+}
+
+func main() {
+	tools := flag.String("tools", defaultTools, "Herramientas a comprobar, CSV")
+	timeout := flag.Int("timeout", 10, "Timeout por herramienta para el comando de versión (segundos)")
+	flag.Parse()
+
+	results := runSelfTest(context.Background(), splitTools(*tools), time.Duration(*timeout)*time.Second)
+	if failed := printSummary(os.Stdout, results); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func splitTools(raw string) []string {
+	seen := make(map[string]struct{})
+	var tools []string
+	for _, part := range strings.Split(raw, ",") {
+		tool := strings.ToLower(strings.TrimSpace(part))
+		if tool == "" {
+			continue
+		}
+		if _, ok := seen[tool]; ok {
+			continue
+		}
+		seen[tool] = struct{}{}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// runSelfTest comprueba, para cada herramienta, que su binario está en el PATH
+// y, si tiene flag de versión (versionArgs), que responde dentro del timeout
+// indicado.
+func runSelfTest(ctx context.Context, tools []string, timeout time.Duration) []checkResult {
+	results := make([]checkResult, 0, len(tools))
+	for _, tool := range tools {
+		results = append(results, checkTool(ctx, tool, timeout))
+	}
+	return results
+}
+
+func checkTool(ctx context.Context, tool string, timeout time.Duration) checkResult {
+	// Las herramientas sin binario externo (rdap, crtsh, dedupe, ...) se
+	// reportan como "builtin".
+	candidates, ok := sources.ToolBinaries[tool]
+	if !ok {
+		return checkResult{Tool: tool, Status: statusBuiltin, Detail: "no external binary required"}
+	}
+	bin, found := findBin(candidates...)
+	if !found {
+		return checkResult{Tool: tool, Status: statusFail, Detail: fmt.Sprintf("not found in PATH (%s)", strings.Join(candidates, ", "))}
+	}
+	args, ok := versionArgs[tool]
+	if !ok {
+		return checkResult{Tool: tool, Binary: bin, Status: statusPass, Detail: "found (no version flag)"}
+	}
+
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	lines := make(chan string)
+	firstLine := make(chan string, 1)
+	go func() {
+		var first string
+		for line := range lines {
+			if first == "" {
+				first = strings.TrimSpace(line)
+			}
+		}
+		firstLine <- first
+	}()
+	err := runCmd(runCtx, "", bin, args, lines)
+	close(lines)
+	version := <-firstLine
+
+	if err != nil {
+		detail := err.Error()
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			detail = fmt.Sprintf("%s timed out after %s", strings.Join(args, " "), timeout)
+		} else if version != "" {
+			detail += ": " + version
+		}
+		return checkResult{Tool: tool, Binary: bin, Status: statusFail, Detail: detail}
+	}
+	return checkResult{Tool: tool, Binary: bin, Status: statusPass, Detail: version}
+}
+
+// printSummary escribe una línea por herramienta y un resumen final. Devuelve
+// el número de herramientas que fallaron.
+func printSummary(w io.Writer, results []checkResult) int {
+	var passed, failed, builtin int
+	for _, res := range results {
+		switch res.Status {
+		case statusPass:
+			passed++
+		case statusFail:
+			failed++
+		case statusBuiltin:
+			builtin++
+		}
+		line := fmt.Sprintf("[%s] %s", res.Status, res.Tool)
+		if res.Binary != "" {
+			line += " (" + res.Binary + ")"
+		}
+		if res.Detail != "" {
+			line += ": " + res.Detail
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "%d passed, %d failed, %d builtin\n", passed, failed, builtin)
+	return failed
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunSelfTestReportsPassAndFail(t *testing.T) {
+	originalFind := findBin
+	originalRun := runCmd
+	t.Cleanup(func() {
+		findBin = originalFind
+		runCmd = originalRun
+	})
+
+	installed := map[string]bool{"subfinder": true, "gau": true, "httpx": true}
+	findBin = func(names ...string) (string, bool) {
+		for _, name := range names {
+			if installed[name] {
+				return name, true
+			}
+		}
+		return "", false
+	}
+	runCmd = func(ctx context.Context, dir, name string, args []string, out chan<- string) error {
+		if diff := cmp.Diff([]string{"--version"}, args); diff != "" {
+			t.Errorf("unexpected args (-want +got):\n%s", diff)
+		}
+		if name == "httpx" {
+			return errors.New("exit status 2")
+		}
+		out <- name + " v1.2.3"
+		return nil
+	}
+
+	results := runSelfTest(context.Background(), []string{"subfinder", "gau", "httpx", "amass", "crtsh"}, time.Second)
+	want := []checkResult{
+		{Tool: "subfinder", Binary: "subfinder", Status: statusPass, Detail: "subfinder v1.2.3"},
+		{Tool: "gau", Binary: "gau", Status: statusPass, Detail: "gau v1.2.3"},
+		{Tool: "httpx", Binary: "httpx", Status: statusFail, Detail: "exit status 2"},
+		{Tool: "amass", Status: statusFail, Detail: "not found in PATH (amass)"},
+		{Tool: "crtsh", Status: statusBuiltin, Detail: "no external binary required"},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Fatalf("unexpected results (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if failed := printSummary(&buf, results); failed != 2 {
+		t.Fatalf("expected 2 failures, got %d", failed)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"[PASS] subfinder (subfinder): subfinder v1.2.3",
+		"[FAIL] amass: not found in PATH (amass)",
+		"2 passed, 2 failed, 1 builtin",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected summary to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestCheckToolWithoutVersionFlagOnlyNeedsBinary(t *testing.T) {
+	originalFind := findBin
+	originalRun := runCmd
+	t.Cleanup(func() {
+		findBin = originalFind
+		runCmd = originalRun
+	})
+
+	findBin = func(names ...string) (string, bool) { return names[0], true }
+	runCmd = func(ctx context.Context, dir, name string, args []string, out chan<- string) error {
+		t.Errorf("%s should not be executed, got args %v", name, args)
+		return errors.New("exit status 1")
+	}
+
+	results := runSelfTest(context.Background(), []string{"waybackurls", "assetfinder", "subjs"}, time.Second)
+	want := []checkResult{
+		{Tool: "waybackurls", Binary: "waybackurls", Status: statusPass, Detail: "found (no version flag)"},
+		{Tool: "assetfinder", Binary: "assetfinder", Status: statusPass, Detail: "found (no version flag)"},
+		{Tool: "subjs", Binary: "subjs", Status: statusPass, Detail: "found (no version flag)"},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Fatalf("unexpected results (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckToolFailsWhenVersionCommandExitsNonZero(t *testing.T) {
+	originalFind := findBin
+	originalRun := runCmd
+	t.Cleanup(func() {
+		findBin = originalFind
+		runCmd = originalRun
+	})
+
+	findBin = func(names ...string) (string, bool) { return names[0], true }
+	runCmd = func(ctx context.Context, dir, name string, args []string, out chan<- string) error {
+		out <- "flag provided but not defined: -version"
+		return errors.New("exit status 1")
+	}
+
+	res := checkTool(context.Background(), "dnsx", time.Second)
+	want := checkResult{Tool: "dnsx", Binary: "dnsx", Status: statusFail, Detail: "exit status 1: flag provided but not defined: -version"}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckToolTimesOut(t *testing.T) {
+	originalFind := findBin
+	originalRun := runCmd
+	t.Cleanup(func() {
+		findBin = originalFind
+		runCmd = originalRun
+	})
+
+	findBin = func(names ...string) (string, bool) { return names[0], true }
+	runCmd = func(ctx context.Context, dir, name string, args []string, out chan<- string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	res := checkTool(context.Background(), "dnsx", 10*time.Millisecond)
+	if res.Status != statusFail || !strings.Contains(res.Detail, "timed out") {
+		t.Fatalf("expected timeout failure, got %#v", res)
+	}
+}
+
+func TestSplitTools(t *testing.T) {
+	got := splitTools(" httpx, GAU,,httpx ")
+	if diff := cmp.Diff([]string{"httpx", "gau"}, got); diff != "" {
+		t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+	}
+}
@@ -719,7 +719,7 @@ func ExtractRouteBase(line string) string {
 	if host := u.Hostname(); host != "" {
 		hostname := strings.ToLower(host)
 		port := u.Port()
-		if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		if isDefaultPort(u.Scheme, port) {
 			port = ""
 		}
 		normalizedHost := hostname
@@ -731,6 +731,11 @@ func ExtractRouteBase(line string) string {
 		}
 		u.Host = normalizedHost
 	}
+	trimTrailingSlash(u)
+	if u.RawQuery != "" {
+		u.RawQuery, _ = stripTrackingQuery(u.RawQuery)
+		u.ForceQuery = false
+	}
 
 	return strings.TrimSpace(u.String())
 }
@@ -759,7 +764,7 @@ func canonicalRouteKey(value string) string {
 
 	hostname := strings.ToLower(host)
 	port := parsed.Port()
-	if (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+	if isDefaultPort(parsed.Scheme, port) {
 		port = ""
 	}
 
@@ -773,6 +778,11 @@ func canonicalRouteKey(value string) string {
 
 	var builder strings.Builder
 	builder.Grow(len(normalizedHost) + len(base))
+	// http y https comparten clave, pero un endpoint WebSocket no es la misma
+	// ruta que la página HTTP homónima.
+	if parsed.Scheme == "ws" || parsed.Scheme == "wss" {
+		builder.WriteString("ws://")
+	}
 	builder.WriteString(normalizedHost)
 
 	path := parsed.EscapedPath()
@@ -799,6 +809,18 @@ func canonicalRouteKey(value string) string {
 	return result
 }
 
+// isDefaultPort indica si port es el puerto implícito del esquema, de modo que
+// puede omitirse sin cambiar el destino.
+func isDefaultPort(scheme, port string) bool {
+	switch scheme {
+	case "http", "ws":
+		return port == "80"
+	case "https", "wss":
+		return port == "443"
+	}
+	return false
+}
+
 func normalizeMetadata(metadata map[string]any) map[string]any {
 	if len(metadata) == 0 {
 		return nil
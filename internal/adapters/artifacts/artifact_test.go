@@ -0,0 +1,31 @@
+package artifacts
+
+import "testing"
+
+func TestExtractRouteBaseKeepsWebSocketScheme(t *testing.T) {
+	cases := map[string]string{
+		"WS://Example.com:80/live":     "ws://example.com/live",
+		"wss://example.com:443/stream": "wss://example.com/stream",
+		"ws://example.com:443/live":    "ws://example.com:443/live",
+		"wss://example.com:80/stream":  "wss://example.com:80/stream",
+		"wss://example.com:8443/s":     "wss://example.com:8443/s",
+	}
+	for input, want := range cases {
+		if got := ExtractRouteBase(input); got != want {
+			t.Errorf("ExtractRouteBase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestKeyForSeparatesWebSocketFromHTTPRoutes(t *testing.T) {
+	httpKey := KeyFor(Artifact{Type: "route", Value: "https://example.com/live"})
+	wsKey := KeyFor(Artifact{Type: "route", Value: "ws://example.com/live"})
+	wssKey := KeyFor(Artifact{Type: "route", Value: "wss://example.com:443/live"})
+
+	if httpKey == wsKey {
+		t.Fatalf("expected ws route not to share the http key %+v", httpKey)
+	}
+	if wsKey != wssKey {
+		t.Fatalf("expected ws and wss to share a key, got %+v and %+v", wsKey, wssKey)
+	}
+}
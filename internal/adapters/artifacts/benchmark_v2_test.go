@@ -336,3 +336,41 @@ func TestSpeedComparison(t *testing.T) {
 	t.Logf("V2 write time: %v", v2Duration)
 	t.Logf("V2 speedup: %.2fx", float64(v1Duration)/float64(v2Duration))
 }
+
+// Benchmark: Vistas pasiva y activa del informe leyendo el manifiesto una vez
+// por vista frente a una sola pasada con varios conjuntos de selectores.
+func writeSelectorSetsFixture(b *testing.B) (string, []map[string]ActiveState) {
+	b.Helper()
+	tmpDir := b.TempDir()
+	writer := NewWriterV2(filepath.Join(tmpDir, "artifacts.jsonl"), "example.com")
+	if err := writer.WriteArtifacts(generateTestArtifacts(5000)); err != nil {
+		b.Fatal(err)
+	}
+	passive := map[string]ActiveState{"domain": PassiveOnly, "route": PassiveOnly, "certificate": PassiveOnly}
+	active := map[string]ActiveState{"domain": ActiveOnly, "route": ActiveOnly, "certificate": ActiveOnly}
+	return tmpDir, []map[string]ActiveState{passive, active}
+}
+
+func BenchmarkCollectArtifactsPerSelectorSet(b *testing.B) {
+	tmpDir, sets := writeSelectorSetsFixture(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, selectors := range sets {
+			if _, err := CollectArtifactsByType(tmpDir, selectors); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCollectArtifactsSinglePass(b *testing.B) {
+	tmpDir, sets := writeSelectorSetsFixture(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := CollectArtifactsBySelectorSets(tmpDir, sets...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package artifacts
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+// burpTimeLayout es el formato de fecha que usa Burp Suite en sus exports.
+const burpTimeLayout = "Mon Jan 02 15:04:05 MST 2006"
+
+// burpItems es la raíz del XML "Save items" de Burp Suite.
+type burpItems struct {
+	XMLName     xml.Name   `xml:"items"`
+	BurpVersion string     `xml:"burpVersion,attr"`
+	ExportTime  string     `xml:"exportTime,attr"`
+	Items       []burpItem `xml:"item"`
+}
+
+type burpItem struct {
+	Time           string    `xml:"time"`
+	URL            burpCDATA `xml:"url"`
+	Host           burpHost  `xml:"host"`
+	Port           string    `xml:"port"`
+	Protocol       string    `xml:"protocol"`
+	Method         burpCDATA `xml:"method"`
+	Path           burpCDATA `xml:"path"`
+	Extension      string    `xml:"extension"`
+	Request        burpBody  `xml:"request"`
+	Status         string    `xml:"status"`
+	ResponseLength string    `xml:"responselength"`
+	MimeType       string    `xml:"mimetype"`
+	Response       burpBody  `xml:"response"`
+	Comment        string    `xml:"comment"`
+}
+
+type burpCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+type burpHost struct {
+	IP   string `xml:"ip,attr"`
+	Name string `xml:",chardata"`
+}
+
+type burpBody struct {
+	Base64 bool   `xml:"base64,attr"`
+	Value  string `xml:",cdata"`
+}
+
+// ExportBurpSitemap lee el manifiesto de srcPath y escribe en dstPath un XML
+// compatible con el formato "Save items" de Burp Suite con las rutas dentro de
+// scope (un scope nil no filtra). Cada item incluye método, URL y, si existe en
+// los metadatos, el status observado, además de una petición mínima en base64
+// para que Burp pueda reenviarla. Devuelve el número de items exportados.
+func ExportBurpSitemap(srcPath, dstPath string, scope *netutil.Scope) (int, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		return 0, fmt.Errorf("burp export: %s: %w", srcPath, err)
+	}
+	all, err := reader.ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("burp export: %s: %w", srcPath, err)
+	}
+
+	out, err := os.Create(dstPath)
+	if err != nil {
+		return 0, fmt.Errorf("burp export: %w", err)
+	}
+	count, err := WriteBurpSitemap(out, all, scope)
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("burp export: %s: %w", dstPath, err)
+	}
+	return count, nil
+}
+
+// WriteBurpSitemap escribe en w el XML de Burp con las rutas de list dentro de
+// scope, sin duplicados por método y URL y ordenadas por URL.
+func WriteBurpSitemap(w io.Writer, list []Artifact, scope *netutil.Scope) (int, error) {
+	doc := burpItems{ExportTime: time.Now().UTC().Format(burpTimeLayout)}
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		if art.Type != "route" || !artifactInScope(art, scope) {
+			continue
+		}
+		item, ok := burpItemFromArtifact(art)
+		if !ok {
+			continue
+		}
+		key := item.Method.Value + " " + item.URL.Value
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		doc.Items = append(doc.Items, item)
+	}
+	sort.SliceStable(doc.Items, func(i, j int) bool {
+		if doc.Items[i].URL.Value != doc.Items[j].URL.Value {
+			return doc.Items[i].URL.Value < doc.Items[j].URL.Value
+		}
+		return doc.Items[i].Method.Value < doc.Items[j].Method.Value
+	})
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return 0, err
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return 0, err
+	}
+	return len(doc.Items), nil
+}
+
+// burpItemFromArtifact construye el item de Burp de una ruta. Solo se exportan
+// URLs absolutas http(s).
+func burpItemFromArtifact(art Artifact) (burpItem, bool) {
+	u, err := url.Parse(strings.TrimSpace(art.Value))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return burpItem{}, false
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	requestPath := u.EscapedPath()
+	if requestPath == "" {
+		requestPath = "/"
+	}
+	if u.RawQuery != "" {
+		requestPath += "?" + u.RawQuery
+	}
+	extension := strings.TrimPrefix(path.Ext(u.Path), ".")
+	if extension == "" {
+		extension = "null"
+	}
+	method := strings.ToUpper(burpMetadataString(art.Metadata, "method"))
+	if method == "" {
+		method = "GET"
+	}
+	request := fmt.Sprintf("%s %s HTTP/1.1\r\nHost: %s\r\n\r\n", method, requestPath, u.Host)
+
+	item := burpItem{
+		URL:       burpCDATA{Value: u.String()},
+		Host:      burpHost{Name: u.Hostname()},
+		Port:      port,
+		Protocol:  u.Scheme,
+		Method:    burpCDATA{Value: method},
+		Path:      burpCDATA{Value: requestPath},
+		Extension: extension,
+		Request:   burpBody{Base64: true, Value: base64.StdEncoding.EncodeToString([]byte(request))},
+		Status:    burpMetadataString(art.Metadata, "status"),
+		MimeType:  burpMetadataString(art.Metadata, "content_type"),
+		Response:  burpBody{Base64: true},
+	}
+	if seen, err := time.Parse(time.RFC3339, art.LastSeen); err == nil {
+		item.Time = seen.UTC().Format(burpTimeLayout)
+	}
+	return item, true
+}
+
+// burpMetadataString devuelve el metadato como texto, aceptando los números
+// que llegan como float64 tras decodificar el manifiesto.
+func burpMetadataString(metadata map[string]any, key string) string {
+	switch v := metadata[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+func TestExportBurpSitemapWritesInScopeRoutes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "artifacts.jsonl")
+	writeArtifactsFile(t, src, []Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true},
+		{Type: "route", Value: "https://app.example.com/login?next=/admin", Active: true, Up: true, Metadata: map[string]any{"status": 200, "content_type": "text/html"}},
+		{Type: "route", Value: "http://api.example.com:8080/v1/users.json", Active: true, Up: true, Metadata: map[string]any{"status": 401, "method": "post"}},
+		{Type: "route", Value: "https://app.example.com/static/app.js", Up: true},
+		{Type: "route", Value: "https://tracker.other.com/pixel", Up: true, Metadata: map[string]any{"status": 200}},
+	})
+
+	dst := filepath.Join(dir, "sitemap.xml")
+	count, err := ExportBurpSitemap(src, dst, netutil.NewScope("example.com", "subdomains"))
+	if err != nil {
+		t.Fatalf("ExportBurpSitemap: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("ExportBurpSitemap exported %d items, want 3", count)
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte(xml.Header)) {
+		t.Fatalf("expected XML declaration, got %q", data[:40])
+	}
+	var doc burpItems
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("sitemap is not valid XML: %v", err)
+	}
+
+	type entry struct {
+		URL, Method, Status, Host, Port, Protocol, Path, Extension string
+	}
+	var got []entry
+	for _, item := range doc.Items {
+		got = append(got, entry{
+			URL:       item.URL.Value,
+			Method:    item.Method.Value,
+			Status:    item.Status,
+			Host:      item.Host.Name,
+			Port:      item.Port,
+			Protocol:  item.Protocol,
+			Path:      item.Path.Value,
+			Extension: item.Extension,
+		})
+	}
+	want := []entry{
+		{URL: "http://api.example.com:8080/v1/users.json", Method: "POST", Status: "401", Host: "api.example.com", Port: "8080", Protocol: "http", Path: "/v1/users.json", Extension: "json"},
+		{URL: "https://app.example.com/login?next=/admin", Method: "GET", Status: "200", Host: "app.example.com", Port: "443", Protocol: "https", Path: "/login?next=/admin", Extension: "null"},
+		{URL: "https://app.example.com/static/app.js", Method: "GET", Host: "app.example.com", Port: "443", Protocol: "https", Path: "/static/app.js", Extension: "js"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected sitemap items (-want +got):\n%s", diff)
+	}
+
+	request, err := base64.StdEncoding.DecodeString(doc.Items[0].Request.Value)
+	if err != nil || !doc.Items[0].Request.Base64 {
+		t.Fatalf("expected base64 request, got %+v (%v)", doc.Items[0].Request, err)
+	}
+	if !strings.HasPrefix(string(request), "POST /v1/users.json HTTP/1.1\r\nHost: api.example.com:8080\r\n") {
+		t.Fatalf("unexpected request: %q", request)
+	}
+	if strings.Contains(string(data), "tracker.other.com") {
+		t.Fatalf("out-of-scope route exported:\n%s", data)
+	}
+}
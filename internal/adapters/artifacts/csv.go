@@ -0,0 +1,68 @@
+package artifacts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvHeader son las columnas que escribe WriteCSV, en orden.
+var csvHeader = []string{"type", "value", "active", "up", "tool", "tools", "occurrences", "metadata"}
+
+// WriteCSV escribe arts en w como CSV (RFC 4180) con una fila de cabecera y
+// una fila por artefacto. Las herramientas se unen con ";" y los metadatos se
+// aplanan en una única columna codificada como JSON, de modo que valores con
+// saltos de línea no rompen la estructura de filas. Si Type está vacío se usa
+// el primer elemento de Types.
+func WriteCSV(w io.Writer, arts []Artifact) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, art := range arts {
+		record, err := csvRecord(art)
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRecord(art Artifact) ([]string, error) {
+	typ := strings.TrimSpace(art.Type)
+	if typ == "" {
+		for _, candidate := range art.Types {
+			if candidate = strings.TrimSpace(candidate); candidate != "" {
+				typ = candidate
+				break
+			}
+		}
+	}
+
+	metadata := ""
+	if len(art.Metadata) > 0 {
+		encoded, err := json.Marshal(art.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("csv: metadata de %q: %w", art.Value, err)
+		}
+		metadata = string(encoded)
+	}
+
+	return []string{
+		typ,
+		art.Value,
+		strconv.FormatBool(art.Active),
+		strconv.FormatBool(art.Up),
+		art.Tool,
+		strings.Join(art.Tools, ";"),
+		strconv.Itoa(art.Occurrences),
+		metadata,
+	}, nil
+}
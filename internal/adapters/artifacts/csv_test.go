@@ -0,0 +1,61 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteCSV(t *testing.T) {
+	arts := []Artifact{
+		{
+			Type:        "route",
+			Value:       "https://example.com/a,b",
+			Active:      true,
+			Up:          true,
+			Tool:        "httpx",
+			Tools:       []string{"httpx", "katana"},
+			Occurrences: 3,
+			Metadata:    map[string]any{"status": 200},
+		},
+		{
+			Types:    []string{"domain", "subdomain"},
+			Value:    "app.example.com",
+			Tool:     "crtsh",
+			Metadata: map[string]any{"note": "línea 1\nlínea 2"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, arts); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := [][]string{
+		{"type", "value", "active", "up", "tool", "tools", "occurrences", "metadata"},
+		{"route", "https://example.com/a,b", "true", "true", "httpx", "httpx;katana", "3", `{"status":200}`},
+		{"domain", "app.example.com", "false", "false", "crtsh", "", "0", `{"note":"línea 1\nlínea 2"}`},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"https://example.com/a,b"`)) {
+		t.Fatalf("expected value with comma to be quoted, got %q", buf.String())
+	}
+}
+
+func TestWriteCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, nil); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if got := buf.String(); got != "type,value,active,up,tool,tools,occurrences,metadata\n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
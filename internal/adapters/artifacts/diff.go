@@ -0,0 +1,240 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ScanDiff es la diferencia entre dos manifiestos: artefactos nuevos,
+// desaparecidos y los presentes en ambos cuyo contenido cambió.
+type ScanDiff struct {
+	Added   []Artifact       `json:"added"`
+	Removed []Artifact       `json:"removed"`
+	Changed []ArtifactChange `json:"changed"`
+}
+
+// ArtifactChange describe un artefacto presente en ambos manifiestos con
+// campos distintos. Fields enumera los campos que cambiaron: "types",
+// "tags", "tools", "metadata" o "up".
+type ArtifactChange struct {
+	Old    Artifact `json:"old"`
+	New    Artifact `json:"new"`
+	Fields []string `json:"fields"`
+}
+
+// Diff compara dos listas de artefactos identificándolos con KeyFor, igual
+// que el store del pipeline. Ni el orden de las listas ni el de Types, Tags o
+// Tools influye en el resultado; ocurrencias y timestamps se ignoran porque
+// cambian en cada ejecución. Las tres listas se devuelven ordenadas por clave.
+func Diff(before, after []Artifact) ScanDiff {
+	oldIndex := indexByKey(before)
+	newIndex := indexByKey(after)
+
+	// Listas vacías en vez de nil para que el JSON muestre [] y no null.
+	diff := ScanDiff{Added: []Artifact{}, Removed: []Artifact{}, Changed: []ArtifactChange{}}
+	for key, current := range newIndex {
+		previous, ok := oldIndex[key]
+		if !ok {
+			diff.Added = append(diff.Added, current)
+			continue
+		}
+		if fields := changedFields(previous, current); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, ArtifactChange{Old: previous, New: current, Fields: fields})
+		}
+	}
+	for key, previous := range oldIndex {
+		if _, ok := newIndex[key]; !ok {
+			diff.Removed = append(diff.Removed, previous)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return keyLess(KeyFor(diff.Added[i]), KeyFor(diff.Added[j])) })
+	sort.Slice(diff.Removed, func(i, j int) bool { return keyLess(KeyFor(diff.Removed[i]), KeyFor(diff.Removed[j])) })
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return keyLess(KeyFor(diff.Changed[i].New), KeyFor(diff.Changed[j].New))
+	})
+	return diff
+}
+
+// DiffFiles lee los manifiestos oldPath y newPath y devuelve su Diff.
+func DiffFiles(oldPath, newPath string) (ScanDiff, error) {
+	before, err := readManifest(oldPath)
+	if err != nil {
+		return ScanDiff{}, err
+	}
+	after, err := readManifest(newPath)
+	if err != nil {
+		return ScanDiff{}, err
+	}
+	return Diff(before, after), nil
+}
+
+// Empty indica si ambos manifiestos son equivalentes.
+func (d ScanDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// WriteJSON escribe d como JSON indentado.
+func (d ScanDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteSummary escribe un resumen legible: los totales y, por sección, una
+// línea por artefacto con su tipo, estado y valor.
+func (d ScanDiff) WriteSummary(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d nuevos, %d eliminados, %d modificados\n", len(d.Added), len(d.Removed), len(d.Changed))
+	if len(d.Added) > 0 {
+		b.WriteString("\nNuevos:\n")
+		for _, art := range d.Added {
+			fmt.Fprintf(&b, "  + %s\n", describeArtifact(art))
+		}
+	}
+	if len(d.Removed) > 0 {
+		b.WriteString("\nEliminados:\n")
+		for _, art := range d.Removed {
+			fmt.Fprintf(&b, "  - %s\n", describeArtifact(art))
+		}
+	}
+	if len(d.Changed) > 0 {
+		b.WriteString("\nModificados:\n")
+		for _, change := range d.Changed {
+			fmt.Fprintf(&b, "  ~ %s (%s)\n", describeArtifact(change.New), strings.Join(change.Fields, ", "))
+			if containsString(change.Fields, "types") {
+				fmt.Fprintf(&b, "      types: %s -> %s\n", formatSet(typeSet(change.Old)), formatSet(typeSet(change.New)))
+			}
+			if containsString(change.Fields, "tools") {
+				fmt.Fprintf(&b, "      tools: %s -> %s\n", formatSet(toolSet(change.Old)), formatSet(toolSet(change.New)))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func readManifest(path string) ([]Artifact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		return nil, fmt.Errorf("diff: %s: %w", path, err)
+	}
+	list, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("diff: %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// indexByKey indexa list por KeyFor; si una clave se repite gana la primera
+// aparición, como en el store.
+func indexByKey(list []Artifact) map[Key]Artifact {
+	index := make(map[Key]Artifact, len(list))
+	for _, art := range list {
+		key := KeyFor(art)
+		if _, exists := index[key]; !exists {
+			index[key] = art
+		}
+	}
+	return index
+}
+
+func changedFields(before, after Artifact) []string {
+	var fields []string
+	if !reflect.DeepEqual(typeSet(before), typeSet(after)) {
+		fields = append(fields, "types")
+	}
+	if !reflect.DeepEqual(sortedSet(before.Tags), sortedSet(after.Tags)) {
+		fields = append(fields, "tags")
+	}
+	if !reflect.DeepEqual(toolSet(before), toolSet(after)) {
+		fields = append(fields, "tools")
+	}
+	if !metadataEqual(before.Metadata, after.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	if before.Up != after.Up {
+		fields = append(fields, "up")
+	}
+	return fields
+}
+
+// typeSet devuelve el tipo principal más los secundarios, sin duplicados y
+// ordenados.
+func typeSet(art Artifact) []string {
+	return sortedSet(append([]string{art.Type}, art.Types...))
+}
+
+func toolSet(art Artifact) []string {
+	return sortedSet(append([]string{art.Tool}, art.Tools...))
+}
+
+func sortedSet(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	set := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		set = append(set, value)
+	}
+	sort.Strings(set)
+	return set
+}
+
+func metadataEqual(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func keyLess(a, b Key) bool {
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	if a.Subtype != b.Subtype {
+		return a.Subtype < b.Subtype
+	}
+	if a.Value != b.Value {
+		return a.Value < b.Value
+	}
+	return !a.Active && b.Active
+}
+
+func describeArtifact(art Artifact) string {
+	state := "pasivo"
+	if art.Active {
+		state = "activo"
+	}
+	return fmt.Sprintf("[%s/%s] %s", art.Type, state, art.Value)
+}
+
+func formatSet(values []string) string {
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffClassifiesAddedRemovedAndChanged(t *testing.T) {
+	before := []Artifact{
+		{Type: "domain", Value: "www.example.com", Tool: "subfinder", Up: true},
+		{Type: "route", Value: "https://example.com/app.js", Tool: "waybackurls", Up: true},
+		{Type: "route", Value: "https://example.com/old", Tool: "waybackurls", Up: true},
+		{Type: "route", Value: "https://example.com/login", Active: true, Up: true, Metadata: map[string]any{"status": float64(200)}},
+	}
+	after := []Artifact{
+		{Type: "route", Value: "https://example.com/login", Active: true, Up: true, Metadata: map[string]any{"status": float64(302)}},
+		{Type: "route", Value: "https://example.com/app.js", Types: []string{"js"}, Tool: "waybackurls", Up: true},
+		{Type: "domain", Value: "www.example.com", Tools: []string{"subfinder"}, Up: true, Occurrences: 4},
+		{Type: "domain", Value: "api.example.com", Tool: "amass", Up: true},
+	}
+
+	diff := Diff(before, after)
+
+	if len(diff.Added) != 1 || diff.Added[0].Value != "api.example.com" {
+		t.Fatalf("unexpected added: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Value != "https://example.com/old" {
+		t.Fatalf("unexpected removed: %+v", diff.Removed)
+	}
+	got := make(map[string][]string)
+	for _, change := range diff.Changed {
+		got[change.New.Value] = change.Fields
+	}
+	want := map[string][]string{
+		"https://example.com/app.js": {"types"},
+		"https://example.com/login":  {"metadata"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected changes: got %v, want %v", got, want)
+	}
+}
+
+func TestDiffIsOrderIndependent(t *testing.T) {
+	before := []Artifact{
+		{Type: "domain", Value: "a.example.com", Tools: []string{"amass", "subfinder"}},
+		{Type: "domain", Value: "b.example.com"},
+	}
+	after := []Artifact{
+		{Type: "domain", Value: "c.example.com"},
+		{Type: "domain", Value: "a.example.com", Tools: []string{"subfinder", "amass"}},
+		{Type: "domain", Value: "d.example.com"},
+	}
+	reversed := []Artifact{after[2], after[1], after[0]}
+
+	first := Diff(before, after)
+	second := Diff([]Artifact{before[1], before[0]}, reversed)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("diff depends on input order:\n%+v\n%+v", first, second)
+	}
+	if len(first.Changed) != 0 {
+		t.Fatalf("reordered tools should not count as a change: %+v", first.Changed)
+	}
+	if len(first.Added) != 2 || first.Added[0].Value != "c.example.com" || first.Added[1].Value != "d.example.com" {
+		t.Fatalf("expected added artifacts sorted by key, got %+v", first.Added)
+	}
+}
+
+func TestDiffFilesWritesSummaryAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.jsonl")
+	newPath := filepath.Join(dir, "new.jsonl")
+	writeArtifactsFile(t, oldPath, []Artifact{
+		{Type: "route", Value: "https://example.com/app.js", Tool: "gau", Up: true},
+	})
+	writeArtifactsFile(t, newPath, []Artifact{
+		{Type: "route", Value: "https://example.com/app.js", Types: []string{"js"}, Tool: "gau", Up: true},
+		{Type: "domain", Value: "new.example.com", Tool: "subfinder", Up: true},
+	})
+
+	diff, err := DiffFiles(oldPath, newPath)
+	if err != nil {
+		t.Fatalf("DiffFiles: %v", err)
+	}
+
+	var summary bytes.Buffer
+	if err := diff.WriteSummary(&summary); err != nil {
+		t.Fatalf("WriteSummary: %v", err)
+	}
+	for _, fragment := range []string{
+		"1 nuevos, 0 eliminados, 1 modificados",
+		"+ [domain/pasivo] new.example.com",
+		"~ [route/pasivo] https://example.com/app.js (types)",
+		"types: [route] -> [js, route]",
+	} {
+		if !strings.Contains(summary.String(), fragment) {
+			t.Fatalf("summary missing %q:\n%s", fragment, summary.String())
+		}
+	}
+
+	var encoded bytes.Buffer
+	if err := diff.WriteJSON(&encoded); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded map[string][]json.RawMessage
+	if err := json.Unmarshal(encoded.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode JSON: %v", err)
+	}
+	if len(decoded["added"]) != 1 || len(decoded["removed"]) != 0 || len(decoded["changed"]) != 1 {
+		t.Fatalf("unexpected JSON diff: %s", encoded.String())
+	}
+	if !strings.Contains(encoded.String(), `"removed": []`) {
+		t.Fatalf("expected empty sections as [], got %s", encoded.String())
+	}
+}
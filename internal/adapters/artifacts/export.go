@@ -0,0 +1,121 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+// ExportInScope lee el manifiesto completo de srcPath y escribe en dstPath un
+// manifiesto v2 con solo los artefactos cuyo host cae dentro de scope (ver
+// artifactScopeNames), conservando metadatos, herramientas y timestamps.
+// Permite recolectar de forma amplia y derivar después un export acotado sin
+// repetir la ejecución.
+// Devuelve cuántos artefactos se conservaron y cuántos se descartaron.
+func ExportInScope(srcPath, dstPath string, scope *netutil.Scope) (kept, dropped int, err error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("export: %s: %w", srcPath, err)
+	}
+	all, err := reader.ReadAll()
+	if err != nil {
+		return 0, 0, fmt.Errorf("export: %s: %w", srcPath, err)
+	}
+
+	filtered := make([]Artifact, 0, len(all))
+	for _, art := range all {
+		if artifactInScope(art, scope) {
+			filtered = append(filtered, art)
+			continue
+		}
+		dropped++
+	}
+
+	header := reader.GetHeader()
+	writer := NewWriterV2(dstPath, header.Target)
+	writer.SetBaseTime(time.Unix(header.Created, 0).UTC())
+	for _, tool := range header.Tools {
+		writer.AddTool(tool)
+	}
+	if err := writer.WriteArtifacts(filtered); err != nil {
+		return 0, 0, fmt.Errorf("export: %s: %w", dstPath, err)
+	}
+	return len(filtered), dropped, nil
+}
+
+// artifactInScope indica si alguno de los nombres del artefacto pasa el scope.
+// Un scope nil no filtra nada y los artefactos sin host (meta, JSON sin campo
+// host) se conservan, porque no hay nada con qué compararlos.
+func artifactInScope(art Artifact, scope *netutil.Scope) bool {
+	if scope == nil {
+		return true
+	}
+	names := artifactScopeNames(art)
+	if len(names) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if scope.AllowsDomain(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactScopeNames devuelve los nombres que se comparan con el scope: el CN
+// y los SAN de los certificados (los comodines por su dominio base), el
+// metadato host si existe, el campo host de los valores JSON (dns, ...) y el
+// valor tal cual para el resto, ya que AllowsDomain extrae el host de URLs, de
+// "host [TIPO] valor" y de host:puerto. Los meta no tienen host.
+func artifactScopeNames(art Artifact) []string {
+	if art.Type == "certificate" {
+		return certificateScopeNames(art.Value)
+	}
+	if host, ok := art.Metadata["host"].(string); ok && strings.TrimSpace(host) != "" {
+		return []string{strings.TrimSpace(host)}
+	}
+	if art.Type == "meta" {
+		return nil
+	}
+	value := strings.TrimSpace(art.Value)
+	if strings.HasPrefix(value, "{") {
+		var record struct {
+			Host string `json:"host"`
+		}
+		if err := json.Unmarshal([]byte(value), &record); err != nil || strings.TrimSpace(record.Host) == "" {
+			return nil
+		}
+		return []string{strings.TrimSpace(record.Host)}
+	}
+	return []string{art.Value}
+}
+
+// certificateScopeNames devuelve el CN y los SAN de un certificado en JSON,
+// con los comodines reducidos a su dominio base.
+func certificateScopeNames(value string) []string {
+	var cert struct {
+		CommonName string   `json:"common_name"`
+		DNSNames   []string `json:"dns_names"`
+	}
+	if err := json.Unmarshal([]byte(value), &cert); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cert.DNSNames)+1)
+	for _, name := range append([]string{cert.CommonName}, cert.DNSNames...) {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*.")
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
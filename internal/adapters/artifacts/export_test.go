@@ -0,0 +1,134 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+func TestExportInScopeDropsOutOfScopeArtifacts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "artifacts.jsonl")
+	writeArtifactsFile(t, src, []Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true, Tool: "subfinder", Tools: []string{"subfinder"}},
+		{Type: "domain", Value: "cdn.other.com", Up: true, Tool: "subfinder", Tools: []string{"subfinder"}},
+		{Type: "route", Value: "https://app.example.com/login", Active: true, Up: true, Tool: "httpx", Tools: []string{"httpx"}, Metadata: map[string]any{"status": float64(200)}},
+		{Type: "route", Value: "https://tracker.other.com/pixel", Up: true, Tool: "gau", Tools: []string{"gau"}},
+		{Type: "certificate", Value: `{"common_name":"*.example.com","dns_names":["*.example.com"],"issuer":"R3","not_before":"2024-01-01T00:00:00Z","not_after":"2025-01-01T00:00:00Z","serial_number":"01"}`, Up: true, Tool: "crtsh", Tools: []string{"crtsh"}},
+	})
+
+	dst := filepath.Join(dir, "scoped.jsonl")
+	kept, dropped, err := ExportInScope(src, dst, netutil.NewScope("example.com", "subdomains"))
+	if err != nil {
+		t.Fatalf("ExportInScope: %v", err)
+	}
+	if kept != 3 || dropped != 2 {
+		t.Fatalf("ExportInScope = (%d kept, %d dropped), want (3, 2)", kept, dropped)
+	}
+
+	f, err := os.Open(dst)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer f.Close()
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		t.Fatalf("NewReaderV2: %v", err)
+	}
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	values := make(map[string]string)
+	for _, art := range got {
+		values[art.Value] = art.Type
+	}
+	for value := range values {
+		if value == "cdn.other.com" || value == "https://tracker.other.com/pixel" {
+			t.Fatalf("out-of-scope artifact survived the export: %q", value)
+		}
+	}
+	if values["app.example.com"] != "domain" || values["https://app.example.com/login"] != "route" {
+		t.Fatalf("expected in-scope domain and route in export, got %v", values)
+	}
+
+	for _, art := range got {
+		if art.Value != "https://app.example.com/login" {
+			continue
+		}
+		if diff := cmp.Diff(map[string]any{"status": float64(200)}, art.Metadata); diff != "" {
+			t.Fatalf("route metadata not preserved (-want +got):\n%s", diff)
+		}
+		if !art.Active || art.Tool != "httpx" {
+			t.Fatalf("route state not preserved: %+v", art)
+		}
+	}
+}
+
+func TestExportInScopeKeepsArtifactsWithoutHost(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "artifacts.jsonl")
+	writeArtifactsFile(t, src, []Artifact{
+		{Type: "meta", Value: "httpx(active): 12/12 ok", Up: true, Tool: "httpx", Tools: []string{"httpx"}},
+		{Type: "rdap", Value: `{"registrar":"Example Registrar","status":["active"]}`, Up: true, Tool: "rdap", Tools: []string{"rdap"}},
+		{Type: "domain", Value: "cdn.other.com", Up: true, Tool: "subfinder", Tools: []string{"subfinder"}},
+	})
+
+	kept, dropped, err := ExportInScope(src, filepath.Join(dir, "scoped.jsonl"), netutil.NewScope("example.com", "subdomains"))
+	if err != nil {
+		t.Fatalf("ExportInScope: %v", err)
+	}
+	if kept != 2 || dropped != 1 {
+		t.Fatalf("ExportInScope = (%d kept, %d dropped), want meta and rdap kept and the foreign domain dropped", kept, dropped)
+	}
+}
+
+func TestExportInScopeScopesByHost(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "artifacts.jsonl")
+	writeArtifactsFile(t, src, []Artifact{
+		{Type: "dns", Value: `{"host":"api.example.com","type":"A","value":"203.0.113.10"}`, Active: true, Up: true, Tool: "dnsx", Tools: []string{"dnsx"}},
+		{Type: "dns", Value: `{"host":"mail.other.com","type":"A","value":"198.51.100.7"}`, Active: true, Up: true, Tool: "dnsx", Tools: []string{"dnsx"}},
+		{Type: "port", Value: "203.0.113.10:443/tcp", Active: true, Up: true, Tool: "naabu", Tools: []string{"naabu"}, Metadata: map[string]any{"host": "www.example.com"}},
+		{Type: "port", Value: "198.51.100.7:25/tcp", Active: true, Up: true, Tool: "naabu", Tools: []string{"naabu"}, Metadata: map[string]any{"host": "mail.other.com"}},
+	})
+
+	dst := filepath.Join(dir, "scoped.jsonl")
+	if _, _, err := ExportInScope(src, dst, netutil.NewScope("example.com", "subdomains")); err != nil {
+		t.Fatalf("ExportInScope: %v", err)
+	}
+	f, err := os.Open(dst)
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	defer f.Close()
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		t.Fatalf("NewReaderV2: %v", err)
+	}
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	var values []string
+	for _, art := range got {
+		values = append(values, art.Value)
+	}
+	sort.Strings(values)
+	want := []string{"203.0.113.10:443/tcp", `{"host":"api.example.com","type":"A","value":"203.0.113.10"}`}
+	if diff := cmp.Diff(want, values); diff != "" {
+		t.Fatalf("unexpected export (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,36 @@
+package artifacts
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// QueryParamNames devuelve los nombres de parámetros de la query de la ruta,
+// sin duplicados y ordenados. Una query mal formada no es un error: se
+// conservan los pares que url.ParseQuery consigue interpretar.
+func QueryParamNames(raw string) []string {
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return nil
+	}
+	u, err := url.Parse(fields[0])
+	if err != nil || u.RawQuery == "" {
+		return nil
+	}
+	values, _ := url.ParseQuery(u.RawQuery)
+	if len(values) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,25 @@
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQueryParamNames(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"https://example.com/search?q=1&page=2&q=3": {"page", "q"},
+		"https://example.com/a?id=1&%zz=bad&b=2":    {"b", "id"},
+		"https://example.com/a?id=1;x=2&z=3":        {"z"},
+		"https://example.com/a [200]":               nil,
+		"https://example.com/a?":                    nil,
+		"":                                          nil,
+	}
+	for raw, want := range cases {
+		if diff := cmp.Diff(want, QueryParamNames(raw)); diff != "" {
+			t.Errorf("QueryParamNames(%q) (-want +got):\n%s", raw, diff)
+		}
+	}
+}
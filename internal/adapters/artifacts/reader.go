@@ -2,9 +2,12 @@ package artifacts
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,12 +19,30 @@ type ReaderV2 struct {
 	scanner  *bufio.Scanner
 	baseTime time.Time
 	header   *HeaderV2
+	line     int // última línea leída (el header es la 1)
 }
 
+// gzipMagic son los dos primeros bytes de un stream gzip.
+var gzipMagic = []byte{0x1f, 0x8b}
+
 // NewReaderV2 crea un nuevo reader para formato v2.0.
-// Espera que la primera línea sea un header v2 válido.
-func NewReaderV2(file *os.File) (*ReaderV2, error) {
-	scanner := bufio.NewScanner(file)
+// Espera que la primera línea sea un header v2 válido. Si el fichero está
+// comprimido con gzip (artifacts.jsonl.gz) se descomprime de forma
+// transparente.
+func NewReaderV2(r io.Reader) (*ReaderV2, error) {
+	buffered := bufio.NewReader(r)
+	var source io.Reader = buffered
+	// Peek no consume bytes, así que el camino sin comprimir lee el fichero
+	// completo desde el principio.
+	if magic, err := buffered.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		source = gz
+	}
+
+	scanner := bufio.NewScanner(source)
 	scanner.Buffer(make([]byte, 0, 64*1024), 2*1024*1024)
 
 	// Leer header v2 (primera línea)
@@ -47,6 +68,7 @@ func NewReaderV2(file *os.File) (*ReaderV2, error) {
 		scanner:  scanner,
 		baseTime: time.Unix(header.Created, 0).UTC(),
 		header:   &header,
+		line:     1,
 	}
 
 	return reader, nil
@@ -58,14 +80,16 @@ func (r *ReaderV2) GetHeader() *HeaderV2 {
 }
 
 // ReadArtifact lee el siguiente artifact del archivo en formato v2.0.
-// Convierte el artifact a la estructura interna Artifact.
+// Convierte el artifact a la estructura interna Artifact. Al final del
+// archivo devuelve io.EOF.
 func (r *ReaderV2) ReadArtifact() (Artifact, error) {
 	if !r.scanner.Scan() {
 		if err := r.scanner.Err(); err != nil {
 			return Artifact{}, err
 		}
-		return Artifact{}, fmt.Errorf("EOF")
+		return Artifact{}, io.EOF
 	}
+	r.line++
 
 	line := strings.TrimSpace(r.scanner.Text())
 	if line == "" {
@@ -81,21 +105,34 @@ func (r *ReaderV2) ReadArtifact() (Artifact, error) {
 	return ToV1(v2, r.baseTime), nil
 }
 
-// ReadAll lee todos los artifacts del archivo.
-func (r *ReaderV2) ReadAll() ([]Artifact, error) {
-	artifacts := []Artifact{}
-
+// Each recorre los artifacts restantes de uno en uno sin acumularlos en
+// memoria. Se detiene en el primer error de lectura o en el primero que
+// devuelva fn, y lo retorna; al llegar al final del archivo devuelve nil.
+func (r *ReaderV2) Each(fn func(Artifact) error) error {
 	for {
 		art, err := r.ReadArtifact()
 		if err != nil {
-			if err.Error() == "EOF" {
-				break
+			if errors.Is(err, io.EOF) {
+				return nil
 			}
-			return nil, err
+			return err
+		}
+		if err := fn(art); err != nil {
+			return err
 		}
-		artifacts = append(artifacts, art)
 	}
+}
 
+// ReadAll lee todos los artifacts del archivo.
+func (r *ReaderV2) ReadAll() ([]Artifact, error) {
+	artifacts := []Artifact{}
+	err := r.Each(func(art Artifact) error {
+		artifacts = append(artifacts, art)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return artifacts, nil
 }
 
@@ -181,6 +218,19 @@ func CollectValuesByType(outdir string, selectors map[string]ActiveState) (map[s
 // devuelve los artefactos agrupados por tipo aplicando el filtro de actividad
 // indicado por selectors. Soporta auto-detección de formato v1/v2.
 func CollectArtifactsByType(outdir string, selectors map[string]ActiveState) (map[string][]Artifact, error) {
+	results, err := CollectArtifactsBySelectorSets(outdir, selectors)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// CollectArtifactsBySelectorSets recorre artifacts.jsonl una sola vez y
+// agrupa los artefactos por tipo para cada conjunto de selectores. El
+// resultado i equivale a CollectArtifactsByType(outdir, sets[i]), de modo que
+// quien necesita varias vistas del manifiesto (p. ej. pasiva y activa en el
+// informe) no lo lee y decodifica varias veces.
+func CollectArtifactsBySelectorSets(outdir string, sets ...map[string]ActiveState) ([]map[string][]Artifact, error) {
 	path := filepath.Join(outdir, "artifacts.jsonl")
 	file, err := os.Open(path)
 	if err != nil {
@@ -194,13 +244,16 @@ func CollectArtifactsByType(outdir string, selectors map[string]ActiveState) (ma
 		return nil, fmt.Errorf("crear reader: %w", err)
 	}
 
-	result := make(map[string][]Artifact, len(selectors))
+	results := make([]map[string][]Artifact, len(sets))
+	for i, selectors := range sets {
+		results[i] = make(map[string][]Artifact, len(selectors))
+	}
 
 	for {
 		artifact, err := reader.ReadArtifact()
 		if err != nil {
 			// EOF es esperado al finalizar el archivo
-			if err.Error() == "EOF" {
+			if errors.Is(err, io.EOF) {
 				break
 			}
 			return nil, fmt.Errorf("leer artifact: %w", err)
@@ -211,66 +264,77 @@ func CollectArtifactsByType(outdir string, selectors map[string]ActiveState) (ma
 			continue
 		}
 
-		typeSet := make(map[string]struct{})
-		orderedTypes := make([]string, 0, len(artifact.Types)+1)
-		if primary := strings.TrimSpace(artifact.Type); primary != "" {
-			if _, exists := typeSet[primary]; !exists {
-				typeSet[primary] = struct{}{}
-				orderedTypes = append(orderedTypes, primary)
-			}
-		}
-		for _, typ := range artifact.Types {
-			typ = strings.TrimSpace(typ)
-			if typ == "" {
-				continue
-			}
-			if _, exists := typeSet[typ]; exists {
-				continue
-			}
-			typeSet[typ] = struct{}{}
-			orderedTypes = append(orderedTypes, typ)
+		orderedTypes := artifactTypeOrder(artifact)
+		for i, selectors := range sets {
+			collectSelected(results[i], artifact, orderedTypes, selectors)
 		}
-		for _, typ := range orderedTypes {
-			typ = strings.TrimSpace(typ)
-			if typ == "" {
-				continue
-			}
-			state, ok := selectors[typ]
-			if !ok {
-				continue
-			}
-			if !state.matches(artifact.Active) {
-				continue
-			}
-			if !state.matchesUp(artifact.Up) {
-				continue
-			}
-			artifactCopy := artifact
-			artifactCopy.Type = typ
-			extras := make([]string, 0, len(orderedTypes))
-			for _, candidate := range orderedTypes {
-				candidate = strings.TrimSpace(candidate)
-				if candidate == "" || candidate == typ {
-					continue
-				}
-				extras = append(extras, candidate)
-			}
-			if len(extras) == 0 {
-				artifactCopy.Types = nil
-			} else {
-				artifactCopy.Types = extras
+	}
+
+	for i, selectors := range sets {
+		for typ := range selectors {
+			if _, ok := results[i][typ]; !ok {
+				results[i][typ] = nil
 			}
-			result[typ] = append(result[typ], artifactCopy)
 		}
 	}
 
-	for typ := range selectors {
-		if _, ok := result[typ]; !ok {
-			result[typ] = nil
+	return results, nil
+}
+
+// artifactTypeOrder devuelve el tipo principal seguido de los secundarios, sin
+// vacíos ni duplicados.
+func artifactTypeOrder(artifact Artifact) []string {
+	typeSet := make(map[string]struct{})
+	orderedTypes := make([]string, 0, len(artifact.Types)+1)
+	if primary := strings.TrimSpace(artifact.Type); primary != "" {
+		typeSet[primary] = struct{}{}
+		orderedTypes = append(orderedTypes, primary)
+	}
+	for _, typ := range artifact.Types {
+		typ = strings.TrimSpace(typ)
+		if typ == "" {
+			continue
 		}
+		if _, exists := typeSet[typ]; exists {
+			continue
+		}
+		typeSet[typ] = struct{}{}
+		orderedTypes = append(orderedTypes, typ)
 	}
+	return orderedTypes
+}
 
-	return result, nil
+// collectSelected añade a result una copia del artefacto por cada tipo
+// seleccionado cuyo filtro de actividad coincide. En cada copia el tipo
+// seleccionado pasa a ser el principal y el resto quedan como secundarios.
+func collectSelected(result map[string][]Artifact, artifact Artifact, orderedTypes []string, selectors map[string]ActiveState) {
+	for _, typ := range orderedTypes {
+		state, ok := selectors[typ]
+		if !ok {
+			continue
+		}
+		if !state.matches(artifact.Active) {
+			continue
+		}
+		if !state.matchesUp(artifact.Up) {
+			continue
+		}
+		artifactCopy := artifact
+		artifactCopy.Type = typ
+		extras := make([]string, 0, len(orderedTypes))
+		for _, candidate := range orderedTypes {
+			if candidate == typ {
+				continue
+			}
+			extras = append(extras, candidate)
+		}
+		if len(extras) == 0 {
+			artifactCopy.Types = nil
+		} else {
+			artifactCopy.Types = extras
+		}
+		result[typ] = append(result[typ], artifactCopy)
+	}
 }
 
 // CollectValues es un envoltorio de conveniencia para solicitar un único tipo
@@ -1,8 +1,13 @@
 package artifacts
 
 import (
+	"errors"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -69,6 +74,74 @@ func TestCollectArtifactsByTypeMultiTypeRecords(t *testing.T) {
 	}
 }
 
+func TestCollectArtifactsBySelectorSetsMatchesPerSetCollection(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, filepath.Join(dir, "artifacts.jsonl"), []Artifact{
+		{Type: "domain", Value: "example.com", Up: true},
+		{Type: "domain", Value: "api.example.com", Active: true, Up: true},
+		{Type: "route", Types: []string{"html"}, Value: "https://app.example.com/login", Up: true},
+		{Type: "route", Types: []string{"html"}, Value: "https://app.example.com/admin", Active: true, Up: true},
+		{Type: "route", Value: "https://down.example.com/", Active: true, Up: false},
+		{Type: "phpinfo", Value: "https://app.example.com/info.php", Active: true, Up: true},
+		{Type: "meta", Value: "  ", Up: true},
+	})
+
+	passive := map[string]ActiveState{"domain": PassiveOnly, "route": PassiveOnly, "html": PassiveOnly, "meta": PassiveOnly}
+	active := map[string]ActiveState{"domain": ActiveOnly, "route": ActiveOnly, "phpinfo": ActiveOnly, "dns": ActiveOnly}
+	both := map[string]ActiveState{"route": AnyState, "html": AnyState}
+
+	got, err := CollectArtifactsBySelectorSets(dir, passive, active, both)
+	if err != nil {
+		t.Fatalf("CollectArtifactsBySelectorSets: %v", err)
+	}
+
+	// Valores esperados por tipo en cada conjunto, derivados a mano del
+	// fixture: meta y dns no tienen artefactos (el meta en blanco se descarta)
+	// pero conservan su entrada.
+	want := []map[string][]string{
+		{
+			"domain": {"example.com"},
+			"route":  {"https://app.example.com/login"},
+			"html":   {"https://app.example.com/login"},
+			"meta":   nil,
+		},
+		{
+			"domain":  {"api.example.com"},
+			"route":   {"https://app.example.com/admin", "https://down.example.com/"},
+			"phpinfo": {"https://app.example.com/info.php"},
+			"dns":     nil,
+		},
+		{
+			"route": {"https://app.example.com/admin", "https://app.example.com/login", "https://down.example.com/"},
+			"html":  {"https://app.example.com/admin", "https://app.example.com/login"},
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(got))
+	}
+	for i := range want {
+		values := make(map[string][]string, len(got[i]))
+		for typ, arts := range got[i] {
+			values[typ] = nil
+			for _, art := range arts {
+				if art.Type != typ {
+					t.Fatalf("selector set %d: artifact %q collected under %q has type %q", i, art.Value, typ, art.Type)
+				}
+				values[typ] = append(values[typ], art.Value)
+			}
+			sort.Strings(values[typ])
+		}
+		if diff := cmp.Diff(want[i], values); diff != "" {
+			t.Fatalf("selector set %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	if diff := cmp.Diff([]string{"route"}, got[0]["html"][0].Types); diff != "" {
+		t.Fatalf("expected the html copy to keep route as secondary type (-want +got):\n%s", diff)
+	}
+}
+
 func writeArtifactsFile(t *testing.T, path string, artifacts []Artifact) {
 	t.Helper()
 
@@ -77,3 +150,156 @@ func writeArtifactsFile(t *testing.T, path string, artifacts []Artifact) {
 		t.Fatalf("WriteArtifacts(%q): %v", path, err)
 	}
 }
+
+func TestReaderV2ReadsGzipAndPlainIdentically(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	arts := []Artifact{
+		{Type: "domain", Value: "example.com", Tool: "subfinder", Up: true},
+		{Type: "route", Value: "https://example.com/login", Active: true, Up: true, Tool: "httpx", Metadata: map[string]any{"status": float64(200)}},
+	}
+
+	plainPath := filepath.Join(dir, "artifacts.jsonl")
+	gzPath := filepath.Join(dir, "artifacts.jsonl.gz")
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, path := range []string{plainPath, gzPath} {
+		writer := NewWriterV2(path, "example.com")
+		writer.SetBaseTime(base)
+		if err := writer.WriteArtifacts(arts); err != nil {
+			t.Fatalf("WriteArtifacts(%q): %v", path, err)
+		}
+	}
+
+	raw, err := os.ReadFile(gzPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(raw) < 2 || raw[0] != 0x1f || raw[1] != 0x8b {
+		t.Fatalf("expected %s to be gzip-compressed, got prefix %q", gzPath, raw[:2])
+	}
+
+	read := func(path string) (*HeaderV2, []Artifact) {
+		t.Helper()
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Open(%q): %v", path, err)
+		}
+		defer f.Close()
+		reader, err := NewReaderV2(f)
+		if err != nil {
+			t.Fatalf("NewReaderV2(%q): %v", path, err)
+		}
+		var got []Artifact
+		for {
+			art, err := reader.ReadArtifact()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				t.Fatalf("ReadArtifact(%q): %v", path, err)
+			}
+			got = append(got, art)
+		}
+		return reader.GetHeader(), got
+	}
+
+	plainHeader, plainArts := read(plainPath)
+	gzHeader, gzArts := read(gzPath)
+	if gzHeader.Target != "example.com" || gzHeader.Created != base.Unix() {
+		t.Fatalf("unexpected gzip header: %+v", gzHeader)
+	}
+	if diff := cmp.Diff(plainHeader, gzHeader); diff != "" {
+		t.Fatalf("headers differ between plain and gzip (-plain +gzip):\n%s", diff)
+	}
+	if len(gzArts) != len(arts) {
+		t.Fatalf("expected %d artifacts from gzip file, got %d", len(arts), len(gzArts))
+	}
+	if diff := cmp.Diff(plainArts, gzArts); diff != "" {
+		t.Fatalf("artifacts differ between plain and gzip (-plain +gzip):\n%s", diff)
+	}
+}
+
+func TestWriterV2SetCompress(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "artifacts.jsonl")
+	writer := NewWriterV2(path, "example.com")
+	writer.SetCompress(true)
+	if err := writer.WriteArtifacts([]Artifact{{Type: "domain", Value: "example.com"}}); err != nil {
+		t.Fatalf("WriteArtifacts: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		t.Fatalf("NewReaderV2: %v", err)
+	}
+	all, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(all) != 1 || all[0].Value != "example.com" {
+		t.Fatalf("unexpected artifacts: %+v", all)
+	}
+}
+
+func TestReaderV2EachStreamsAndStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "artifacts.jsonl")
+	writeArtifactsFile(t, path, []Artifact{
+		{Type: "domain", Value: "a.example.com"},
+		{Type: "domain", Value: "b.example.com"},
+		{Type: "domain", Value: "c.example.com"},
+	})
+
+	open := func() *ReaderV2 {
+		t.Helper()
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		t.Cleanup(func() { f.Close() })
+		reader, err := NewReaderV2(f)
+		if err != nil {
+			t.Fatalf("NewReaderV2: %v", err)
+		}
+		return reader
+	}
+
+	var all []string
+	reader := open()
+	if err := reader.Each(func(art Artifact) error {
+		all = append(all, art.Value)
+		return nil
+	}); err != nil {
+		t.Fatalf("Each: %v", err)
+	}
+	if diff := cmp.Diff([]string{"a.example.com", "b.example.com", "c.example.com"}, all); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+	if _, err := reader.ReadArtifact(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF after the last artifact, got %v", err)
+	}
+
+	stop := errors.New("stop")
+	var seen []string
+	err := open().Each(func(art Artifact) error {
+		seen = append(seen, art.Value)
+		if len(seen) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected Each to return the callback error, got %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected Each to stop after 2 artifacts, got %v", seen)
+	}
+}
@@ -0,0 +1,44 @@
+package artifacts
+
+import "time"
+
+// FirstSeenSince indica si el artefacto se descubrió en since o después según
+// su first_seen. Los artefactos sin first_seen (o con un valor ilegible), como
+// los de manifiestos anteriores a ese campo, se consideran antiguos.
+func FirstSeenSince(artifact Artifact, since time.Time) bool {
+	if artifact.FirstSeen == "" {
+		return false
+	}
+	seen, err := time.Parse(time.RFC3339, artifact.FirstSeen)
+	if err != nil {
+		return false
+	}
+	return !seen.Before(since)
+}
+
+// FilterSince devuelve los artefactos de list descubiertos desde since. Con
+// since cero no se filtra y se devuelve list tal cual.
+func FilterSince(list []Artifact, since time.Time) []Artifact {
+	if since.IsZero() {
+		return list
+	}
+	filtered := list[:0:0]
+	for _, artifact := range list {
+		if FirstSeenSince(artifact, since) {
+			filtered = append(filtered, artifact)
+		}
+	}
+	return filtered
+}
+
+// FilterSinceByType aplica FilterSince a cada tipo de byType.
+func FilterSinceByType(byType map[string][]Artifact, since time.Time) map[string][]Artifact {
+	if since.IsZero() || byType == nil {
+		return byType
+	}
+	filtered := make(map[string][]Artifact, len(byType))
+	for typ, list := range byType {
+		filtered[typ] = FilterSince(list, since)
+	}
+	return filtered
+}
@@ -0,0 +1,28 @@
+package artifacts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	list := []Artifact{
+		{Type: "domain", Value: "new.example.com", FirstSeen: "2024-03-01T10:00:00Z"},
+		{Type: "domain", Value: "boundary.example.com", FirstSeen: "2024-01-01T00:00:00Z"},
+		{Type: "domain", Value: "old.example.com", FirstSeen: "2023-12-31T23:59:59Z"},
+		{Type: "domain", Value: "legacy.example.com"},
+		{Type: "domain", Value: "broken.example.com", FirstSeen: "ayer"},
+	}
+
+	got := FilterSince(list, since)
+	if len(got) != 2 || got[0].Value != "new.example.com" || got[1].Value != "boundary.example.com" {
+		t.Fatalf("unexpected filtered artifacts: %+v", got)
+	}
+	if len(list) != 5 || list[2].Value != "old.example.com" {
+		t.Fatalf("FilterSince modified its input: %+v", list)
+	}
+	if all := FilterSince(list, time.Time{}); len(all) != len(list) {
+		t.Fatalf("zero since should not filter, got %d artifacts", len(all))
+	}
+}
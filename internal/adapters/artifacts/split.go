@@ -0,0 +1,131 @@
+package artifacts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"passive-rec/internal/types"
+)
+
+// splitDirName es el subdirectorio de outdir donde SplitByType escribe un
+// manifiesto por tipo.
+const splitDirName = "artifacts"
+
+// SplitByType lee <dir>/artifacts.jsonl y escribe <dir>/artifacts/<tipo>.jsonl
+// con los artefactos cuyo tipo principal o alguno de sus tipos secundarios
+// registrados en types.Registry coincide, de modo
+// que un artefacto con varios tipos aparece en cada fichero. Cada fichero es
+// un manifiesto v2 con la cabecera del original. Los tipos sin artefactos no
+// generan fichero y se eliminan los .jsonl de una división anterior.
+func SplitByType(dir string) error {
+	src := filepath.Join(dir, "artifacts.jsonl")
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		return fmt.Errorf("split: %s: %w", src, err)
+	}
+	byType := make(map[string][]Artifact)
+	var order []string
+	err = reader.Each(func(art Artifact) error {
+		for _, typ := range splitTypes(art) {
+			if _, ok := byType[typ]; !ok {
+				order = append(order, typ)
+			}
+			byType[typ] = append(byType[typ], art)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("split: %s: %w", src, err)
+	}
+
+	outDir := filepath.Join(dir, splitDirName)
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	stale, err := filepath.Glob(filepath.Join(outDir, "*.jsonl"))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	header := reader.GetHeader()
+	for _, typ := range order {
+		writer := NewWriterV2(filepath.Join(outDir, typ+".jsonl"), header.Target)
+		writer.SetBaseTime(time.Unix(header.Created, 0).UTC())
+		for _, tool := range header.Tools {
+			writer.AddTool(tool)
+		}
+		if err := writer.WriteArtifacts(byType[typ]); err != nil {
+			return fmt.Errorf("split: %s: %w", typ, err)
+		}
+	}
+	return nil
+}
+
+// splitSecondaryTypes son los tipos de Types que tienen fichero propio: los
+// de types.Registry, con su nombre legacy y con el nuevo. El resto de
+// entradas de Types (reglas de gf, plantillas de Nuclei, ...) no son tipos de
+// artefacto y no generan fichero.
+var splitSecondaryTypes = func() map[string]struct{} {
+	known := make(map[string]struct{}, 2*len(types.Registry))
+	for _, def := range types.Registry {
+		known[def.Type] = struct{}{}
+		known[types.NewToLegacyType(def.Type, def.Subtype)] = struct{}{}
+	}
+	return known
+}()
+
+// splitTypes devuelve los nombres de fichero (sin extensión) en los que debe
+// aparecer el artefacto: su tipo principal y los de Types registrados, sin
+// repetir.
+func splitTypes(art Artifact) []string {
+	seen := make(map[string]struct{})
+	var names []string
+	for i, typ := range append([]string{art.Type}, art.Types...) {
+		if i > 0 {
+			if _, ok := splitSecondaryTypes[strings.TrimSpace(typ)]; !ok {
+				continue
+			}
+		}
+		name := splitFileName(typ)
+		if name == "" {
+			continue
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
+
+// splitFileName convierte un tipo en un nombre de fichero seguro: conserva
+// letras, dígitos, '.', '_' y '-' y sustituye el resto por '_'.
+func splitFileName(typ string) string {
+	typ = strings.TrimSpace(typ)
+	if typ == "" || typ == "." || typ == ".." {
+		return ""
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, typ)
+}
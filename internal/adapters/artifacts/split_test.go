@@ -0,0 +1,114 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitByTypeWritesOneManifestPerType(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, filepath.Join(dir, "artifacts.jsonl"), []Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true, Tool: "subfinder", Tools: []string{"subfinder"}},
+		{Type: "route", Types: []string{"api"}, Value: "https://app.example.com/api/v1", Up: true, Tool: "gau", Tools: []string{"gau"}},
+		{Type: "route", Value: "https://app.example.com/login", Up: true, Tool: "gau", Tools: []string{"gau"}},
+		{Type: "certificate", Value: `{"common_name":"example.com"}`, Up: true, Tool: "crtsh", Tools: []string{"crtsh"}},
+	})
+	// Restos de una división anterior con un tipo que ya no existe.
+	if err := os.MkdirAll(filepath.Join(dir, "artifacts"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "artifacts", "dns.jsonl"), []byte("stale\n"), 0o644); err != nil {
+		t.Fatalf("write stale: %v", err)
+	}
+
+	if err := SplitByType(dir); err != nil {
+		t.Fatalf("SplitByType: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "artifacts"))
+	if err != nil {
+		t.Fatalf("read split dir: %v", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	if diff := cmp.Diff([]string{"api.jsonl", "certificate.jsonl", "domain.jsonl", "route.jsonl"}, files); diff != "" {
+		t.Fatalf("unexpected split files (-want +got):\n%s", diff)
+	}
+
+	read := func(name string) []string {
+		t.Helper()
+		f, err := os.Open(filepath.Join(dir, "artifacts", name))
+		if err != nil {
+			t.Fatalf("open %s: %v", name, err)
+		}
+		defer f.Close()
+		reader, err := NewReaderV2(f)
+		if err != nil {
+			t.Fatalf("NewReaderV2 %s: %v", name, err)
+		}
+		arts, err := reader.ReadAll()
+		if err != nil {
+			t.Fatalf("ReadAll %s: %v", name, err)
+		}
+		var values []string
+		for _, art := range arts {
+			values = append(values, art.Value)
+		}
+		sort.Strings(values)
+		return values
+	}
+	if diff := cmp.Diff([]string{"https://app.example.com/api/v1", "https://app.example.com/login"}, read("route.jsonl")); diff != "" {
+		t.Fatalf("unexpected routes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"https://app.example.com/api/v1"}, read("api.jsonl")); diff != "" {
+		t.Fatalf("expected the multi-type route in api.jsonl too (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"app.example.com"}, read("domain.jsonl")); diff != "" {
+		t.Fatalf("unexpected domains (-want +got):\n%s", diff)
+	}
+}
+
+func TestSplitTypesIgnoresRuleIDs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		art  Artifact
+		want []string
+	}{
+		{Artifact{Type: "route", Types: []string{"api", "js"}}, []string{"route", "api", "js"}},
+		{Artifact{Type: "gfFinding", Types: []string{"aws-keys", "sqli"}}, []string{"gfFinding"}},
+		{Artifact{Type: "finding", Subtype: "nuclei", Types: []string{"CVE-2021-44228"}}, []string{"finding"}},
+		{Artifact{Type: "meta", Types: []string{"gfFinding", "../etc"}}, []string{"meta", "gfFinding"}},
+	}
+	for _, tc := range cases {
+		if diff := cmp.Diff(tc.want, splitTypes(tc.art)); diff != "" {
+			t.Errorf("splitTypes(%s %v) mismatch (-want +got):\n%s", tc.art.Type, tc.art.Types, diff)
+		}
+	}
+}
+
+func TestSplitFileNameSanitizesTypes(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"route":       "route",
+		" gfFinding ": "gfFinding",
+		"../etc":      ".._etc",
+		"a/b c":       "a_b_c",
+		"..":          "",
+		"":            "",
+	}
+	for input, want := range cases {
+		if got := splitFileName(input); got != want {
+			t.Errorf("splitFileName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
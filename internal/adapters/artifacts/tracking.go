@@ -0,0 +1,104 @@
+package artifacts
+
+import (
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultTrackingParams son los parámetros de tracking que se eliminan de las
+// rutas cuando se activa el filtrado sin una lista explícita. Un "*" final
+// indica coincidencia por prefijo.
+var DefaultTrackingParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "gclsrc", "msclkid", "yclid",
+	"mc_cid", "mc_eid", "_ga", "_gl", "igshid", "twclid", "ttclid",
+}
+
+type trackingMatcher struct {
+	exact    map[string]struct{}
+	prefixes []string
+}
+
+var trackingParams atomic.Pointer[trackingMatcher]
+
+// SetTrackingParams configura los parámetros que ExtractRouteBase elimina de
+// la query string para que las rutas que solo difieren en ellos se deduplican.
+// Una lista vacía desactiva el filtrado (comportamiento por defecto).
+func SetTrackingParams(patterns []string) {
+	matcher := &trackingMatcher{exact: make(map[string]struct{})}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if prefix != "" {
+				matcher.prefixes = append(matcher.prefixes, prefix)
+			}
+			continue
+		}
+		matcher.exact[pattern] = struct{}{}
+	}
+	if len(matcher.exact) == 0 && len(matcher.prefixes) == 0 {
+		trackingParams.Store(nil)
+		return
+	}
+	trackingParams.Store(matcher)
+}
+
+func (m *trackingMatcher) matches(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := m.exact[name]; ok {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrackingParams devuelve los nombres de parámetros de tracking presentes en la
+// ruta que ExtractRouteBase eliminaría con la configuración actual.
+func TrackingParams(raw string) []string {
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return nil
+	}
+	u, err := url.Parse(fields[0])
+	if err != nil || u.RawQuery == "" {
+		return nil
+	}
+	_, removed := stripTrackingQuery(u.RawQuery)
+	return removed
+}
+
+// stripTrackingQuery elimina de la query cruda los parámetros de tracking
+// conservando el orden y la codificación del resto.
+func stripTrackingQuery(rawQuery string) (string, []string) {
+	matcher := trackingParams.Load()
+	if matcher == nil || rawQuery == "" {
+		return rawQuery, nil
+	}
+	var kept []string
+	var removed []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(pair, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if matcher.matches(name) {
+			removed = append(removed, name)
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	if len(removed) == 0 {
+		return rawQuery, nil
+	}
+	return strings.Join(kept, "&"), removed
+}
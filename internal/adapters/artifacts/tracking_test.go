@@ -0,0 +1,42 @@
+package artifacts
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractRouteBaseStripsTrackingParams(t *testing.T) {
+	SetTrackingParams(DefaultTrackingParams)
+	t.Cleanup(func() { SetTrackingParams(nil) })
+
+	cases := map[string]string{
+		"https://example.com/page?utm_source=x&utm_medium=y":  "https://example.com/page",
+		"https://example.com/page?id=1&utm_campaign=z":        "https://example.com/page?id=1",
+		"https://example.com/page?fbclid=abc&id=1&gclid=def":  "https://example.com/page?id=1",
+		"https://example.com/page?UTM_Source=x&b=2&a=1 [200]": "https://example.com/page?b=2&a=1",
+		"https://example.com/page?utmost=1":                   "https://example.com/page?utmost=1",
+	}
+	for input, want := range cases {
+		if got := ExtractRouteBase(input); got != want {
+			t.Errorf("ExtractRouteBase(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	got := TrackingParams("https://example.com/page?utm_source=x&id=1&fbclid=abc [200]")
+	if diff := cmp.Diff([]string{"utm_source", "fbclid"}, got); diff != "" {
+		t.Fatalf("unexpected tracking params (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtractRouteBaseKeepsTrackingParamsByDefault(t *testing.T) {
+	SetTrackingParams(nil)
+
+	input := "https://example.com/page?utm_source=x"
+	if got := ExtractRouteBase(input); got != input {
+		t.Fatalf("ExtractRouteBase(%q) = %q, want unchanged", input, got)
+	}
+	if got := TrackingParams(input); len(got) != 0 {
+		t.Fatalf("expected no tracking params when disabled, got %v", got)
+	}
+}
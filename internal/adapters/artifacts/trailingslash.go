@@ -0,0 +1,28 @@
+package artifacts
+
+import (
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+var collapseTrailingSlash atomic.Bool
+
+// SetCollapseTrailingSlash activa que ExtractRouteBase elimine la barra final
+// de las rutas que no son la raíz, de modo que https://x/path y
+// https://x/path/ se deduplican como una sola ruta. Desactivado por defecto.
+func SetCollapseTrailingSlash(enabled bool) {
+	collapseTrailingSlash.Store(enabled)
+}
+
+// trimTrailingSlash elimina una única barra final del path de u si el
+// colapso está activo. La raíz ("/") y la query se conservan.
+func trimTrailingSlash(u *url.URL) {
+	if !collapseTrailingSlash.Load() || len(u.Path) <= 1 || !strings.HasSuffix(u.Path, "/") {
+		return
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimSuffix(u.RawPath, "/")
+	}
+}
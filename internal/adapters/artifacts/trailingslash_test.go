@@ -0,0 +1,32 @@
+package artifacts
+
+import "testing"
+
+func TestExtractRouteBaseCollapsesTrailingSlash(t *testing.T) {
+	SetCollapseTrailingSlash(true)
+	t.Cleanup(func() { SetCollapseTrailingSlash(false) })
+
+	cases := map[string]string{
+		"https://example.com/path/":           "https://example.com/path",
+		"HTTPS://Example.COM:443/path/ [200]": "https://example.com/path",
+		"https://example.com/a/b/?id=1":       "https://example.com/a/b?id=1",
+		"https://example.com/search?q=a/":     "https://example.com/search?q=a/",
+		"https://example.com/":                "https://example.com/",
+		"https://example.com/dir//":           "https://example.com/dir/",
+		"https://example.com/a%2Fb/":          "https://example.com/a%2Fb",
+	}
+	for input, want := range cases {
+		if got := ExtractRouteBase(input); got != want {
+			t.Errorf("ExtractRouteBase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestExtractRouteBaseKeepsTrailingSlashByDefault(t *testing.T) {
+	SetCollapseTrailingSlash(false)
+
+	input := "https://example.com/path/"
+	if got := ExtractRouteBase(input); got != input {
+		t.Fatalf("ExtractRouteBase(%q) = %q, want unchanged", input, got)
+	}
+}
@@ -0,0 +1,80 @@
+package artifacts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+// ExportURLList lee el manifiesto de srcPath y escribe en dstPath (p. ej.
+// urls.txt) las URLs únicas de las rutas dentro de scope, pasivas y activas,
+// una por línea y sin metadatos, para alimentar a otros escáneres. Devuelve el
+// número de URLs escritas.
+func ExportURLList(srcPath, dstPath string, scope *netutil.Scope) (int, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader, err := NewReaderV2(f)
+	if err != nil {
+		return 0, fmt.Errorf("url export: %s: %w", srcPath, err)
+	}
+	all, err := reader.ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("url export: %s: %w", srcPath, err)
+	}
+
+	out, err := os.Create(dstPath)
+	if err != nil {
+		return 0, fmt.Errorf("url export: %w", err)
+	}
+	count, err := WriteURLList(out, all, scope)
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("url export: %s: %w", dstPath, err)
+	}
+	return count, nil
+}
+
+// WriteURLList escribe en w las URLs absolutas de las rutas de list dentro de
+// scope, ordenadas y sin duplicados. La misma URL vista en pasivo y en activo
+// se escribe una sola vez.
+func WriteURLList(w io.Writer, list []Artifact, scope *netutil.Scope) (int, error) {
+	seen := make(map[string]struct{})
+	var urls []string
+	for _, art := range list {
+		if art.Type != "route" || !artifactInScope(art, scope) {
+			continue
+		}
+		value := ExtractRouteBase(art.Value)
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			continue
+		}
+		if _, dup := seen[value]; dup {
+			continue
+		}
+		seen[value] = struct{}{}
+		urls = append(urls, value)
+	}
+	sort.Strings(urls)
+
+	bw := bufio.NewWriter(w)
+	for _, value := range urls {
+		if _, err := bw.WriteString(value + "\n"); err != nil {
+			return 0, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return len(urls), nil
+}
@@ -0,0 +1,47 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+func TestExportURLListWritesUnionOfRoutes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "artifacts.jsonl")
+	writeArtifactsFile(t, src, []Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true},
+		{Type: "route", Value: "https://app.example.com/login", Up: true},
+		{Type: "route", Value: "https://app.example.com/login", Active: true, Up: true, Metadata: map[string]any{"status": 200}},
+		{Type: "route", Value: "https://app.example.com/admin [403]", Active: true, Up: true},
+		{Type: "route", Value: "http://api.example.com/v1/users?id=1", Up: true},
+		{Type: "route", Value: "https://tracker.other.com/pixel", Up: true},
+		{Type: "route", Value: "/relative/path", Up: true},
+	})
+
+	dst := filepath.Join(dir, "urls.txt")
+	count, err := ExportURLList(src, dst, netutil.NewScope("example.com", "subdomains"))
+	if err != nil {
+		t.Fatalf("ExportURLList: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("ExportURLList wrote %d urls, want 3", count)
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "http://api.example.com/v1/users?id=1\n" +
+		"https://app.example.com/admin\n" +
+		"https://app.example.com/login\n"
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Fatalf("unexpected urls.txt (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,64 @@
+package artifacts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxValidationErrors es el número de infracciones que Validate detalla; el
+// resto solo se cuenta.
+const maxValidationErrors = 20
+
+// Validate comprueba que r contenga un artifacts.jsonl conforme al formato
+// v2: header con schema y target, y registros con type y value no vacíos.
+// Lee con NewReaderV2, así que acepta lo mismo que el reader (incluido gzip).
+// Devuelve nil si todo es válido o un error múltiple (errors.Join) con las
+// primeras infracciones indicando su número de línea y un resumen del resto.
+// Un header ilegible o un fallo de lectura se devuelven sin seguir.
+func Validate(r io.Reader) error {
+	reader, err := NewReaderV2(r)
+	if err != nil {
+		return fmt.Errorf("línea 1: %w", err)
+	}
+
+	var errs []error
+	omitted := 0
+	report := func(err error) {
+		if len(errs) < maxValidationErrors {
+			errs = append(errs, err)
+			return
+		}
+		omitted++
+	}
+
+	if strings.TrimSpace(reader.GetHeader().Target) == "" {
+		report(errors.New("línea 1: header sin target"))
+	}
+	for {
+		art, err := reader.ReadArtifact()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if scanErr := reader.scanner.Err(); scanErr != nil {
+				report(fmt.Errorf("línea %d: %w", reader.line+1, scanErr))
+				break
+			}
+			report(fmt.Errorf("línea %d: %w", reader.line, err))
+			continue
+		}
+		if strings.TrimSpace(art.Type) == "" {
+			report(fmt.Errorf("línea %d: registro sin type", reader.line))
+		}
+		if strings.TrimSpace(art.Value) == "" {
+			report(fmt.Errorf("línea %d: registro sin value", reader.line))
+		}
+	}
+
+	if omitted > 0 {
+		errs = append(errs, fmt.Errorf("... y %d errores más", omitted))
+	}
+	return errors.Join(errs...)
+}
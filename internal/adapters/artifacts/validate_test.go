@@ -0,0 +1,87 @@
+package artifacts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateAcceptsWriterOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "artifacts.jsonl")
+	writeArtifactsFile(t, path, []Artifact{
+		{Type: "domain", Value: "www.test.com", Up: true},
+		{Type: "route", Value: "https://www.test.com/login", Active: true, Up: true},
+	})
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	if err := Validate(f); err != nil {
+		t.Fatalf("expected writer output to be valid, got %v", err)
+	}
+}
+
+func TestValidateReportsViolationsWithLineNumbers(t *testing.T) {
+	input := strings.Join([]string{
+		`{"$":"2.0","@":1700000000,"target":""}`,
+		`{"t":"domain","v":"www.example.com","s":"u"}`,
+		`{"t":"","v":"orphan","s":"u"}`,
+		`{"t":"route","v":"","s":"u"}`,
+		`not json`,
+	}, "\n")
+
+	err := Validate(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("expected violations to be reported")
+	}
+	got := strings.Split(err.Error(), "\n")
+	want := []string{
+		"línea 1: header sin target",
+		"línea 3: registro sin type",
+		"línea 4: registro sin value",
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 violations, got %q", got)
+	}
+	for i, line := range want {
+		if got[i] != line {
+			t.Fatalf("violation %d = %q, want %q", i, got[i], line)
+		}
+	}
+	if !strings.HasPrefix(got[3], "línea 5: ") {
+		t.Fatalf("expected the invalid JSON on line 5 to be reported, got %q", got[3])
+	}
+}
+
+func TestValidateSummarizesAfterTwentyErrors(t *testing.T) {
+	lines := []string{`{"$":"2.0","@":1700000000,"target":"example.com"}`}
+	for i := 0; i < 25; i++ {
+		lines = append(lines, fmt.Sprintf(`{"t":"route","v":"","s":"u","n":%d}`, i))
+	}
+
+	err := Validate(strings.NewReader(strings.Join(lines, "\n")))
+	if err == nil {
+		t.Fatal("expected violations to be reported")
+	}
+	got := strings.Split(err.Error(), "\n")
+	if len(got) != maxValidationErrors+1 {
+		t.Fatalf("expected %d detailed errors plus a summary, got %d", maxValidationErrors, len(got))
+	}
+	if got[0] != "línea 2: registro sin value" || got[maxValidationErrors-1] != "línea 21: registro sin value" {
+		t.Fatalf("unexpected detailed errors: %q ... %q", got[0], got[maxValidationErrors-1])
+	}
+	if got[maxValidationErrors] != "... y 5 errores más" {
+		t.Fatalf("unexpected summary %q", got[maxValidationErrors])
+	}
+}
+
+func TestValidateRejectsUnknownSchema(t *testing.T) {
+	err := Validate(strings.NewReader(`{"$":"1.0","target":"example.com"}`))
+	if err == nil || !strings.Contains(err.Error(), "schema") {
+		t.Fatalf("expected a schema error, got %v", err)
+	}
+}
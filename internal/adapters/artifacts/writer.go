@@ -2,9 +2,11 @@ package artifacts
 
 import (
 	"bufio"
+	"compress/gzip"
 	"encoding/json"
 	"io"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -15,15 +17,20 @@ type WriterV2 struct {
 	headerWritten bool
 	target        string
 	tools         []string
+	compress      bool
 }
 
-// NewWriterV2 crea un nuevo writer para formato v2.
+// NewWriterV2 crea un nuevo writer para formato v2. Si path termina en ".gz"
+// la salida se comprime con gzip (ver SetCompress).
 func NewWriterV2(path, target string) *WriterV2 {
+	// La cabecera guarda Created en segundos: se trunca el tiempo base para que
+	// los timestamps relativos se reconstruyan sin desfase al releer.
 	return &WriterV2{
 		path:     path,
-		baseTime: time.Now().UTC(),
+		baseTime: time.Now().UTC().Truncate(time.Second),
 		target:   target,
 		tools:    []string{},
+		compress: strings.HasSuffix(strings.ToLower(path), ".gz"),
 	}
 }
 
@@ -88,7 +95,13 @@ func (w *WriterV2) WriteArtifacts(artifacts []Artifact) error {
 	}
 	defer f.Close()
 
-	writer := bufio.NewWriter(f)
+	var dst io.Writer = f
+	var gz *gzip.Writer
+	if w.compress {
+		gz = gzip.NewWriter(f)
+		dst = gz
+	}
+	writer := bufio.NewWriter(dst)
 
 	// Escribir header
 	if err := w.WriteHeader(writer); err != nil {
@@ -113,8 +126,13 @@ func (w *WriterV2) WriteArtifacts(artifacts []Artifact) error {
 	if err := writer.Flush(); err != nil {
 		return err
 	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
 
-	return nil
+	return f.Close()
 }
 
 // AddTool agrega una tool al catálogo del header.
@@ -127,6 +145,12 @@ func (w *WriterV2) AddTool(tool string) {
 	w.tools = append(w.tools, tool)
 }
 
+// SetCompress activa o desactiva la compresión gzip de WriteArtifacts. Los
+// ficheros comprimidos se leen igual con NewReaderV2.
+func (w *WriterV2) SetCompress(compress bool) {
+	w.compress = compress
+}
+
 // SetBaseTime establece el tiempo base para timestamps relativos.
 func (w *WriterV2) SetBaseTime(t time.Time) {
 	w.baseTime = t
@@ -0,0 +1,287 @@
+// Package export publica el directorio de salida de una ejecución en un
+// almacenamiento de objetos compatible con S3.
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// httpClient y now permiten sustituir la red y el reloj en tests.
+	httpClient = http.DefaultClient
+	now        = time.Now
+	// maxObjectSize es el tamaño máximo que S3 acepta en un único PUT (5 GiB).
+	// No se usan subidas multipart: los ficheros mayores se rechazan antes de
+	// enviar nada.
+	maxObjectSize int64 = 5 << 30
+)
+
+// unsignedPayload indica a S3 que el cuerpo no va firmado: así cada fichero se
+// envía en streaming sin leerlo antes para calcular su hash.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// s3Credentials son las credenciales y el destino leídos del entorno con los
+// mismos nombres que usan las herramientas de AWS.
+type s3Credentials struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Region       string
+	// Endpoint sustituye al de AWS para almacenamientos compatibles (MinIO,
+	// R2...). Con él se usan rutas path-style (endpoint/bucket/clave).
+	Endpoint string
+}
+
+func credentialsFromEnv() (s3Credentials, error) {
+	creds := s3Credentials{
+		AccessKey:    strings.TrimSpace(os.Getenv("AWS_ACCESS_KEY_ID")),
+		SecretKey:    strings.TrimSpace(os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		SessionToken: strings.TrimSpace(os.Getenv("AWS_SESSION_TOKEN")),
+		Region:       strings.TrimSpace(os.Getenv("AWS_REGION")),
+		Endpoint:     strings.TrimSpace(os.Getenv("AWS_ENDPOINT_URL_S3")),
+	}
+	if creds.Region == "" {
+		creds.Region = strings.TrimSpace(os.Getenv("AWS_DEFAULT_REGION"))
+	}
+	if creds.Region == "" {
+		creds.Region = "us-east-1"
+	}
+	if creds.Endpoint == "" {
+		creds.Endpoint = strings.TrimSpace(os.Getenv("AWS_ENDPOINT_URL"))
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return s3Credentials{}, errors.New("export: faltan credenciales (AWS_ACCESS_KEY_ID y AWS_SECRET_ACCESS_KEY)")
+	}
+	return creds, nil
+}
+
+// ParseS3URL separa un destino s3://bucket/prefijo en bucket y prefijo (sin
+// barras en los extremos).
+func ParseS3URL(dst string) (bucket, prefix string, err error) {
+	u, err := url.Parse(strings.TrimSpace(dst))
+	if err != nil {
+		return "", "", fmt.Errorf("export: destino %q inválido: %w", dst, err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("export: destino %q inválido: se esperaba s3://bucket/prefijo", dst)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// UploadDir sube cada fichero de dir a dst (s3://bucket/prefijo) con la clave
+// prefijo/<ruta relativa>. Los ficheros se envían en streaming desde disco,
+// de modo que un artifacts.jsonl grande no se carga en memoria, con el
+// Content-Type inferido de la extensión. Cada fichero se sube con un único PUT,
+// así que los mayores de 5 GiB fallan sin llegar a enviarse. Un fallo en un
+// fichero no detiene el resto; los errores se devuelven combinados.
+func UploadDir(ctx context.Context, dir string, dst string) error {
+	bucket, prefix, err := ParseS3URL(dst)
+	if err != nil {
+		return err
+	}
+	creds, err := credentialsFromEnv()
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.Type().IsRegular() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("export: recorrer %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	var errs []error
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		key := path.Join(prefix, filepath.ToSlash(rel))
+		if err := putObject(ctx, creds, bucket, key, file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// putObject sube el fichero local file a bucket/key con un PUT firmado.
+func putObject(ctx context.Context, creds s3Credentials, bucket, key, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	if info.Size() > maxObjectSize {
+		return fmt.Errorf("export: %s: %d bytes supera el máximo de %d bytes de un PUT de S3 (5 GiB); comprímelo o súbelo aparte", key, info.Size(), maxObjectSize)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL(creds, bucket, key), f)
+	if err != nil {
+		return fmt.Errorf("export: %s: %w", key, err)
+	}
+	req.ContentLength = info.Size()
+	if info.Size() == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("Content-Type", contentTypeFor(key))
+	signV4(req, creds, now().UTC())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("export: PUT %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("export: PUT %s: %s: %s", key, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func objectURL(creds s3Credentials, bucket, key string) string {
+	if creds.Endpoint != "" {
+		return strings.TrimRight(creds.Endpoint, "/") + "/" + escapePath(bucket) + "/" + escapePath(key)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, creds.Region, escapePath(key))
+}
+
+// contentTypeFor devuelve el Content-Type de una clave según su extensión.
+func contentTypeFor(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".html", ".htm":
+		return "text/html; charset=utf-8"
+	case ".json":
+		return "application/json"
+	case ".jsonl", ".ndjson":
+		return "application/x-ndjson"
+	case ".txt", ".passive", ".active":
+		return "text/plain; charset=utf-8"
+	}
+	if typ := mime.TypeByExtension(path.Ext(name)); typ != "" {
+		return typ
+	}
+	return "application/octet-stream"
+}
+
+// signV4 firma req con AWS Signature Version 4 para el servicio s3. Si req no
+// trae ya el hash del cuerpo en X-Amz-Content-Sha256, el cuerpo no se firma
+// (UNSIGNED-PAYLOAD).
+func signV4(req *http.Request, creds s3Credentials, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + creds.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretKey), date)
+	key = hmacSHA256(key, creds.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// escapePath codifica cada segmento de una clave como exige SigV4: solo los
+// caracteres no reservados (A-Z, a-z, 0-9, '-', '_', '.', '~') van sin
+// codificar.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		var b strings.Builder
+		for _, c := range []byte(segment) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+				b.WriteByte(c)
+				continue
+			}
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, "/")
+}
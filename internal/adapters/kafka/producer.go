@@ -0,0 +1,79 @@
+// Package kafka publica artefactos del pipeline en un topic de Kafka.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Producer implementa pipeline.ArtifactProducer sobre un kafka.Writer. Los
+// mensajes con la misma clave (tipo de artefacto) van a la misma partición.
+// Las escrituras son asíncronas: el primer error de entrega lo guarda el
+// callback Completion y Close lo devuelve.
+type Producer struct {
+	writer *kafkago.Writer
+
+	mu       sync.Mutex
+	firstErr error
+}
+
+// NewProducer crea un productor asíncrono para el topic indicado.
+func NewProducer(brokers []string, topic string) (*Producer, error) {
+	var addrs []string
+	for _, broker := range brokers {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			addrs = append(addrs, broker)
+		}
+	}
+	topic = strings.TrimSpace(topic)
+	if len(addrs) == 0 {
+		return nil, errors.New("kafka: no se indicaron brokers")
+	}
+	if topic == "" {
+		return nil, errors.New("kafka: topic vacío")
+	}
+	p := &Producer{}
+	p.writer = &kafkago.Writer{
+		Addr:         kafkago.TCP(addrs...),
+		Topic:        topic,
+		Balancer:     &kafkago.Hash{},
+		BatchTimeout: 100 * time.Millisecond,
+		Async:        true,
+		Completion:   p.complete,
+	}
+	return p, nil
+}
+
+// complete recibe el resultado de cada lote asíncrono y guarda el primer
+// error, que de otro modo se perdería.
+func (p *Producer) complete(_ []kafkago.Message, err error) {
+	if err == nil {
+		return
+	}
+	p.mu.Lock()
+	if p.firstErr == nil {
+		p.firstErr = err
+	}
+	p.mu.Unlock()
+}
+
+// Produce encola un mensaje con la clave y el valor dados.
+func (p *Producer) Produce(ctx context.Context, key, value []byte) error {
+	return p.writer.WriteMessages(ctx, kafkago.Message{Key: key, Value: value})
+}
+
+// Close vacía los mensajes pendientes, cierra las conexiones y devuelve el
+// primer error de entrega de los lotes asíncronos.
+func (p *Producer) Close() error {
+	// writer.Close espera a los Completion pendientes.
+	closeErr := p.writer.Close()
+	p.mu.Lock()
+	deliveryErr := p.firstErr
+	p.mu.Unlock()
+	return errors.Join(closeErr, deliveryErr)
+}
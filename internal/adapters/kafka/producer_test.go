@@ -0,0 +1,48 @@
+package kafka
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewProducerValidatesConfig(t *testing.T) {
+	if _, err := NewProducer(nil, "artifacts"); err == nil {
+		t.Fatalf("expected error without brokers")
+	}
+	if _, err := NewProducer([]string{"localhost:9092"}, " "); err == nil {
+		t.Fatalf("expected error without topic")
+	}
+	p, err := NewProducer([]string{" localhost:9092 ", ""}, "artifacts")
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+	if p.writer.Topic != "artifacts" || p.writer.Addr.String() != "localhost:9092" {
+		t.Fatalf("unexpected writer config: topic=%q addr=%q", p.writer.Topic, p.writer.Addr.String())
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestProducerCloseReturnsFirstDeliveryError(t *testing.T) {
+	p, err := NewProducer([]string{"localhost:9092"}, "artifacts")
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+	if p.writer.Completion == nil {
+		t.Fatalf("expected a Completion callback on the async writer")
+	}
+	first := errors.New("kafka: broker unavailable")
+	p.writer.Completion(nil, nil)
+	p.writer.Completion(nil, first)
+	p.writer.Completion(nil, errors.New("kafka: second failure"))
+
+	err = p.Close()
+	if !errors.Is(err, first) {
+		t.Fatalf("expected Close to return the first delivery error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "second failure") {
+		t.Fatalf("expected only the first delivery error, got %v", err)
+	}
+}
@@ -232,7 +232,14 @@ func buildHTMLReport(report *analysis.Report) string {
 	sb.WriteString(` | <strong>Report Generated:</strong> `)
 	sb.WriteString(report.ReportDate.Format("2006-01-02 15:04:05"))
 	sb.WriteString(`</p>
-        </header>
+`)
+	if report.Since != "" {
+		sb.WriteString(`            <p><strong>Window:</strong> only artifacts discovered since `)
+		sb.WriteString(html.EscapeString(report.Since))
+		sb.WriteString(`</p>
+`)
+	}
+	sb.WriteString(`        </header>
 `)
 
 	// Executive Summary
@@ -338,6 +345,40 @@ func writeHTMLSummary(sb *strings.Builder, report *analysis.Report) {
             </table>`)
 	}
 
+	// Desglose por herramienta y tipo
+	if len(report.Summary.ToolBreakdown) > 0 {
+		sb.WriteString(`
+            <details>
+                <summary>Artifacts by Tool and Type</summary>
+                <table>
+                    <thead>
+                        <tr>
+                            <th>Tool</th>
+                            <th>Type</th>
+                            <th>Count</th>
+                        </tr>
+                    </thead>
+                    <tbody>`)
+		for _, row := range analysis.SortedToolBreakdown(report.Summary.ToolBreakdown) {
+			sb.WriteString(`
+                        <tr>
+                            <td>`)
+			sb.WriteString(html.EscapeString(row.Tool))
+			sb.WriteString(`</td>
+                            <td>`)
+			sb.WriteString(html.EscapeString(row.Type))
+			sb.WriteString(`</td>
+                            <td>`)
+			sb.WriteString(fmt.Sprintf("%d", row.Count))
+			sb.WriteString(`</td>
+                        </tr>`)
+		}
+		sb.WriteString(`
+                    </tbody>
+                </table>
+            </details>`)
+	}
+
 	sb.WriteString(`
         </div>`)
 }
@@ -697,6 +738,50 @@ func writeHTMLSecurity(sb *strings.Builder, security *analysis.SecurityFindings)
         </div>`)
 }
 
+// writeHTMLEmailSecurity muestra los registros SPF y DMARC del target y sus
+// políticas; los problemas concretos aparecen como hallazgos de seguridad.
+func writeHTMLEmailSecurity(sb *strings.Builder, email *analysis.EmailSecurity) {
+	orMissing := func(values []string) string {
+		if len(values) == 0 {
+			return `<em>not found</em>`
+		}
+		escaped := make([]string, 0, len(values))
+		for _, value := range values {
+			escaped = append(escaped, `<code>`+html.EscapeString(value)+`</code>`)
+		}
+		return strings.Join(escaped, "<br>")
+	}
+	rows := [][2]string{
+		{"SPF", orMissing(email.SPF)},
+		{"DMARC (_dmarc." + html.EscapeString(email.Domain) + ")", orMissing(email.DMARC)},
+	}
+	if email.SPFAll != "" {
+		rows = append(rows, [2]string{"SPF all mechanism", html.EscapeString(email.SPFAll)})
+	}
+	if email.DMARCPolicy != "" {
+		rows = append(rows, [2]string{"DMARC policy", html.EscapeString(email.DMARCPolicy)})
+	}
+
+	sb.WriteString(`
+            <h3>Email Security</h3>
+            <table>
+                <tbody>`)
+	for _, row := range rows {
+		sb.WriteString(`
+                    <tr>
+                        <td><strong>`)
+		sb.WriteString(row[0])
+		sb.WriteString(`</strong></td>
+                        <td>`)
+		sb.WriteString(row[1])
+		sb.WriteString(`</td>
+                    </tr>`)
+	}
+	sb.WriteString(`
+                </tbody>
+            </table>`)
+}
+
 func writeHTMLInfrastructure(sb *strings.Builder, infra *analysis.Infrastructure) {
 	sb.WriteString(`
         <div class="card">
@@ -731,6 +816,11 @@ func writeHTMLInfrastructure(sb *strings.Builder, infra *analysis.Infrastructure
             </table>`)
 	}
 
+	// Email Security
+	if infra.EmailSecurity != nil {
+		writeHTMLEmailSecurity(sb, infra.EmailSecurity)
+	}
+
 	// DNS
 	if len(infra.Nameservers) > 0 {
 		sb.WriteString(`
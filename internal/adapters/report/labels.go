@@ -0,0 +1,335 @@
+package report
+
+// reportLabels contiene los textos de la plantilla HTML por idioma. Las claves
+// con verbos de formato (%d, %.1f) se usan con la función tr de la plantilla;
+// las hl_* y severity_* son los textos de los highlights (ver highlightText).
+var reportLabels = map[string]map[string]string{
+	"es": {
+		"page_title":              "Informe passive-rec",
+		"heading":                 "Informe de passive-rec",
+		"surface_for":             "Evaluación de superficie para",
+		"generated":               "Generado",
+		"since_window":            "Solo artefactos descubiertos desde",
+		"output_dir":              "Directorio de salida",
+		"mode_mixed":              "Modo mixto (pasivo + activo)",
+		"mode_passive":            "Modo pasivo",
+		"section_summary":         "Resumen ejecutivo",
+		"section_highlights":      "Hallazgos clave",
+		"section_domains":         "Dominios",
+		"section_routes":          "Rutas",
+		"section_certificates":    "Certificados",
+		"section_meta":            "Meta",
+		"section_environments":    "Entornos no productivos",
+		"environments_subtext":    "Subdominios que siguen patrones de desarrollo, pruebas o preproducción (dev, test, staging, uat, qa). Suelen tener controles más laxos que producción.",
+		"nav_active":              "Recolección activa",
+		"summary_subtext":         "Vista rápida de los hallazgos más relevantes para priorizar acciones.",
+		"card_total":              "Total de artefactos procesados",
+		"card_total_sub":          "Entradas combinadas de dominios, rutas y certificados.",
+		"unique_domains":          "Dominios únicos",
+		"unique_domains_sub":      "Incluye %d dominios registrables distintos.",
+		"unique_hosts":            "Hosts únicos en rutas",
+		"unique_hosts_sub":        "Cobertura sobre %d esquemas de servicio; %.1f%% sin HTTPS.",
+		"unique_certificates":     "Certificados únicos",
+		"unique_certificates_sub": "%d por expirar en %d días.",
+		"finding_tag":             "Hallazgo",
+		"no_highlights":           "Sin hallazgos destacados generados automáticamente.",
+		"domains_total":           "Total de dominios recolectados",
+		"registrable":             "registrables",
+		"domains_avg_labels":      "Niveles promedio por dominio",
+		"domains_wildcards":       "Dominios comodín detectados",
+		"domains_subtext":         "Los dominios con mayor frecuencia ayudan a identificar activos críticos y oportunidades para consolidar cobertura.",
+		"top_registrable":         "Top dominios registrables",
+		"th_domain":               "Dominio",
+		"th_count":                "Conteo",
+		"label_distribution":      "Distribución por niveles",
+		"th_labels":               "Niveles",
+		"top_tlds":                "Top TLDs observados",
+		"interesting_domains":     "Dominios potencialmente sensibles",
+		"routes_total":            "Total de rutas",
+		"routes_unique_hosts":     "Hosts únicos observados",
+		"routes_avg_depth":        "Profundidad promedio de ruta",
+		"routes_https":            "Uso de HTTPS",
+		"routes_https_value":      "%.1f%% de las rutas.",
+		"routes_subtext":          "Las rutas identificadas permiten priorizar revisiones de servicios y detectar activos expuestos.",
+		"routes_insecure_hosts":   "Hosts con protocolos inseguros",
+		"schemes_by_volume":       "Esquemas por volumen",
+		"th_scheme":               "Esquema",
+		"path_depth":              "Profundidad de rutas",
+		"th_segments":             "Segmentos",
+		"insecure_hosts":          "Hosts con tráfico no cifrado",
+		"observed_ports":          "Puertos observados",
+		"top_params":              "Parámetros de query más frecuentes",
+		"th_param":                "Parámetro",
+		"th_port":                 "Puerto",
+		"nonstandard_ports":       "Servicios en puertos no estándar",
+		"interesting_paths":       "Endpoints con palabras clave sensibles",
+		"websocket_endpoints":     "Endpoints WebSocket",
+		"unique_issuers":          "Emisores únicos",
+		"expired_certificates":    "Certificados vencidos",
+		"unique_registrable":      "Dominios registrables únicos",
+		"certs_subtext":           "Los certificados permiten medir la higiene criptográfica y planificar renovaciones.",
+		"certs_expiring":          "Certificados por expirar (%d días)",
+		"next_expiration":         "Próximo vencimiento",
+		"latest_expiration":       "Último vencimiento observado",
+		"certs_top_registrable":   "Top dominios registrables asociados",
+		"top_issuers":             "Top emisores",
+		"th_issuer":               "Emisor",
+		"expired_list":            "Certificados vencidos destacados",
+		"expiring_list":           "Certificados próximos a expirar",
+		"wildcard_certs":          "Certificados wildcard",
+		"wildcard_certs_sub":      "%d certificados cubren nombres comodín (*.dominio).",
+		"no_meta":                 "Sin entradas meta.",
+		"section_ips":             "Infraestructura IP",
+		"unique_ips":              "IPs únicas",
+		"ips_subtext":             "Direcciones resueltas por las herramientas. Las subredes /24 con varios hosts suelen compartir proveedor o segmento de red.",
+		"top_subnets":             "Subredes /24 con más hosts",
+		"th_subnet":               "Subred",
+		"th_hosts":                "Hosts",
+		"section_emails":          "Correos descubiertos",
+		"unique_emails":           "Direcciones únicas",
+		"emails_subtext":          "Direcciones de correo de dominios en scope extraídas de las fuentes. Útiles para ingeniería social y para deducir el formato de usuario.",
+		"th_emails":               "Direcciones",
+		"section_favicons":        "Favicons",
+		"shared_favicons":         "Hashes compartidos por varios hosts",
+		"favicons_subtext":        "Hosts agrupados por el hash mmh3 de su favicon (el de http.favicon.hash en Shodan). Un mismo hash en hosts distintos sugiere infraestructura o producto común.",
+		"th_favicon_hash":         "Hash (mmh3)",
+		"section_ports":           "Puertos abiertos",
+		"open_ports":              "Puertos abiertos",
+		"port_hosts":              "Hosts con puertos abiertos",
+		"risky_ports":             "Servicios de riesgo expuestos",
+		"ports_subtext":           "Resultados importados de escáneres de puertos. Telnet, SMB, RDP, VNC, bases de datos y cachés no deberían ser accesibles desde Internet.",
+		"top_open_ports":          "Puertos en más hosts",
+		"top_port_hosts":          "Hosts con más puertos abiertos",
+		"th_host":                 "Host",
+		"active_title":            "Resultados de recolección activa",
+		"active_subtext":          "Hallazgos derivados de validaciones activas contra los activos descubiertos.",
+		"active_domains":          "Dominios activos detectados",
+		"active_domains_sub":      "%d dominios únicos observados.",
+		"active_routes":           "Rutas activas evaluadas",
+		"active_routes_sub":       "%d hosts; %.1f%% con HTTPS.",
+		"active_dns":              "Registros DNS activos",
+		"active_dns_sub":          "%d dominios con registros.",
+		"active_certs":            "Certificados activos observados",
+		"active_certs_sub":        "%d certificados únicos.",
+		"active_highlights":       "Hallazgos activos clave",
+		"no_active_highlights":    "Sin hallazgos activos destacados.",
+		"active_raw_domains":      "Dominios detectados",
+		"showing_domains":         "Mostrando %d de %d dominios activos.",
+		"no_active_domains":       "No se recolectaron dominios activos.",
+		"dns_records":             "Registros DNS",
+		"showing_dns":             "Mostrando %d de %d registros DNS.",
+		"no_active_dns":           "No se registraron resultados DNS activos.",
+		"record_types":            "Tipos de registros observados",
+		"th_type":                 "Tipo",
+		"active_raw_routes":       "Rutas activas",
+		"showing_routes":          "Mostrando %d de %d rutas activas.",
+		"no_active_routes":        "No se registraron rutas activas.",
+		"active_meta":             "Meta activa",
+		"no_active_meta":          "Sin entradas meta activas.",
+		"active_certs_section":    "Certificados (activos)",
+		"total_collected":         "Total recolectado",
+		"unique":                  "únicos",
+		"active_expired":          "Certificados vencidos detectados",
+		"active_expiring":         "Certificados por expirar pronto",
+		"footer_passive":          "Este informe resume artefactos recolectados de manera pasiva. Revise los hallazgos y priorice acciones según el apetito de riesgo de la organización.",
+		"footer_mixed":            "Este informe resume artefactos recolectados de manera pasiva y activa. Revise los hallazgos y priorice acciones según el apetito de riesgo de la organización.",
+		"hl_severity":             "%s (severidad %s)",
+		"severity_critical":       "crítica",
+		"severity_high":           "alta",
+		"severity_medium":         "media",
+		"severity_low":            "baja",
+		"hl_insecure_hosts":       "%d hosts exponen servicios sin HTTPS (por ejemplo %s)",
+		"hl_insecure_host":        "%d host expone servicios sin HTTPS (por ejemplo %s)",
+		"hl_insecure_routes":      "%.1f%% de las rutas carecen de HTTPS",
+		"hl_nonstandard_ports":    "Servicios en puertos no estándar detectados: %s",
+		"hl_sensitive_paths":      "Endpoints potencialmente sensibles encontrados (ej. %s)",
+		"hl_sensitive_domains":    "Dominios que sugieren entornos sensibles: %s",
+		"hl_expired_certs":        "%d certificados vencidos detectados",
+		"hl_expired_certs_ex":     "%d certificados vencidos, incluyendo %s",
+		"hl_expiring_certs":       "%d certificados por expirar en %d días",
+		"hl_expiring_certs_ex":    "%d certificados por expirar en %d días (ej. %s)",
+		"hl_wildcard_certs":       "%d certificados wildcard; una clave comprometida cubre todos los subdominios (ej. %s)",
+		"hl_weak_ciphers":         "Cipher suites TLS débiles negociadas: %s",
+		"hl_insecure_cookies":     "Cookies sin atributos Secure/HttpOnly/SameSite: %s",
+		"hl_clickjacking":         "Páginas HTTPS sin protección anti-clickjacking (severidad media): %s",
+		"hl_reflected_params":     "Parámetros reflejados sin escapar (posible XSS, severidad alta): %s",
+		"hl_phpinfo":              "Páginas phpinfo() expuestas (severidad alta)",
+		"hl_metrics":              "Endpoints de métricas Prometheus expuestos (severidad media/alta)",
+		"hl_api_schema":           "Ficheros de esquema de API expuestos (GraphQL SDL, RAML) que revelan la superficie completa",
+		"hl_search_cluster":       "Clusters de búsqueda expuestos (Elasticsearch/Kibana/Solr, severidad crítica)",
+		"hl_iac_state":            "Ficheros de estado de IaC expuestos (Terraform/Ansible, severidad crítica)",
+		"hl_apache_config":        "Ficheros .htaccess/.htpasswd expuestos (severidad alta/crítica)",
+		"hl_credential_file":      "Ficheros de credenciales expuestos (cloud, SSH, tokens, severidad crítica)",
+		"hl_actuator":             "Endpoints de Spring Boot Actuator expuestos (heapdump crítico; env, mappings y otros filtran configuración)",
+		"hl_mail":                 "Interfaces de correo web expuestas (webmail, OWA, Exchange; severidad media)",
+		"hl_source_code":          "Ficheros de código fuente servidos en crudo (.rb, .java, .inc, .phps; severidad alta)",
+		"hl_git":                  "Repositorios git expuestos (packs, objetos y reflogs permiten reconstruirlos por completo, severidad crítica)",
+		"hl_server_status":        "Páginas de estado del servidor expuestas (severidad alta)",
+		"hl_shared_favicons":      "Favicons idénticos en hosts distintos (posible infraestructura compartida): %s",
+		"hl_risky_ports":          "Servicios de riesgo expuestos (Telnet, SMB, RDP, bases de datos...): %s",
+	},
+	"en": {
+		"page_title":              "passive-rec report",
+		"heading":                 "passive-rec report",
+		"surface_for":             "Attack surface assessment for",
+		"generated":               "Generated",
+		"since_window":            "Only artifacts discovered since",
+		"output_dir":              "Output directory",
+		"mode_mixed":              "Mixed mode (passive + active)",
+		"mode_passive":            "Passive mode",
+		"section_summary":         "Executive summary",
+		"section_highlights":      "Key findings",
+		"section_domains":         "Domains",
+		"section_routes":          "Routes",
+		"section_certificates":    "Certificates",
+		"section_meta":            "Meta",
+		"section_environments":    "Non-production environments",
+		"environments_subtext":    "Subdomains matching development, testing or pre-production patterns (dev, test, staging, uat, qa). They tend to have weaker controls than production.",
+		"nav_active":              "Active collection",
+		"summary_subtext":         "Quick view of the most relevant findings to prioritise actions.",
+		"card_total":              "Total artifacts processed",
+		"card_total_sub":          "Combined domain, route and certificate entries.",
+		"unique_domains":          "Unique domains",
+		"unique_domains_sub":      "Includes %d distinct registrable domains.",
+		"unique_hosts":            "Unique hosts in routes",
+		"unique_hosts_sub":        "Coverage across %d service schemes; %.1f%% without HTTPS.",
+		"unique_certificates":     "Unique certificates",
+		"unique_certificates_sub": "%d expiring within %d days.",
+		"finding_tag":             "Finding",
+		"no_highlights":           "No automatically generated highlights.",
+		"domains_total":           "Total domains collected",
+		"registrable":             "registrable",
+		"domains_avg_labels":      "Average labels per domain",
+		"domains_wildcards":       "Wildcard domains detected",
+		"domains_subtext":         "The most frequent domains help identify critical assets and opportunities to consolidate coverage.",
+		"top_registrable":         "Top registrable domains",
+		"th_domain":               "Domain",
+		"th_count":                "Count",
+		"label_distribution":      "Label distribution",
+		"th_labels":               "Labels",
+		"top_tlds":                "Top observed TLDs",
+		"interesting_domains":     "Potentially sensitive domains",
+		"routes_total":            "Total routes",
+		"routes_unique_hosts":     "Unique hosts observed",
+		"routes_avg_depth":        "Average path depth",
+		"routes_https":            "HTTPS usage",
+		"routes_https_value":      "%.1f%% of routes.",
+		"routes_subtext":          "Identified routes help prioritise service reviews and spot exposed assets.",
+		"routes_insecure_hosts":   "Hosts using insecure protocols",
+		"schemes_by_volume":       "Schemes by volume",
+		"th_scheme":               "Scheme",
+		"path_depth":              "Path depth",
+		"th_segments":             "Segments",
+		"insecure_hosts":          "Hosts with unencrypted traffic",
+		"observed_ports":          "Observed ports",
+		"top_params":              "Most common query parameters",
+		"th_param":                "Parameter",
+		"th_port":                 "Port",
+		"nonstandard_ports":       "Services on non-standard ports",
+		"interesting_paths":       "Endpoints with sensitive keywords",
+		"websocket_endpoints":     "WebSocket endpoints",
+		"unique_issuers":          "Unique issuers",
+		"expired_certificates":    "Expired certificates",
+		"unique_registrable":      "Unique registrable domains",
+		"certs_subtext":           "Certificates help measure cryptographic hygiene and plan renewals.",
+		"certs_expiring":          "Certificates expiring (%d days)",
+		"next_expiration":         "Next expiration",
+		"latest_expiration":       "Latest expiration observed",
+		"certs_top_registrable":   "Top associated registrable domains",
+		"top_issuers":             "Top issuers",
+		"th_issuer":               "Issuer",
+		"expired_list":            "Notable expired certificates",
+		"expiring_list":           "Certificates about to expire",
+		"wildcard_certs":          "Wildcard certificates",
+		"wildcard_certs_sub":      "%d certificates cover wildcard names (*.domain).",
+		"no_meta":                 "No meta entries.",
+		"section_ips":             "IP infrastructure",
+		"unique_ips":              "Unique IPs",
+		"ips_subtext":             "Addresses resolved by the tools. /24 subnets with several hosts usually share a provider or network segment.",
+		"top_subnets":             "/24 subnets with most hosts",
+		"th_subnet":               "Subnet",
+		"th_hosts":                "Hosts",
+		"section_emails":          "Discovered emails",
+		"unique_emails":           "Unique addresses",
+		"emails_subtext":          "Email addresses from in-scope domains extracted from the sources. Useful for social engineering and for inferring the username format.",
+		"th_emails":               "Addresses",
+		"section_favicons":        "Favicons",
+		"shared_favicons":         "Hashes shared by several hosts",
+		"favicons_subtext":        "Hosts grouped by the mmh3 hash of their favicon (Shodan's http.favicon.hash). The same hash on different hosts suggests shared infrastructure or product.",
+		"th_favicon_hash":         "Hash (mmh3)",
+		"section_ports":           "Open ports",
+		"open_ports":              "Open ports",
+		"port_hosts":              "Hosts with open ports",
+		"risky_ports":             "Exposed risky services",
+		"ports_subtext":           "Results imported from port scanners. Telnet, SMB, RDP, VNC, databases and caches should not be reachable from the Internet.",
+		"top_open_ports":          "Ports on most hosts",
+		"top_port_hosts":          "Hosts with most open ports",
+		"th_host":                 "Host",
+		"active_title":            "Active collection results",
+		"active_subtext":          "Findings derived from active validation against the discovered assets.",
+		"active_domains":          "Active domains detected",
+		"active_domains_sub":      "%d unique domains observed.",
+		"active_routes":           "Active routes evaluated",
+		"active_routes_sub":       "%d hosts; %.1f%% over HTTPS.",
+		"active_dns":              "Active DNS records",
+		"active_dns_sub":          "%d domains with records.",
+		"active_certs":            "Active certificates observed",
+		"active_certs_sub":        "%d unique certificates.",
+		"active_highlights":       "Key active findings",
+		"no_active_highlights":    "No notable active findings.",
+		"active_raw_domains":      "Detected domains",
+		"showing_domains":         "Showing %d of %d active domains.",
+		"no_active_domains":       "No active domains were collected.",
+		"dns_records":             "DNS records",
+		"showing_dns":             "Showing %d of %d DNS records.",
+		"no_active_dns":           "No active DNS results were recorded.",
+		"record_types":            "Observed record types",
+		"th_type":                 "Type",
+		"active_raw_routes":       "Active routes",
+		"showing_routes":          "Showing %d of %d active routes.",
+		"no_active_routes":        "No active routes were recorded.",
+		"active_meta":             "Active meta",
+		"no_active_meta":          "No active meta entries.",
+		"active_certs_section":    "Certificates (active)",
+		"total_collected":         "Total collected",
+		"unique":                  "unique",
+		"active_expired":          "Expired certificates detected",
+		"active_expiring":         "Certificates expiring soon",
+		"footer_passive":          "This report summarises passively collected artifacts. Review the findings and prioritise actions according to the organisation's risk appetite.",
+		"footer_mixed":            "This report summarises passively and actively collected artifacts. Review the findings and prioritise actions according to the organisation's risk appetite.",
+		"hl_severity":             "%s (%s severity)",
+		"severity_critical":       "critical",
+		"severity_high":           "high",
+		"severity_medium":         "medium",
+		"severity_low":            "low",
+		"hl_insecure_hosts":       "%d hosts expose services without HTTPS (for example %s)",
+		"hl_insecure_host":        "%d host exposes services without HTTPS (for example %s)",
+		"hl_insecure_routes":      "%.1f%% of routes lack HTTPS",
+		"hl_nonstandard_ports":    "Services detected on non-standard ports: %s",
+		"hl_sensitive_paths":      "Potentially sensitive endpoints found (e.g. %s)",
+		"hl_sensitive_domains":    "Domains suggesting sensitive environments: %s",
+		"hl_expired_certs":        "%d expired certificates detected",
+		"hl_expired_certs_ex":     "%d expired certificates, including %s",
+		"hl_expiring_certs":       "%d certificates expiring within %d days",
+		"hl_expiring_certs_ex":    "%d certificates expiring within %d days (e.g. %s)",
+		"hl_wildcard_certs":       "%d wildcard certificates; one compromised key covers every subdomain (e.g. %s)",
+		"hl_weak_ciphers":         "Weak TLS cipher suites negotiated: %s",
+		"hl_insecure_cookies":     "Cookies without Secure/HttpOnly/SameSite attributes: %s",
+		"hl_clickjacking":         "HTTPS pages without clickjacking protection (medium severity): %s",
+		"hl_reflected_params":     "Parameters reflected unescaped (possible XSS, high severity): %s",
+		"hl_phpinfo":              "Exposed phpinfo() pages (high severity)",
+		"hl_metrics":              "Exposed Prometheus metrics endpoints (medium/high severity)",
+		"hl_api_schema":           "Exposed API schema files (GraphQL SDL, RAML) revealing the full surface",
+		"hl_search_cluster":       "Exposed search clusters (Elasticsearch/Kibana/Solr, critical severity)",
+		"hl_iac_state":            "Exposed IaC state files (Terraform/Ansible, critical severity)",
+		"hl_apache_config":        "Exposed .htaccess/.htpasswd files (high/critical severity)",
+		"hl_credential_file":      "Exposed credential files (cloud, SSH, tokens, critical severity)",
+		"hl_actuator":             "Exposed Spring Boot Actuator endpoints (heapdump is critical; env, mappings and others leak configuration)",
+		"hl_mail":                 "Exposed webmail interfaces (webmail, OWA, Exchange; medium severity)",
+		"hl_source_code":          "Source code files served raw (.rb, .java, .inc, .phps; high severity)",
+		"hl_git":                  "Exposed git repositories (packs, objects and reflogs allow rebuilding them entirely, critical severity)",
+		"hl_server_status":        "Exposed server status pages (high severity)",
+		"hl_shared_favicons":      "Identical favicons on different hosts (possible shared infrastructure): %s",
+		"hl_risky_ports":          "Exposed risky services (Telnet, SMB, RDP, databases...): %s",
+	},
+}
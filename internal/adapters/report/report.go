@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,66 +26,134 @@ import (
 
 // Generate reads the artifact manifest and renders an HTML report in cfg.OutDir.
 func Generate(ctx context.Context, cfg *config.Config) error {
-	if cfg == nil {
-		return errors.New("report: missing config")
+	data, err := buildReportData(ctx, cfg)
+	if err != nil {
+		return err
 	}
+
+	reportPath := filepath.Join(cfg.OutDir, "report.html")
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
+	f, err := os.Create(reportPath)
+	if err != nil {
+		return fmt.Errorf("report: create %q: %w", reportPath, err)
+	}
+	defer f.Close()
+
+	if err := reportTmpl.Execute(f, data); err != nil {
+		return fmt.Errorf("report: render: %w", err)
+	}
+	return nil
+}
+
+// GenerateStatsJSON writes report-stats.json in cfg.OutDir with the same data
+// the HTML report renders (overview, domains, routes, certificates, highlights
+// and the active view), so dashboards can consume it without scraping HTML.
+func GenerateStatsJSON(ctx context.Context, cfg *config.Config) error {
+	data, err := buildReportData(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("report: marshal stats: %w", err)
+	}
+	statsPath := filepath.Join(cfg.OutDir, "report-stats.json")
+	if err := os.WriteFile(statsPath, append(encoded, '\n'), 0o644); err != nil {
+		return fmt.Errorf("report: write %q: %w", statsPath, err)
+	}
+	return nil
+}
+
+// buildReportData lee el manifiesto con los selectores del informe y calcula
+// las estadísticas y highlights comunes a la salida HTML y JSON.
+func buildReportData(ctx context.Context, cfg *config.Config) (reportData, error) {
+	if cfg == nil {
+		return reportData{}, errors.New("report: missing config")
+	}
+	if err := ctx.Err(); err != nil {
+		return reportData{}, err
+	}
+
 	exists, err := artifacts.Exists(cfg.OutDir)
 	if err != nil {
-		return fmt.Errorf("report: artifacts manifest: %w", err)
+		return reportData{}, fmt.Errorf("report: artifacts manifest: %w", err)
 	}
 
 	selectors := map[string]artifacts.ActiveState{
-		"domain":      artifacts.PassiveOnly,
-		"route":       artifacts.PassiveOnly,
-		"certificate": artifacts.PassiveOnly,
-		"meta":        artifacts.PassiveOnly,
+		"domain":          artifacts.PassiveOnly,
+		"route":           artifacts.PassiveOnly,
+		"certificate":     artifacts.PassiveOnly,
+		"meta":            artifacts.PassiveOnly,
+		"server-status":   artifacts.AnyState,
+		"search-cluster":  artifacts.AnyState,
+		"iac-state":       artifacts.AnyState,
+		"apache-config":   artifacts.AnyState,
+		"git":             artifacts.AnyState,
+		"credential-file": artifacts.AnyState,
+		"actuator":        artifacts.AnyState,
+		"mail":            artifacts.AnyState,
+		"source-code":     artifacts.AnyState,
+		"dns":             artifacts.AnyState,
+		"ip":              artifacts.AnyState,
+		"email":           artifacts.AnyState,
+		"favicon":         artifacts.AnyState,
+		"port":            artifacts.AnyState,
+	}
+	activeSelectors := map[string]artifacts.ActiveState{
+		"domain":      artifacts.ActiveOnly,
+		"route":       artifacts.ActiveOnly,
+		"certificate": artifacts.ActiveOnly,
+		"meta":        artifacts.ActiveOnly,
+		"dns":         artifacts.ActiveOnly,
+		"phpinfo":     artifacts.ActiveOnly,
+		"metrics":     artifacts.ActiveOnly,
+		"api-schema":  artifacts.ActiveOnly,
+	}
+	// Las vistas pasiva y activa se obtienen con una sola lectura del manifiesto.
+	sets := []map[string]artifacts.ActiveState{selectors}
+	if cfg.Active {
+		sets = append(sets, activeSelectors)
 	}
-	var passiveArtifacts map[string][]artifacts.Artifact
+	var passiveArtifacts, activeArtifacts map[string][]artifacts.Artifact
 	if exists {
-		passiveArtifacts, err = artifacts.CollectArtifactsByType(cfg.OutDir, selectors)
+		collected, err := artifacts.CollectArtifactsBySelectorSets(cfg.OutDir, sets...)
 		if err != nil {
-			return fmt.Errorf("report: passive artifacts: %w", err)
+			return reportData{}, fmt.Errorf("report: artifacts: %w", err)
+		}
+		passiveArtifacts = artifacts.FilterSinceByType(collected[0], cfg.Since)
+		if cfg.Active {
+			activeArtifacts = artifacts.FilterSinceByType(collected[1], cfg.Since)
 		}
 	}
 
 	domains := artifactValues(passiveArtifacts["domain"])
 	routes := artifactValues(passiveArtifacts["route"])
-	certs := artifactValues(passiveArtifacts["certificate"])
+	certs := mergeRemappedCerts(artifactValues(passiveArtifacts["certificate"]), cfg, "certs.passive")
 	meta := artifactValues(passiveArtifacts["meta"])
+	limits := rowLimits(cfg.ReportRowLimits)
+	rules, err := newHighlightRules(cfg.HighlightRules)
+	if err != nil {
+		return reportData{}, err
+	}
+	lang, labels := reportLabelsFor(cfg.ReportLang)
+	text := highlightText(reportLabels[lang])
 
 	var active activeData
 	if cfg.Active {
 		if err := ctx.Err(); err != nil {
-			return err
-		}
-
-		activeSelectors := map[string]artifacts.ActiveState{
-			"domain":      artifacts.ActiveOnly,
-			"route":       artifacts.ActiveOnly,
-			"certificate": artifacts.ActiveOnly,
-			"meta":        artifacts.ActiveOnly,
-			"dns":         artifacts.ActiveOnly,
-		}
-
-		var activeArtifacts map[string][]artifacts.Artifact
-		if exists {
-			activeArtifacts, err = artifacts.CollectArtifactsByType(cfg.OutDir, activeSelectors)
-			if err != nil {
-				return fmt.Errorf("report: active artifacts: %w", err)
-			}
+			return reportData{}, err
 		}
 
 		activeDomains := artifactValues(activeArtifacts["domain"])
 		activeRoutes := artifactValues(activeArtifacts["route"])
-		activeCerts := artifactValues(activeArtifacts["certificate"])
+		activeCerts := mergeRemappedCerts(artifactValues(activeArtifacts["certificate"]), cfg, "certs.active")
 		activeMeta := artifactValues(activeArtifacts["meta"])
 		activeDNSRecords, err := parseDNSArtifacts(activeArtifacts["dns"])
 		if err != nil {
-			return fmt.Errorf("report: active dns artifacts: %w", err)
+			return reportData{}, fmt.Errorf("report: active dns artifacts: %w", err)
 		}
 
 		active = activeData{
@@ -92,21 +162,46 @@ func Generate(ctx context.Context, cfg *config.Config) error {
 			RawDNS:     formatDNSRecords(activeDNSRecords),
 			Meta:       activeMeta,
 		}
-		active.Domains = buildDomainStats(activeDomains)
-		active.Routes = buildRouteStats(activeRoutes)
-		active.DNS = buildDNSStats(activeDNSRecords)
-		active.Certificates = buildCertStats(activeCerts)
-		active.Highlights = buildHighlights(active.Domains, active.Routes, active.Certificates)
+		active.Domains = buildDomainStats(activeDomains, limits)
+		active.Routes = buildRouteStats(activeRoutes, limits)
+		active.DNS = buildDNSStats(activeDNSRecords, limits)
+		active.Certificates = buildCertStats(activeCerts, limits, cfg.CertExpiryWindowDays)
+		active.Highlights = buildHighlights(active.Domains, active.Routes, active.Certificates, rules, text)
+		if weak := collectWeakCiphers(activeArtifacts["route"]); len(weak) > 0 {
+			active.Highlights = append(active.Highlights, text.sprintf("hl_weak_ciphers", strings.Join(limitStrings(weak, 3), ", ")))
+		}
+		if cookies := collectInsecureCookies(activeArtifacts["route"]); len(cookies) > 0 {
+			active.Highlights = append(active.Highlights, text.sprintf("hl_insecure_cookies", strings.Join(limitStrings(cookies, 3), ", ")))
+		}
+		if framed := collectClickjackingPages(activeArtifacts["route"]); len(framed) > 0 {
+			active.Highlights = append(active.Highlights, text.sprintf("hl_clickjacking", strings.Join(limitStrings(framed, 3), ", ")))
+		}
+		if reflected := collectReflectedParams(activeArtifacts["route"]); len(reflected) > 0 {
+			active.Highlights = append(active.Highlights, text.sprintf("hl_reflected_params", strings.Join(limitStrings(reflected, 3), ", ")))
+		}
+		active.Highlights = appendExposedPagesHighlight(active.Highlights, text["hl_phpinfo"], activeArtifacts["phpinfo"])
+		active.Highlights = appendExposedPagesHighlight(active.Highlights, text["hl_metrics"], activeArtifacts["metrics"])
+		active.Highlights = appendExposedPagesHighlight(active.Highlights, text["hl_api_schema"], activeArtifacts["api-schema"])
 	}
 
-	domainStats := buildDomainStats(domains)
-	routeStats := buildRouteStats(routes)
-	certStats := buildCertStats(certs)
+	domainStats := buildDomainStats(domains, limits)
+	routeStats := buildRouteStats(routes, limits)
+	certStats := buildCertStats(certs, limits, cfg.CertExpiryWindowDays)
+	ipStats := buildIPStats(artifactValues(passiveArtifacts["ip"]), limits)
+	emailStats := buildEmailStats(artifactValues(passiveArtifacts["email"]), limits)
+	faviconStats := buildFaviconStats(passiveArtifacts["favicon"], limits)
+	portStats := buildPortStats(passiveArtifacts["port"], limits)
+	highlights := buildPassiveHighlights(domainStats, routeStats, certStats, rules, text, passiveArtifacts)
+	highlights = appendSharedFaviconHighlight(highlights, faviconStats, text)
+	highlights = appendRiskyPortsHighlight(highlights, portStats, rules, text)
 
-	data := reportData{
+	return reportData{
+		Lang:        lang,
+		L:           labels,
 		Target:      cfg.Target,
 		OutDir:      cfg.OutDir,
 		GeneratedAt: time.Now().Format(time.RFC3339),
+		Since:       formatSinceWindow(cfg.Since),
 		Overview: overviewStats{
 			TotalArtifacts:        domainStats.Total + routeStats.Total + certStats.Total,
 			UniqueDomains:         domainStats.Unique,
@@ -118,117 +213,195 @@ func Generate(ctx context.Context, cfg *config.Config) error {
 		Domains:      domainStats,
 		Routes:       routeStats,
 		Certificates: certStats,
+		IPs:          ipStats,
+		Emails:       emailStats,
+		Favicons:     faviconStats,
+		Ports:        portStats,
 		Meta:         meta,
-		Highlights:   buildHighlights(domainStats, routeStats, certStats),
+		Highlights:   highlights,
+		Environments: buildEnvironmentGroups(limits, passiveArtifacts["domain"], activeArtifacts["domain"]),
 		ActiveMode:   cfg.Active,
+		ShowActive:   cfg.Active && !active.empty(),
 		Active:       active,
-	}
-
-	reportPath := filepath.Join(cfg.OutDir, "report.html")
-	if err := ctx.Err(); err != nil {
-		return err
-	}
-
-	f, err := os.Create(reportPath)
-	if err != nil {
-		return fmt.Errorf("report: create %q: %w", reportPath, err)
-	}
-	defer f.Close()
+	}, nil
+}
 
-	if err := reportTmpl.Execute(f, data); err != nil {
-		return fmt.Errorf("report: render: %w", err)
+// formatSinceWindow devuelve la fecha de -since tal como se muestra en el
+// informe, o "" si no se filtró.
+func formatSinceWindow(since time.Time) string {
+	if since.IsZero() {
+		return ""
 	}
-	return nil
+	return since.UTC().Format(time.RFC3339)
 }
 
 type countItem struct {
-	Name  string
-	Count int
+	Name  string `json:"name"`
+	Count int    `json:"count"`
 }
 
 type domainStats struct {
-	Total             int
-	Unique            int
-	UniqueRegistrable int
-	AverageLabels     float64
-	TopRegistrable    []countItem
-	LabelHistogram    []countItem
-	TopTLDs           []countItem
-	WildcardCount     int
-	Interesting       []string
+	Total             int         `json:"total"`
+	Unique            int         `json:"unique"`
+	UniqueRegistrable int         `json:"unique_registrable"`
+	AverageLabels     float64     `json:"average_labels"`
+	TopRegistrable    []countItem `json:"top_registrable"`
+	LabelHistogram    []countItem `json:"label_histogram"`
+	TopTLDs           []countItem `json:"top_tlds"`
+	WildcardCount     int         `json:"wildcard_count"`
+	Interesting       []string    `json:"interesting"`
+}
+
+// environmentGroup agrupa los hosts marcados con un mismo entorno no
+// productivo (metadato "environment" de los artefactos domain).
+type environmentGroup struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Hosts []string `json:"hosts"`
 }
 
 type routeStats struct {
-	Total             int
-	UniqueHosts       int
-	UniqueSchemes     int
-	SecurePercentage  float64
-	TopHosts          []countItem
-	SchemeHistogram   []countItem
-	DepthHistogram    []countItem
-	AveragePathDepth  float64
-	InsecureHosts     []countItem
-	InsecureHostTotal int
-	TopPorts          []countItem
-	InterestingPaths  []string
-	NonStandardPorts  []string
+	Total             int         `json:"total"`
+	UniqueHosts       int         `json:"unique_hosts"`
+	UniqueSchemes     int         `json:"unique_schemes"`
+	SecurePercentage  float64     `json:"secure_percentage"`
+	TopHosts          []countItem `json:"top_hosts"`
+	SchemeHistogram   []countItem `json:"scheme_histogram"`
+	DepthHistogram    []countItem `json:"depth_histogram"`
+	AveragePathDepth  float64     `json:"average_path_depth"`
+	InsecureHosts     []countItem `json:"insecure_hosts"`
+	InsecureHostTotal int         `json:"insecure_host_total"`
+	TopPorts          []countItem `json:"top_ports"`
+	TopParams         []countItem `json:"top_params"`
+	InterestingPaths  []string    `json:"interesting_paths"`
+	NonStandardPorts  []string    `json:"non_standard_ports"`
+	WebSockets        []string    `json:"websockets"`
 }
 
 type certStats struct {
-	Total             int
-	Unique            int
-	UniqueRegistrable int
-	UniqueIssuers     int
-	Expired           int
-	ExpiringSoon      int
-	SoonThresholdDays int
-	NextExpiration    string
-	LatestExpiration  string
-	TopRegistrable    []countItem
-	TopIssuers        []countItem
-	ExpiringSoonList  []string
-	ExpiredList       []string
+	Total             int         `json:"total"`
+	Unique            int         `json:"unique"`
+	UniqueRegistrable int         `json:"unique_registrable"`
+	UniqueIssuers     int         `json:"unique_issuers"`
+	Expired           int         `json:"expired"`
+	ExpiringSoon      int         `json:"expiring_soon"`
+	SoonThresholdDays int         `json:"soon_threshold_days"`
+	NextExpiration    string      `json:"next_expiration"`
+	LatestExpiration  string      `json:"latest_expiration"`
+	TopRegistrable    []countItem `json:"top_registrable"`
+	TopIssuers        []countItem `json:"top_issuers"`
+	ExpiringSoonList  []string    `json:"expiring_soon_list"`
+	ExpiredList       []string    `json:"expired_list"`
+	WildcardCertCount int         `json:"wildcard_cert_count"`
+	WildcardCertList  []string    `json:"wildcard_cert_list"`
 }
 
 type overviewStats struct {
-	TotalArtifacts        int
-	UniqueDomains         int
-	UniqueHosts           int
-	UniqueCertificates    int
-	SecureRoutesPercent   float64
-	InsecureRoutesPercent float64
+	TotalArtifacts        int     `json:"total_artifacts"`
+	UniqueDomains         int     `json:"unique_domains"`
+	UniqueHosts           int     `json:"unique_hosts"`
+	UniqueCertificates    int     `json:"unique_certificates"`
+	SecureRoutesPercent   float64 `json:"secure_routes_percent"`
+	InsecureRoutesPercent float64 `json:"insecure_routes_percent"`
 }
 
 type reportData struct {
-	Target       string
-	OutDir       string
-	GeneratedAt  string
-	Overview     overviewStats
-	Domains      domainStats
-	Routes       routeStats
-	Certificates certStats
-	Meta         []string
-	Highlights   []string
-	ActiveMode   bool
-	Active       activeData
+	Lang         string                   `json:"lang"`
+	L            map[string]template.HTML `json:"-"`
+	Target       string                   `json:"target"`
+	OutDir       string                   `json:"out_dir"`
+	GeneratedAt  string                   `json:"generated_at"`
+	Since        string                   `json:"since,omitempty"`
+	Overview     overviewStats            `json:"overview"`
+	Domains      domainStats              `json:"domains"`
+	Routes       routeStats               `json:"routes"`
+	Certificates certStats                `json:"certificates"`
+	IPs          ipStats                  `json:"ips"`
+	Emails       emailStats               `json:"emails"`
+	Favicons     faviconStats             `json:"favicons"`
+	Ports        portStats                `json:"ports"`
+	Meta         []string                 `json:"meta"`
+	Highlights   []string                 `json:"highlights"`
+	Environments []environmentGroup       `json:"environments"`
+	ActiveMode   bool                     `json:"active_mode"`
+	ShowActive   bool                     `json:"show_active"`
+	Active       activeData               `json:"active"`
 }
 
 type activeData struct {
-	Domains      domainStats
-	Routes       routeStats
-	Certificates certStats
-	DNS          dnsStats
-	Meta         []string
-	RawDomains   []string
-	RawRoutes    []string
-	RawDNS       []string
-	Highlights   []string
+	Domains      domainStats `json:"domains"`
+	Routes       routeStats  `json:"routes"`
+	Certificates certStats   `json:"certificates"`
+	DNS          dnsStats    `json:"dns"`
+	Meta         []string    `json:"meta"`
+	RawDomains   []string    `json:"raw_domains"`
+	RawRoutes    []string    `json:"raw_routes"`
+	RawDNS       []string    `json:"raw_dns"`
+	Highlights   []string    `json:"highlights"`
+}
+
+// empty indica si la recolección activa no produjo ningún dato que mostrar, en
+// cuyo caso la sección activa se omite del informe.
+func (a activeData) empty() bool {
+	return a.Domains.Total == 0 &&
+		a.Routes.Total == 0 &&
+		a.DNS.Total == 0 &&
+		a.Certificates.Total == 0 &&
+		len(a.Meta) == 0
+}
+
+// ipStats resume las direcciones IP resueltas: únicas, reparto IPv4/IPv6 y
+// las subredes /24 IPv4 con más hosts.
+type ipStats struct {
+	Total      int         `json:"total"`
+	Unique     int         `json:"unique"`
+	IPv4       int         `json:"ipv4"`
+	IPv6       int         `json:"ipv6"`
+	TopSubnets []countItem `json:"top_subnets"`
+}
+
+// emailStats agrupa las direcciones de correo descubiertas por dominio.
+type emailStats struct {
+	Total   int          `json:"total"`
+	Unique  int          `json:"unique"`
+	Domains []emailGroup `json:"domains"`
+}
+
+type emailGroup struct {
+	Domain string   `json:"domain"`
+	Emails []string `json:"emails"`
+}
+
+// faviconStats agrupa los hosts por el hash mmh3 de su favicon (metadato
+// "favicon_hash"). Un mismo hash en hosts distintos apunta a infraestructura
+// o producto compartido.
+type faviconStats struct {
+	Total  int            `json:"total"`
+	Shared int            `json:"shared"`
+	Groups []faviconGroup `json:"groups"`
+}
+
+type faviconGroup struct {
+	Hash  int64    `json:"hash"`
+	Hosts []string `json:"hosts"`
+}
+
+// portStats resume los puertos abiertos importados de escáneres de puertos
+// (artefactos port): los puertos presentes en más hosts, los hosts con más
+// puertos abiertos y los servicios de riesgo expuestos (riskyPorts).
+type portStats struct {
+	Total       int         `json:"total"`
+	UniqueHosts int         `json:"unique_hosts"`
+	TopPorts    []countItem `json:"top_ports"`
+	TopHosts    []countItem `json:"top_hosts"`
+	RiskyTotal  int         `json:"risky_total"`
+	Risky       []string    `json:"risky"`
 }
 
 type dnsStats struct {
-	Total       int
-	UniqueHosts int
-	RecordTypes []countItem
+	Total       int         `json:"total"`
+	UniqueHosts int         `json:"unique_hosts"`
+	RecordTypes []countItem `json:"record_types"`
 }
 
 type dnsRecord struct {
@@ -241,8 +414,10 @@ type dnsRecord struct {
 
 const (
 	topN               = 10
-	certExpirySoonDays = 30
 	maxInterestingRows = 10
+	// defaultCertExpirySoonDays se usa si la configuración no fija
+	// CertExpiryWindowDays.
+	defaultCertExpirySoonDays = 30
 )
 
 var certTimeLayouts = []string{
@@ -255,10 +430,34 @@ var certTimeLayouts = []string{
 
 var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
 
+// defaultReportLang es el idioma del informe si no se indica otro o si el
+// indicado no está disponible.
+const defaultReportLang = "es"
+
+// reportLabelsFor devuelve el idioma efectivo y sus textos para la plantilla.
+// Los textos son constantes del propio paquete, por lo que se marcan como HTML
+// seguro y se renderizan igual que si estuvieran escritos en la plantilla.
+func reportLabelsFor(lang string) (string, map[string]template.HTML) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	labels, ok := reportLabels[lang]
+	if !ok {
+		lang, labels = defaultReportLang, reportLabels[defaultReportLang]
+	}
+	safe := make(map[string]template.HTML, len(labels))
+	for key, value := range labels {
+		safe[key] = template.HTML(value)
+	}
+	return lang, safe
+}
+
 var reportTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
 	"hasData":    func(items []countItem) bool { return len(items) > 0 },
 	"hasStrings": func(items []string) bool { return len(items) > 0 },
 	"limit":      func(items []string, n int) []string { return limitStrings(items, n) },
+	// tr formatea un texto traducido con verbos de formato (%d, %.1f).
+	"tr": func(format template.HTML, args ...any) template.HTML {
+		return template.HTML(fmt.Sprintf(string(format), args...))
+	},
 }).Parse(reportTemplate))
 
 var (
@@ -288,6 +487,40 @@ func artifactValues(list []artifacts.Artifact) []string {
 	return values
 }
 
+// mergeRemappedCerts añade a values los certificados del fichero name en el
+// directorio que cfg.TypeDirs asigna a "certificate" (relativo a OutDir o
+// absoluto) que no estén ya en el manifiesto. Sin reasignación, o si el
+// fichero no existe, devuelve values sin cambios.
+func mergeRemappedCerts(values []string, cfg *config.Config, name string) []string {
+	dir := strings.TrimSpace(cfg.TypeDirs["certificate"])
+	if dir == "" {
+		return values
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cfg.OutDir, dir)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return values
+	}
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		seen[value] = struct{}{}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = cleanReportText(line)
+		if line == "" {
+			continue
+		}
+		if _, dup := seen[line]; dup {
+			continue
+		}
+		seen[line] = struct{}{}
+		values = append(values, line)
+	}
+	return values
+}
+
 func artifactRawValues(list []artifacts.Artifact) []string {
 	if len(list) == 0 {
 		return nil
@@ -449,68 +682,321 @@ func summarizeDNSRaw(raw string) string {
 	return strings.Join(parts, "; ")
 }
 
-func buildDomainStats(domains []string) domainStats {
+// domainAccumulator acumula los contadores de buildDomainStats sobre un trozo
+// de los dominios.
+type domainAccumulator struct {
+	total             int
+	wildcards         int
+	totalLabels       int
+	registrableCounts map[string]int
+	labelHistogram    map[string]int
+	tldCounts         map[string]int
+	uniqueDomains     map[string]struct{}
+	interesting       map[string]struct{}
+}
+
+func newDomainAccumulator() *domainAccumulator {
+	return &domainAccumulator{
+		registrableCounts: make(map[string]int),
+		labelHistogram:    make(map[string]int),
+		tldCounts:         make(map[string]int),
+		uniqueDomains:     make(map[string]struct{}),
+		interesting:       make(map[string]struct{}),
+	}
+}
+
+func (a *domainAccumulator) add(raw string) {
+	d := strings.TrimSpace(raw)
+	if d == "" {
+		return
+	}
+	lowered := strings.ToLower(d)
+	a.total++
+	a.uniqueDomains[lowered] = struct{}{}
+	if strings.HasPrefix(d, "*.") {
+		a.wildcards++
+	}
+	if registrable := registrableDomain(d); registrable != "" {
+		a.registrableCounts[registrable]++
+	}
+	if suffix, _ := publicsuffix.PublicSuffix(lowered); suffix != "" {
+		a.tldCounts[suffix]++
+	} else {
+		parts := strings.Split(lowered, ".")
+		if len(parts) > 0 {
+			a.tldCounts[parts[len(parts)-1]]++
+		}
+	}
+	levels := strings.Count(lowered, ".") + 1
+	labelKey := fmt.Sprintf("%d niveles", levels)
+	a.labelHistogram[labelKey]++
+	a.totalLabels += levels
+	for _, keyword := range interestingDomainKeywords {
+		if strings.Contains(lowered, keyword) {
+			a.interesting[lowered] = struct{}{}
+			break
+		}
+	}
+}
+
+func (a *domainAccumulator) merge(other *domainAccumulator) {
+	a.total += other.total
+	a.wildcards += other.wildcards
+	a.totalLabels += other.totalLabels
+	mergeCounts(a.registrableCounts, other.registrableCounts)
+	mergeCounts(a.labelHistogram, other.labelHistogram)
+	mergeCounts(a.tldCounts, other.tldCounts)
+	mergeSet(a.uniqueDomains, other.uniqueDomains)
+	mergeSet(a.interesting, other.interesting)
+}
+
+// buildDomainStats calcula las estadísticas de dominios repartiendo el
+// recorrido como buildRouteStats.
+func buildDomainStats(domains []string, limits rowLimits) domainStats {
 	stats := domainStats{}
 	if len(domains) == 0 {
 		return stats
 	}
-	registrableCounts := make(map[string]int)
-	labelHistogram := make(map[string]int)
-	tldCounts := make(map[string]int)
-	uniqueDomains := make(map[string]struct{})
-	uniqueRegistrable := make(map[string]struct{})
-	interesting := make(map[string]struct{})
-	var totalLabels int
-	for _, raw := range domains {
-		d := strings.TrimSpace(raw)
-		if d == "" {
+	acc := accumulateSharded(domains, newDomainAccumulator)
+	stats.Total = acc.total
+	stats.WildcardCount = acc.wildcards
+	stats.TopRegistrable = topItems(acc.registrableCounts, limits.top("domain"))
+	stats.LabelHistogram = topItems(acc.labelHistogram, len(acc.labelHistogram))
+	stats.TopTLDs = topItems(acc.tldCounts, limits.top("domain"))
+	stats.Unique = len(acc.uniqueDomains)
+	stats.UniqueRegistrable = len(acc.registrableCounts)
+	if len(acc.interesting) > 0 {
+		stats.Interesting = sortedStringsWithLimit(acc.interesting, limits.interesting("domain"))
+	}
+	if stats.Total > 0 {
+		stats.AverageLabels = float64(acc.totalLabels) / float64(stats.Total)
+	}
+	return stats
+}
+
+// buildIPStats agrupa las IPs por subred /24 (solo IPv4). Los valores se
+// normalizan con netutil.NormalizeDomain y se comparan en su forma canónica,
+// de modo que "2001:DB8::0001" y "[2001:db8::1]" cuentan como la misma IP.
+func buildIPStats(ips []string, limits rowLimits) ipStats {
+	stats := ipStats{}
+	if len(ips) == 0 {
+		return stats
+	}
+	unique := make(map[string]struct{})
+	subnets := make(map[string]int)
+	for _, raw := range ips {
+		ip := net.ParseIP(netutil.NormalizeDomain(raw))
+		if ip == nil {
 			continue
 		}
-		lowered := strings.ToLower(d)
 		stats.Total++
-		uniqueDomains[lowered] = struct{}{}
-		if strings.HasPrefix(strings.TrimSpace(raw), "*.") {
-			stats.WildcardCount++
+		key := ip.String()
+		if _, dup := unique[key]; dup {
+			continue
 		}
-		registrable := registrableDomain(d)
-		if registrable != "" {
-			uniqueRegistrable[registrable] = struct{}{}
-			registrableCounts[registrable]++
+		unique[key] = struct{}{}
+		ip4 := ip.To4()
+		if ip4 == nil {
+			stats.IPv6++
+			continue
 		}
-		if suffix, _ := publicsuffix.PublicSuffix(lowered); suffix != "" {
-			tldCounts[suffix]++
-		} else {
-			parts := strings.Split(lowered, ".")
-			if len(parts) > 0 {
-				tldCounts[parts[len(parts)-1]]++
-			}
+		stats.IPv4++
+		subnet := net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+		subnets[subnet.String()]++
+	}
+	stats.Unique = len(unique)
+	if len(subnets) > 0 {
+		stats.TopSubnets = topItems(subnets, limits.top("ip"))
+	}
+	return stats
+}
+
+// buildEmailStats agrupa las direcciones por dominio, sin distinguir
+// mayúsculas. Los dominios con más direcciones van primero y, dentro de cada
+// uno, las direcciones se ordenan alfabéticamente y se limitan a
+// limits.interesting("email").
+func buildFaviconStats(list []artifacts.Artifact, limits rowLimits) faviconStats {
+	stats := faviconStats{}
+	byHash := make(map[int64]map[string]struct{})
+	for _, art := range list {
+		hash, ok := faviconHashOf(art.Metadata["favicon_hash"])
+		if !ok {
+			continue
 		}
-		levels := strings.Count(lowered, ".") + 1
-		labelKey := fmt.Sprintf("%d niveles", levels)
-		labelHistogram[labelKey]++
-		totalLabels += levels
-		for _, keyword := range interestingDomainKeywords {
-			if strings.Contains(lowered, keyword) {
-				interesting[lowered] = struct{}{}
-				break
+		u, err := url.Parse(artifacts.ExtractRouteBase(art.Value))
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		hosts := byHash[hash]
+		if hosts == nil {
+			hosts = make(map[string]struct{})
+			byHash[hash] = hosts
+		}
+		hosts[strings.ToLower(u.Hostname())] = struct{}{}
+		stats.Total++
+	}
+	for hash, hosts := range byHash {
+		if len(hosts) > 1 {
+			stats.Shared++
+		}
+		stats.Groups = append(stats.Groups, faviconGroup{
+			Hash:  hash,
+			Hosts: sortedStringsWithLimit(hosts, limits.interesting("favicon")),
+		})
+	}
+	sort.Slice(stats.Groups, func(i, j int) bool {
+		ci, cj := len(byHash[stats.Groups[i].Hash]), len(byHash[stats.Groups[j].Hash])
+		if ci != cj {
+			return ci > cj
+		}
+		return stats.Groups[i].Hash < stats.Groups[j].Hash
+	})
+	if limit := limits.top("favicon"); len(stats.Groups) > limit {
+		stats.Groups = stats.Groups[:limit]
+	}
+	return stats
+}
+
+// faviconHashOf lee el metadato favicon_hash, que llega como float64 desde
+// JSON.
+func faviconHashOf(value any) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case string:
+		hash, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return hash, err == nil
+	}
+	return 0, false
+}
+
+// riskyPorts son los puertos cuyo servicio no debería quedar expuesto a
+// Internet (administración remota, compartición de ficheros, bases de datos y
+// cachés sin autenticación por defecto), con el nombre que se muestra si el
+// escáner no identificó el servicio.
+var riskyPorts = map[int]string{
+	21:    "ftp",
+	23:    "telnet",
+	135:   "msrpc",
+	139:   "netbios",
+	445:   "smb",
+	1433:  "mssql",
+	2375:  "docker",
+	3306:  "mysql",
+	3389:  "rdp",
+	5432:  "postgresql",
+	5900:  "vnc",
+	6379:  "redis",
+	9200:  "elasticsearch",
+	11211: "memcached",
+	27017: "mongodb",
+}
+
+// buildPortStats agrupa los artefactos port por puerto ("22/tcp") y por host
+// a partir de sus metadatos host, port y protocol. Los puertos de riskyPorts
+// se listan como host:puerto/proto con el servicio detectado.
+func buildPortStats(list []artifacts.Artifact, limits rowLimits) portStats {
+	stats := portStats{}
+	seen := make(map[string]struct{})
+	ports := make(map[string]int)
+	hosts := make(map[string]int)
+	risky := make(map[string]struct{})
+	for _, art := range list {
+		host, _ := art.Metadata["host"].(string)
+		port, ok := portNumberOf(art.Metadata["port"])
+		if host == "" || !ok {
+			continue
+		}
+		protocol, _ := art.Metadata["protocol"].(string)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		portKey := fmt.Sprintf("%d/%s", port, protocol)
+		endpoint := net.JoinHostPort(host, strconv.Itoa(port)) + "/" + protocol
+		if _, dup := seen[endpoint]; dup {
+			continue
+		}
+		seen[endpoint] = struct{}{}
+		stats.Total++
+		ports[portKey]++
+		hosts[host]++
+		if name, ok := riskyPorts[port]; ok {
+			if service, _ := art.Metadata["service"].(string); service != "" {
+				name = service
 			}
+			risky[fmt.Sprintf("%s (%s)", endpoint, name)] = struct{}{}
 		}
 	}
-	stats.TopRegistrable = topItems(registrableCounts, topN)
-	stats.LabelHistogram = topItems(labelHistogram, len(labelHistogram))
-	stats.TopTLDs = topItems(tldCounts, topN)
-	stats.Unique = len(uniqueDomains)
-	stats.UniqueRegistrable = len(uniqueRegistrable)
-	if len(interesting) > 0 {
-		stats.Interesting = sortedStringsWithLimit(interesting, maxInterestingRows)
+	stats.UniqueHosts = len(hosts)
+	stats.TopPorts = topItems(ports, limits.top("port"))
+	stats.TopHosts = topItems(hosts, limits.top("port"))
+	stats.RiskyTotal = len(risky)
+	if len(risky) > 0 {
+		stats.Risky = sortedStringsWithLimit(risky, limits.interesting("port"))
 	}
-	if stats.Total > 0 {
-		stats.AverageLabels = float64(totalLabels) / float64(stats.Total)
+	return stats
+}
+
+// portNumberOf lee el metadato port, que llega como float64 desde JSON.
+func portNumberOf(value any) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), v >= 1 && v <= 65535
+	case int:
+		return v, v >= 1 && v <= 65535
+	case string:
+		port, err := strconv.Atoi(strings.TrimSpace(v))
+		return port, err == nil && port >= 1 && port <= 65535
+	}
+	return 0, false
+}
+
+func buildEmailStats(emails []string, limits rowLimits) emailStats {
+	stats := emailStats{}
+	if len(emails) == 0 {
+		return stats
+	}
+	byDomain := make(map[string]map[string]struct{})
+	for _, raw := range emails {
+		email, domain, ok := netutil.NormalizeEmail(raw)
+		if !ok {
+			continue
+		}
+		stats.Total++
+		set := byDomain[domain]
+		if set == nil {
+			set = make(map[string]struct{})
+			byDomain[domain] = set
+		}
+		set[email] = struct{}{}
+	}
+	for domain, set := range byDomain {
+		list := make([]string, 0, len(set))
+		for email := range set {
+			list = append(list, email)
+		}
+		sort.Strings(list)
+		stats.Unique += len(list)
+		if limit := limits.interesting("email"); len(list) > limit {
+			list = list[:limit]
+		}
+		stats.Domains = append(stats.Domains, emailGroup{Domain: domain, Emails: list})
 	}
+	sort.Slice(stats.Domains, func(i, j int) bool {
+		ci, cj := len(byDomain[stats.Domains[i].Domain]), len(byDomain[stats.Domains[j].Domain])
+		if ci != cj {
+			return ci > cj
+		}
+		return stats.Domains[i].Domain < stats.Domains[j].Domain
+	})
 	return stats
 }
 
-func buildDNSStats(records []dnsRecord) dnsStats {
+func buildDNSStats(records []dnsRecord, limits rowLimits) dnsStats {
 	stats := dnsStats{}
 	if len(records) == 0 {
 		return stats
@@ -561,7 +1047,7 @@ func buildDNSStats(records []dnsRecord) dnsStats {
 		}
 	}
 	stats.UniqueHosts = len(seenHosts)
-	stats.RecordTypes = topItems(typeCounts, topN)
+	stats.RecordTypes = topItems(typeCounts, limits.top("dns"))
 	return stats
 }
 
@@ -583,12 +1069,19 @@ func cleanReportText(value string) string {
 	return strings.Join(fields, " ")
 }
 
-func buildCertStats(certsLines []string) certStats {
-	return buildCertStatsAt(certsLines, time.Now())
+func buildCertStats(certsLines []string, limits rowLimits, soonDays int) certStats {
+	return buildCertStatsAt(certsLines, time.Now(), limits, soonDays)
 }
 
-func buildCertStatsAt(certsLines []string, now time.Time) certStats {
-	stats := certStats{SoonThresholdDays: certExpirySoonDays}
+// buildCertStatsAt calcula las estadísticas de certificados en el instante
+// now. Los que vencen en soonDays días o menos cuentan como por expirar; con
+// soonDays <= 0 se usa defaultCertExpirySoonDays.
+func buildCertStatsAt(certsLines []string, now time.Time, limits rowLimits, soonDays int) certStats {
+	if soonDays <= 0 {
+		soonDays = defaultCertExpirySoonDays
+	}
+	soonWindow := certExpirySoonWindow(soonDays)
+	stats := certStats{SoonThresholdDays: soonDays}
 	if len(certsLines) == 0 {
 		return stats
 	}
@@ -599,6 +1092,7 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 	uniqueIssuers := make(map[string]struct{})
 	expiringSoon := make(map[string]struct{})
 	expired := make(map[string]struct{})
+	wildcards := make(map[string]struct{})
 	var nextExpiration time.Time
 	var latestExpiration time.Time
 	for _, raw := range certsLines {
@@ -617,7 +1111,12 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 			issuerCounts[issuer]++
 			uniqueIssuers[strings.ToLower(issuer)] = struct{}{}
 		}
+		isWildcard := false
 		for _, name := range record.AllNames() {
+			if wildcard := strings.ToLower(strings.TrimSpace(name)); strings.HasPrefix(wildcard, "*.") {
+				isWildcard = true
+				wildcards[wildcard] = struct{}{}
+			}
 			registrable := registrableDomain(name)
 			if registrable == "" {
 				continue
@@ -625,6 +1124,9 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 			uniqueRegistrable[registrable] = struct{}{}
 			registrableCounts[registrable]++
 		}
+		if isWildcard {
+			stats.WildcardCertCount++
+		}
 		if expiry := parseCertTime(record.NotAfter); !expiry.IsZero() {
 			displayName := certDisplayName(record)
 			if expiry.Before(now) {
@@ -634,7 +1136,7 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 				}
 				continue
 			}
-			if expiry.Sub(now) <= certExpirySoonWindow() {
+			if expiry.Sub(now) <= soonWindow {
 				stats.ExpiringSoon++
 				if displayName != "" {
 					expiringSoon[fmt.Sprintf("%s (vence %s)", displayName, expiry.Format("2006-01-02"))] = struct{}{}
@@ -648,16 +1150,19 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 			}
 		}
 	}
-	stats.TopRegistrable = topItems(registrableCounts, topN)
-	stats.TopIssuers = topItems(issuerCounts, topN)
+	stats.TopRegistrable = topItems(registrableCounts, limits.top("certificate"))
+	stats.TopIssuers = topItems(issuerCounts, limits.top("certificate"))
 	stats.Unique = len(uniqueCerts)
 	stats.UniqueRegistrable = len(uniqueRegistrable)
 	stats.UniqueIssuers = len(uniqueIssuers)
 	if len(expiringSoon) > 0 {
-		stats.ExpiringSoonList = sortedStringsWithLimit(expiringSoon, maxInterestingRows)
+		stats.ExpiringSoonList = sortedStringsWithLimit(expiringSoon, limits.interesting("certificate"))
 	}
 	if len(expired) > 0 {
-		stats.ExpiredList = sortedStringsWithLimit(expired, maxInterestingRows)
+		stats.ExpiredList = sortedStringsWithLimit(expired, limits.interesting("certificate"))
+	}
+	if len(wildcards) > 0 {
+		stats.WildcardCertList = sortedStringsWithLimit(wildcards, limits.interesting("certificate"))
 	}
 	if !nextExpiration.IsZero() {
 		stats.NextExpiration = nextExpiration.Format("2006-01-02")
@@ -668,123 +1173,177 @@ func buildCertStatsAt(certsLines []string, now time.Time) certStats {
 	return stats
 }
 
-func buildRouteStats(routes []string) routeStats {
-	stats := routeStats{}
-	if len(routes) == 0 {
-		return stats
+// routeAccumulator acumula los contadores de buildRouteStats sobre un trozo
+// de las rutas.
+type routeAccumulator struct {
+	total              int
+	httpsCount         int
+	totalDepth         int
+	hostCounts         map[string]int
+	schemeHistogram    map[string]int
+	depthHistogram     map[string]int
+	insecureHostCounts map[string]int
+	portCounts         map[string]int
+	paramCounts        map[string]int
+	interestingPaths   map[string]struct{}
+	nonStandard        map[string]struct{}
+	webSockets         map[string]struct{}
+}
+
+func newRouteAccumulator() *routeAccumulator {
+	return &routeAccumulator{
+		hostCounts:         make(map[string]int),
+		schemeHistogram:    make(map[string]int),
+		depthHistogram:     make(map[string]int),
+		insecureHostCounts: make(map[string]int),
+		portCounts:         make(map[string]int),
+		paramCounts:        make(map[string]int),
+		interestingPaths:   make(map[string]struct{}),
+		nonStandard:        make(map[string]struct{}),
+		webSockets:         make(map[string]struct{}),
 	}
-	hostCounts := make(map[string]int)
-	schemeHistogram := make(map[string]int)
-	depthHistogram := make(map[string]int)
-	insecureHostCounts := make(map[string]int)
-	portCounts := make(map[string]int)
-	interestingPaths := make(map[string]struct{})
-	nonStandard := make(map[string]struct{})
-	var totalDepth int
-	uniqueHosts := make(map[string]struct{})
-	httpsCount := 0
-	for _, raw := range routes {
-		trimmed := strings.TrimSpace(raw)
-		if trimmed == "" {
-			continue
-		}
-		fields := strings.Fields(trimmed)
-		candidate := trimmed
-		if len(fields) > 0 {
-			candidate = fields[0]
-		}
-		u, err := url.Parse(candidate)
-		if err != nil {
-			continue
-		}
-		stats.Total++
-		host := strings.TrimSpace(u.Host)
-		if host == "" {
-			host = "(sin host)"
-		}
-		loweredHost := strings.ToLower(host)
-		hostCounts[loweredHost]++
-		uniqueHosts[loweredHost] = struct{}{}
-		rawScheme := strings.ToLower(u.Scheme)
-		scheme := rawScheme
-		if scheme == "" {
-			scheme = "(vacío)"
-		}
-		schemeHistogram[scheme]++
-		if rawScheme == "https" {
-			httpsCount++
-		} else if rawScheme != "" {
-			hostname := strings.ToLower(u.Hostname())
-			if hostname != "" {
-				insecureHostCounts[hostname]++
-			}
+}
+
+func (a *routeAccumulator) add(raw string) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return
+	}
+	fields := strings.Fields(trimmed)
+	candidate := trimmed
+	if len(fields) > 0 {
+		candidate = fields[0]
+	}
+	u, err := url.Parse(candidate)
+	if err != nil {
+		return
+	}
+	a.total++
+	host := strings.TrimSpace(u.Host)
+	if host == "" {
+		host = "(sin host)"
+	}
+	a.hostCounts[strings.ToLower(host)]++
+	rawScheme := strings.ToLower(u.Scheme)
+	scheme := rawScheme
+	if scheme == "" {
+		scheme = "(vacío)"
+	}
+	a.schemeHistogram[scheme]++
+	if rawScheme == "ws" || rawScheme == "wss" {
+		a.webSockets[rawScheme+"://"+strings.ToLower(host)+u.EscapedPath()] = struct{}{}
+	}
+	// wss cuenta como seguro; ws alimenta los hosts inseguros igual que http.
+	if rawScheme == "https" || rawScheme == "wss" {
+		a.httpsCount++
+	} else if rawScheme != "" {
+		hostname := strings.ToLower(u.Hostname())
+		if hostname != "" {
+			a.insecureHostCounts[hostname]++
 		}
-		path := strings.Trim(u.Path, "/")
-		depth := 0
-		if path != "" {
-			depth = len(strings.Split(path, "/"))
-		}
-		depthKey := fmt.Sprintf("%d segmentos", depth)
-		depthHistogram[depthKey]++
-		totalDepth += depth
-		port := u.Port()
-		if port == "" {
-			if def := defaultPortForScheme(rawScheme); def != "" {
-				port = def
-			}
+	}
+	path := strings.Trim(u.Path, "/")
+	depth := 0
+	if path != "" {
+		depth = len(strings.Split(path, "/"))
+	}
+	depthKey := fmt.Sprintf("%d segmentos", depth)
+	a.depthHistogram[depthKey]++
+	a.totalDepth += depth
+	port := u.Port()
+	if port == "" {
+		if def := defaultPortForScheme(rawScheme); def != "" {
+			port = def
 		}
-		displayPort := port
-		if displayPort == "" {
-			displayPort = "(sin puerto)"
+	}
+	displayPort := port
+	if displayPort == "" {
+		displayPort = "(sin puerto)"
+	}
+	a.portCounts[displayPort]++
+	for _, param := range artifacts.QueryParamNames(candidate) {
+		a.paramCounts[param]++
+	}
+	if port != "" && isNonStandardPort(rawScheme, port) {
+		endpointScheme := rawScheme
+		if endpointScheme == "" {
+			endpointScheme = scheme
 		}
-		portCounts[displayPort]++
-		if port != "" && isNonStandardPort(rawScheme, port) {
-			endpointScheme := rawScheme
-			if endpointScheme == "" {
-				endpointScheme = scheme
-			}
-			endpoint := fmt.Sprintf("%s://%s", endpointScheme, host)
-			nonStandard[strings.ToLower(endpoint)] = struct{}{}
-		}
-		loweredCandidate := strings.ToLower(candidate)
-		for _, keyword := range interestingRouteKeywords {
-			if strings.Contains(loweredCandidate, keyword) {
-				normalized := candidate
-				if u.Scheme != "" && u.Host != "" {
-					normalized = u.Scheme + "://" + u.Host + u.Path
-					if u.RawQuery != "" {
-						normalized += "?" + u.RawQuery
-					}
-				} else if normalized == "" {
-					normalized = fmt.Sprintf("%s://%s", rawScheme, host)
+		endpoint := fmt.Sprintf("%s://%s", endpointScheme, host)
+		a.nonStandard[strings.ToLower(endpoint)] = struct{}{}
+	}
+	loweredCandidate := strings.ToLower(candidate)
+	for _, keyword := range interestingRouteKeywords {
+		if strings.Contains(loweredCandidate, keyword) {
+			normalized := candidate
+			if u.Scheme != "" && u.Host != "" {
+				normalized = u.Scheme + "://" + u.Host + u.Path
+				if u.RawQuery != "" {
+					normalized += "?" + u.RawQuery
 				}
-				interestingPaths[normalized] = struct{}{}
-				break
+			} else if normalized == "" {
+				normalized = fmt.Sprintf("%s://%s", rawScheme, host)
 			}
+			a.interestingPaths[normalized] = struct{}{}
+			break
 		}
 	}
-	stats.TopHosts = topItems(hostCounts, topN)
-	stats.SchemeHistogram = topItems(schemeHistogram, len(schemeHistogram))
-	stats.DepthHistogram = topItems(depthHistogram, len(depthHistogram))
-	stats.AveragePathDepth = float64(totalDepth)
-	stats.UniqueHosts = len(uniqueHosts)
-	stats.UniqueSchemes = len(schemeHistogram)
-	if len(insecureHostCounts) > 0 {
-		stats.InsecureHosts = topItems(insecureHostCounts, topN)
-		stats.InsecureHostTotal = len(insecureHostCounts)
+}
+
+func (a *routeAccumulator) merge(other *routeAccumulator) {
+	a.total += other.total
+	a.httpsCount += other.httpsCount
+	a.totalDepth += other.totalDepth
+	mergeCounts(a.hostCounts, other.hostCounts)
+	mergeCounts(a.schemeHistogram, other.schemeHistogram)
+	mergeCounts(a.depthHistogram, other.depthHistogram)
+	mergeCounts(a.insecureHostCounts, other.insecureHostCounts)
+	mergeCounts(a.portCounts, other.portCounts)
+	mergeCounts(a.paramCounts, other.paramCounts)
+	mergeSet(a.interestingPaths, other.interestingPaths)
+	mergeSet(a.nonStandard, other.nonStandard)
+	mergeSet(a.webSockets, other.webSockets)
+}
+
+// buildRouteStats calcula las estadísticas de rutas. Con manifiestos grandes
+// el recorrido se reparte entre varias goroutines (ver accumulateSharded); el
+// resultado es el mismo que con una sola porque los parciales solo se suman y
+// topItems desempata por nombre.
+func buildRouteStats(routes []string, limits rowLimits) routeStats {
+	stats := routeStats{}
+	if len(routes) == 0 {
+		return stats
+	}
+	acc := accumulateSharded(routes, newRouteAccumulator)
+	stats.Total = acc.total
+	stats.TopHosts = topItems(acc.hostCounts, limits.top("route"))
+	stats.SchemeHistogram = topItems(acc.schemeHistogram, len(acc.schemeHistogram))
+	stats.DepthHistogram = topItems(acc.depthHistogram, len(acc.depthHistogram))
+	stats.AveragePathDepth = float64(acc.totalDepth)
+	stats.UniqueHosts = len(acc.hostCounts)
+	stats.UniqueSchemes = len(acc.schemeHistogram)
+	if len(acc.insecureHostCounts) > 0 {
+		stats.InsecureHosts = topItems(acc.insecureHostCounts, limits.top("route"))
+		stats.InsecureHostTotal = len(acc.insecureHostCounts)
+	}
+	if len(acc.portCounts) > 0 {
+		stats.TopPorts = topItems(acc.portCounts, len(acc.portCounts))
 	}
-	if len(portCounts) > 0 {
-		stats.TopPorts = topItems(portCounts, len(portCounts))
+	if len(acc.paramCounts) > 0 {
+		stats.TopParams = topItems(acc.paramCounts, limits.top("route"))
 	}
-	if len(interestingPaths) > 0 {
-		stats.InterestingPaths = sortedStringsWithLimit(interestingPaths, maxInterestingRows)
+	if len(acc.interestingPaths) > 0 {
+		stats.InterestingPaths = sortedStringsWithLimit(acc.interestingPaths, limits.interesting("route"))
 	}
-	if len(nonStandard) > 0 {
-		stats.NonStandardPorts = sortedStringsWithLimit(nonStandard, maxInterestingRows)
+	if len(acc.nonStandard) > 0 {
+		stats.NonStandardPorts = sortedStringsWithLimit(acc.nonStandard, limits.interesting("route"))
+	}
+	if len(acc.webSockets) > 0 {
+		stats.WebSockets = sortedStringsWithLimit(acc.webSockets, limits.interesting("route"))
 	}
 	if stats.Total > 0 {
 		stats.AveragePathDepth = stats.AveragePathDepth / float64(stats.Total)
-		stats.SecurePercentage = (float64(httpsCount) / float64(stats.Total)) * 100
+		stats.SecurePercentage = (float64(acc.httpsCount) / float64(stats.Total)) * 100
 	}
 	return stats
 }
@@ -806,6 +1365,27 @@ func registrableDomain(domain string) string {
 	return strings.ToLower(registrable)
 }
 
+// rowLimits asocia un tipo de artefacto (domain, route, certificate, dns) con
+// el número máximo de filas de sus tablas en el informe. Los tipos sin límite
+// configurado usan topN y maxInterestingRows.
+type rowLimits map[string]int
+
+// top devuelve el límite de filas de los rankings del tipo.
+func (l rowLimits) top(typ string) int {
+	if limit, ok := l[typ]; ok && limit > 0 {
+		return limit
+	}
+	return topN
+}
+
+// interesting devuelve el límite de filas de los listados destacados del tipo.
+func (l rowLimits) interesting(typ string) int {
+	if limit, ok := l[typ]; ok && limit > 0 {
+		return limit
+	}
+	return maxInterestingRows
+}
+
 func topItems(counts map[string]int, n int) []countItem {
 	if len(counts) == 0 || n == 0 {
 		return nil
@@ -839,8 +1419,8 @@ func parseCertTime(value string) time.Time {
 	return time.Time{}
 }
 
-func certExpirySoonWindow() time.Duration {
-	return time.Duration(certExpirySoonDays) * 24 * time.Hour
+func certExpirySoonWindow(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
 }
 
 func sortedStringsWithLimit(m map[string]struct{}, limit int) []string {
@@ -857,9 +1437,9 @@ func sortedStringsWithLimit(m map[string]struct{}, limit int) []string {
 
 func defaultPortForScheme(scheme string) string {
 	switch scheme {
-	case "http":
+	case "http", "ws":
 		return "80"
-	case "https":
+	case "https", "wss":
 		return "443"
 	case "ssh":
 		return "22"
@@ -902,53 +1482,341 @@ func limitStrings(values []string, max int) []string {
 	return values[:max]
 }
 
-func buildHighlights(domains domainStats, routes routeStats, certs certStats) []string {
+// Reglas de highlight configurables con cfg.HighlightRules.
+const (
+	highlightInsecureHTTP     = "insecure-http"
+	highlightNonStandardPorts = "nonstandard-ports"
+	highlightSensitivePaths   = "sensitive-paths"
+	highlightSensitiveDomains = "sensitive-domains"
+	highlightExpiredCerts     = "expired-certs"
+	highlightExpiringCerts    = "expiring-certs"
+	highlightWildcardCerts    = "wildcard-certs"
+	highlightRiskyPorts       = "risky-ports"
+
+	// highlightRuleOff desactiva una regla.
+	highlightRuleOff = "off"
+)
+
+// knownHighlightRules es la tabla con la que config valida highlight-rules.
+var knownHighlightRules = config.HighlightRuleNames
+
+// highlightSeverities son las severidades que admite una regla de highlight;
+// su texto en cada idioma es la clave severity_<severidad> de reportLabels.
+var highlightSeverities = map[string]struct{}{
+	"critical": {},
+	"high":     {},
+	"medium":   {},
+	"low":      {},
+}
+
+// highlightText son los textos de los highlights en el idioma del informe
+// (claves hl_* y severity_* de reportLabels).
+type highlightText map[string]string
+
+// sprintf formatea el texto de la clave con los argumentos dados.
+func (t highlightText) sprintf(key string, args ...any) string {
+	return fmt.Sprintf(t[key], args...)
+}
+
+// highlightRules asocia cada regla de buildHighlights con la severidad con la
+// que se anuncia o con "off" para suprimirla. Las reglas sin entrada se
+// muestran como hasta ahora, sin severidad explícita.
+type highlightRules map[string]string
+
+// newHighlightRules valida la configuración de reglas de highlight.
+func newHighlightRules(raw map[string]string) (highlightRules, error) {
+	rules := make(highlightRules, len(raw))
+	for name, severity := range raw {
+		name = strings.ToLower(strings.TrimSpace(name))
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if _, ok := knownHighlightRules[name]; !ok {
+			return nil, fmt.Errorf("report: regla de highlight desconocida %q", name)
+		}
+		if _, ok := highlightSeverities[severity]; !ok && severity != highlightRuleOff {
+			return nil, fmt.Errorf("report: severidad inválida %q para la regla %q", severity, name)
+		}
+		rules[name] = severity
+	}
+	return rules, nil
+}
+
+func (r highlightRules) enabled(name string) bool {
+	return r[name] != highlightRuleOff
+}
+
+// format añade la severidad configurada para la regla al texto del highlight.
+func (r highlightRules) format(name, highlight string, text highlightText) string {
+	if _, ok := highlightSeverities[r[name]]; ok {
+		return text.sprintf("hl_severity", highlight, text["severity_"+r[name]])
+	}
+	return highlight
+}
+
+func buildHighlights(domains domainStats, routes routeStats, certs certStats, rules highlightRules, text highlightText) []string {
 	var highlights []string
+	add := func(rule, highlight string) {
+		if rules.enabled(rule) {
+			highlights = append(highlights, rules.format(rule, highlight, text))
+		}
+	}
 	if routes.SecurePercentage < 100 {
 		if len(routes.InsecureHosts) > 0 {
 			count := routes.InsecureHostTotal
-			verb := "exponen"
-			noun := "hosts"
+			key := "hl_insecure_hosts"
 			if count == 1 {
-				verb = "expone"
-				noun = "host"
+				key = "hl_insecure_host"
 			}
-			highlights = append(highlights, fmt.Sprintf("%d %s %s servicios sin HTTPS (por ejemplo %s)", count, noun, verb, routes.InsecureHosts[0].Name))
+			add(highlightInsecureHTTP, text.sprintf(key, count, routes.InsecureHosts[0].Name))
 		} else {
-			highlights = append(highlights, fmt.Sprintf("%.1f%% de las rutas carecen de HTTPS", 100-routes.SecurePercentage))
+			add(highlightInsecureHTTP, text.sprintf("hl_insecure_routes", 100-routes.SecurePercentage))
 		}
 	}
 	if len(routes.NonStandardPorts) > 0 {
-		highlights = append(highlights, fmt.Sprintf("Servicios en puertos no estándar detectados: %s", strings.Join(limitStrings(routes.NonStandardPorts, 3), ", ")))
+		add(highlightNonStandardPorts, text.sprintf("hl_nonstandard_ports", strings.Join(limitStrings(routes.NonStandardPorts, 3), ", ")))
 	}
 	if len(routes.InterestingPaths) > 0 {
-		highlights = append(highlights, fmt.Sprintf("Endpoints potencialmente sensibles encontrados (ej. %s)", routes.InterestingPaths[0]))
+		add(highlightSensitivePaths, text.sprintf("hl_sensitive_paths", routes.InterestingPaths[0]))
 	}
 	if len(domains.Interesting) > 0 {
-		highlights = append(highlights, fmt.Sprintf("Dominios que sugieren entornos sensibles: %s", strings.Join(limitStrings(domains.Interesting, 3), ", ")))
+		add(highlightSensitiveDomains, text.sprintf("hl_sensitive_domains", strings.Join(limitStrings(domains.Interesting, 3), ", ")))
 	}
 	if certs.Expired > 0 {
 		if len(certs.ExpiredList) > 0 {
-			highlights = append(highlights, fmt.Sprintf("%d certificados vencidos, incluyendo %s", certs.Expired, certs.ExpiredList[0]))
+			add(highlightExpiredCerts, text.sprintf("hl_expired_certs_ex", certs.Expired, certs.ExpiredList[0]))
 		} else {
-			highlights = append(highlights, fmt.Sprintf("%d certificados vencidos detectados", certs.Expired))
+			add(highlightExpiredCerts, text.sprintf("hl_expired_certs", certs.Expired))
 		}
 	}
 	if certs.ExpiringSoon > 0 {
 		if len(certs.ExpiringSoonList) > 0 {
-			highlights = append(highlights, fmt.Sprintf("%d certificados por expirar pronto (ej. %s)", certs.ExpiringSoon, certs.ExpiringSoonList[0]))
+			add(highlightExpiringCerts, text.sprintf("hl_expiring_certs_ex", certs.ExpiringSoon, certs.SoonThresholdDays, certs.ExpiringSoonList[0]))
 		} else {
-			highlights = append(highlights, fmt.Sprintf("%d certificados por expirar en %d días", certs.ExpiringSoon, certExpirySoonDays))
+			add(highlightExpiringCerts, text.sprintf("hl_expiring_certs", certs.ExpiringSoon, certs.SoonThresholdDays))
 		}
 	}
+	if certs.WildcardCertCount > 0 && len(certs.WildcardCertList) > 0 {
+		add(highlightWildcardCerts, text.sprintf("hl_wildcard_certs", certs.WildcardCertCount, certs.WildcardCertList[0]))
+	}
 	return highlights
 }
 
+// buildEnvironmentGroups agrupa por entorno los dominios con metadato
+// "environment". Los grupos se ordenan por nombre y cada uno lista sus hosts
+// ordenados hasta el límite de filas destacadas del tipo domain.
+func buildEnvironmentGroups(limits rowLimits, lists ...[]artifacts.Artifact) []environmentGroup {
+	byEnv := make(map[string]map[string]struct{})
+	for _, list := range lists {
+		for _, art := range list {
+			env, _ := art.Metadata["environment"].(string)
+			env = strings.TrimSpace(env)
+			host := strings.TrimSpace(art.Value)
+			if env == "" || host == "" {
+				continue
+			}
+			if byEnv[env] == nil {
+				byEnv[env] = make(map[string]struct{})
+			}
+			byEnv[env][host] = struct{}{}
+		}
+	}
+	if len(byEnv) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(byEnv))
+	for env := range byEnv {
+		names = append(names, env)
+	}
+	sort.Strings(names)
+	groups := make([]environmentGroup, 0, len(names))
+	for _, env := range names {
+		groups = append(groups, environmentGroup{
+			Name:  env,
+			Count: len(byEnv[env]),
+			Hosts: sortedStringsWithLimit(byEnv[env], limits.interesting("domain")),
+		})
+	}
+	return groups
+}
+
+// buildPassiveHighlights combina los highlights generales con los de páginas
+// sensibles expuestas detectadas por categoría.
+func buildPassiveHighlights(domains domainStats, routes routeStats, certs certStats, rules highlightRules, text highlightText, passive map[string][]artifacts.Artifact) []string {
+	highlights := buildHighlights(domains, routes, certs, rules, text)
+	highlights = appendExposedPagesHighlight(highlights, text["hl_search_cluster"], passive["search-cluster"])
+	highlights = appendExposedPagesHighlight(highlights, text["hl_iac_state"], passive["iac-state"])
+	highlights = appendExposedPagesHighlight(highlights, text["hl_apache_config"], passive["apache-config"])
+	highlights = appendExposedPagesHighlight(highlights, text["hl_credential_file"], passive["credential-file"])
+	highlights = appendExposedPagesHighlight(highlights, text["hl_actuator"], passive["actuator"])
+	highlights = appendMailHighlight(highlights, passive["mail"], passive["dns"], text)
+	highlights = appendExposedPagesHighlight(highlights, text["hl_source_code"], passive["source-code"])
+	highlights = appendExposedPagesHighlight(highlights, text["hl_git"], passive["git"])
+	return appendExposedPagesHighlight(highlights, text["hl_server_status"], passive["server-status"])
+}
+
+// appendSharedFaviconHighlight añade los hashes de favicon que comparten
+// hosts distintos, señal de infraestructura o producto común.
+func appendSharedFaviconHighlight(highlights []string, stats faviconStats, text highlightText) []string {
+	var shared []string
+	for _, group := range stats.Groups {
+		if len(group.Hosts) < 2 {
+			continue
+		}
+		shared = append(shared, fmt.Sprintf("%d (%s)", group.Hash, strings.Join(limitStrings(group.Hosts, 3), ", ")))
+	}
+	if len(shared) == 0 {
+		return highlights
+	}
+	return append(highlights, text.sprintf("hl_shared_favicons", strings.Join(limitStrings(shared, 3), "; ")))
+}
+
+// appendRiskyPortsHighlight añade los servicios de riesgo (Telnet, SMB, RDP,
+// Redis...) que los escáneres de puertos encontraron abiertos.
+func appendRiskyPortsHighlight(highlights []string, stats portStats, rules highlightRules, text highlightText) []string {
+	if stats.RiskyTotal == 0 || !rules.enabled(highlightRiskyPorts) {
+		return highlights
+	}
+	highlight := text.sprintf("hl_risky_ports", strings.Join(limitStrings(stats.Risky, 3), ", "))
+	return append(highlights, rules.format(highlightRiskyPorts, highlight, text))
+}
+
+// appendExposedPagesHighlight añade un highlight "<label>: url, ..." si la
+// lista contiene páginas expuestas (server-status, phpinfo, ...).
+func appendExposedPagesHighlight(highlights []string, label string, list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, value := range artifactValues(list) {
+		seen[value] = struct{}{}
+	}
+	if len(seen) == 0 {
+		return highlights
+	}
+	pages := sortedStringsWithLimit(seen, 0)
+	return append(highlights, fmt.Sprintf("%s: %s", label, strings.Join(limitStrings(pages, 3), ", ")))
+}
+
+// appendMailHighlight añade las interfaces de correo web expuestas junto con
+// los registros MX conocidos, que completan el mapa de la infraestructura de
+// correo.
+func appendMailHighlight(highlights []string, mail, dns []artifacts.Artifact, text highlightText) []string {
+	label := text["hl_mail"]
+	if mx := collectMXHosts(dns); len(mx) > 0 {
+		label += fmt.Sprintf(" [MX: %s]", strings.Join(limitStrings(mx, 3), ", "))
+	}
+	return appendExposedPagesHighlight(highlights, label, mail)
+}
+
+// collectMXHosts devuelve los servidores de correo (ordenados y sin
+// duplicados) de los registros DNS de tipo MX, tomados de los metadatos o del
+// valor "host [MX] prioridad servidor".
+func collectMXHosts(list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		typ, _ := art.Metadata["type"].(string)
+		value, _ := art.Metadata["value"].(string)
+		if typ == "" || value == "" {
+			open := strings.Index(art.Value, " [")
+			closeIdx := strings.Index(art.Value, "] ")
+			if open <= 0 || closeIdx <= open {
+				continue
+			}
+			typ = art.Value[open+2 : closeIdx]
+			value = art.Value[closeIdx+2:]
+		}
+		if !strings.EqualFold(strings.TrimSpace(typ), "MX") {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		host := strings.TrimSuffix(strings.ToLower(fields[len(fields)-1]), ".")
+		if host != "" {
+			seen[host] = struct{}{}
+		}
+	}
+	return sortedStringsWithLimit(seen, 0)
+}
+
+// collectWeakCiphers devuelve entradas "host (cipher)" ordenadas para las rutas
+// activas cuyo handshake negoció una cipher suite marcada como débil.
+func collectWeakCiphers(list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		cipher, _ := art.Metadata["weak_cipher"].(string)
+		cipher = strings.TrimSpace(cipher)
+		if cipher == "" {
+			continue
+		}
+		host := art.Value
+		if u, err := url.Parse(art.Value); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		seen[fmt.Sprintf("%s (%s)", host, cipher)] = struct{}{}
+	}
+	return sortedStringsWithLimit(seen, 0)
+}
+
+// collectInsecureCookies devuelve entradas "host (cookie)" ordenadas para las
+// cookies marcadas sin atributos de seguridad en rutas activas.
+func collectInsecureCookies(list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		names, _ := art.Metadata["insecure_cookies"].([]any)
+		if len(names) == 0 {
+			continue
+		}
+		host := art.Value
+		if u, err := url.Parse(art.Value); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		for _, raw := range names {
+			name, _ := raw.(string)
+			if name = strings.TrimSpace(name); name != "" {
+				seen[fmt.Sprintf("%s (%s)", host, name)] = struct{}{}
+			}
+		}
+	}
+	return sortedStringsWithLimit(seen, 0)
+}
+
+// collectClickjackingPages devuelve las rutas activas marcadas como candidatas
+// a clickjacking (sin X-Frame-Options ni frame-ancestors), ordenadas.
+func collectClickjackingPages(list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		if flagged, _ := art.Metadata["clickjacking"].(bool); !flagged {
+			continue
+		}
+		if value := strings.TrimSpace(art.Value); value != "" {
+			seen[value] = struct{}{}
+		}
+	}
+	return sortedStringsWithLimit(seen, 0)
+}
+
+// collectReflectedParams devuelve entradas "host (parámetro)" ordenadas para las
+// rutas activas con un parámetro reflejado sin escapar.
+func collectReflectedParams(list []artifacts.Artifact) []string {
+	seen := make(map[string]struct{})
+	for _, art := range list {
+		param, _ := art.Metadata["reflected_param"].(string)
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		host := art.Value
+		if u, err := url.Parse(art.Value); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		seen[fmt.Sprintf("%s (%s)", host, param)] = struct{}{}
+	}
+	return sortedStringsWithLimit(seen, 0)
+}
+
 const reportTemplate = `<!DOCTYPE html>
-<html lang="es">
+<html lang="{{.Lang}}">
 <head>
         <meta charset="utf-8">
-        <title>Informe passive-rec</title>
+        <title>{{.L.page_title}}</title>
         <style>
                 :root {
                         color-scheme: light;
@@ -1216,111 +2084,117 @@ const reportTemplate = `<!DOCTYPE html>
         <div class="layout">
                 <header class="masthead">
                         <div>
-                                <h1>Informe de passive-rec</h1>
-                                <p>Evaluación de superficie para <strong>{{.Target}}</strong></p>
-                                <p class="meta-line">Generado: {{.GeneratedAt}} · Directorio de salida: {{.OutDir}}</p>
+                                <h1>{{.L.heading}}</h1>
+                                <p>{{.L.surface_for}} <strong>{{.Target}}</strong></p>
+                                <p class="meta-line">{{.L.generated}}: {{.GeneratedAt}} · {{.L.output_dir}}: {{.OutDir}}</p>
+                                {{if .Since}}<p class="meta-line">{{.L.since_window}}: <strong>{{.Since}}</strong></p>{{end}}
                         </div>
                         <div class="badge-set">
                                 <span class="tag tag-product">passive-rec</span>
                                 {{if .ActiveMode}}
-                                <span class="tag tag-active">Modo mixto (pasivo + activo)</span>
+                                <span class="tag tag-active">{{.L.mode_mixed}}</span>
                                 {{else}}
-                                <span class="tag tag-passive">Modo pasivo</span>
+                                <span class="tag tag-passive">{{.L.mode_passive}}</span>
                                 {{end}}
                         </div>
                 </header>
                 <nav class="quick-nav">
-                        <a href="#resumen">Resumen ejecutivo</a>
-                        <a href="#hallazgos">Hallazgos clave</a>
-                        <a href="#dominios">Dominios</a>
-                        <a href="#rutas">Rutas</a>
-                        <a href="#certificados">Certificados</a>
-                        <a href="#meta">Meta</a>
-                        {{if .ActiveMode}}<a href="#activo">Recolección activa</a>{{end}}
+                        <a href="#resumen">{{.L.section_summary}}</a>
+                        <a href="#hallazgos">{{.L.section_highlights}}</a>
+                        <a href="#dominios">{{.L.section_domains}}</a>
+                        {{if .Environments}}<a href="#entornos">{{.L.section_environments}}</a>{{end}}
+                        <a href="#rutas">{{.L.section_routes}}</a>
+                        <a href="#certificados">{{.L.section_certificates}}</a>
+                        {{if gt .IPs.Total 0}}<a href="#ips">{{.L.section_ips}}</a>{{end}}
+                        {{if gt .Emails.Total 0}}<a href="#correos">{{.L.section_emails}}</a>{{end}}
+                        {{if gt .Favicons.Total 0}}<a href="#favicons">{{.L.section_favicons}}</a>{{end}}
+                        {{if gt .Ports.Total 0}}<a href="#puertos">{{.L.section_ports}}</a>{{end}}
+                        <a href="#meta">{{.L.section_meta}}</a>
+                        {{if .ShowActive}}<a href="#activo">{{.L.nav_active}}</a>{{end}}
                 </nav>
                 <main>
                         <section id="resumen" class="panel">
-                                <h2>Resumen ejecutivo</h2>
-                                <p class="subtext">Vista rápida de los hallazgos más relevantes para priorizar acciones.</p>
+                                <h2>{{.L.section_summary}}</h2>
+                                <p class="subtext">{{.L.summary_subtext}}</p>
                                 <div class="cards">
                                         <div class="card">
-                                                <h3>Total de artefactos procesados</h3>
+                                                <h3>{{.L.card_total}}</h3>
                                                 <p class="metric">{{.Overview.TotalArtifacts}}</p>
-                                                <p class="subtext">Entradas combinadas de dominios, rutas y certificados.</p>
+                                                <p class="subtext">{{.L.card_total_sub}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Dominios únicos</h3>
+                                                <h3>{{.L.unique_domains}}</h3>
                                                 <p class="metric">{{.Overview.UniqueDomains}}</p>
-                                                <p class="subtext">Incluye {{.Domains.UniqueRegistrable}} dominios registrables distintos.</p>
+                                                <p class="subtext">{{tr .L.unique_domains_sub .Domains.UniqueRegistrable}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Hosts únicos en rutas</h3>
+                                                <h3>{{.L.unique_hosts}}</h3>
                                                 <p class="metric">{{.Overview.UniqueHosts}}</p>
-                                                <p class="subtext">Cobertura sobre {{.Routes.UniqueSchemes}} esquemas de servicio; {{printf "%.1f" .Overview.InsecureRoutesPercent}}% sin HTTPS.</p>
+                                                <p class="subtext">{{tr .L.unique_hosts_sub .Routes.UniqueSchemes .Overview.InsecureRoutesPercent}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Certificados únicos</h3>
+                                                <h3>{{.L.unique_certificates}}</h3>
                                                 <p class="metric">{{.Overview.UniqueCertificates}}</p>
-                                                <p class="subtext">{{.Certificates.ExpiringSoon}} por expirar en {{.Certificates.SoonThresholdDays}} días.</p>
+                                                <p class="subtext">{{tr .L.unique_certificates_sub .Certificates.ExpiringSoon .Certificates.SoonThresholdDays}}</p>
                                         </div>
                                 </div>
                         </section>
 
                         <section id="hallazgos" class="panel">
-                                <h2>Hallazgos clave</h2>
+                                <h2>{{.L.section_highlights}}</h2>
                                 {{if hasStrings .Highlights}}
                                 <ul class="insights">
                                         {{range .Highlights}}
-                                        <li><span class="insight-tag">Hallazgo</span><span class="insight-text">{{.}}</span></li>
+                                        <li><span class="insight-tag">{{$.L.finding_tag}}</span><span class="insight-text">{{.}}</span></li>
                                         {{end}}
                                 </ul>
                                 {{else}}
-                                <p class="muted">Sin hallazgos destacados generados automáticamente.</p>
+                                <p class="muted">{{.L.no_highlights}}</p>
                                 {{end}}
                         </section>
 
                         <section id="dominios" class="panel">
-                                <h2>Dominios</h2>
+                                <h2>{{.L.section_domains}}</h2>
                                 <div class="grid">
                                         <div>
-                                                <p><strong>Total de dominios recolectados:</strong> {{.Domains.Total}}</p>
-                                                <p><strong>Dominios únicos:</strong> {{.Domains.Unique}} (registrables: {{.Domains.UniqueRegistrable}})</p>
-                                                <p><strong>Niveles promedio por dominio:</strong> {{printf "%.2f" .Domains.AverageLabels}}</p>
-                                                <p><strong>Dominios comodín detectados:</strong> {{.Domains.WildcardCount}}</p>
+                                                <p><strong>{{.L.domains_total}}:</strong> {{.Domains.Total}}</p>
+                                                <p><strong>{{.L.unique_domains}}:</strong> {{.Domains.Unique}} ({{.L.registrable}}: {{.Domains.UniqueRegistrable}})</p>
+                                                <p><strong>{{.L.domains_avg_labels}}:</strong> {{printf "%.2f" .Domains.AverageLabels}}</p>
+                                                <p><strong>{{.L.domains_wildcards}}:</strong> {{.Domains.WildcardCount}}</p>
                                         </div>
                                         <div>
-                                                <p class="subtext">Los dominios con mayor frecuencia ayudan a identificar activos críticos y oportunidades para consolidar cobertura.</p>
+                                                <p class="subtext">{{.L.domains_subtext}}</p>
                                         </div>
                                 </div>
                                 {{if hasData .Domains.TopRegistrable}}
-                                <h3>Top dominios registrables</h3>
+                                <h3>{{.L.top_registrable}}</h3>
                                 <table>
-                                        <tr><th>Dominio</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_domain}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Domains.TopRegistrable}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Domains.LabelHistogram}}
-                                <h3>Distribución por niveles</h3>
+                                <h3>{{.L.label_distribution}}</h3>
                                 <table>
-                                        <tr><th>Niveles</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_labels}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Domains.LabelHistogram}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Domains.TopTLDs}}
-                                <h3>Top TLDs observados</h3>
+                                <h3>{{.L.top_tlds}}</h3>
                                 <table>
-                                        <tr><th>TLD</th><th>Conteo</th></tr>
+                                        <tr><th>TLD</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Domains.TopTLDs}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasStrings .Domains.Interesting}}
-                                <h3>Dominios potencialmente sensibles</h3>
+                                <h3>{{.L.interesting_domains}}</h3>
                                 <ul>
                                         {{range .Domains.Interesting}}
                                         <li>{{.}}</li>
@@ -1329,58 +2203,82 @@ const reportTemplate = `<!DOCTYPE html>
                                 {{end}}
                         </section>
 
+                        {{if .Environments}}
+                        <section id="entornos" class="panel">
+                                <h2>{{.L.section_environments}}</h2>
+                                <p class="subtext">{{.L.environments_subtext}}</p>
+                                {{range .Environments}}
+                                <h3>{{.Name}} ({{.Count}})</h3>
+                                <ul>
+                                        {{range .Hosts}}
+                                        <li>{{.}}</li>
+                                        {{end}}
+                                </ul>
+                                {{end}}
+                        </section>
+                        {{end}}
+
                         <section id="rutas" class="panel">
-                                <h2>Rutas</h2>
+                                <h2>{{.L.section_routes}}</h2>
                                 <div class="grid">
                                         <div>
-                                                <p><strong>Total de rutas:</strong> {{.Routes.Total}}</p>
-                                                <p><strong>Hosts únicos observados:</strong> {{.Routes.UniqueHosts}}</p>
-                                                <p><strong>Profundidad promedio de ruta:</strong> {{printf "%.2f" .Routes.AveragePathDepth}}</p>
-                                                <p><strong>Uso de HTTPS:</strong> {{printf "%.1f" .Routes.SecurePercentage}}% de las rutas.</p>
+                                                <p><strong>{{.L.routes_total}}:</strong> {{.Routes.Total}}</p>
+                                                <p><strong>{{.L.routes_unique_hosts}}:</strong> {{.Routes.UniqueHosts}}</p>
+                                                <p><strong>{{.L.routes_avg_depth}}:</strong> {{printf "%.2f" .Routes.AveragePathDepth}}</p>
+                                                <p><strong>{{.L.routes_https}}:</strong> {{tr .L.routes_https_value .Routes.SecurePercentage}}</p>
                                         </div>
                                         <div>
-                                                <p class="subtext">Las rutas identificadas permiten priorizar revisiones de servicios y detectar activos expuestos.</p>
-                                                <p><strong>Hosts con protocolos inseguros:</strong> {{.Routes.InsecureHostTotal}}</p>
+                                                <p class="subtext">{{.L.routes_subtext}}</p>
+                                                <p><strong>{{.L.routes_insecure_hosts}}:</strong> {{.Routes.InsecureHostTotal}}</p>
                                         </div>
                                 </div>
                                 {{if hasData .Routes.SchemeHistogram}}
-                                <h3>Esquemas por volumen</h3>
+                                <h3>{{.L.schemes_by_volume}}</h3>
                                 <table>
-                                        <tr><th>Esquema</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_scheme}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Routes.SchemeHistogram}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Routes.DepthHistogram}}
-                                <h3>Profundidad de rutas</h3>
+                                <h3>{{.L.path_depth}}</h3>
                                 <table>
-                                        <tr><th>Segmentos</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_segments}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Routes.DepthHistogram}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Routes.InsecureHosts}}
-                                <h3>Hosts con tráfico no cifrado</h3>
+                                <h3>{{.L.insecure_hosts}}</h3>
                                 <table>
-                                        <tr><th>Host</th><th>Rutas</th></tr>
+                                        <tr><th>Host</th><th>{{.L.section_routes}}</th></tr>
                                         {{range .Routes.InsecureHosts}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Routes.TopPorts}}
-                                <h3>Puertos observados</h3>
+                                <h3>{{.L.observed_ports}}</h3>
                                 <table>
-                                        <tr><th>Puerto</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_port}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Routes.TopPorts}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
+                                {{if hasData .Routes.TopParams}}
+                                <h3>{{.L.top_params}}</h3>
+                                <table>
+                                        <tr><th>{{.L.th_param}}</th><th>{{.L.section_routes}}</th></tr>
+                                        {{range .Routes.TopParams}}
+                                        <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+                                        {{end}}
+                                </table>
+                                {{end}}
                                 {{if hasStrings .Routes.NonStandardPorts}}
-                                <h3>Servicios en puertos no estándar</h3>
+                                <h3>{{.L.nonstandard_ports}}</h3>
                                 <ul>
                                         {{range .Routes.NonStandardPorts}}
                                         <li>{{.}}</li>
@@ -1388,55 +2286,63 @@ const reportTemplate = `<!DOCTYPE html>
                                 </ul>
                                 {{end}}
                                 {{if hasStrings .Routes.InterestingPaths}}
-                                <h3>Endpoints con palabras clave sensibles</h3>
+                                <h3>{{.L.interesting_paths}}</h3>
                                 <ul>
                                         {{range .Routes.InterestingPaths}}
                                         <li>{{.}}</li>
                                         {{end}}
                                 </ul>
                                 {{end}}
+                                {{if hasStrings .Routes.WebSockets}}
+                                <h3>{{.L.websocket_endpoints}}</h3>
+                                <ul>
+                                        {{range .Routes.WebSockets}}
+                                        <li>{{.}}</li>
+                                        {{end}}
+                                </ul>
+                                {{end}}
                         </section>
 
                         <section id="certificados" class="panel">
-                                <h2>Certificados</h2>
+                                <h2>{{.L.section_certificates}}</h2>
                                 <div class="grid">
                                         <div>
-                                                <p><strong>Certificados únicos:</strong> {{.Certificates.Unique}}</p>
-                                                <p><strong>Emisores únicos:</strong> {{.Certificates.UniqueIssuers}}</p>
-                                                <p><strong>Certificados vencidos:</strong> {{.Certificates.Expired}}</p>
-                                                <p><strong>Dominios registrables únicos:</strong> {{.Certificates.UniqueRegistrable}}</p>
+                                                <p><strong>{{.L.unique_certificates}}:</strong> {{.Certificates.Unique}}</p>
+                                                <p><strong>{{.L.unique_issuers}}:</strong> {{.Certificates.UniqueIssuers}}</p>
+                                                <p><strong>{{.L.expired_certificates}}:</strong> {{.Certificates.Expired}}</p>
+                                                <p><strong>{{.L.unique_registrable}}:</strong> {{.Certificates.UniqueRegistrable}}</p>
                                         </div>
                                         <div>
-                                                <p class="subtext">Los certificados permiten medir la higiene criptográfica y planificar renovaciones.</p>
-                                                <p><strong>Certificados por expirar ({{.Certificates.SoonThresholdDays}} días):</strong> {{.Certificates.ExpiringSoon}}</p>
+                                                <p class="subtext">{{.L.certs_subtext}}</p>
+                                                <p><strong>{{tr .L.certs_expiring .Certificates.SoonThresholdDays}}:</strong> {{.Certificates.ExpiringSoon}}</p>
                                                 {{if .Certificates.NextExpiration}}
-                                                <p><strong>Próximo vencimiento:</strong> {{.Certificates.NextExpiration}}</p>
+                                                <p><strong>{{.L.next_expiration}}:</strong> {{.Certificates.NextExpiration}}</p>
                                                 {{end}}
                                                 {{if .Certificates.LatestExpiration}}
-                                                <p><strong>Último vencimiento observado:</strong> {{.Certificates.LatestExpiration}}</p>
+                                                <p><strong>{{.L.latest_expiration}}:</strong> {{.Certificates.LatestExpiration}}</p>
                                                 {{end}}
                                         </div>
                                 </div>
                                 {{if hasData .Certificates.TopRegistrable}}
-                                <h3>Top dominios registrables asociados</h3>
+                                <h3>{{.L.certs_top_registrable}}</h3>
                                 <table>
-                                        <tr><th>Dominio</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_domain}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Certificates.TopRegistrable}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasData .Certificates.TopIssuers}}
-                                <h3>Top emisores</h3>
+                                <h3>{{.L.top_issuers}}</h3>
                                 <table>
-                                        <tr><th>Emisor</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_issuer}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Certificates.TopIssuers}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
                                 {{if hasStrings .Certificates.ExpiredList}}
-                                <h3>Certificados vencidos destacados</h3>
+                                <h3>{{.L.expired_list}}</h3>
                                 <ul>
                                         {{range .Certificates.ExpiredList}}
                                         <li>{{.}}</li>
@@ -1444,17 +2350,123 @@ const reportTemplate = `<!DOCTYPE html>
                                 </ul>
                                 {{end}}
                                 {{if hasStrings .Certificates.ExpiringSoonList}}
-                                <h3>Certificados próximos a expirar</h3>
+                                <h3>{{.L.expiring_list}}</h3>
                                 <ul>
                                         {{range .Certificates.ExpiringSoonList}}
                                         <li>{{.}}</li>
                                         {{end}}
                                 </ul>
                                 {{end}}
+                                {{if hasStrings .Certificates.WildcardCertList}}
+                                <h3>{{.L.wildcard_certs}}</h3>
+                                <p class="muted">{{tr .L.wildcard_certs_sub .Certificates.WildcardCertCount}}</p>
+                                <ul>
+                                        {{range .Certificates.WildcardCertList}}
+                                        <li>{{.}}</li>
+                                        {{end}}
+                                </ul>
+                                {{end}}
                         </section>
 
+                        {{if gt .IPs.Total 0}}
+                        <section id="ips" class="panel">
+                                <h2>{{.L.section_ips}}</h2>
+                                <div class="grid">
+                                        <div>
+                                                <p><strong>{{.L.unique_ips}}:</strong> {{.IPs.Unique}}</p>
+                                                <p><strong>IPv4:</strong> {{.IPs.IPv4}}</p>
+                                                <p><strong>IPv6:</strong> {{.IPs.IPv6}}</p>
+                                        </div>
+                                        <div>
+                                                <p class="subtext">{{.L.ips_subtext}}</p>
+                                        </div>
+                                </div>
+                                {{if hasData .IPs.TopSubnets}}
+                                <h3>{{.L.top_subnets}}</h3>
+                                <table>
+                                        <tr><th>{{.L.th_subnet}}</th><th>{{.L.th_hosts}}</th></tr>
+                                        {{range .IPs.TopSubnets}}
+                                        <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+                                        {{end}}
+                                </table>
+                                {{end}}
+                        </section>
+                        {{end}}
+
+                        {{if gt .Emails.Total 0}}
+                        <section id="correos" class="panel">
+                                <h2>{{.L.section_emails}}</h2>
+                                <p><strong>{{.L.unique_emails}}:</strong> {{.Emails.Unique}}</p>
+                                <p class="subtext">{{.L.emails_subtext}}</p>
+                                <table>
+                                        <tr><th>{{.L.th_domain}}</th><th>{{.L.th_emails}}</th></tr>
+                                        {{range .Emails.Domains}}
+                                        <tr><td>{{.Domain}}</td><td>{{range $i, $e := .Emails}}{{if $i}}, {{end}}{{$e}}{{end}}</td></tr>
+                                        {{end}}
+                                </table>
+                        </section>
+                        {{end}}
+
+                        {{if gt .Favicons.Total 0}}
+                        <section id="favicons" class="panel">
+                                <h2>{{.L.section_favicons}}</h2>
+                                <p><strong>{{.L.shared_favicons}}:</strong> {{.Favicons.Shared}}</p>
+                                <p class="subtext">{{.L.favicons_subtext}}</p>
+                                <table>
+                                        <tr><th>{{.L.th_favicon_hash}}</th><th>{{.L.th_hosts}}</th></tr>
+                                        {{range .Favicons.Groups}}
+                                        <tr><td>{{.Hash}}</td><td>{{range $i, $h := .Hosts}}{{if $i}}, {{end}}{{$h}}{{end}}</td></tr>
+                                        {{end}}
+                                </table>
+                        </section>
+                        {{end}}
+
+                        {{if gt .Ports.Total 0}}
+                        <section id="puertos" class="panel">
+                                <h2>{{.L.section_ports}}</h2>
+                                <div class="grid">
+                                        <div>
+                                                <p><strong>{{.L.open_ports}}:</strong> {{.Ports.Total}}</p>
+                                                <p><strong>{{.L.port_hosts}}:</strong> {{.Ports.UniqueHosts}}</p>
+                                                <p><strong>{{.L.risky_ports}}:</strong> {{.Ports.RiskyTotal}}</p>
+                                        </div>
+                                        <div>
+                                                <p class="subtext">{{.L.ports_subtext}}</p>
+                                        </div>
+                                </div>
+                                <div class="grid">
+                                        <div>
+                                                <h3>{{.L.top_open_ports}}</h3>
+                                                <table>
+                                                        <tr><th>{{.L.th_port}}</th><th>{{.L.th_hosts}}</th></tr>
+                                                        {{range .Ports.TopPorts}}
+                                                        <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+                                                        {{end}}
+                                                </table>
+                                        </div>
+                                        <div>
+                                                <h3>{{.L.top_port_hosts}}</h3>
+                                                <table>
+                                                        <tr><th>{{.L.th_host}}</th><th>{{.L.open_ports}}</th></tr>
+                                                        {{range .Ports.TopHosts}}
+                                                        <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
+                                                        {{end}}
+                                                </table>
+                                        </div>
+                                </div>
+                                {{if hasStrings .Ports.Risky}}
+                                <h3>{{.L.risky_ports}}</h3>
+                                <ul>
+                                        {{range .Ports.Risky}}
+                                        <li>{{.}}</li>
+                                        {{end}}
+                                </ul>
+                                {{end}}
+                        </section>
+                        {{end}}
+
                         <section id="meta" class="panel">
-                                <h2>Meta</h2>
+                                <h2>{{.L.section_meta}}</h2>
                                 {{if .Meta}}
                                 <ul>
                                         {{range .Meta}}
@@ -1462,47 +2474,46 @@ const reportTemplate = `<!DOCTYPE html>
                                         {{end}}
                                 </ul>
                                 {{else}}
-                                <p class="muted">Sin entradas meta.</p>
+                                <p class="muted">{{.L.no_meta}}</p>
                                 {{end}}
                         </section>
-                        {{if .ActiveMode}}
+                        {{if .ShowActive}}
                         <section id="activo" class="panel">
-                                <h2>Resultados de recolección activa</h2>
-                                <p class="subtext">Hallazgos derivados de validaciones activas contra los activos descubiertos.</p>
-                                {{if or (gt .Active.Domains.Total 0) (gt .Active.Routes.Total 0) (gt .Active.DNS.Total 0) (gt (len .Active.Meta) 0) (gt .Active.Certificates.Total 0)}}
+                                <h2>{{.L.active_title}}</h2>
+                                <p class="subtext">{{.L.active_subtext}}</p>
                                 <div class="cards">
                                         <div class="card">
-                                                <h3>Dominios activos detectados</h3>
+                                                <h3>{{.L.active_domains}}</h3>
                                                 <p class="metric">{{.Active.Domains.Total}}</p>
-                                                <p class="subtext">{{.Active.Domains.Unique}} dominios únicos observados.</p>
+                                                <p class="subtext">{{tr .L.active_domains_sub .Active.Domains.Unique}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Rutas activas evaluadas</h3>
+                                                <h3>{{.L.active_routes}}</h3>
                                                 <p class="metric">{{.Active.Routes.Total}}</p>
-                                                <p class="subtext">{{.Active.Routes.UniqueHosts}} hosts; {{printf "%.1f" .Active.Routes.SecurePercentage}}% con HTTPS.</p>
+                                                <p class="subtext">{{tr .L.active_routes_sub .Active.Routes.UniqueHosts .Active.Routes.SecurePercentage}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Registros DNS activos</h3>
+                                                <h3>{{.L.active_dns}}</h3>
                                                 <p class="metric">{{.Active.DNS.Total}}</p>
-                                                <p class="subtext">{{.Active.DNS.UniqueHosts}} dominios con registros.</p>
+                                                <p class="subtext">{{tr .L.active_dns_sub .Active.DNS.UniqueHosts}}</p>
                                         </div>
                                         <div class="card">
-                                                <h3>Certificados activos observados</h3>
+                                                <h3>{{.L.active_certs}}</h3>
                                                 <p class="metric">{{.Active.Certificates.Total}}</p>
-                                                <p class="subtext">{{.Active.Certificates.Unique}} certificados únicos.</p>
+                                                <p class="subtext">{{tr .L.active_certs_sub .Active.Certificates.Unique}}</p>
                                         </div>
                                 </div>
                                 {{if hasStrings .Active.Highlights}}
-                                <h3>Hallazgos activos clave</h3>
+                                <h3>{{.L.active_highlights}}</h3>
                                 <ul class="insights">
                                         {{range .Active.Highlights}}
-                                        <li><span class="insight-tag">Hallazgo</span><span class="insight-text">{{.}}</span></li>
+                                        <li><span class="insight-tag">{{$.L.finding_tag}}</span><span class="insight-text">{{.}}</span></li>
                                         {{end}}
                                 </ul>
                                 {{else}}
-                                <p class="muted">Sin hallazgos activos destacados.</p>
+                                <p class="muted">{{.L.no_active_highlights}}</p>
                                 {{end}}
-                                <h3>Dominios detectados</h3>
+                                <h3>{{.L.active_raw_domains}}</h3>
                                 {{if hasStrings .Active.RawDomains}}
                                 <ul>
                                         {{range (limit .Active.RawDomains 25)}}
@@ -1510,12 +2521,12 @@ const reportTemplate = `<!DOCTYPE html>
                                         {{end}}
                                 </ul>
                                 {{if gt (len .Active.RawDomains) 25}}
-                                <p class="muted">Mostrando 25 de {{len .Active.RawDomains}} dominios activos.</p>
+                                <p class="muted">{{tr .L.showing_domains 25 (len .Active.RawDomains)}}</p>
                                 {{end}}
                                 {{else}}
-                                <p class="muted">No se recolectaron dominios activos.</p>
+                                <p class="muted">{{.L.no_active_domains}}</p>
                                 {{end}}
-                                <h3>Registros DNS</h3>
+                                <h3>{{.L.dns_records}}</h3>
                                 {{if hasStrings .Active.RawDNS}}
                                 <ul>
                                         {{range (limit .Active.RawDNS 25)}}
@@ -1523,21 +2534,21 @@ const reportTemplate = `<!DOCTYPE html>
                                         {{end}}
                                 </ul>
                                 {{if gt (len .Active.RawDNS) 25}}
-                                <p class="muted">Mostrando 25 de {{len .Active.RawDNS}} registros DNS.</p>
+                                <p class="muted">{{tr .L.showing_dns 25 (len .Active.RawDNS)}}</p>
                                 {{end}}
                                 {{else}}
-                                <p class="muted">No se registraron resultados DNS activos.</p>
+                                <p class="muted">{{.L.no_active_dns}}</p>
                                 {{end}}
                                 {{if hasData .Active.DNS.RecordTypes}}
-                                <h4>Tipos de registros observados</h4>
+                                <h4>{{.L.record_types}}</h4>
                                 <table class="metrics">
-                                        <tr><th>Tipo</th><th>Conteo</th></tr>
+                                        <tr><th>{{.L.th_type}}</th><th>{{.L.th_count}}</th></tr>
                                         {{range .Active.DNS.RecordTypes}}
                                         <tr><td>{{.Name}}</td><td>{{.Count}}</td></tr>
                                         {{end}}
                                 </table>
                                 {{end}}
-                                <h3>Rutas activas</h3>
+                                <h3>{{.L.active_raw_routes}}</h3>
                                 {{if hasStrings .Active.RawRoutes}}
                                 <ul>
                                         {{range (limit .Active.RawRoutes 25)}}
@@ -1545,12 +2556,12 @@ const reportTemplate = `<!DOCTYPE html>
                                         {{end}}
                                 </ul>
                                 {{if gt (len .Active.RawRoutes) 25}}
-                                <p class="muted">Mostrando 25 de {{len .Active.RawRoutes}} rutas activas.</p>
+                                <p class="muted">{{tr .L.showing_routes 25 (len .Active.RawRoutes)}}</p>
                                 {{end}}
                                 {{else}}
-                                <p class="muted">No se registraron rutas activas.</p>
+                                <p class="muted">{{.L.no_active_routes}}</p>
                                 {{end}}
-                                <h3>Meta activa</h3>
+                                <h3>{{.L.active_meta}}</h3>
                                 {{if hasStrings .Active.Meta}}
                                 <ul>
                                         {{range .Active.Meta}}
@@ -1558,13 +2569,13 @@ const reportTemplate = `<!DOCTYPE html>
                                         {{end}}
                                 </ul>
                                 {{else}}
-                                <p class="muted">Sin entradas meta activas.</p>
+                                <p class="muted">{{.L.no_active_meta}}</p>
                                 {{end}}
                                 {{if gt .Active.Certificates.Total 0}}
-                                <h3>Certificados (activos)</h3>
-                                <p><strong>Total recolectado:</strong> {{.Active.Certificates.Total}} (únicos: {{.Active.Certificates.Unique}})</p>
+                                <h3>{{.L.active_certs_section}}</h3>
+                                <p><strong>{{.L.total_collected}}:</strong> {{.Active.Certificates.Total}} ({{.L.unique}}: {{.Active.Certificates.Unique}})</p>
                                 {{if hasStrings .Active.Certificates.ExpiredList}}
-                                <h4>Certificados vencidos detectados</h4>
+                                <h4>{{.L.active_expired}}</h4>
                                 <ul>
                                         {{range .Active.Certificates.ExpiredList}}
                                         <li>{{.}}</li>
@@ -1572,7 +2583,7 @@ const reportTemplate = `<!DOCTYPE html>
                                 </ul>
                                 {{end}}
                                 {{if hasStrings .Active.Certificates.ExpiringSoonList}}
-                                <h4>Certificados por expirar pronto</h4>
+                                <h4>{{.L.active_expiring}}</h4>
                                 <ul>
                                         {{range .Active.Certificates.ExpiringSoonList}}
                                         <li>{{.}}</li>
@@ -1580,14 +2591,11 @@ const reportTemplate = `<!DOCTYPE html>
                                 </ul>
                                 {{end}}
                                 {{end}}
-                                {{else}}
-                                <p class="muted">No se recolectaron hallazgos activos.</p>
-                                {{end}}
                         </section>
                         {{end}}
                 </main>
                 <footer>
-                        <p>Este informe resume artefactos recolectados de manera pasiva{{if .ActiveMode}} y activa{{end}}. Revise los hallazgos y priorice acciones según el apetito de riesgo de la organización.</p>
+                        <p>{{if .ActiveMode}}{{.L.footer_mixed}}{{else}}{{.L.footer_passive}}{{end}}</p>
                 </footer>
         </div>
 </body>
@@ -3,8 +3,10 @@ package report
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -172,6 +174,531 @@ func TestGenerateIncludesActiveData(t *testing.T) {
 	}
 }
 
+func TestGenerateGroupsDomainsByEnvironment(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "dev.example.com", Up: true, Metadata: map[string]any{"environment": "development"}},
+		{Type: "domain", Value: "staging.example.com", Up: true, Metadata: map[string]any{"environment": "staging"}},
+		{Type: "domain", Value: "uat.example.com", Up: true, Metadata: map[string]any{"environment": "uat"}},
+		{Type: "domain", Value: "api.stg.example.com", Active: true, Up: true, Metadata: map[string]any{"environment": "staging"}},
+		{Type: "domain", Value: "www.example.com", Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	start := strings.Index(contents, `<section id="entornos"`)
+	if start < 0 {
+		t.Fatalf("expected environments section in report.html")
+	}
+	section := contents[start:]
+	section = section[:strings.Index(section, "</section>")]
+	for _, want := range []string{"development (1)", "staging (2)", "uat (1)", "api.stg.example.com", "staging.example.com"} {
+		if !strings.Contains(section, want) {
+			t.Fatalf("expected environments section to contain %q\nsection:\n%s", want, section)
+		}
+	}
+	if strings.Contains(section, "www.example.com") {
+		t.Fatalf("production host should not be listed under environments:\n%s", section)
+	}
+
+	empty := t.TempDir()
+	writeArtifacts(t, empty, []artifacts.Artifact{{Type: "domain", Value: "www.example.com", Up: true}})
+	if err := Generate(context.Background(), &config.Config{Target: "example.com", OutDir: empty}); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(readFile(t, filepath.Join(empty, "report.html")), `id="entornos"`) {
+		t.Fatalf("environments section should be omitted without flagged domains")
+	}
+}
+
+func TestGenerateHighlightsWeakCiphers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://legacy.example.com/", Active: true, Up: true, Metadata: map[string]any{
+			"tls_cipher":  "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+			"weak_cipher": "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+		}},
+		{Type: "route", Value: "https://modern.example.com/", Active: true, Up: true, Metadata: map[string]any{
+			"tls_cipher": "TLS_AES_128_GCM_SHA256",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Cipher suites TLS débiles negociadas: legacy.example.com (TLS_RSA_WITH_3DES_EDE_CBC_SHA)"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+	if strings.Contains(contents, "modern.example.com (") {
+		t.Fatalf("strong cipher should not be highlighted")
+	}
+}
+
+func TestGenerateHighlightsInsecureCookies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://app.example.com/login", Active: true, Up: true, Metadata: map[string]any{
+			"insecure_cookies": []string{"session"},
+		}},
+		{Type: "route", Value: "https://secure.example.com/", Active: true, Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Cookies sin atributos Secure/HttpOnly/SameSite: app.example.com (session)"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsClickjackingPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://app.example.com/account", Active: true, Up: true, Metadata: map[string]any{
+			"clickjacking": true,
+			"severity":     "medium",
+		}},
+		{Type: "route", Value: "https://secure.example.com/", Active: true, Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Páginas HTTPS sin protección anti-clickjacking (severidad media): https://app.example.com/account"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+	if strings.Contains(contents, "anti-clickjacking (severidad media): https://secure.example.com") {
+		t.Fatalf("protected page should not be highlighted")
+	}
+}
+
+func TestGenerateHighlightsReflectedParams(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://app.example.com/search?q=1", Active: true, Up: true, Metadata: map[string]any{
+			"reflected_param": "q",
+			"severity":        "high",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Parámetros reflejados sin escapar (posible XSS, severidad alta): app.example.com (q)"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateFindsCertsWithRemappedTypeDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	marshal := func(record certs.Record) string {
+		t.Helper()
+		value, err := record.Marshal()
+		if err != nil {
+			t.Fatalf("marshal cert: %v", err)
+		}
+		return value
+	}
+	manifestCert := marshal(certs.Record{Source: "crt.sh", CommonName: "vpn.example.com", Issuer: "Example CA", NotAfter: "2099-01-01T00:00:00Z"})
+	// Solo existe en el directorio reasignado, no en artifacts.jsonl.
+	remappedCert := marshal(certs.Record{Source: "crt.sh", CommonName: "legacy-mail.example.com", Issuer: "Remapped CA", NotAfter: "2099-01-01T00:00:00Z"})
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "certificate", Value: manifestCert, Up: true, Tool: "crt.sh"},
+	})
+	certDir := filepath.Join(dir, "custom-certs")
+	if err := os.MkdirAll(certDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(certDir, "certs.passive"), []byte(manifestCert+"\n"+remappedCert+"\n"), 0o644); err != nil {
+		t.Fatalf("write remapped certs: %v", err)
+	}
+
+	cfg := &config.Config{
+		Target:   "example.com",
+		OutDir:   dir,
+		TypeDirs: map[string]string{"certificate": "custom-certs"},
+	}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	if !strings.Contains(contents, "Certificados únicos:</strong> 2") {
+		t.Fatalf("expected the remapped certificate to be counted once alongside the manifest one\nreport contents:\n%s", contents)
+	}
+	if !strings.Contains(contents, "Remapped CA") {
+		t.Fatalf("expected the issuer only present in the remapped directory\nreport contents:\n%s", contents)
+	}
+}
+
+func TestGenerateHighlightsServerStatusPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/server-status", Up: true},
+		{Type: "server-status", Value: "https://example.com/server-status", Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Páginas de estado del servidor expuestas (severidad alta): https://example.com/server-status"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsSearchClusters(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "search-cluster", Value: "https://es.example.com:9200/_cat/indices", Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Clusters de búsqueda expuestos (Elasticsearch/Kibana/Solr, severidad crítica): https://es.example.com:9200/_cat/indices"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateGroupsHostsBySharedFavicon(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "favicon", Types: []string{"route"}, Value: "https://a.example.com/favicon.ico", Active: true, Up: true, Metadata: map[string]any{"favicon_hash": -1234567}},
+		{Type: "favicon", Types: []string{"route"}, Value: "https://b.example.com/static/favicon.ico", Active: true, Up: true, Metadata: map[string]any{"favicon_hash": -1234567}},
+		{Type: "favicon", Types: []string{"route"}, Value: "https://a.example.com/static/favicon.ico", Active: true, Up: true, Metadata: map[string]any{"favicon_hash": -1234567}},
+		{Type: "favicon", Types: []string{"route"}, Value: "https://c.example.com/favicon.ico", Active: true, Up: true, Metadata: map[string]any{"favicon_hash": 42}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	for _, want := range []string{
+		"Favicons idénticos en hosts distintos (posible infraestructura compartida): -1234567 (a.example.com, b.example.com)",
+		"<tr><td>-1234567</td><td>a.example.com, b.example.com</td></tr>",
+		"<tr><td>42</td><td>c.example.com</td></tr>",
+	} {
+		if !strings.Contains(contents, want) {
+			t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+		}
+	}
+}
+
+func TestGenerateHighlightsIaCState(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "iac-state", Value: "https://example.com/terraform.tfstate", Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Ficheros de estado de IaC expuestos (Terraform/Ansible, severidad crítica): https://example.com/terraform.tfstate"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsCredentialFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "credential-file", Value: "https://example.com/.aws/credentials", Up: true, Metadata: map[string]any{
+			"file":     ".aws/credentials",
+			"severity": "critical",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Ficheros de credenciales expuestos (cloud, SSH, tokens, severidad crítica): https://example.com/.aws/credentials"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsActuatorEndpoints(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "actuator", Value: "https://example.com/actuator/heapdump", Up: true, Metadata: map[string]any{
+			"actuator_endpoint": "heapdump",
+			"severity":          "critical",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Endpoints de Spring Boot Actuator expuestos (heapdump crítico; env, mappings y otros filtran configuración): https://example.com/actuator/heapdump"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsGitExposure(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "git", Value: "https://example.com/.git/logs/HEAD", Up: true, Metadata: map[string]any{
+			"git_component": "logs",
+			"severity":      "critical",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Repositorios git expuestos (packs, objetos y reflogs permiten reconstruirlos por completo, severidad crítica): https://example.com/.git/logs/HEAD"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsMailInterfacesWithMX(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "mail", Value: "https://mail.example.com/owa/auth/logon.aspx", Up: true},
+		{Type: "dns", Value: "example.com [MX] 10 mx1.example.com.", Active: true, Up: true, Metadata: map[string]any{"host": "example.com", "type": "MX", "value": "10 mx1.example.com."}},
+		{Type: "dns", Value: "example.com [A] 203.0.113.10", Active: true, Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Interfaces de correo web expuestas (webmail, OWA, Exchange; severidad media) [MX: mx1.example.com]: https://mail.example.com/owa/auth/logon.aspx"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsExposedSourceCode(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "source-code", Types: []string{"route"}, Value: "https://example.com/lib/functions.phps", Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Ficheros de código fuente servidos en crudo (.rb, .java, .inc, .phps; severidad alta): https://example.com/lib/functions.phps"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateHighlightsPHPInfoPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/info.php", Active: true, Up: true},
+		{Type: "phpinfo", Value: "https://example.com/info.php", Active: true, Up: true, Metadata: map[string]any{
+			"severity": "high",
+		}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	want := "Páginas phpinfo() expuestas (severidad alta): https://example.com/info.php"
+	if !strings.Contains(contents, want) {
+		t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+	}
+}
+
+func TestGenerateOmitsEmptyActiveSection(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "example.com", Active: false, Up: true},
+		{Type: "route", Value: "https://example.com", Active: false, Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	for _, unwanted := range []string{`id="activo"`, `href="#activo"`, "Resultados de recolección activa"} {
+		if strings.Contains(contents, unwanted) {
+			t.Fatalf("expected report.html to omit %q for an empty active run", unwanted)
+		}
+	}
+	if !strings.Contains(contents, "Modo mixto (pasivo + activo)") {
+		t.Fatalf("expected report to keep the active mode badge")
+	}
+}
+
+func TestGenerateEnglishReport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true},
+		{Type: "route", Value: "https://app.example.com/login", Up: true},
+		{Type: "domain", Value: "api.example.com", Active: true, Up: true},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Active: true, ReportLang: "en"}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	for _, want := range []string{
+		`<html lang="en">`,
+		"<h2>Executive summary</h2>",
+		"<h2>Key findings</h2>",
+		"<h2>Domains</h2>",
+		"<h2>Routes</h2>",
+		"<h2>Certificates</h2>",
+		"<h2>Active collection results</h2>",
+		"Mixed mode (passive + active)",
+	} {
+		if !strings.Contains(contents, want) {
+			t.Fatalf("expected English report to contain %q\nreport contents:\n%s", want, contents)
+		}
+	}
+	for _, unwanted := range []string{"Resumen ejecutivo", "Hallazgos clave", "Certificados únicos"} {
+		if strings.Contains(contents, unwanted) {
+			t.Fatalf("English report should not contain %q", unwanted)
+		}
+	}
+}
+
+func TestGenerateEnglishReportTranslatesHighlights(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "http://app.example.com/login", Up: true},
+		{Type: "git", Value: "https://app.example.com/.git/logs/HEAD", Up: true},
+		{Type: "route", Value: "https://app.example.com/phpinfo.php", Active: true, Up: true},
+		{Type: "phpinfo", Value: "https://app.example.com/phpinfo.php", Active: true, Up: true},
+	})
+
+	cfg := &config.Config{
+		Target:         "example.com",
+		OutDir:         dir,
+		Active:         true,
+		ReportLang:     "en",
+		HighlightRules: map[string]string{"insecure-http": "high"},
+	}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	for _, want := range []string{
+		"1 host exposes services without HTTPS (for example app.example.com) (high severity)",
+		"Exposed git repositories",
+		"Exposed phpinfo() pages (high severity): https://app.example.com/phpinfo.php",
+	} {
+		if !strings.Contains(contents, want) {
+			t.Fatalf("expected English report to contain %q\nreport contents:\n%s", want, contents)
+		}
+	}
+	for _, unwanted := range []string{"servicios sin HTTPS", "severidad", "Repositorios git", "Páginas phpinfo()"} {
+		if strings.Contains(contents, unwanted) {
+			t.Fatalf("English report should not contain %q", unwanted)
+		}
+	}
+}
+
+func TestReportLabelsAreComplete(t *testing.T) {
+	t.Parallel()
+
+	for key := range reportLabels[defaultReportLang] {
+		for lang, labels := range reportLabels {
+			if _, ok := labels[key]; !ok {
+				t.Errorf("label %q missing for language %q", key, lang)
+			}
+		}
+	}
+}
+
 func TestGenerateHandlesMissingFiles(t *testing.T) {
 	t.Parallel()
 
@@ -190,7 +717,7 @@ func TestGenerateHandlesMissingFiles(t *testing.T) {
 func TestBuildDomainStatsSkipsEmpty(t *testing.T) {
 	t.Parallel()
 
-	stats := buildDomainStats([]string{"example.com", " ", "", "sub.example.com"})
+	stats := buildDomainStats([]string{"example.com", " ", "", "sub.example.com"}, nil)
 	if stats.Total != 2 {
 		t.Fatalf("Total = %d, want 2", stats.Total)
 	}
@@ -207,7 +734,7 @@ func TestBuildDNSStats(t *testing.T) {
 		{Host: "api.example.com", Type: "AAAA", Value: "::1", Raw: "api.example.com [AAAA] ::1"},
 		{Host: "cdn.example.com", Type: "CNAME", Value: "edge.example.net", Raw: "cdn.example.com [CNAME] edge.example.net"},
 		{Raw: " "},
-	})
+	}, nil)
 	if stats.Total != 3 {
 		t.Fatalf("Total = %d, want 3", stats.Total)
 	}
@@ -226,7 +753,7 @@ func TestBuildCertStatsSkipsEmpty(t *testing.T) {
 	if err != nil {
 		t.Fatalf("marshal record: %v", err)
 	}
-	stats := buildCertStats([]string{valid, "   ", ""})
+	stats := buildCertStats([]string{valid, "   ", ""}, nil, 0)
 	if stats.Total != 1 {
 		t.Fatalf("Total = %d, want 1", stats.Total)
 	}
@@ -257,7 +784,7 @@ func TestBuildCertStatsExpiryCounters(t *testing.T) {
 		t.Fatalf("marshal future: %v", err)
 	}
 	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
-	stats := buildCertStatsAt([]string{expired, soon, future}, now)
+	stats := buildCertStatsAt([]string{expired, soon, future}, now, nil, 30)
 	if stats.Expired != 1 {
 		t.Fatalf("Expired = %d, want 1", stats.Expired)
 	}
@@ -278,6 +805,33 @@ func TestBuildCertStatsExpiryCounters(t *testing.T) {
 	}
 }
 
+func TestBuildCertStatsHonorsConfiguredExpiryWindow(t *testing.T) {
+	t.Parallel()
+
+	soon, err := (certs.Record{CommonName: "soon.example.com", Issuer: "Example CA", NotAfter: "2024-01-15T00:00:00Z"}).Marshal()
+	if err != nil {
+		t.Fatalf("marshal soon: %v", err)
+	}
+	later, err := (certs.Record{CommonName: "later.example.com", Issuer: "Example CA", NotAfter: "2024-03-01T00:00:00Z"}).Marshal()
+	if err != nil {
+		t.Fatalf("marshal later: %v", err)
+	}
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	stats := buildCertStatsAt([]string{soon, later}, now, nil, 90)
+	if stats.ExpiringSoon != 2 || len(stats.ExpiringSoonList) != 2 || stats.SoonThresholdDays != 90 {
+		t.Fatalf("expected both certificates within 90 days, got %+v", stats)
+	}
+	highlights := buildHighlights(domainStats{}, routeStats{SecurePercentage: 100}, stats, nil, highlightText(reportLabels[defaultReportLang]))
+	if len(highlights) != 1 || !strings.Contains(highlights[0], "2 certificados por expirar en 90 días") {
+		t.Fatalf("expected the highlight to mention the 90-day window, got %q", highlights)
+	}
+
+	if stats := buildCertStatsAt([]string{soon, later}, now, nil, 0); stats.ExpiringSoon != 1 || stats.SoonThresholdDays != defaultCertExpirySoonDays {
+		t.Fatalf("expected the default window without configuration, got %+v", stats)
+	}
+}
+
 func TestBuildRouteStatsIgnoresInvalidLines(t *testing.T) {
 	t.Parallel()
 
@@ -287,7 +841,7 @@ func TestBuildRouteStatsIgnoresInvalidLines(t *testing.T) {
 		"http://[::1", // invalid URL, should be skipped
 		"https://secure.example.com/dashboard 200 OK",
 	}
-	stats := buildRouteStats(routes)
+	stats := buildRouteStats(routes, nil)
 	if stats.Total != 2 {
 		t.Fatalf("Total = %d, want 2", stats.Total)
 	}
@@ -299,6 +853,167 @@ func TestBuildRouteStatsIgnoresInvalidLines(t *testing.T) {
 	}
 }
 
+func TestBuildRouteStatsListsWebSockets(t *testing.T) {
+	t.Parallel()
+
+	routes := []string{
+		"wss://chat.example.com/socket",
+		"ws://legacy.example.com/live",
+		"https://app.example.com/",
+		"wss://chat.example.com:8443/socket",
+	}
+	stats := buildRouteStats(routes, nil)
+	want := []string{
+		"ws://legacy.example.com/live",
+		"wss://chat.example.com/socket",
+		"wss://chat.example.com:8443/socket",
+	}
+	if !reflect.DeepEqual(stats.WebSockets, want) {
+		t.Fatalf("WebSockets = %v, want %v", stats.WebSockets, want)
+	}
+	// wss cuenta como seguro; solo el host ws:// aparece como inseguro.
+	if len(stats.InsecureHosts) != 1 || stats.InsecureHosts[0].Name != "legacy.example.com" {
+		t.Fatalf("InsecureHosts = %+v, want only legacy.example.com", stats.InsecureHosts)
+	}
+	if got := int(stats.SecurePercentage + 0.5); got != 75 {
+		t.Fatalf("SecurePercentage ≈ %.2f, want 75", stats.SecurePercentage)
+	}
+	if want := []string{"wss://chat.example.com:8443"}; !reflect.DeepEqual(stats.NonStandardPorts, want) {
+		t.Fatalf("NonStandardPorts = %v, want %v", stats.NonStandardPorts, want)
+	}
+}
+
+func TestBuildRouteStatsCountsQueryParams(t *testing.T) {
+	t.Parallel()
+
+	routes := []string{
+		"https://app.example.com/search?q=a&page=1",
+		"https://app.example.com/list?page=2&sort=asc&page=3",
+		"https://app.example.com/item?id=7&%zz",
+		"https://app.example.com/about",
+	}
+	stats := buildRouteStats(routes, nil)
+	counts := make(map[string]int)
+	for _, item := range stats.TopParams {
+		counts[item.Name] = item.Count
+	}
+	want := map[string]int{"page": 2, "q": 1, "sort": 1, "id": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("TopParams = %+v, want %v", stats.TopParams, want)
+	}
+	for name, count := range want {
+		if counts[name] != count {
+			t.Fatalf("param %q count = %d, want %d", name, counts[name], count)
+		}
+	}
+	if stats.TopParams[0].Name != "page" {
+		t.Fatalf("expected page to be the most common param, got %+v", stats.TopParams)
+	}
+}
+
+func TestBuildStatsApplyRowLimitsPerType(t *testing.T) {
+	t.Parallel()
+
+	var domains, routes []string
+	for i := 0; i < 30; i++ {
+		domains = append(domains, fmt.Sprintf("admin.site%02d.com", i))
+		routes = append(routes, fmt.Sprintf("http://host%02d.example.com:8%03d/admin", i, i))
+	}
+	limits := rowLimits{"domain": 25, "route": 3}
+
+	domainStats := buildDomainStats(domains, limits)
+	if got := len(domainStats.TopRegistrable); got != 25 {
+		t.Fatalf("len(TopRegistrable) = %d, want 25", got)
+	}
+	if got := len(domainStats.Interesting); got != 25 {
+		t.Fatalf("len(Interesting) = %d, want 25", got)
+	}
+
+	routeStats := buildRouteStats(routes, limits)
+	if got := len(routeStats.TopHosts); got != 3 {
+		t.Fatalf("len(TopHosts) = %d, want 3", got)
+	}
+	if got := len(routeStats.InsecureHosts); got != 3 {
+		t.Fatalf("len(InsecureHosts) = %d, want 3", got)
+	}
+	if got := len(routeStats.NonStandardPorts); got != 3 {
+		t.Fatalf("len(NonStandardPorts) = %d, want 3", got)
+	}
+
+	defaults := buildDomainStats(domains, nil)
+	if got := len(defaults.TopRegistrable); got != topN {
+		t.Fatalf("len(TopRegistrable) without limits = %d, want %d", got, topN)
+	}
+}
+
+func TestBuildHighlightsAppliesCustomRules(t *testing.T) {
+	t.Parallel()
+
+	routes := routeStats{
+		SecurePercentage:  50,
+		InsecureHosts:     []countItem{{Name: "legacy.example.com", Count: 2}},
+		InsecureHostTotal: 1,
+	}
+	certs := certStats{ExpiringSoon: 1, ExpiringSoonList: []string{"www.example.com"}}
+
+	defaults := buildHighlights(domainStats{}, routes, certs, nil, highlightText(reportLabels[defaultReportLang]))
+	if len(defaults) != 2 {
+		t.Fatalf("expected 2 default highlights, got %v", defaults)
+	}
+	for _, highlight := range defaults {
+		if strings.Contains(highlight, "severidad") {
+			t.Fatalf("default highlight should not carry a severity: %q", highlight)
+		}
+	}
+
+	rules, err := newHighlightRules(map[string]string{"Insecure-HTTP": "High", "expiring-certs": "off"})
+	if err != nil {
+		t.Fatalf("newHighlightRules: %v", err)
+	}
+	got := buildHighlights(domainStats{}, routes, certs, rules, highlightText(reportLabels[defaultReportLang]))
+	if len(got) != 1 {
+		t.Fatalf("expected expiring-certs highlight to be suppressed, got %v", got)
+	}
+	if !strings.HasSuffix(got[0], "(severidad alta)") || !strings.Contains(got[0], "legacy.example.com") {
+		t.Fatalf("unexpected insecure-http highlight: %q", got[0])
+	}
+
+	critical, err := newHighlightRules(map[string]string{"expiring-certs": "critical"})
+	if err != nil {
+		t.Fatalf("newHighlightRules: %v", err)
+	}
+	got = buildHighlights(domainStats{}, routes, certs, critical, highlightText(reportLabels[defaultReportLang]))
+	if len(got) != 2 || !strings.HasSuffix(got[1], "(severidad crítica)") {
+		t.Fatalf("expected expiring-certs highlight with critical severity, got %v", got)
+	}
+}
+
+func TestHighlightRuleConstantsAreKnownToConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range []string{
+		highlightInsecureHTTP, highlightNonStandardPorts, highlightSensitivePaths, highlightSensitiveDomains,
+		highlightExpiredCerts, highlightExpiringCerts, highlightWildcardCerts, highlightRiskyPorts,
+	} {
+		if _, ok := config.HighlightRuleNames[rule]; !ok {
+			t.Fatalf("highlight rule %q is missing from config.HighlightRuleNames", rule)
+		}
+	}
+}
+
+func TestNewHighlightRulesRejectsInvalidEntries(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []map[string]string{
+		{"unknown-rule": "high"},
+		{"insecure-http": "urgent"},
+	} {
+		if _, err := newHighlightRules(raw); err == nil {
+			t.Fatalf("expected error for rules %v", raw)
+		}
+	}
+}
+
 func TestBuildDomainStatsGroupsMultiLevelTLDs(t *testing.T) {
 	t.Parallel()
 
@@ -307,7 +1022,7 @@ func TestBuildDomainStatsGroupsMultiLevelTLDs(t *testing.T) {
 		"portal.example.co.uk",
 		"example.co.uk",
 		"app.example.com",
-	})
+	}, nil)
 
 	if stats.UniqueRegistrable != 2 {
 		t.Fatalf("UniqueRegistrable = %d, want 2", stats.UniqueRegistrable)
@@ -343,7 +1058,7 @@ func TestBuildCertStatsGroupsMultiLevelTLDs(t *testing.T) {
 		t.Fatalf("marshal record: %v", err)
 	}
 
-	stats := buildCertStats([]string{record})
+	stats := buildCertStats([]string{record}, nil, 0)
 
 	if stats.UniqueRegistrable != 2 {
 		t.Fatalf("UniqueRegistrable = %d, want 2", stats.UniqueRegistrable)
@@ -389,3 +1104,367 @@ func readFile(t *testing.T, path string) string {
 	}
 	return string(data)
 }
+
+func TestGenerateStatsJSONMatchesReportData(t *testing.T) {
+	t.Parallel()
+
+	for _, active := range []bool{false, true} {
+		active := active
+		t.Run(fmt.Sprintf("active=%v", active), func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			writeArtifacts(t, dir, []artifacts.Artifact{
+				{Type: "domain", Value: "example.com", Up: true},
+				{Type: "domain", Value: "app.example.com", Up: true},
+				{Type: "route", Value: "https://app.example.com/login", Up: true},
+				{Type: "route", Value: "http://app.example.com/admin", Up: true},
+				{Type: "domain", Value: "app.example.com", Active: true, Up: true},
+				{Type: "route", Value: "https://app.example.com/login [200]", Active: true, Up: true},
+			})
+
+			cfg := &config.Config{Target: "example.com", OutDir: dir, Active: active}
+			if err := GenerateStatsJSON(context.Background(), cfg); err != nil {
+				t.Fatalf("GenerateStatsJSON: %v", err)
+			}
+			want, err := buildReportData(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("buildReportData: %v", err)
+			}
+
+			var got struct {
+				Target   string `json:"target"`
+				Overview struct {
+					TotalArtifacts int `json:"total_artifacts"`
+					UniqueHosts    int `json:"unique_hosts"`
+				} `json:"overview"`
+				Routes struct {
+					Total    int `json:"total"`
+					TopHosts []struct {
+						Name  string `json:"name"`
+						Count int    `json:"count"`
+					} `json:"top_hosts"`
+				} `json:"routes"`
+				ActiveMode bool `json:"active_mode"`
+				Active     struct {
+					Routes struct {
+						Total int `json:"total"`
+					} `json:"routes"`
+				} `json:"active"`
+			}
+			if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "report-stats.json"))), &got); err != nil {
+				t.Fatalf("decode report-stats.json: %v", err)
+			}
+
+			if got.Target != "example.com" || got.ActiveMode != active {
+				t.Fatalf("unexpected target/mode: %+v", got)
+			}
+			if got.Overview.TotalArtifacts != want.Overview.TotalArtifacts || got.Overview.UniqueHosts != want.Overview.UniqueHosts {
+				t.Fatalf("overview mismatch: got %+v, want %+v", got.Overview, want.Overview)
+			}
+			if got.Routes.Total != 2 || len(got.Routes.TopHosts) != 1 || got.Routes.TopHosts[0].Name != "app.example.com" || got.Routes.TopHosts[0].Count != 2 {
+				t.Fatalf("unexpected passive routes: %+v", got.Routes)
+			}
+			wantActive := 0
+			if active {
+				wantActive = 1
+			}
+			if got.Active.Routes.Total != wantActive {
+				t.Fatalf("expected %d active routes, got %d", wantActive, got.Active.Routes.Total)
+			}
+		})
+	}
+}
+
+func TestBuildCertStatsDetectsWildcardCertificates(t *testing.T) {
+	t.Parallel()
+
+	wildcard, err := (certs.Record{
+		CommonName: "*.example.com",
+		DNSNames:   []string{"*.example.com", "example.com"},
+		NotAfter:   "2030-01-01T00:00:00Z",
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("marshal wildcard record: %v", err)
+	}
+	plain, err := (certs.Record{
+		CommonName: "app.example.com",
+		DNSNames:   []string{"app.example.com"},
+		NotAfter:   "2030-01-01T00:00:00Z",
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("marshal plain record: %v", err)
+	}
+
+	stats := buildCertStats([]string{wildcard, plain}, nil, 0)
+
+	if stats.WildcardCertCount != 1 {
+		t.Fatalf("WildcardCertCount = %d, want 1", stats.WildcardCertCount)
+	}
+	if len(stats.WildcardCertList) != 1 || stats.WildcardCertList[0] != "*.example.com" {
+		t.Fatalf("unexpected wildcard list: %v", stats.WildcardCertList)
+	}
+	if stats.UniqueRegistrable != 1 {
+		t.Fatalf("UniqueRegistrable = %d, want 1", stats.UniqueRegistrable)
+	}
+
+	highlights := buildHighlights(domainStats{}, routeStats{SecurePercentage: 100}, stats, nil, highlightText(reportLabels[defaultReportLang]))
+	found := false
+	for _, h := range highlights {
+		if strings.Contains(h, "certificados wildcard") && strings.Contains(h, "*.example.com") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected wildcard highlight, got %v", highlights)
+	}
+
+	disabled := buildHighlights(domainStats{}, routeStats{SecurePercentage: 100}, stats, highlightRules{highlightWildcardCerts: highlightRuleOff}, highlightText(reportLabels[defaultReportLang]))
+	if len(disabled) != 0 {
+		t.Fatalf("expected wildcard highlight to be suppressed, got %v", disabled)
+	}
+}
+
+func TestBuildIPStatsClustersSubnets(t *testing.T) {
+	t.Parallel()
+
+	ips := []string{
+		"1.2.3.4",
+		"1.2.3.99",
+		"1.2.3.4",
+		"5.6.7.8",
+		"2001:DB8::0001",
+		"[2001:db8::1]",
+		"2001:db8::2",
+		"not-an-ip",
+	}
+	stats := buildIPStats(ips, nil)
+
+	if stats.Unique != 5 {
+		t.Fatalf("Unique = %d, want 5", stats.Unique)
+	}
+	if stats.IPv4 != 3 || stats.IPv6 != 2 {
+		t.Fatalf("IPv4/IPv6 = %d/%d, want 3/2", stats.IPv4, stats.IPv6)
+	}
+	if len(stats.TopSubnets) != 2 {
+		t.Fatalf("TopSubnets = %+v, want 2 subnets", stats.TopSubnets)
+	}
+	if stats.TopSubnets[0] != (countItem{Name: "1.2.3.0/24", Count: 2}) {
+		t.Fatalf("top subnet = %+v, want 1.2.3.0/24 with 2 hosts", stats.TopSubnets[0])
+	}
+}
+
+func TestBuildPortStatsFlagsRiskyPorts(t *testing.T) {
+	t.Parallel()
+
+	port := func(host string, number float64, protocol, service string) artifacts.Artifact {
+		meta := map[string]any{"host": host, "port": number, "protocol": protocol}
+		if service != "" {
+			meta["service"] = service
+		}
+		return artifacts.Artifact{Type: "port", Value: fmt.Sprintf("%s:%v/%s", host, number, protocol), Active: true, Up: true, Metadata: meta}
+	}
+	stats := buildPortStats([]artifacts.Artifact{
+		port("10.0.0.5", 22, "tcp", "ssh"),
+		port("10.0.0.5", 3389, "tcp", ""),
+		port("10.0.0.5", 22, "tcp", "ssh"),
+		port("api.example.com", 22, "tcp", "ssh"),
+		port("api.example.com", 443, "tcp", "https"),
+		port("cache.example.com", 6379, "tcp", "redis"),
+		port("10.0.0.5", 0, "tcp", ""),
+		{Type: "port", Value: "broken", Metadata: map[string]any{"port": 80}},
+	}, nil)
+
+	if stats.Total != 5 || stats.UniqueHosts != 3 {
+		t.Fatalf("Total/UniqueHosts = %d/%d, want 5/3", stats.Total, stats.UniqueHosts)
+	}
+	if stats.TopPorts[0] != (countItem{Name: "22/tcp", Count: 2}) {
+		t.Fatalf("top port = %+v, want 22/tcp on 2 hosts", stats.TopPorts[0])
+	}
+	if stats.TopHosts[0] != (countItem{Name: "10.0.0.5", Count: 2}) {
+		t.Fatalf("top host = %+v, want 10.0.0.5 with 2 ports", stats.TopHosts[0])
+	}
+	wantRisky := []string{"10.0.0.5:3389/tcp (rdp)", "cache.example.com:6379/tcp (redis)"}
+	if stats.RiskyTotal != 2 || !reflect.DeepEqual(stats.Risky, wantRisky) {
+		t.Fatalf("Risky = %d %v, want %v", stats.RiskyTotal, stats.Risky, wantRisky)
+	}
+}
+
+func TestGenerateRendersOpenPortsSection(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "port", Value: "10.0.0.5:23/tcp", Active: true, Up: true, Metadata: map[string]any{"host": "10.0.0.5", "port": 23, "protocol": "tcp", "service": "telnet"}},
+		{Type: "port", Value: "10.0.0.5:443/tcp", Active: true, Up: true, Metadata: map[string]any{"host": "10.0.0.5", "port": 443, "protocol": "tcp"}},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	contents := readFile(t, filepath.Join(dir, "report.html"))
+	for _, want := range []string{
+		`<section id="puertos" class="panel">`,
+		"Servicios de riesgo expuestos (Telnet, SMB, RDP, bases de datos...): 10.0.0.5:23/tcp (telnet)",
+		"<tr><td>10.0.0.5</td><td>2</td></tr>",
+	} {
+		if !strings.Contains(contents, want) {
+			t.Fatalf("expected report.html to contain %q\nreport contents:\n%s", want, contents)
+		}
+	}
+
+	cfg.HighlightRules = map[string]string{"risky-ports": "off"}
+	if err := Generate(context.Background(), cfg); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if contents := readFile(t, filepath.Join(dir, "report.html")); strings.Contains(contents, "Servicios de riesgo expuestos (") {
+		t.Fatalf("risky-ports highlight should be suppressed when the rule is off")
+	}
+}
+
+func TestBuildEmailStatsGroupsByDomain(t *testing.T) {
+	t.Parallel()
+
+	emails := []string{
+		"bob@example.com",
+		"Alice@Example.com",
+		"alice@example.com",
+		"mailto:ops@api.example.com",
+		"carol@example.com",
+		"not-an-email",
+	}
+	stats := buildEmailStats(emails, nil)
+
+	if stats.Total != 5 || stats.Unique != 4 {
+		t.Fatalf("Total/Unique = %d/%d, want 5/4", stats.Total, stats.Unique)
+	}
+	want := []emailGroup{
+		{Domain: "example.com", Emails: []string{"alice@example.com", "bob@example.com", "carol@example.com"}},
+		{Domain: "api.example.com", Emails: []string{"ops@api.example.com"}},
+	}
+	if !reflect.DeepEqual(stats.Domains, want) {
+		t.Fatalf("Domains = %+v, want %+v", stats.Domains, want)
+	}
+}
+
+// syntheticRoutes genera n URLs variadas (hosts, esquemas, puertos,
+// parámetros y rutas interesantes) para comparar y medir buildRouteStats.
+func syntheticRoutes(n int) []string {
+	schemes := []string{"https", "http"}
+	paths := []string{"", "/", "/api/v1/users", "/admin/login", "/static/app.js", "/a/b/c/d"}
+	routes := make([]string, n)
+	for i := range routes {
+		host := fmt.Sprintf("h%d.example.com", i%997)
+		if i%13 == 0 {
+			host += ":8443"
+		}
+		routes[i] = fmt.Sprintf("%s://%s%s?id=%d&q%d=x", schemes[i%len(schemes)], host, paths[i%len(paths)], i, i%7)
+	}
+	return routes
+}
+
+func syntheticDomains(n int) []string {
+	domains := make([]string, n)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("svc%d.dev%d.example.co.uk", i%5003, i%11)
+		if i%17 == 0 {
+			domains[i] = "*." + domains[i]
+		}
+	}
+	return domains
+}
+
+func TestShardedStatsMatchSequential(t *testing.T) {
+	original := statsWorkers
+	t.Cleanup(func() { statsWorkers = original })
+
+	routes := syntheticRoutes(5 * minItemsPerShard)
+	domains := syntheticDomains(5 * minItemsPerShard)
+
+	statsWorkers = 1
+	wantRoutes := buildRouteStats(routes, nil)
+	wantDomains := buildDomainStats(domains, nil)
+
+	statsWorkers = 4
+	gotRoutes := buildRouteStats(routes, nil)
+	gotDomains := buildDomainStats(domains, nil)
+
+	if !reflect.DeepEqual(wantRoutes, gotRoutes) {
+		t.Fatalf("sharded route stats differ from sequential:\nwant %+v\ngot  %+v", wantRoutes, gotRoutes)
+	}
+	if !reflect.DeepEqual(wantDomains, gotDomains) {
+		t.Fatalf("sharded domain stats differ from sequential:\nwant %+v\ngot  %+v", wantDomains, gotDomains)
+	}
+}
+
+func BenchmarkBuildRouteStats(b *testing.B) {
+	routes := syntheticRoutes(500_000)
+	original := statsWorkers
+	b.Cleanup(func() { statsWorkers = original })
+
+	workerCounts := []int{1}
+	if original > 1 {
+		workerCounts = append(workerCounts, original)
+	}
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			statsWorkers = workers
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buildRouteStats(routes, nil)
+			}
+		})
+	}
+}
+
+func TestBuildReportDataFiltersBySince(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeArtifacts(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "old.example.com", Up: true, FirstSeen: "2023-06-01T10:00:00Z"},
+		{Type: "domain", Value: "new.example.com", Up: true, FirstSeen: "2024-02-01T10:00:00Z"},
+		{Type: "domain", Value: "unknown.example.com", Up: true},
+		{Type: "route", Value: "https://new.example.com/login", Up: true, FirstSeen: "2024-01-01T00:00:00Z"},
+	})
+
+	cfg := &config.Config{Target: "example.com", OutDir: dir, Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	data, err := buildReportData(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("buildReportData: %v", err)
+	}
+	if data.Domains.Total != 1 {
+		t.Fatalf("expected only the domain discovered after -since, got %d", data.Domains.Total)
+	}
+	if data.Routes.Total != 1 {
+		t.Fatalf("expected the route first seen on the -since date to be kept, got %d", data.Routes.Total)
+	}
+	if data.Since != "2024-01-01T00:00:00Z" {
+		t.Fatalf("expected since window 2024-01-01T00:00:00Z, got %q", data.Since)
+	}
+}
+
+func TestGenerateV2WritesAnalysisCacheOnlyWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{false, true} {
+		dir := t.TempDir()
+		writeArtifacts(t, dir, []artifacts.Artifact{
+			{Type: "domain", Value: "example.com", Up: true},
+			{Type: "resource", Subtype: "javascript", Value: "https://example.com/static/jquery.min.js", Up: true},
+		})
+
+		cfg := &config.Config{Target: "example.com", OutDir: dir, AnalysisCache: enabled}
+		if err := GenerateV2(context.Background(), cfg); err != nil {
+			t.Fatalf("GenerateV2(analysis cache %t): %v", enabled, err)
+		}
+		_, err := os.Stat(filepath.Join(dir, "reports", "analysis-cache.json"))
+		if enabled && err != nil {
+			t.Fatalf("expected the analysis cache with AnalysisCache set: %v", err)
+		}
+		if !enabled && !os.IsNotExist(err) {
+			t.Fatalf("expected no analysis cache by default, got err=%v", err)
+		}
+	}
+}
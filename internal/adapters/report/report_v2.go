@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -52,7 +53,7 @@ func GenerateV2(ctx context.Context, cfg *config.Config) error {
 	for {
 		art, err := reader.ReadArtifact()
 		if err != nil {
-			if err.Error() == "EOF" {
+			if errors.Is(err, io.EOF) {
 				break
 			}
 			return fmt.Errorf("report: read artifact: %w", err)
@@ -61,6 +62,10 @@ func GenerateV2(ctx context.Context, cfg *config.Config) error {
 	}
 
 	logx.Debug("Artifacts cargados", logx.Fields{"count": len(arts)})
+	if !cfg.Since.IsZero() {
+		arts = artifacts.FilterSince(arts, cfg.Since)
+		logx.Debug("Artifacts filtrados por fecha", logx.Fields{"since": formatSinceWindow(cfg.Since), "count": len(arts)})
+	}
 
 	// Inferir header si no está disponible
 	if header.Target == "" {
@@ -73,6 +78,10 @@ func GenerateV2(ctx context.Context, cfg *config.Config) error {
 	// Crear analizador con opciones por defecto
 	opts := analysis.DefaultAnalysisOptions()
 	opts.EnableTimeline = true // Habilitar timeline para reportes completos
+	if cfg.AnalysisCache {
+		// Reutilizar la detección de tecnologías de ejecuciones anteriores
+		opts.CachePath = filepath.Join(cfg.OutDir, "reports", "analysis-cache.json")
+	}
 
 	analyzer := analysis.NewAnalyzer(arts, header, opts)
 
@@ -82,6 +91,7 @@ func GenerateV2(ctx context.Context, cfg *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("report: analyze: %w", err)
 	}
+	report.Since = formatSinceWindow(cfg.Since)
 
 	// Crear carpeta /reports
 	reportsDir := filepath.Join(cfg.OutDir, "reports")
@@ -118,6 +128,14 @@ func GenerateV2(ctx context.Context, cfg *config.Config) error {
 		logx.Debug("Reporte guardado", logx.Fields{"format": "html", "path": reportsDir + "/report.html"})
 	}
 
+	// Generar SARIF
+	logx.Debug("Generando reporte", logx.Fields{"format": "sarif"})
+	if err := GenerateSARIF(report, reportsDir); err != nil {
+		logx.Warn("Fallo generar SARIF", logx.Fields{"error": err.Error()})
+	} else {
+		logx.Debug("Reporte guardado", logx.Fields{"format": "sarif", "path": reportsDir + "/report.sarif"})
+	}
+
 	// Generar PDF
 	logx.Debug("Generando reporte", logx.Fields{"format": "pdf"})
 	if err := GeneratePDF(report, reportsDir); err != nil {
@@ -0,0 +1,186 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"passive-rec/internal/core/analysis"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "passive-rec"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name,omitempty"`
+	ShortDescription *sarifMessage   `json:"shortDescription,omitempty"`
+	FullDescription  *sarifMessage   `json:"fullDescription,omitempty"`
+	Help             *sarifMessage   `json:"help,omitempty"`
+	Properties       *sarifRuleProps `json:"properties,omitempty"`
+}
+
+type sarifRuleProps struct {
+	Tags []string `json:"tags,omitempty"`
+	CWE  string   `json:"cwe,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// GenerateSARIF escribe report.sarif en dir con los hallazgos de seguridad
+// del reporte en formato SARIF 2.1.0, para integrarlos en CI (p. ej. el code
+// scanning de GitHub). Sin hallazgos se escribe igualmente un documento válido
+// con results vacío.
+func GenerateSARIF(report *analysis.Report, dir string) error {
+	data, err := json.MarshalIndent(buildSARIF(report), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal sarif: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "report.sarif"), data, 0644); err != nil {
+		return fmt.Errorf("write sarif: %w", err)
+	}
+	return nil
+}
+
+// buildSARIF convierte cada hallazgo en un result. Las reglas se deduplican
+// por ID del hallazgo, conservando el orden de primera aparición.
+func buildSARIF(report *analysis.Report) sarifLog {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: sarifToolName, Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	if report != nil && report.Security != nil {
+		seenRules := make(map[string]struct{})
+		for _, finding := range report.Security.Findings {
+			ruleID := strings.TrimSpace(finding.ID)
+			if ruleID == "" {
+				ruleID = strings.TrimSpace(finding.Category)
+			}
+			if ruleID == "" {
+				continue
+			}
+			if _, ok := seenRules[ruleID]; !ok {
+				seenRules[ruleID] = struct{}{}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleFor(ruleID, finding))
+			}
+			message := strings.TrimSpace(finding.Description)
+			if message == "" {
+				message = strings.TrimSpace(finding.Title)
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: message},
+				Locations: sarifLocations(finding),
+			})
+		}
+	}
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+func sarifRuleFor(id string, finding analysis.Finding) sarifRule {
+	rule := sarifRule{ID: id, Name: strings.TrimSpace(finding.Title)}
+	if rule.Name != "" {
+		rule.ShortDescription = &sarifMessage{Text: rule.Name}
+	}
+	if desc := strings.TrimSpace(finding.Description); desc != "" {
+		rule.FullDescription = &sarifMessage{Text: desc}
+	}
+	if remediation := strings.TrimSpace(finding.Remediation); remediation != "" {
+		rule.Help = &sarifMessage{Text: remediation}
+	}
+	category := strings.TrimSpace(finding.Category)
+	cwe := strings.TrimSpace(finding.CWE)
+	if category != "" || cwe != "" {
+		rule.Properties = &sarifRuleProps{CWE: cwe}
+		if category != "" {
+			rule.Properties.Tags = []string{category}
+		}
+	}
+	return rule
+}
+
+// sarifLevel traduce la severidad del hallazgo al nivel SARIF.
+func sarifLevel(severity string) string {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLocations devuelve una ubicación por cada URL absoluta de la evidencia
+// (o de Location), sin duplicados. Las evidencias que no son URLs se ignoran.
+func sarifLocations(finding analysis.Finding) []sarifLocation {
+	seen := make(map[string]struct{})
+	var locations []sarifLocation
+	for _, candidate := range append([]string{finding.Location}, finding.Evidence...) {
+		candidate = strings.TrimSpace(candidate)
+		if idx := strings.IndexAny(candidate, " \t"); idx >= 0 {
+			candidate = candidate[:idx]
+		}
+		u, err := url.Parse(candidate)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			continue
+		}
+		uri := u.String()
+		if _, ok := seen[uri]; ok {
+			continue
+		}
+		seen[uri] = struct{}{}
+		locations = append(locations, sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}},
+		})
+	}
+	return locations
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/core/analysis"
+)
+
+func readSARIF(t *testing.T, dir string) sarifLog {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "report.sarif"))
+	if err != nil {
+		t.Fatalf("read report.sarif: %v", err)
+	}
+	var doc sarifLog
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("report.sarif is not valid JSON: %v", err)
+	}
+	return doc
+}
+
+func TestGenerateSARIFMapsFindings(t *testing.T) {
+	t.Parallel()
+
+	report := &analysis.Report{Security: &analysis.SecurityFindings{Findings: []analysis.Finding{
+		{
+			ID:          "exposed-env",
+			Category:    "exposure",
+			Title:       "Exposed .env file",
+			Description: "An environment file is publicly reachable.",
+			Severity:    "critical",
+			Evidence:    []string{"https://example.com/.env", "not a url"},
+			CWE:         "CWE-200",
+			Remediation: "Block access to dotfiles.",
+		},
+		{
+			ID:          "exposed-env",
+			Category:    "exposure",
+			Title:       "Exposed .env file",
+			Description: "An environment file is publicly reachable.",
+			Severity:    "high",
+			Evidence:    []string{"https://app.example.com/.env [200]"},
+		},
+		{
+			ID:          "missing-hsts",
+			Category:    "misconfiguration",
+			Title:       "Missing HSTS",
+			Description: "HSTS header not set.",
+			Severity:    "medium",
+		},
+		{
+			ID:          "verbose-banner",
+			Title:       "Verbose server banner",
+			Description: "Server banner reveals the version.",
+			Severity:    "low",
+			Location:    "https://example.com/",
+		},
+	}}}
+
+	dir := t.TempDir()
+	if err := GenerateSARIF(report, dir); err != nil {
+		t.Fatalf("GenerateSARIF: %v", err)
+	}
+	doc := readSARIF(t, dir)
+
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: version=%q runs=%d", doc.Version, len(doc.Runs))
+	}
+	run := doc.Runs[0]
+
+	var ruleIDs []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+	if diff := cmp.Diff([]string{"exposed-env", "missing-hsts", "verbose-banner"}, ruleIDs); diff != "" {
+		t.Fatalf("unexpected rules (-want +got):\n%s", diff)
+	}
+
+	type result struct {
+		RuleID, Level, Message string
+		URIs                   []string
+	}
+	var got []result
+	for _, r := range run.Results {
+		var uris []string
+		for _, loc := range r.Locations {
+			uris = append(uris, loc.PhysicalLocation.ArtifactLocation.URI)
+		}
+		got = append(got, result{RuleID: r.RuleID, Level: r.Level, Message: r.Message.Text, URIs: uris})
+	}
+	want := []result{
+		{RuleID: "exposed-env", Level: "error", Message: "An environment file is publicly reachable.", URIs: []string{"https://example.com/.env"}},
+		{RuleID: "exposed-env", Level: "error", Message: "An environment file is publicly reachable.", URIs: []string{"https://app.example.com/.env"}},
+		{RuleID: "missing-hsts", Level: "warning", Message: "HSTS header not set."},
+		{RuleID: "verbose-banner", Level: "note", Message: "Server banner reveals the version.", URIs: []string{"https://example.com/"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected results (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSARIFWithoutFindings(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := GenerateSARIF(&analysis.Report{}, dir); err != nil {
+		t.Fatalf("GenerateSARIF: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "report.sarif"))
+	if err != nil {
+		t.Fatalf("read report.sarif: %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("report.sarif is not valid JSON: %v", err)
+	}
+	runs, _ := raw["runs"].([]any)
+	if len(runs) != 1 {
+		t.Fatalf("expected one run, got %v", raw["runs"])
+	}
+	results, ok := runs[0].(map[string]any)["results"].([]any)
+	if !ok || len(results) != 0 {
+		t.Fatalf("expected an empty results array, got %v", runs[0].(map[string]any)["results"])
+	}
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"runtime"
+	"sync"
+)
+
+// statsWorkers es el número máximo de goroutines que reparten el cálculo de
+// estadísticas de dominios y rutas. Es una variable para poder fijarlo en
+// tests y benchmarks.
+var statsWorkers = runtime.NumCPU()
+
+// minItemsPerShard evita repartir entradas pequeñas, donde el coste de
+// lanzar goroutines y fusionar mapas supera al del propio cálculo.
+const minItemsPerShard = 8192
+
+// statsAccumulator acumula contadores parciales sobre un trozo de la entrada.
+// merge debe ser conmutativa (sumas y uniones) para que el resultado no
+// dependa del reparto.
+type statsAccumulator[A any] interface {
+	add(item string)
+	merge(other A)
+}
+
+// accumulateSharded reparte items en trozos contiguos entre hasta
+// statsWorkers goroutines, cada una con su propio acumulador, y fusiona los
+// parciales en orden de trozo. Con pocas entradas se acumula en la goroutine
+// actual.
+func accumulateSharded[A statsAccumulator[A]](items []string, newAcc func() A) A {
+	shards := statsWorkers
+	if limit := len(items) / minItemsPerShard; shards > limit {
+		shards = limit
+	}
+	if shards <= 1 {
+		acc := newAcc()
+		for _, item := range items {
+			acc.add(item)
+		}
+		return acc
+	}
+
+	size := (len(items) + shards - 1) / shards
+	partials := make([]A, 0, shards)
+	for start := 0; start < len(items); start += size {
+		partials = append(partials, newAcc())
+	}
+	var wg sync.WaitGroup
+	for i := range partials {
+		start := i * size
+		end := min(start+size, len(items))
+		wg.Add(1)
+		go func(acc A, chunk []string) {
+			defer wg.Done()
+			for _, item := range chunk {
+				acc.add(item)
+			}
+		}(partials[i], items[start:end])
+	}
+	wg.Wait()
+
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		result.merge(partial)
+	}
+	return result
+}
+
+func mergeCounts(dst, src map[string]int) {
+	for key, count := range src {
+		dst[key] += count
+	}
+}
+
+func mergeSet(dst, src map[string]struct{}) {
+	for key := range src {
+		dst[key] = struct{}{}
+	}
+}
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"net/url"
+	"strings"
+)
+
+// actuatorSeverities asocia los endpoints sensibles de Spring Boot Actuator
+// con su severidad. heapdump y jolokia permiten extraer secretos de memoria o
+// ejecutar operaciones JMX; env, configprops y logfile filtran configuración.
+// Los endpoints benignos (health, info) no se incluyen.
+var actuatorSeverities = map[string]string{
+	"heapdump":       "critical",
+	"jolokia":        "critical",
+	"env":            "high",
+	"configprops":    "high",
+	"threaddump":     "high",
+	"logfile":        "high",
+	"httptrace":      "high",
+	"trace":          "high",
+	"sessions":       "high",
+	"gateway":        "high",
+	"mappings":       "medium",
+	"beans":          "medium",
+	"loggers":        "medium",
+	"conditions":     "low",
+	"scheduledtasks": "low",
+	"caches":         "low",
+}
+
+// DetectActuatorEndpoint indica si la URL apunta a un endpoint sensible de
+// Spring Boot Actuator (/actuator/<endpoint>, también bajo un context path o
+// con subrutas como /actuator/env/<propiedad>) y devuelve el endpoint y su
+// severidad.
+func DetectActuatorEndpoint(raw string) (endpoint, severity string, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", false
+	}
+	lowerPath := strings.ToLower(u.Path)
+	idx := strings.Index(lowerPath, "/actuator/")
+	if idx < 0 {
+		return "", "", false
+	}
+	rest := lowerPath[idx+len("/actuator/"):]
+	if slash := strings.Index(rest, "/"); slash >= 0 {
+		rest = rest[:slash]
+	}
+	severity, ok = actuatorSeverities[rest]
+	if !ok {
+		return "", "", false
+	}
+	return rest, severity, true
+}
@@ -0,0 +1,30 @@
+package routes
+
+import "testing"
+
+func TestDetectActuatorEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantEndpoint string
+		wantSeverity string
+		ok           bool
+	}{
+		{name: "heapdump", input: "https://example.com/actuator/heapdump", wantEndpoint: "heapdump", wantSeverity: "critical", ok: true},
+		{name: "env", input: "https://example.com/actuator/env", wantEndpoint: "env", wantSeverity: "high", ok: true},
+		{name: "env property", input: "https://example.com/api/actuator/env/spring.datasource.password", wantEndpoint: "env", wantSeverity: "high", ok: true},
+		{name: "mappings", input: "https://example.com/Actuator/Mappings", wantEndpoint: "mappings", wantSeverity: "medium", ok: true},
+		{name: "health", input: "https://example.com/actuator/health", ok: false},
+		{name: "actuator index", input: "https://example.com/actuator", ok: false},
+		{name: "unrelated env", input: "https://example.com/env", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, severity, ok := DetectActuatorEndpoint(tt.input)
+			if ok != tt.ok || endpoint != tt.wantEndpoint || severity != tt.wantSeverity {
+				t.Fatalf("DetectActuatorEndpoint(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.input, endpoint, severity, ok, tt.wantEndpoint, tt.wantSeverity, tt.ok)
+			}
+		})
+	}
+}
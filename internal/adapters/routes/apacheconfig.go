@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ApacheConfigFile describe un fichero de configuración de Apache expuesto y
+// su severidad.
+type ApacheConfigFile struct {
+	File     string
+	Severity string
+}
+
+// apacheConfigFiles relaciona los ficheros por directorio de Apache con su
+// severidad: .htaccess revela reglas de acceso y reescritura, mientras que
+// .htpasswd/.htdigest contienen hashes de credenciales.
+var apacheConfigFiles = []ApacheConfigFile{
+	{File: ".htpasswd", Severity: "critical"},
+	{File: ".htdigest", Severity: "critical"},
+	{File: ".htaccess", Severity: "high"},
+}
+
+// DetectApacheConfig indica si la URL apunta a un .htaccess, .htpasswd o
+// .htdigest (incluidas copias como .htpasswd.bak) y devuelve el fichero
+// reconocido con su severidad.
+func DetectApacheConfig(raw string) (ApacheConfigFile, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ApacheConfigFile{}, false
+	}
+	base := path.Base(strings.ToLower(u.Path))
+	for _, candidate := range apacheConfigFiles {
+		if base == candidate.File || strings.HasPrefix(base, candidate.File+".") || strings.HasPrefix(base, candidate.File+"~") {
+			return candidate, true
+		}
+	}
+	return ApacheConfigFile{}, false
+}
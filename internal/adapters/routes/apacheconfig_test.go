@@ -0,0 +1,28 @@
+package routes
+
+import "testing"
+
+func TestDetectApacheConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  ApacheConfigFile
+		ok    bool
+	}{
+		{name: "htaccess", input: "https://example.com/.htaccess", want: ApacheConfigFile{File: ".htaccess", Severity: "high"}, ok: true},
+		{name: "nested htpasswd", input: "https://example.com/admin/.htpasswd", want: ApacheConfigFile{File: ".htpasswd", Severity: "critical"}, ok: true},
+		{name: "htpasswd backup", input: "https://example.com/.htpasswd.bak", want: ApacheConfigFile{File: ".htpasswd", Severity: "critical"}, ok: true},
+		{name: "htdigest", input: "https://example.com/private/.htdigest", want: ApacheConfigFile{File: ".htdigest", Severity: "critical"}, ok: true},
+		{name: "clean route", input: "https://example.com/about", ok: false},
+		{name: "similar name", input: "https://example.com/docs/htaccess-guide", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectApacheConfig(tt.input)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("DetectApacheConfig(%q) = (%+v, %v), want (%+v, %v)", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Formatos de ficheros de esquema de API reconocidos por su extensión.
+const (
+	APISchemaGraphQL   = "graphql"
+	APISchemaRAML      = "raml"
+	APISchemaBlueprint = "api-blueprint"
+	APISchemaWSDL      = "wsdl"
+)
+
+// apiSchemaExtensions asocia las extensiones de ficheros de esquema de API
+// (más allá de Swagger/OpenAPI, que se detectan por nombre) con su formato.
+var apiSchemaExtensions = map[string]string{
+	".graphql":  APISchemaGraphQL,
+	".graphqls": APISchemaGraphQL,
+	".gql":      APISchemaGraphQL,
+	".raml":     APISchemaRAML,
+	".apib":     APISchemaBlueprint,
+	".wsdl":     APISchemaWSDL,
+}
+
+// APISchemaFormat indica si la URL apunta a un fichero de esquema de API
+// (schema.graphql, api.raml, ...) y devuelve su formato.
+func APISchemaFormat(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	format, ok := apiSchemaExtensions[strings.ToLower(path.Ext(u.Path))]
+	return format, ok
+}
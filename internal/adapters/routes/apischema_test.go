@@ -0,0 +1,22 @@
+package routes
+
+import "testing"
+
+func TestAPISchemaFormat(t *testing.T) {
+	cases := map[string]string{
+		"https://example.com/schema.graphql":        APISchemaGraphQL,
+		"https://example.com/graphql/schema.gql":    APISchemaGraphQL,
+		"https://example.com/api/v1/API.RAML?raw=1": APISchemaRAML,
+		"https://example.com/docs/api.apib":         APISchemaBlueprint,
+		"https://example.com/service.wsdl":          APISchemaWSDL,
+		"https://example.com/swagger.yaml":          "",
+		"https://example.com/graphql":               "",
+		"https://example.com/about":                 "",
+	}
+	for input, want := range cases {
+		got, ok := APISchemaFormat(input)
+		if got != want || ok != (want != "") {
+			t.Errorf("APISchemaFormat(%q) = (%q, %v), want %q", input, got, ok, want)
+		}
+	}
+}
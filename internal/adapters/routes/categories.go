@@ -29,6 +29,23 @@ const (
 	CategoryArchives Category = "archives"
 	CategoryFeeds    Category = "feeds"
 	CategoryGraphQL  Category = "graphql"
+	// CategoryServerStatus agrupa páginas de estado del servidor (Apache
+	// server-status/server-info, nginx stub_status) que exponen internals.
+	CategoryServerStatus Category = "server-status"
+	// CategorySearchCluster agrupa endpoints de clusters de búsqueda
+	// (Elasticsearch, Kibana, Solr) que suelen quedar expuestos sin auth.
+	CategorySearchCluster Category = "search-cluster"
+	// CategoryIaCState agrupa ficheros de estado/secretos de infraestructura
+	// como código (terraform.tfstate, vaults de Ansible).
+	CategoryIaCState Category = "iac-state"
+	// CategoryMail agrupa interfaces de correo web y de Exchange (webmail,
+	// Roundcube, OWA, EWS) que mapean la infraestructura de correo.
+	CategoryMail Category = "mail"
+	// CategorySourceCode agrupa ficheros de código fuente servidos tal cual
+	// (.rb, .java, .inc, .phps, ...), señal de un servidor mal configurado.
+	CategorySourceCode Category = "source-code"
+	// CategoryWebSocket agrupa endpoints WebSocket (esquemas ws:// y wss://).
+	CategoryWebSocket Category = "websocket"
 )
 
 // Categorization devuelve categorías y razones (útil para logging / informes)
@@ -57,8 +74,10 @@ func DetectCategoriesEx(route string) Categorization {
 
 	lowerFull := strings.ToLower(trimmed)
 	pathComponent := trimmed
+	scheme := ""
 	u, err := url.Parse(trimmed)
 	if err == nil {
+		scheme = strings.ToLower(u.Scheme)
 		if u.Path != "" {
 			pathComponent = u.Path
 		} else if u.Opaque != "" {
@@ -97,6 +116,11 @@ func DetectCategoriesEx(route string) Categorization {
 		}
 	}
 
+	// WebSocket (ws:// y wss://)
+	if scheme == "ws" || scheme == "wss" {
+		add(CategoryWebSocket, "esquema "+scheme)
+	}
+
 	// --- Reglas por extensión rápida (tabla) ---
 	if ext != "" {
 		switch ext {
@@ -132,6 +156,14 @@ func DetectCategoriesEx(route string) Categorization {
 			}
 		}
 
+		// Esquemas de API (GraphQL SDL, RAML, API Blueprint, WSDL)
+		if format, ok := apiSchemaExtensions[ext]; ok {
+			add(CategoryAPI, "esquema de API ("+format+")")
+			if format == APISchemaGraphQL {
+				add(CategoryGraphQL, "esquema GraphQL (SDL)")
+			}
+		}
+
 		// Tipos estáticos comunes
 		switch ext {
 		case ".js", ".mjs", ".cjs":
@@ -177,6 +209,31 @@ func DetectCategoriesEx(route string) Categorization {
 		add(CategoryFeeds, "ruta sugiere feed (rss/atom)")
 	}
 
+	// Páginas de estado del servidor (server-status, nginx_status, ...)
+	if isServerStatusPath(lowerPath) {
+		add(CategoryServerStatus, "página de estado del servidor")
+	}
+
+	// Endpoints de Elasticsearch/Kibana/Solr (_cat, _cluster, solr/admin, ...)
+	if isSearchClusterPath(lowerPath) {
+		add(CategorySearchCluster, "endpoint de cluster de búsqueda")
+	}
+
+	// Estado de Terraform y vaults de Ansible
+	if isIaCStatePath(lowerPath, base) {
+		add(CategoryIaCState, "fichero de estado/secretos de IaC")
+	}
+
+	// Webmail y Exchange (OWA, Roundcube, autodiscover, ...)
+	if isMailPath(lowerPath) {
+		add(CategoryMail, "interfaz de correo web")
+	}
+
+	// Código fuente servido en crudo
+	if isSourceCodeExt(ext) && confirmSourceCode(trimmed) {
+		add(CategorySourceCode, "código fuente expuesto ("+ext+")")
+	}
+
 	// Heurística META (secretos, backups, etc.)
 	if shouldCategorizeMeta(base, nameNoExt, ext, lowerFull) {
 		add(CategoryMeta, "heurística de meta/secretos")
@@ -196,6 +253,7 @@ func DetectCategoriesEx(route string) Categorization {
 var orderedCats = []Category{
 	CategoryAPI,
 	CategoryGraphQL,
+	CategoryWebSocket,
 	CategoryCrawl,
 	CategoryJSON,
 	CategoryFeeds,
@@ -210,6 +268,11 @@ var orderedCats = []Category{
 	CategoryDocs,
 	CategoryArchives,
 	CategoryMaps,
+	CategoryServerStatus,
+	CategorySearchCluster,
+	CategoryIaCState,
+	CategoryMail,
+	CategorySourceCode,
 	CategoryMeta,
 }
 
@@ -275,6 +338,176 @@ func isCrawlPathWithoutExt(lowerPath string) bool {
 	return strings.HasSuffix(lowerPath, "/robots") || strings.HasSuffix(lowerPath, "/robots/")
 }
 
+// serverStatusPaths son rutas de estado expuestas por Apache (mod_status,
+// mod_info) y nginx (stub_status). "/status" solo se considera en la raíz para
+// no confundirlo con endpoints de salud de APIs.
+var serverStatusPaths = []string{"/server-status", "/server-info", "/nginx_status", "/nginx-status", "/basic_status"}
+
+func isServerStatusPath(lowerPath string) bool {
+	trimmed := strings.TrimSuffix(lowerPath, "/")
+	if trimmed == "/status" {
+		return true
+	}
+	for _, candidate := range serverStatusPaths {
+		if strings.HasSuffix(trimmed, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchClusterPathMarkers son fragmentos de ruta propios de las APIs de
+// administración de Elasticsearch, Kibana y Solr.
+var searchClusterPathMarkers = []string{
+	"/_cat/", "/_cluster/", "/_nodes", "/_all/_search", "/_aliases", "/_mapping",
+	"/solr/admin", "/app/kibana", "/_plugin/kibana",
+}
+
+func isSearchClusterPath(lowerPath string) bool {
+	trimmed := strings.TrimSuffix(lowerPath, "/")
+	if strings.HasSuffix(trimmed, "/_cat") || strings.HasSuffix(trimmed, "/_cluster") {
+		return true
+	}
+	for _, marker := range searchClusterPathMarkers {
+		if strings.Contains(trimmed+"/", marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// iacStateSuffixes son extensiones de ficheros de estado y variables de
+// Terraform, que incluyen recursos y secretos en claro.
+var iacStateSuffixes = []string{".tfstate", ".tfstate.backup", ".tfvars"}
+
+// ansibleVaultSuffixes son extensiones explícitas de ficheros cifrados con
+// ansible-vault.
+var ansibleVaultSuffixes = []string{".vault", ".vault.yml", ".vault.yaml"}
+
+// ansibleVarsDirs son los directorios de variables de Ansible donde un fichero
+// vault* (vault, vault.yml, vault_prod.yml...) es un vault cifrado. Fuera de
+// ellos "vault" es un nombre de ruta corriente (p. ej. la UI de HashiCorp
+// Vault en /vault).
+var ansibleVarsDirs = []string{"/group_vars/", "/host_vars/", "/vars/"}
+
+// mailPathSegments son primeros segmentos de ruta de interfaces de correo web
+// y de Exchange. Solo se mira el primer segmento para no confundirlos con
+// endpoints de APIs (/api/mail/send).
+var mailPathSegments = map[string]struct{}{
+	"mail":         {},
+	"webmail":      {},
+	"roundcube":    {},
+	"rainloop":     {},
+	"squirrelmail": {},
+	"horde":        {},
+	"sogo":         {},
+	"zimbra":       {},
+	"owa":          {},
+	"ecp":          {},
+	"ews":          {},
+	"autodiscover": {},
+}
+
+// sourceCodeExtensions son extensiones de código fuente que un servidor bien
+// configurado ejecuta o no sirve nunca; .phps e .inc son las variantes de PHP
+// que se entregan como texto. .py y .pl quedan fuera: suelen ser endpoints CGI
+// que se ejecutan y, sin Content-Type que lo confirme, darían falsos positivos.
+var sourceCodeExtensions = map[string]struct{}{
+	".rb":   {},
+	".go":   {},
+	".java": {},
+	".cs":   {},
+	".inc":  {},
+	".phps": {},
+}
+
+// SourceCodeContentType, si no es nil, devuelve el Content-Type observado para
+// una ruta. Permite confirmar que un fichero de código se sirve como texto; si
+// devuelve false (sin dato) se confía solo en la extensión.
+var SourceCodeContentType func(route string) (string, bool)
+
+func isSourceCodeExt(ext string) bool {
+	_, ok := sourceCodeExtensions[ext]
+	return ok
+}
+
+// confirmSourceCode descarta las rutas cuyo Content-Type conocido no es texto
+// (p. ej. un .inc que el servidor ejecuta y devuelve como HTML).
+func confirmSourceCode(route string) bool {
+	if SourceCodeContentType == nil {
+		return true
+	}
+	contentType, ok := SourceCodeContentType(route)
+	if !ok {
+		return true
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if idx := strings.Index(contentType, ";"); idx >= 0 {
+		contentType = strings.TrimSpace(contentType[:idx])
+	}
+	if contentType == "text/html" {
+		return false
+	}
+	return strings.HasPrefix(contentType, "text/") || strings.HasSuffix(contentType, "-source")
+}
+
+func isMailPath(lowerPath string) bool {
+	first := strings.TrimPrefix(lowerPath, "/")
+	if idx := strings.Index(first, "/"); idx >= 0 {
+		first = first[:idx]
+	}
+	_, ok := mailPathSegments[first]
+	return ok
+}
+
+func isIaCStatePath(lowerPath, base string) bool {
+	for _, suffix := range iacStateSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	for _, suffix := range ansibleVaultSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	if !strings.HasPrefix(base, "vault") {
+		return false
+	}
+	for _, dir := range ansibleVarsDirs {
+		if strings.Contains(lowerPath, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchClusterSignatures relacionan fragmentos de respuesta (cuerpo o título
+// reportado por httpx) con el producto que los emite.
+var searchClusterSignatures = []struct {
+	marker  string
+	product string
+}{
+	{marker: "you know, for search", product: "elasticsearch"},
+	{marker: "\"cluster_name\"", product: "elasticsearch"},
+	{marker: "kbn-name", product: "kibana"},
+	{marker: "[kibana]", product: "kibana"},
+	{marker: "<title>kibana</title>", product: "kibana"},
+	{marker: "solr admin", product: "solr"},
+}
+
+// SearchClusterSignature busca en una respuesta (o en la línea de httpx con su
+// título) la firma de un cluster de búsqueda y devuelve el producto detectado.
+func SearchClusterSignature(text string) (string, bool) {
+	lower := strings.ToLower(text)
+	for _, sig := range searchClusterSignatures {
+		if strings.Contains(lower, sig.marker) {
+			return sig.product, true
+		}
+	}
+	return "", false
+}
+
 func shouldCategorizeMeta(base, nameNoExt, ext, lowerFull string) bool {
 	if base == "" {
 		return false
@@ -22,7 +22,32 @@ func TestDetectCategories(t *testing.T) {
 		{name: "meta", input: "https://example.com/backup.zip", want: []Category{CategoryArchives, CategoryMeta}},
 		{name: "multiple", input: "https://example.com/backup.zip?token=abc", want: []Category{CategoryArchives, CategoryMeta}},
 		{name: "graphql path", input: "https://api.example.com/graphql?query={users}", want: []Category{CategoryAPI, CategoryGraphQL}},
+		{name: "apache server-status", input: "https://example.com/server-status", want: []Category{CategoryServerStatus}},
+		{name: "nginx status", input: "https://example.com/nginx_status", want: []Category{CategoryServerStatus}},
+		{name: "elasticsearch cat indices", input: "https://search.example.com:9200/_cat/indices?v", want: []Category{CategorySearchCluster}},
+		{name: "solr admin", input: "https://example.com/solr/admin/cores?action=STATUS", want: []Category{CategorySearchCluster}},
+		{name: "terraform state", input: "https://example.com/terraform.tfstate", want: []Category{CategoryIaCState}},
+		{name: "ansible vault", input: "https://example.com/ansible/group_vars/all/vault.yml", want: []Category{CategoryIaCState}},
+		{name: "vault docs page", input: "https://example.com/docs/vault-guide", want: []Category{}},
+		{name: "hashicorp vault ui", input: "https://example.com/vault", want: []Category{}},
+		{name: "bare vault.yml", input: "https://example.com/static/vault.yml", want: []Category{}},
+		{name: "ansible role vault", input: "https://example.com/roles/db/vars/vault.yml", want: []Category{CategoryIaCState}},
+		{name: "vault extension", input: "https://example.com/deploy/prod.vault.yml", want: []Category{CategoryIaCState}},
+		{name: "nested status path", input: "https://example.com/orders/status", want: []Category{}},
+		{name: "graphql schema", input: "https://example.com/schema.graphql", want: []Category{CategoryAPI, CategoryGraphQL}},
+		{name: "raml spec", input: "https://example.com/docs/api.raml", want: []Category{CategoryAPI}},
+		{name: "clean route", input: "https://example.com/about-us", want: []Category{}},
+		{name: "webmail", input: "https://example.com/webmail/", want: []Category{CategoryMail}},
+		{name: "outlook web access", input: "https://mail.example.com/owa/auth/logon.aspx?url=x", want: []Category{CategoryMail}},
+		{name: "mail api endpoint", input: "https://example.com/api/mail/send", want: []Category{CategoryAPI}},
+		{name: "phps source", input: "https://example.com/lib/functions.phps", want: []Category{CategorySourceCode}},
+		{name: "php include", input: "https://example.com/includes/common.inc", want: []Category{CategorySourceCode}},
+		{name: "perl cgi", input: "https://example.com/cgi-bin/search.pl?q=x", want: []Category{}},
+		{name: "python cgi", input: "https://example.com/cgi-bin/status.py", want: []Category{}},
+		{name: "php page", input: "https://example.com/index.php?id=1", want: []Category{}},
 		{name: "operationName without graphql", input: "https://example.com/api/search?operationName=listUsers", want: []Category{CategoryAPI}},
+		{name: "websocket", input: "ws://example.com/live", want: []Category{CategoryWebSocket}},
+		{name: "secure websocket api", input: "WSS://example.com/api/stream", want: []Category{CategoryAPI, CategoryWebSocket}},
 	}
 
 	for _, tt := range tests {
@@ -34,3 +59,45 @@ func TestDetectCategories(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchClusterSignature(t *testing.T) {
+	cases := map[string]string{
+		`{"name":"node-1","cluster_name":"prod","tagline":"You Know, for Search"}`: "elasticsearch",
+		"[200] [text/html] [Kibana]":             "kibana",
+		"<html><title>Solr Admin</title></html>": "solr",
+		"[200] [text/html] [Acme Store]":         "",
+	}
+	for input, want := range cases {
+		got, ok := SearchClusterSignature(input)
+		if got != want || ok != (want != "") {
+			t.Errorf("SearchClusterSignature(%q) = (%q, %v), want %q", input, got, ok, want)
+		}
+	}
+}
+
+func TestDetectCategoriesConfirmsSourceCodeContentType(t *testing.T) {
+	contentTypes := map[string]string{
+		"https://example.com/includes/common.inc": "text/plain; charset=utf-8",
+		"https://example.com/includes/header.inc": "text/html; charset=utf-8",
+	}
+	original := SourceCodeContentType
+	SourceCodeContentType = func(route string) (string, bool) {
+		ct, ok := contentTypes[route]
+		return ct, ok
+	}
+	t.Cleanup(func() { SourceCodeContentType = original })
+
+	tests := []struct {
+		input string
+		want  []Category
+	}{
+		{input: "https://example.com/includes/common.inc", want: []Category{CategorySourceCode}},
+		{input: "https://example.com/includes/header.inc", want: []Category{}},
+		{input: "https://example.com/app/Main.java", want: []Category{CategorySourceCode}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, DetectCategories(tt.input)); diff != "" {
+			t.Fatalf("DetectCategories(%q) mismatch (-want +got):\n%s", tt.input, diff)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// credentialFileNames son ficheros de credenciales reconocibles por su nombre
+// en cualquier directorio: claves SSH privadas y ficheros de tokens de
+// gestores de paquetes y herramientas.
+var credentialFileNames = map[string]struct{}{
+	"id_rsa":                               {},
+	"id_dsa":                               {},
+	"id_ecdsa":                             {},
+	"id_ed25519":                           {},
+	".npmrc":                               {},
+	".pypirc":                              {},
+	".netrc":                               {},
+	".git-credentials":                     {},
+	"application_default_credentials.json": {},
+}
+
+// credentialDirFiles son ficheros de credenciales que solo lo son dentro de
+// su directorio de configuración (p. ej. .aws/credentials).
+var credentialDirFiles = []string{
+	".aws/credentials",
+	".aws/config",
+	".docker/config.json",
+	".kube/config",
+	".config/gcloud/credentials.db",
+	".config/gcloud/access_tokens.db",
+	".config/gcloud/legacy_credentials",
+}
+
+// credentialDirs son directorios cuyo contenido completo son credenciales.
+var credentialDirs = []string{
+	"/.azure/",
+	"/.config/gcloud/legacy_credentials/",
+}
+
+// DetectCredentialFile indica si la URL apunta a un fichero de credenciales
+// conocido (AWS, Azure, gcloud, claves SSH, .npmrc...) y devuelve el fichero
+// reconocido. Todos se consideran críticos.
+func DetectCredentialFile(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	lowerPath := strings.TrimSuffix(strings.ToLower(u.Path), "/")
+	if lowerPath == "" {
+		return "", false
+	}
+	for _, file := range credentialDirFiles {
+		if strings.HasSuffix(lowerPath, "/"+file) {
+			return file, true
+		}
+	}
+	for _, dir := range credentialDirs {
+		if idx := strings.Index(lowerPath, dir); idx >= 0 && len(lowerPath) > idx+len(dir) {
+			return strings.TrimPrefix(lowerPath[idx:], "/"), true
+		}
+	}
+	base := path.Base(lowerPath)
+	if _, ok := credentialFileNames[base]; ok {
+		return base, true
+	}
+	return "", false
+}
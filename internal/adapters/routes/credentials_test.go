@@ -0,0 +1,29 @@
+package routes
+
+import "testing"
+
+func TestDetectCredentialFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		ok    bool
+	}{
+		{name: "aws credentials", input: "https://example.com/.aws/credentials", want: ".aws/credentials", ok: true},
+		{name: "nested ssh key", input: "https://example.com/home/deploy/.ssh/id_rsa", want: "id_rsa", ok: true},
+		{name: "npmrc", input: "https://example.com/.npmrc", want: ".npmrc", ok: true},
+		{name: "azure tokens", input: "https://example.com/.azure/accessTokens.json", want: ".azure/accesstokens.json", ok: true},
+		{name: "gcloud adc", input: "https://example.com/.config/gcloud/application_default_credentials.json", want: "application_default_credentials.json", ok: true},
+		{name: "public key", input: "https://example.com/.ssh/id_rsa.pub", ok: false},
+		{name: "benign route", input: "https://example.com/docs/credentials", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectCredentialFile(tt.input)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("DetectCredentialFile(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
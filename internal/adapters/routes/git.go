@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Componentes de un repositorio git expuesto reconocidos por
+// DetectGitComponent.
+const (
+	GitComponentConfig     = "config"
+	GitComponentHead       = "HEAD"
+	GitComponentIndex      = "index"
+	GitComponentPackedRefs = "packed-refs"
+	GitComponentRefs       = "refs"
+	GitComponentLogs       = "logs"
+	GitComponentPack       = "pack"
+	GitComponentObject     = "object"
+	GitComponentRepository = "repository"
+)
+
+// gitLooseObjectPattern reconoce objetos sueltos (objects/ab/cdef...).
+var gitLooseObjectPattern = regexp.MustCompile(`^objects/[0-9a-f]{2}/[0-9a-f]{38}$`)
+
+// DetectGitComponent indica si la URL apunta a un fichero dentro de un
+// directorio .git y devuelve el componente expuesto. Además de config, los
+// packs (objects/pack/*.pack|*.idx), los objetos sueltos, el index, las refs y
+// los reflogs (logs/HEAD) permiten reconstruir el repositorio completo.
+func DetectGitComponent(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	p := u.Path
+	idx := strings.Index(strings.ToLower(p), "/.git/")
+	if idx < 0 {
+		if strings.HasSuffix(strings.ToLower(p), "/.git") {
+			return GitComponentRepository, true
+		}
+		return "", false
+	}
+	rel := strings.TrimSuffix(p[idx+len("/.git/"):], "/")
+	lowerRel := strings.ToLower(rel)
+
+	switch {
+	case lowerRel == "config":
+		return GitComponentConfig, true
+	case rel == "HEAD" || rel == "ORIG_HEAD" || rel == "FETCH_HEAD":
+		return GitComponentHead, true
+	case lowerRel == "index":
+		return GitComponentIndex, true
+	case lowerRel == "packed-refs":
+		return GitComponentPackedRefs, true
+	case strings.HasPrefix(lowerRel, "refs/"):
+		return GitComponentRefs, true
+	case strings.HasPrefix(lowerRel, "logs/"):
+		return GitComponentLogs, true
+	case strings.HasPrefix(lowerRel, "objects/pack/") && (strings.HasSuffix(lowerRel, ".pack") || strings.HasSuffix(lowerRel, ".idx")):
+		return GitComponentPack, true
+	case gitLooseObjectPattern.MatchString(lowerRel):
+		return GitComponentObject, true
+	}
+	return GitComponentRepository, true
+}
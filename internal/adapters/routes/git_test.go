@@ -0,0 +1,31 @@
+package routes
+
+import "testing"
+
+func TestDetectGitComponent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		ok    bool
+	}{
+		{name: "config", input: "https://example.com/.git/config", want: GitComponentConfig, ok: true},
+		{name: "pack", input: "https://example.com/.git/objects/pack/pack-0123456789abcdef0123456789abcdef01234567.pack", want: GitComponentPack, ok: true},
+		{name: "pack index", input: "https://example.com/app/.git/objects/pack/pack-0123456789abcdef0123456789abcdef01234567.idx", want: GitComponentPack, ok: true},
+		{name: "logs head", input: "https://example.com/.git/logs/HEAD", want: GitComponentLogs, ok: true},
+		{name: "head", input: "https://example.com/.git/HEAD", want: GitComponentHead, ok: true},
+		{name: "loose object", input: "https://example.com/.git/objects/ab/0123456789abcdef0123456789abcdef012345", want: GitComponentObject, ok: true},
+		{name: "repository root", input: "https://example.com/.git/", want: GitComponentRepository, ok: true},
+		{name: "clean route", input: "https://example.com/about", ok: false},
+		{name: "gitignore", input: "https://example.com/.gitignore", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectGitComponent(tt.input)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("DetectGitComponent(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
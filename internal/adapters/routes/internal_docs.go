@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Plataformas de documentación interna reconocidas por DetectInternalDoc.
+const (
+	InternalDocConfluence = "confluence"
+	InternalDocNotion     = "notion"
+	InternalDocSharePoint = "sharepoint"
+	InternalDocWiki       = "wiki"
+)
+
+// internalWikiLabels son primeras etiquetas de host que suelen identificar
+// wikis o intranets corporativas (wiki.example.com, intranet.example.com).
+var internalWikiLabels = map[string]struct{}{
+	"wiki":     {},
+	"wikis":    {},
+	"intranet": {},
+	"intra":    {},
+	"kb":       {},
+}
+
+// DetectInternalDoc indica si la URL apunta a una plataforma de documentación
+// interna (Confluence, Notion, SharePoint o wikis corporativas) y devuelve el
+// nombre de la plataforma. Los enlaces a estas plataformas desde páginas
+// públicas suelen revelar documentación interna expuesta.
+func DetectInternalDoc(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	lowerPath := strings.ToLower(u.Path)
+
+	switch {
+	case strings.HasSuffix(host, ".atlassian.net") && (lowerPath == "/wiki" || strings.HasPrefix(lowerPath, "/wiki/")):
+		return InternalDocConfluence, true
+	case strings.HasPrefix(host, "confluence.") || strings.Contains(lowerPath, "/confluence/"):
+		return InternalDocConfluence, true
+	case host == "notion.so" || strings.HasSuffix(host, ".notion.so") || strings.HasSuffix(host, ".notion.site"):
+		return InternalDocNotion, true
+	case strings.HasSuffix(host, ".sharepoint.com") || strings.Contains(lowerPath, "/_layouts/15/"):
+		return InternalDocSharePoint, true
+	}
+
+	if label, _, ok := strings.Cut(host, "."); ok {
+		if _, known := internalWikiLabels[label]; known {
+			return InternalDocWiki, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,30 @@
+package routes
+
+import "testing"
+
+func TestDetectInternalDoc(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		platform string
+		ok       bool
+	}{
+		{name: "confluence cloud", input: "https://acme.atlassian.net/wiki/spaces/ENG/pages/123", platform: InternalDocConfluence, ok: true},
+		{name: "confluence server", input: "https://confluence.acme.com/display/OPS/Runbook", platform: InternalDocConfluence, ok: true},
+		{name: "sharepoint", input: "https://acme.sharepoint.com/sites/IT/Shared%20Documents/vpn.docx", platform: InternalDocSharePoint, ok: true},
+		{name: "notion", input: "https://www.notion.so/acme/Onboarding-abc123", platform: InternalDocNotion, ok: true},
+		{name: "internal wiki", input: "https://wiki.acme.com/index.php/Deploy", platform: InternalDocWiki, ok: true},
+		{name: "public page", input: "https://www.example.com/about", ok: false},
+		{name: "jira is not docs", input: "https://acme.atlassian.net/browse/ENG-1", ok: false},
+		{name: "relative", input: "/wiki/page", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform, ok := DetectInternalDoc(tt.input)
+			if ok != tt.ok || platform != tt.platform {
+				t.Fatalf("DetectInternalDoc(%q) = (%q, %v), want (%q, %v)", tt.input, platform, ok, tt.platform, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"net/url"
+	"strings"
+)
+
+// wellKnownPrefix es el directorio reservado por RFC 8615.
+const wellKnownPrefix = "/.well-known/"
+
+// wellKnownAuthPaths son los recursos bajo /.well-known/ que describen flujos
+// de autenticación: cambio de contraseña, descubrimiento OIDC/OAuth, claves de
+// firma y WebAuthn/passkeys.
+var wellKnownAuthPaths = map[string]struct{}{
+	"change-password":            {},
+	"openid-configuration":       {},
+	"oauth-authorization-server": {},
+	"oauth-protected-resource":   {},
+	"jwks.json":                  {},
+	"webauthn":                   {},
+	"passkey-endpoints":          {},
+}
+
+// DetectWellKnownAuth indica si la URL apunta a un recurso /.well-known/ de
+// autenticación y devuelve su ruta normalizada (p. ej.
+// "/.well-known/change-password"). Se aceptan prefijos como los realms de
+// Keycloak (/realms/main/.well-known/openid-configuration).
+func DetectWellKnownAuth(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	lower := strings.ToLower(u.Path)
+	idx := strings.LastIndex(lower, wellKnownPrefix)
+	if idx < 0 {
+		return "", false
+	}
+	name := strings.TrimSuffix(lower[idx+len(wellKnownPrefix):], "/")
+	if _, ok := wellKnownAuthPaths[name]; !ok {
+		return "", false
+	}
+	return wellKnownPrefix + name, true
+}
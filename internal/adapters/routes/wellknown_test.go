@@ -0,0 +1,28 @@
+package routes
+
+import "testing"
+
+func TestDetectWellKnownAuth(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		ok    bool
+	}{
+		{name: "change password", input: "https://example.com/.well-known/change-password", want: "/.well-known/change-password", ok: true},
+		{name: "openid realm", input: "https://auth.example.com/realms/main/.well-known/openid-configuration/", want: "/.well-known/openid-configuration", ok: true},
+		{name: "oauth metadata", input: "https://example.com/.well-known/OAuth-Authorization-Server", want: "/.well-known/oauth-authorization-server", ok: true},
+		{name: "passkeys", input: "https://example.com/.well-known/passkey-endpoints?x=1", want: "/.well-known/passkey-endpoints", ok: true},
+		{name: "security txt", input: "https://example.com/.well-known/security.txt", ok: false},
+		{name: "clean route", input: "https://example.com/account/change-password", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectWellKnownAuth(tt.input)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("DetectWellKnownAuth(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
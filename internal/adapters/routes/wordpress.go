@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Problemas de WordPress reconocidos por DetectWordPressIssue.
+const (
+	WordPressUserEnumeration = "user-enumeration"
+	WordPressConfigBackup    = "config-backup"
+	WordPressXMLRPC          = "xmlrpc"
+	WordPressDebugLog        = "debug-log"
+)
+
+// WordPressIssue describe una ruta sensible de WordPress y su severidad.
+type WordPressIssue struct {
+	Issue    string
+	Severity string
+}
+
+// wpConfigBackupSuffixes son sufijos con los que editores y despliegues dejan
+// copias legibles de wp-config.php (el .php original se ejecuta y no filtra).
+var wpConfigBackupSuffixes = []string{".bak", ".old", ".orig", ".save", ".swp", ".txt", ".dist", "~"}
+
+// DetectWordPressIssue indica si la URL corresponde a una ruta sensible de
+// WordPress: enumeración de usuarios vía REST API, copias de wp-config.php,
+// xmlrpc.php (fuerza bruta amplificada, pingbacks) o el debug.log de
+// wp-content.
+func DetectWordPressIssue(raw string) (WordPressIssue, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return WordPressIssue{}, false
+	}
+	lowerPath := strings.TrimSuffix(strings.ToLower(u.Path), "/")
+	base := path.Base(lowerPath)
+
+	switch {
+	case strings.HasSuffix(lowerPath, "/wp-json/wp/v2/users") || strings.Contains(lowerPath, "/wp-json/wp/v2/users/"):
+		return WordPressIssue{Issue: WordPressUserEnumeration, Severity: "medium"}, true
+	case strings.TrimSuffix(strings.ToLower(u.Query().Get("rest_route")), "/") == "/wp/v2/users":
+		return WordPressIssue{Issue: WordPressUserEnumeration, Severity: "medium"}, true
+	case strings.HasPrefix(base, "wp-config.php") && base != "wp-config.php":
+		for _, suffix := range wpConfigBackupSuffixes {
+			if strings.HasSuffix(base, suffix) {
+				return WordPressIssue{Issue: WordPressConfigBackup, Severity: "critical"}, true
+			}
+		}
+	case base == "xmlrpc.php":
+		return WordPressIssue{Issue: WordPressXMLRPC, Severity: "medium"}, true
+	case strings.HasSuffix(lowerPath, "/wp-content/debug.log"):
+		return WordPressIssue{Issue: WordPressDebugLog, Severity: "high"}, true
+	}
+	return WordPressIssue{}, false
+}
@@ -0,0 +1,32 @@
+package routes
+
+import "testing"
+
+func TestDetectWordPressIssue(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  WordPressIssue
+		ok    bool
+	}{
+		{name: "rest users", input: "https://blog.example.com/wp-json/wp/v2/users", want: WordPressIssue{Issue: WordPressUserEnumeration, Severity: "medium"}, ok: true},
+		{name: "rest users by id", input: "https://blog.example.com/wp-json/wp/v2/users/1", want: WordPressIssue{Issue: WordPressUserEnumeration, Severity: "medium"}, ok: true},
+		{name: "rest_route users", input: "https://blog.example.com/?rest_route=/wp/v2/users", want: WordPressIssue{Issue: WordPressUserEnumeration, Severity: "medium"}, ok: true},
+		{name: "wp-config backup", input: "https://blog.example.com/wp-config.php.bak", want: WordPressIssue{Issue: WordPressConfigBackup, Severity: "critical"}, ok: true},
+		{name: "wp-config editor copy", input: "https://blog.example.com/wp-config.php~", want: WordPressIssue{Issue: WordPressConfigBackup, Severity: "critical"}, ok: true},
+		{name: "xmlrpc", input: "https://blog.example.com/xmlrpc.php", want: WordPressIssue{Issue: WordPressXMLRPC, Severity: "medium"}, ok: true},
+		{name: "debug log", input: "https://blog.example.com/wp-content/debug.log", want: WordPressIssue{Issue: WordPressDebugLog, Severity: "high"}, ok: true},
+		{name: "wp-config itself", input: "https://blog.example.com/wp-config.php", ok: false},
+		{name: "posts api", input: "https://blog.example.com/wp-json/wp/v2/posts", ok: false},
+		{name: "non wordpress", input: "https://www.example.com/about", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectWordPressIssue(tt.input)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("DetectWordPressIssue(%q) = (%+v, %v), want (%+v, %v)", tt.input, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -18,14 +18,12 @@ func Amass(ctx context.Context, target string, out chan<- string, active bool) e
 		return runner.RunCommand(ctx, bin, args, out)
 	}
 
-	passiveArgs := []string{"enum", "-passive", "-d", target}
-	passiveErr := run(passiveArgs)
+	passiveErr := run(amassArgs(target, false))
 	if !active {
 		return passiveErr
 	}
 
-	activeArgs := []string{"enum", "-d", target}
-	activeErr := run(activeArgs)
+	activeErr := run(amassArgs(target, true))
 
 	switch {
 	case passiveErr == nil:
@@ -36,3 +34,12 @@ func Amass(ctx context.Context, target string, out chan<- string, active bool) e
 		return errors.Join(passiveErr, activeErr)
 	}
 }
+
+// amassArgs devuelve los argumentos de amass enum para target, en modo pasivo
+// salvo con active.
+func amassArgs(target string, active bool) []string {
+	if active {
+		return []string{"enum", "-d", target}
+	}
+	return []string{"enum", "-passive", "-d", target}
+}
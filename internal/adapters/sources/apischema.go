@@ -0,0 +1,181 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// graphqlTypePattern captura las definiciones con nombre de un SDL GraphQL
+	// (type, input, interface, enum, union, scalar), incluidas las "extend".
+	graphqlTypePattern = regexp.MustCompile(`(?m)^\s*(?:extend\s+)?(?:type|input|interface|enum|union|scalar)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+	// graphqlRootPattern localiza la apertura de los tipos raíz de operaciones.
+	graphqlRootPattern = regexp.MustCompile(`(?m)^\s*(?:extend\s+)?type\s+(Query|Mutation|Subscription)\b[^{]*\{`)
+	// graphqlFieldPattern captura el nombre de un campo al inicio de línea.
+	graphqlFieldPattern = regexp.MustCompile(`(?m)^\s*([_A-Za-z][_0-9A-Za-z]*)\s*[(:]`)
+)
+
+// graphqlSchema resume un SDL GraphQL: los tipos definidos y las operaciones
+// (Query.x, Mutation.y, Subscription.z) declaradas en los tipos raíz.
+type graphqlSchema struct {
+	Types      []string
+	Operations []string
+}
+
+// parseGraphQLSchema extrae tipos y operaciones de un fichero SDL GraphQL.
+// Devuelve error si el cuerpo no contiene ninguna definición de tipo.
+func parseGraphQLSchema(body []byte) (graphqlSchema, error) {
+	text := stripGraphQLDescriptions(string(body))
+
+	types := make(map[string]struct{})
+	for _, match := range graphqlTypePattern.FindAllStringSubmatch(text, -1) {
+		types[match[1]] = struct{}{}
+	}
+	if len(types) == 0 {
+		return graphqlSchema{}, errors.New("esquema GraphQL sin definiciones de tipo")
+	}
+
+	operations := make(map[string]struct{})
+	for _, loc := range graphqlRootPattern.FindAllStringSubmatchIndex(text, -1) {
+		root := text[loc[2]:loc[3]]
+		block := text[loc[1]:]
+		if end := strings.Index(block, "}"); end >= 0 {
+			block = block[:end]
+		}
+		for _, field := range graphqlFieldPattern.FindAllStringSubmatch(block, -1) {
+			operations[root+"."+field[1]] = struct{}{}
+		}
+	}
+
+	return graphqlSchema{Types: sortedKeys(types), Operations: sortedKeys(operations)}, nil
+}
+
+// stripGraphQLDescriptions elimina comentarios (#) y descripciones entre
+// triple comilla, que pueden contener texto con apariencia de definiciones.
+func stripGraphQLDescriptions(text string) string {
+	for {
+		start := strings.Index(text, `"""`)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start+3:], `"""`)
+		if end < 0 {
+			text = text[:start]
+			break
+		}
+		text = text[:start] + text[start+3+end+3:]
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseRAMLRoutes extrae los recursos declarados en una especificación RAML y
+// los resuelve contra su baseUri o, si no es utilizable, contra el origen de
+// la propia especificación.
+func parseRAMLRoutes(specURL string, body []byte) ([]openapiRoute, error) {
+	if !strings.HasPrefix(strings.TrimSpace(string(body)), "#%RAML") {
+		return nil, errors.New("documento sin cabecera #%RAML")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("especificación RAML inválida: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+
+	spec, err := url.Parse(specURL)
+	if err != nil {
+		return nil, err
+	}
+	base := ramlBaseURL(spec, root)
+
+	var routes []openapiRoute
+	collectRAMLResources(root, "", base, &routes)
+	sort.Slice(routes, func(i, j int) bool { return routes[i].URL < routes[j].URL })
+	return routes, nil
+}
+
+// collectRAMLResources recorre los recursos anidados (claves que empiezan por
+// "/") acumulando su ruta completa y los métodos HTTP declarados.
+func collectRAMLResources(node *yaml.Node, prefix, base string, routes *[]openapiRoute) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := strings.TrimSpace(node.Content[i].Value)
+		value := node.Content[i+1]
+		if !strings.HasPrefix(key, "/") {
+			continue
+		}
+		full := prefix + key
+		var methods []string
+		if value.Kind == yaml.MappingNode {
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				lower := strings.ToLower(strings.TrimSuffix(value.Content[j].Value, "?"))
+				if _, ok := openapiHTTPVerbs[lower]; ok {
+					methods = append(methods, strings.ToUpper(lower))
+				}
+			}
+		}
+		sort.Strings(methods)
+		*routes = append(*routes, openapiRoute{URL: strings.TrimSuffix(base, "/") + full, Methods: methods})
+		if value.Kind == yaml.MappingNode {
+			collectRAMLResources(value, full, base, routes)
+		}
+	}
+}
+
+// ramlBaseURL devuelve el baseUri de la especificación sustituyendo {version}
+// por la versión declarada. Si quedan parámetros sin resolver o no es http(s)
+// se usa el origen de la especificación.
+func ramlBaseURL(spec *url.URL, root *yaml.Node) string {
+	origin := (&url.URL{Scheme: spec.Scheme, Host: spec.Host}).String()
+	var baseURI, version string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case "baseUri":
+			baseURI = strings.TrimSpace(root.Content[i+1].Value)
+		case "version":
+			version = strings.TrimSpace(root.Content[i+1].Value)
+		}
+	}
+	if baseURI == "" {
+		return origin
+	}
+	if version != "" {
+		baseURI = strings.ReplaceAll(baseURI, "{version}", version)
+	}
+	if strings.Contains(baseURI, "{") {
+		return origin
+	}
+	ref, err := url.Parse(baseURI)
+	if err != nil {
+		return origin
+	}
+	resolved := spec.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return origin
+	}
+	resolved.RawQuery = ""
+	resolved.Fragment = ""
+	return resolved.String()
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	list := make([]string, 0, len(set))
+	for key := range set {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,136 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const fakeGraphQLSchema = `# Esquema de ejemplo
+"""
+type NotAType { fake: String }
+"""
+type Query {
+  users(limit: Int): [User!]!
+  user(id: ID!): User
+}
+
+type Mutation {
+  deleteUser(id: ID!): Boolean
+}
+
+type User {
+  id: ID!
+  email: String
+}
+
+input UserFilter {
+  role: Role
+}
+
+enum Role { ADMIN USER }
+`
+
+const fakeRAMLSpec = `#%RAML 1.0
+title: Fake API
+version: v2
+baseUri: https://api.example.com/{version}
+/users:
+  get:
+  post:
+  /{userId}:
+    get:
+    delete:
+/health:
+  get:
+`
+
+func TestParseGraphQLSchema(t *testing.T) {
+	schema, err := parseGraphQLSchema([]byte(fakeGraphQLSchema))
+	if err != nil {
+		t.Fatalf("parseGraphQLSchema: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Mutation", "Query", "Role", "User", "UserFilter"}, schema.Types); diff != "" {
+		t.Fatalf("unexpected types (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Mutation.deleteUser", "Query.user", "Query.users"}, schema.Operations); diff != "" {
+		t.Fatalf("unexpected operations (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseGraphQLSchema([]byte("<html>not a schema</html>")); err == nil {
+		t.Fatalf("expected error for non-schema body")
+	}
+}
+
+func TestParseRAMLRoutes(t *testing.T) {
+	routes, err := parseRAMLRoutes("https://docs.example.com/api.raml", []byte(fakeRAMLSpec))
+	if err != nil {
+		t.Fatalf("parseRAMLRoutes: %v", err)
+	}
+	want := []openapiRoute{
+		{URL: "https://api.example.com/v2/health", Methods: []string{"GET"}},
+		{URL: "https://api.example.com/v2/users", Methods: []string{"GET", "POST"}},
+		{URL: "https://api.example.com/v2/users/{userId}", Methods: []string{"DELETE", "GET"}},
+	}
+	if diff := cmp.Diff(want, routes); diff != "" {
+		t.Fatalf("unexpected routes (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseRAMLRoutes("https://docs.example.com/api.raml", []byte("title: no header")); err == nil {
+		t.Fatalf("expected error without #%%RAML header")
+	}
+}
+
+func TestOpenAPIParsesSchemaFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schema.graphql":
+			_, _ = w.Write([]byte(fakeGraphQLSchema))
+		case "/api.raml":
+			_, _ = w.Write([]byte(strings.Replace(fakeRAMLSpec, "baseUri: https://api.example.com/{version}\n", "", 1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	originalLoader := openapiClientLoader
+	openapiClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { openapiClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/schema.graphql", Up: true},
+		{Type: "route", Value: srv.URL + "/api.raml", Up: true},
+		{Type: "route", Value: srv.URL + "/about", Up: true},
+	})
+
+	out := make(chan string, 20)
+	if err := OpenAPI(context.Background(), dir, out); err != nil {
+		t.Fatalf("OpenAPI returned error: %v", err)
+	}
+	close(out)
+
+	var got []string
+	for line := range out {
+		got = append(got, line)
+	}
+	sort.Strings(got)
+	want := []string{
+		`active: apischema: {"format":"graphql","operations":["Mutation.deleteUser","Query.user","Query.users"],"types":["Mutation","Query","Role","User","UserFilter"],"url":"` + srv.URL + `/schema.graphql"}`,
+		`active: apischema: {"format":"raml","path_count":3,"url":"` + srv.URL + `/api.raml"}`,
+		`active: openapi: {"methods":["DELETE","GET"],"spec":"` + srv.URL + `/api.raml","url":"` + srv.URL + `/users/{userId}"}`,
+		`active: openapi: {"methods":["GET","POST"],"spec":"` + srv.URL + `/api.raml","url":"` + srv.URL + `/users"}`,
+		`active: openapi: {"methods":["GET"],"spec":"` + srv.URL + `/api.raml","url":"` + srv.URL + `/health"}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected lines (-want +got):\n%s", diff)
+	}
+}
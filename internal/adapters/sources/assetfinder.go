@@ -31,12 +31,17 @@ func Assetfinder(ctx context.Context, target string, out chan<- string) error {
 		}
 	}()
 
-	err := runner.RunCommand(ctx, bin, []string{"--subs-only", target}, filtered)
+	err := runner.RunCommand(ctx, bin, assetfinderArgs(target), filtered)
 	close(filtered)
 	wg.Wait()
 	return err
 }
 
+// assetfinderArgs devuelve los argumentos de assetfinder para target.
+func assetfinderArgs(target string) []string {
+	return []string{"--subs-only", target}
+}
+
 func isAssetfinderEmpty(line string) bool {
 	return strings.EqualFold(strings.TrimSpace(line), "no assets were discovered")
 }
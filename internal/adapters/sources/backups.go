@@ -0,0 +1,143 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+var (
+	backupsHTTPTimeout  = 10 * time.Second
+	backupsClientLoader = func() *http.Client {
+		return newActiveHTTPClient(backupsHTTPTimeout)
+	}
+	backupsWorkerCount = 8
+	// backupsSizeThreshold marca como backup completo (alto valor) cualquier
+	// archivo cuyo Content-Length supere este tamaño.
+	backupsSizeThreshold int64 = 10 * 1024 * 1024
+
+	backupArchiveSuffixes = []string{
+		".zip", ".tar", ".tar.gz", ".tgz", ".tar.bz2", ".gz", ".rar", ".7z",
+		".sql", ".sql.gz", ".sql.zip", ".dump", ".bak",
+	}
+)
+
+// BackupArchives realiza una petición HEAD sobre las rutas que parecen archivos
+// de backup (.zip, .tar.gz, .sql, ...) y emite su tamaño con el prefijo
+// "active: backup:". Los archivos que superan backupsSizeThreshold se marcan
+// como de alto valor.
+func BackupArchives(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: backups skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isBackupArchiveURL(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	client := backupsClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: backupsHTTPTimeout}
+	}
+
+	workers := backupsWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, candidate := range candidates {
+		candidate := candidate
+		group.Go(func() error {
+			size, ok := headContentLength(groupCtx, client, candidate)
+			if !ok {
+				return nil
+			}
+			payload, err := json.Marshal(map[string]any{
+				"url":        candidate,
+				"size":       size,
+				"high_value": size >= backupsSizeThreshold,
+			})
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: backup: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isBackupArchiveURL indica si la ruta termina en una extensión típica de
+// backups o volcados de base de datos.
+func isBackupArchiveURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	lowerPath := strings.ToLower(u.Path)
+	for _, suffix := range backupArchiveSuffixes {
+		if strings.HasSuffix(lowerPath, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// headContentLength devuelve el Content-Length anunciado por una respuesta
+// HEAD exitosa. Devuelve false si la petición falla, la respuesta no es 2xx o
+// el servidor no anuncia el tamaño.
+func headContentLength(ctx context.Context, client *http.Client, target string) (int64, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return 0, false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, false
+	}
+	if resp.ContentLength < 0 {
+		return 0, false
+	}
+	return resp.ContentLength, true
+}
@@ -0,0 +1,103 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestIsBackupArchiveURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/backup.zip":         true,
+		"https://example.com/site.tar.gz":        true,
+		"https://example.com/db/dump.SQL":        true,
+		"https://example.com/assets/app.js":      false,
+		"https://example.com/download?file=a.gz": false,
+	}
+	for input, want := range cases {
+		if got := isBackupArchiveURL(input); got != want {
+			t.Errorf("isBackupArchiveURL(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestBackupArchivesRecordsSizes(t *testing.T) {
+	sizes := map[string]int{
+		"/full-backup.zip": 50 * 1024 * 1024,
+		"/small.tar.gz":    2048,
+		"/db.sql":          20 * 1024 * 1024,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		size, ok := sizes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(size))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	originalLoader := backupsClientLoader
+	originalThreshold := backupsSizeThreshold
+	backupsClientLoader = func() *http.Client { return srv.Client() }
+	backupsSizeThreshold = 10 * 1024 * 1024
+	t.Cleanup(func() {
+		backupsClientLoader = originalLoader
+		backupsSizeThreshold = originalThreshold
+	})
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/full-backup.zip", Up: true},
+		{Type: "route", Value: srv.URL + "/small.tar.gz", Up: true},
+		{Type: "route", Value: srv.URL + "/db.sql", Up: true},
+		{Type: "route", Value: srv.URL + "/missing.zip", Up: true},
+		{Type: "route", Value: srv.URL + "/index.html", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := BackupArchives(context.Background(), dir, out); err != nil {
+		t.Fatalf("BackupArchives: %v", err)
+	}
+	close(out)
+
+	type result struct {
+		URL       string `json:"url"`
+		Size      int64  `json:"size"`
+		HighValue bool   `json:"high_value"`
+	}
+	var got []result
+	for line := range out {
+		if !strings.HasPrefix(line, "active: backup: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r result
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: backup: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, r)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].URL < got[j].URL })
+
+	want := []result{
+		{URL: srv.URL + "/db.sql", Size: 20 * 1024 * 1024, HighValue: true},
+		{URL: srv.URL + "/full-backup.zip", Size: 50 * 1024 * 1024, HighValue: true},
+		{URL: srv.URL + "/small.tar.gz", Size: 2048, HighValue: false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected backup results (-want +got):\n%s", diff)
+	}
+}
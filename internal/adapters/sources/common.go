@@ -2,9 +2,13 @@ package sources
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
+	"time"
 
 	"passive-rec/internal/core/runner"
+	"passive-rec/internal/platform/config"
 )
 
 // runSimpleSource es un helper para ejecutar herramientas simples que:
@@ -32,3 +36,26 @@ func runSimpleSingleBin(ctx context.Context, binName string, args []string, out
 	}
 	return runner.RunCommand(ctx, binName, args, out)
 }
+
+// newActiveHTTPClient construye el cliente HTTP usado por las comprobaciones
+// activas: respeta el proxy del entorno y las CAs adicionales configuradas, y
+// pasa cada petición por el limitador y el Prober compartidos (ver
+// ConfigureRateLimit y ConfigureActiveProbing).
+func newActiveHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &proberTransport{base: newActiveTransport(), prober: currentActiveProber(), limiter: currentRateLimiter()},
+		Timeout:   timeout,
+	}
+}
+
+// newActiveTransport construye el transporte base de newActiveHTTPClient.
+func newActiveTransport() *http.Transport {
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		ResponseHeaderTimeout: 10 * time.Second,
+	}
+	if pool := config.CustomRootCAs(); pool != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return transport
+}
@@ -0,0 +1,222 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const (
+	// dependencyMaxManifestSize limita el tamaño de los manifiestos descargados.
+	dependencyMaxManifestSize = 2 * 1024 * 1024
+
+	dependencyEcosystemNPM  = "npm"
+	dependencyEcosystemPyPI = "pypi"
+)
+
+var (
+	dependencyHTTPTimeout  = 10 * time.Second
+	dependencyClientLoader = func() *http.Client {
+		return newActiveHTTPClient(dependencyHTTPTimeout)
+	}
+	// dependencyFetch descarga un manifiesto. Se sustituye en los tests para
+	// evitar peticiones reales.
+	dependencyFetch = fetchDependencyManifest
+
+	// dependencyManifestParsers asocia el nombre de fichero del manifiesto con
+	// su ecosistema y parser.
+	dependencyManifestParsers = map[string]struct {
+		ecosystem string
+		parse     func([]byte) ([]dependency, error)
+	}{
+		"package.json":     {ecosystem: dependencyEcosystemNPM, parse: parsePackageJSON},
+		"requirements.txt": {ecosystem: dependencyEcosystemPyPI, parse: parseRequirementsTxt},
+	}
+)
+
+// dependency es una dependencia declarada en un manifiesto.
+type dependency struct {
+	Name    string
+	Version string
+}
+
+// DependencyManifests descarga los package.json y requirements.txt expuestos
+// entre las rutas y emite cada dependencia declarada (nombre y versión) con el
+// prefijo "active: dependency:" para alimentar el análisis de EOL/CVE.
+func DependencyManifests(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: dependencies skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || dependencyManifestName(candidate) == "" {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		parser := dependencyManifestParsers[dependencyManifestName(candidate)]
+		body, err := dependencyFetch(ctx, candidate)
+		if err != nil {
+			out <- fmt.Sprintf("active: meta: dependencies %s: %v", candidate, err)
+			continue
+		}
+		deps, err := parser.parse(body)
+		if err != nil {
+			out <- fmt.Sprintf("active: meta: dependencies %s: %v", candidate, err)
+			continue
+		}
+		for _, dep := range deps {
+			payload, err := json.Marshal(map[string]string{
+				"name":      dep.Name,
+				"version":   dep.Version,
+				"ecosystem": parser.ecosystem,
+				"manifest":  candidate,
+			})
+			if err != nil {
+				continue
+			}
+			out <- "active: dependency: " + string(payload)
+		}
+	}
+	return nil
+}
+
+// dependencyManifestName devuelve el nombre de manifiesto soportado al que
+// apunta la URL, o "" si no es uno de ellos.
+func dependencyManifestName(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	name := strings.ToLower(path.Base(u.Path))
+	if _, ok := dependencyManifestParsers[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+// parsePackageJSON extrae las dependencias (incluidas las de desarrollo) de un
+// package.json, ordenadas por nombre.
+func parsePackageJSON(body []byte) ([]dependency, error) {
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("package.json inválido: %w", err)
+	}
+	versions := make(map[string]string)
+	for _, group := range []map[string]string{
+		manifest.OptionalDependencies,
+		manifest.PeerDependencies,
+		manifest.DevDependencies,
+		manifest.Dependencies,
+	} {
+		for name, version := range group {
+			if name = strings.TrimSpace(name); name != "" {
+				versions[name] = strings.TrimSpace(version)
+			}
+		}
+	}
+	return sortedDependencies(versions), nil
+}
+
+// parseRequirementsTxt extrae las dependencias de un requirements.txt. Las
+// líneas sin versión fijada se devuelven con la especificación tal cual (p.
+// ej. ">=2.0") o vacía; las opciones (-r, -e, --index-url) se ignoran.
+func parseRequirementsTxt(body []byte) ([]dependency, error) {
+	versions := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		if idx := strings.Index(line, ";"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+		name, version := line, ""
+		if idx := strings.IndexAny(line, "=<>!~"); idx >= 0 {
+			name, version = line[:idx], strings.TrimSpace(line[idx:])
+			version = strings.TrimPrefix(version, "==")
+		}
+		if idx := strings.Index(name, "["); idx >= 0 {
+			name = name[:idx]
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, " /:") {
+			continue
+		}
+		versions[name] = version
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sortedDependencies(versions), nil
+}
+
+func sortedDependencies(versions map[string]string) []dependency {
+	deps := make([]dependency, 0, len(versions))
+	for name, version := range versions {
+		deps = append(deps, dependency{Name: name, Version: version})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps
+}
+
+func fetchDependencyManifest(ctx context.Context, target string) ([]byte, error) {
+	client := dependencyClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: dependencyHTTPTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, dependencyMaxManifestSize))
+}
@@ -0,0 +1,87 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestParseRequirementsTxt(t *testing.T) {
+	body := []byte(`# runtime
+Django==3.2.4
+requests[security]>=2.25 ; python_version > "3.6"
+-r base.txt
+celery
+`)
+	got, err := parseRequirementsTxt(body)
+	if err != nil {
+		t.Fatalf("parseRequirementsTxt: %v", err)
+	}
+	want := []dependency{
+		{Name: "Django", Version: "3.2.4"},
+		{Name: "celery"},
+		{Name: "requests", Version: ">=2.25"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+}
+
+func TestDependencyManifestsExtractsPackageJSON(t *testing.T) {
+	manifests := map[string]string{
+		"https://example.com/package.json": `{
+			"name": "frontend",
+			"dependencies": {"lodash": "4.17.15", "react": "^16.8.0"},
+			"devDependencies": {"webpack": "4.41.2"}
+		}`,
+	}
+	originalFetch := dependencyFetch
+	dependencyFetch = func(_ context.Context, target string) ([]byte, error) {
+		body, ok := manifests[target]
+		if !ok {
+			return nil, errors.New("status 404")
+		}
+		return []byte(body), nil
+	}
+	t.Cleanup(func() { dependencyFetch = originalFetch })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/package.json", Up: true},
+		{Type: "route", Value: "https://example.com/static/app.js", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := DependencyManifests(context.Background(), dir, out); err != nil {
+		t.Fatalf("DependencyManifests: %v", err)
+	}
+	close(out)
+
+	var got []map[string]string
+	for line := range out {
+		if !strings.HasPrefix(line, "active: dependency: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var entry map[string]string
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: dependency: ")), &entry); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, entry)
+	}
+
+	manifest := "https://example.com/package.json"
+	want := []map[string]string{
+		{"name": "lodash", "version": "4.17.15", "ecosystem": "npm", "manifest": manifest},
+		{"name": "react", "version": "^16.8.0", "ecosystem": "npm", "manifest": manifest},
+		{"name": "webpack", "version": "4.41.2", "ecosystem": "npm", "manifest": manifest},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,77 @@
+package sources
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"sort"
+
+	"passive-rec/internal/platform/netutil"
+)
+
+// dnsWildcardProbes es el número de subdominios aleatorios consultados. La zona
+// solo se marca como comodín si todos resuelven, para no confundir un fallo
+// puntual del resolver con un registro comodín.
+const dnsWildcardProbes = 2
+
+var (
+	// dnsWildcardLookup resuelve un host. Se sustituye en los tests para simular
+	// zonas con y sin registro comodín.
+	dnsWildcardLookup = func(ctx context.Context, host string) ([]string, error) {
+		return net.DefaultResolver.LookupHost(ctx, host)
+	}
+	// dnsWildcardLabel genera la etiqueta aleatoria de cada sonda.
+	dnsWildcardLabel = randomDNSLabel
+)
+
+// DNSWildcard comprueba si la zona del target tiene un registro DNS comodín
+// resolviendo subdominios aleatorios que no deberían existir. Si resuelven,
+// emite "active: dnswildcard: {json}" con la zona y las direcciones devueltas
+// para que el pipeline marque los subdominios descubiertos después como
+// wildcard_resolved.
+func DNSWildcard(ctx context.Context, target string, out chan<- string) error {
+	zone := netutil.NormalizeDomain(target)
+	if zone == "" || net.ParseIP(zone) != nil {
+		out <- "active: meta: dnswildcard skipped (target is not a domain)"
+		return nil
+	}
+
+	addresses := make(map[string]struct{})
+	for i := 0; i < dnsWildcardProbes; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		addrs, err := dnsWildcardLookup(ctx, dnsWildcardLabel()+"."+zone)
+		if err != nil || len(addrs) == 0 {
+			out <- "active: meta: dnswildcard: no wildcard record for " + zone
+			return nil
+		}
+		for _, addr := range addrs {
+			addresses[addr] = struct{}{}
+		}
+	}
+
+	list := make([]string, 0, len(addresses))
+	for addr := range addresses {
+		list = append(list, addr)
+	}
+	sort.Strings(list)
+	payload, err := json.Marshal(map[string]any{"zone": zone, "addresses": list})
+	if err != nil {
+		return err
+	}
+	out <- "active: dnswildcard: " + string(payload)
+	return nil
+}
+
+// randomDNSLabel devuelve una etiqueta de 16 caracteres hexadecimales con un
+// prefijo fijo para que las sondas sean reconocibles en los logs del DNS.
+func randomDNSLabel() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "passive-rec-wildcard-probe"
+	}
+	return "prwc-" + hex.EncodeToString(buf)
+}
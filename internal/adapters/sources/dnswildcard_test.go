@@ -0,0 +1,75 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func stubDNSWildcard(t *testing.T, lookup func(host string) ([]string, error)) *[]string {
+	t.Helper()
+	var probed []string
+	originalLookup, originalLabel := dnsWildcardLookup, dnsWildcardLabel
+	n := 0
+	dnsWildcardLabel = func() string {
+		n++
+		return fmt.Sprintf("probe%d", n)
+	}
+	dnsWildcardLookup = func(_ context.Context, host string) ([]string, error) {
+		probed = append(probed, host)
+		return lookup(host)
+	}
+	t.Cleanup(func() {
+		dnsWildcardLookup, dnsWildcardLabel = originalLookup, originalLabel
+	})
+	return &probed
+}
+
+func collectLines(out chan string) []string {
+	close(out)
+	var lines []string
+	for line := range out {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestDNSWildcardFlagsWildcardZone(t *testing.T) {
+	probed := stubDNSWildcard(t, func(host string) ([]string, error) {
+		if strings.HasSuffix(host, ".example.com") {
+			return []string{"203.0.113.7", "203.0.113.5"}, nil
+		}
+		return nil, errors.New("no such host")
+	})
+
+	out := make(chan string, 4)
+	if err := DNSWildcard(context.Background(), "https://Example.com/", out); err != nil {
+		t.Fatalf("DNSWildcard: %v", err)
+	}
+	lines := collectLines(out)
+
+	want := `active: dnswildcard: {"addresses":["203.0.113.5","203.0.113.7"],"zone":"example.com"}`
+	if len(lines) != 1 || lines[0] != want {
+		t.Fatalf("unexpected output %q, want %q", lines, want)
+	}
+	if len(*probed) != dnsWildcardProbes || (*probed)[0] != "probe1.example.com" {
+		t.Fatalf("unexpected probes: %v", *probed)
+	}
+}
+
+func TestDNSWildcardIgnoresZoneWithoutWildcard(t *testing.T) {
+	stubDNSWildcard(t, func(string) ([]string, error) {
+		return nil, errors.New("no such host")
+	})
+
+	out := make(chan string, 4)
+	if err := DNSWildcard(context.Background(), "example.com", out); err != nil {
+		t.Fatalf("DNSWildcard: %v", err)
+	}
+	lines := collectLines(out)
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "active: meta: dnswildcard: no wildcard") {
+		t.Fatalf("expected only a meta line, got %q", lines)
+	}
+}
@@ -171,11 +171,17 @@ func DNSX(ctx context.Context, domains []string, outDir string, out chan<- strin
 
 			if out != nil {
 				out <- "active: dns:" + serialized
+				for _, ip := range dnsxRecordIPs(trimmed, recordType, value) {
+					out <- "active: ip: " + ip
+				}
 			}
 		}
 	}()
 
-	execErr := dnsxRunCmd(ctx, bin, []string{"-all", "-json", "-l", tmpPath}, lineCh)
+	execErr := waitRateLimit(ctx)
+	if execErr == nil {
+		execErr = dnsxRunCmd(ctx, bin, dnsxArgs(tmpPath), lineCh)
+	}
 	close(lineCh)
 	wg.Wait()
 
@@ -229,6 +235,38 @@ func extractNormalizedHost(host, raw string) string {
 	return netutil.NormalizeDomain(candidate)
 }
 
+// dnsxRecordIPs devuelve las IPs de los registros A/AAAA de una línea de dnsx,
+// tanto en formato texto ("host [A] ip") como en el JSON de -json (campos a y
+// aaaa), sin repetir.
+func dnsxRecordIPs(line, recordType, value string) []string {
+	var candidates []string
+	if strings.EqualFold(recordType, "A") || strings.EqualFold(recordType, "AAAA") {
+		candidates = extractIPs(value)
+	} else if strings.HasPrefix(line, "{") {
+		var rec struct {
+			A    []string `json:"a"`
+			AAAA []string `json:"aaaa"`
+		}
+		if err := json.Unmarshal([]byte(line), &rec); err == nil {
+			candidates = append(rec.A, rec.AAAA...)
+		}
+	}
+	seen := make(map[string]struct{}, len(candidates))
+	var ips []string
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if net.ParseIP(candidate) == nil {
+			continue
+		}
+		if _, dup := seen[candidate]; dup {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		ips = append(ips, candidate)
+	}
+	return ips
+}
+
 // resolvePTRs resuelve PTRs para todas las IPs presentes en value.
 func resolvePTRs(ctx context.Context, value string) []string {
 	ips := extractIPs(value)
@@ -314,3 +352,8 @@ func emitMeta(out chan<- string, format string, args ...any) {
 	}
 	out <- "active: meta: " + msg
 }
+
+// dnsxArgs devuelve los argumentos de dnsx para la lista de hosts listPath.
+func dnsxArgs(listPath string) []string {
+	return []string{"-all", "-json", "-l", listPath}
+}
@@ -59,13 +59,23 @@ func TestDNSXInvokesBinaryAndWritesOutput(t *testing.T) {
 		dnsxPTRLookup = originalPTR
 	})
 
-	metaCh := make(chan string, 4)
+	metaCh := make(chan string, 8)
 	if err := DNSX(context.Background(), []string{" vpn.example.com ", "cdn.example.com"}, dir, metaCh); err != nil {
 		t.Fatalf("DNSX returned error: %v", err)
 	}
 	close(metaCh)
 
-	meta := collect(metaCh)
+	var meta, ips []string
+	for _, entry := range collect(metaCh) {
+		if strings.HasPrefix(entry, "active: ip: ") {
+			ips = append(ips, strings.TrimPrefix(entry, "active: ip: "))
+			continue
+		}
+		meta = append(meta, entry)
+	}
+	if got, want := strings.Join(ips, ","), "203.0.113.10,2001:db8::1"; got != want {
+		t.Fatalf("unexpected ip lines: got %q, want %q", got, want)
+	}
 	if len(meta) != 4 {
 		t.Fatalf("unexpected meta output count: got %d (%v)", len(meta), meta)
 	}
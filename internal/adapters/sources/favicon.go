@@ -0,0 +1,186 @@
+package sources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// faviconMaxBodySize limita el tamaño descargado por favicon.
+const faviconMaxBodySize = 1024 * 1024
+
+var (
+	faviconHTTPTimeout  = 10 * time.Second
+	faviconClientLoader = func() *http.Client {
+		return newActiveHTTPClient(faviconHTTPTimeout)
+	}
+	faviconWorkerCount = 8
+	// faviconFetch descarga un favicon. Se sustituye en los tests para evitar
+	// peticiones reales.
+	faviconFetch = fetchFaviconBody
+)
+
+// FaviconHashes descarga los favicons que aparecen entre las rutas activas y
+// emite su hash mmh3 al estilo de Shodan (http.favicon.hash) con el prefijo
+// "active: favicon: <url> <hash>". Hosts con el mismo hash suelen compartir
+// infraestructura o producto.
+func FaviconHashes(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.ActiveOnly)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: favicon skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isFaviconPath(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	workers := faviconWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, candidate := range candidates {
+		candidate := candidate
+		group.Go(func() error {
+			body, err := faviconFetch(groupCtx, candidate)
+			if err != nil || len(body) == 0 {
+				return nil
+			}
+			line := fmt.Sprintf("active: favicon: %s %d", candidate, faviconHash(body))
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- line:
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isFaviconPath indica si la ruta apunta a un favicon (/favicon.ico,
+// /static/favicon-32x32.png, ...).
+func isFaviconPath(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	name := strings.ToLower(path.Base(u.Path))
+	return strings.HasPrefix(name, "favicon") || path.Ext(name) == ".ico"
+}
+
+// faviconHash calcula el hash de Shodan: MurmurHash3 (x86, 32 bits, semilla
+// 0) del contenido en base64 con saltos de línea cada 76 caracteres, como
+// base64.encodebytes de Python, interpretado con signo.
+func faviconHash(body []byte) int32 {
+	encoded := base64.StdEncoding.EncodeToString(body)
+	var b strings.Builder
+	b.Grow(len(encoded) + len(encoded)/76 + 1)
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return int32(murmur3Sum32([]byte(b.String()), 0))
+}
+
+// murmur3Sum32 implementa MurmurHash3_x86_32.
+func murmur3Sum32(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+	h := seed
+	blocks := len(data) / 4
+	for i := 0; i < blocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+	tail := data[blocks*4:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+func fetchFaviconBody(ctx context.Context, target string) ([]byte, error) {
+	client := faviconClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: faviconHTTPTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, faviconMaxBodySize))
+}
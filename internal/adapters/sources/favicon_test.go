@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestMurmur3Sum32(t *testing.T) {
+	cases := map[string]uint32{
+		"":      0,
+		"hello": 0x248bfa47,
+		"The quick brown fox jumps over the lazy dog": 0x2e4ff723,
+	}
+	for input, want := range cases {
+		if got := murmur3Sum32([]byte(input), 0); got != want {
+			t.Errorf("murmur3Sum32(%q) = %#x, want %#x", input, got, want)
+		}
+	}
+}
+
+func TestFaviconHashWrapsBase64Lines(t *testing.T) {
+	body := []byte(strings.Repeat("\x00\x01icon", 20))
+	encoded := "AAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFp\n" +
+		"Y29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29uAAFpY29u\n" +
+		"AAFpY29u\n"
+	if got, want := faviconHash(body), int32(murmur3Sum32([]byte(encoded), 0)); got != want {
+		t.Fatalf("faviconHash = %d, want %d", got, want)
+	}
+}
+
+func TestFaviconHashesEmitsHashPerFavicon(t *testing.T) {
+	icon := []byte("shared-icon")
+	bodies := map[string][]byte{
+		"https://example.com/favicon.ico":               icon,
+		"https://app.example.com/static/favicon-32.png": icon,
+	}
+	originalFetch := faviconFetch
+	faviconFetch = func(_ context.Context, target string) ([]byte, error) {
+		body, ok := bodies[target]
+		if !ok {
+			return nil, errors.New("status 404")
+		}
+		return body, nil
+	}
+	t.Cleanup(func() { faviconFetch = originalFetch })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/favicon.ico [200]", Active: true, Up: true},
+		{Type: "route", Value: "https://app.example.com/static/favicon-32.png", Active: true, Up: true},
+		{Type: "route", Value: "https://missing.example.com/favicon.ico", Active: true, Up: true},
+		{Type: "route", Value: "https://passive.example.com/favicon.ico", Up: true},
+		{Type: "route", Value: "https://example.com/about", Active: true, Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := FaviconHashes(context.Background(), dir, out); err != nil {
+		t.Fatalf("FaviconHashes: %v", err)
+	}
+	close(out)
+	var got []string
+	for line := range out {
+		got = append(got, line)
+	}
+	sort.Strings(got)
+
+	hash := faviconHash(icon)
+	want := []string{
+		fmt.Sprintf("active: favicon: https://app.example.com/static/favicon-32.png %d", hash),
+		fmt.Sprintf("active: favicon: https://example.com/favicon.ico %d", hash),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected favicon lines (-want +got):\n%s", diff)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"regexp"
@@ -12,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"golang.org/x/sync/errgroup"
@@ -39,22 +41,30 @@ const (
 
 // httpxJSONResponse representa la respuesta JSON de httpx con la nueva configuración
 type httpxJSONResponse struct {
-	Timestamp     string   `json:"timestamp"`
-	URL           string   `json:"url"`
-	Input         string   `json:"input"`
-	StatusCode    int      `json:"status_code"`
-	ContentType   string   `json:"content_type"`
-	ContentLength int      `json:"content_length"`
-	Title         string   `json:"title"`
-	Webserver     string   `json:"webserver"`
-	Tech          []string `json:"tech"`
-	Host          string   `json:"host"`
-	Port          string   `json:"port"`
-	Scheme        string   `json:"scheme"`
-	Path          string   `json:"path"`
-	Method        string   `json:"method"`
-	A             []string `json:"a"` // DNS A records
-	Failed        bool     `json:"failed"`
+	Timestamp     string         `json:"timestamp"`
+	URL           string         `json:"url"`
+	Input         string         `json:"input"`
+	StatusCode    int            `json:"status_code"`
+	ContentType   string         `json:"content_type"`
+	ContentLength int            `json:"content_length"`
+	Title         string         `json:"title"`
+	Webserver     string         `json:"webserver"`
+	Tech          []string       `json:"tech"`
+	Host          string         `json:"host"`
+	Port          string         `json:"port"`
+	Scheme        string         `json:"scheme"`
+	Path          string         `json:"path"`
+	Method        string         `json:"method"`
+	A             []string       `json:"a"` // DNS A records
+	Failed        bool           `json:"failed"`
+	TLS           *httpxTLS      `json:"tls,omitempty"`
+	Header        map[string]any `json:"header,omitempty"`
+}
+
+// httpxTLS recoge los datos del handshake TLS que httpx expone con -tls-grab.
+type httpxTLS struct {
+	Version string `json:"tls_version"`
+	Cipher  string `json:"cipher"`
 }
 
 var (
@@ -73,6 +83,19 @@ var (
 	ansiEscapeSequences = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
 )
 
+// ConfigureHTTPXRetry hace que cada lote de httpx se reintente hasta attempts
+// veces con espera exponencial desde backoff si el binario termina con error.
+// attempts <= 1 restaura la ejecución sin reintentos.
+func ConfigureHTTPXRetry(attempts int, backoff time.Duration) {
+	if attempts <= 1 {
+		httpxRunCmd = runner.RunCommand
+		return
+	}
+	httpxRunCmd = func(ctx context.Context, name string, args []string, out chan<- string) error {
+		return runner.RunCommandWithRetry(ctx, "", name, args, out, attempts, backoff)
+	}
+}
+
 func HTTPX(ctx context.Context, outdir string, out chan<- string) error {
 	bin, err := httpxBinFinder()
 	if err != nil {
@@ -234,6 +257,11 @@ func runHTTPXWorkers(
 				default:
 				}
 
+				// Cada lote es una ejecución externa: respeta el límite compartido.
+				if err := waitRateLimit(groupCtx); err != nil {
+					return err
+				}
+
 				tmpPath, cleanup, err := inputWriter(combined[br.start:br.end])
 				if err != nil {
 					if cleanup != nil {
@@ -241,30 +269,10 @@ func runHTTPXWorkers(
 					}
 					return err
 				}
-				// Usar directamente httpxMaxThreads - las herramientas de red pueden manejar
-				// muchos más threads que CPUs físicos debido a I/O bound operations
-				threads := httpxMaxThreads
-
 				// Asegurar cleanup incluso si runCmd falla
 				func() {
 					defer cleanup()
-					err = runCmd(groupCtx, bin, []string{
-						"-l", tmpPath,
-						"-silent",
-						"-no-color",
-						"-timeout", "7",
-						"-retries", "1",
-						"-follow-redirects",
-						"-threads", strconv.Itoa(threads),
-						"-rl", strconv.Itoa(httpxRateLimit),
-						"-x", "HEAD",
-						"-status-code",
-						"-title",
-						"-content-type",
-						"-json",
-						"-nf",  // no-fallback: display both HTTP and HTTPS results
-						"-nfs", // no-fallback-scheme: respect input scheme (http/https)
-					}, intermediate)
+					err = runCmd(groupCtx, bin, httpxArgs(tmpPath), intermediate)
 				}()
 				if err != nil {
 					return err
@@ -309,7 +317,7 @@ func processHTTPXJSON(line string) []string {
 
 	// Emitir la URL si tiene un status code válido
 	if shouldForwardHTTPXRoute(true, resp.StatusCode) && resp.URL != "" {
-		out = append(out, resp.URL)
+		out = append(out, buildHTTPXRouteLine(resp))
 	}
 
 	// Emitir dominio
@@ -317,12 +325,32 @@ func processHTTPXJSON(line string) []string {
 		out = append(out, domain)
 	}
 
+	// Emitir las IPs resueltas (registros A y host si httpx lo da como IP)
+	for _, ip := range httpxResolvedIPs(resp) {
+		out = append(out, "ip: "+ip)
+	}
+
 	// Emitir HTML si corresponde
 	hasHTMLContent := strings.Contains(strings.ToLower(resp.ContentType), "text/html")
 	if shouldEmitHTTPXHTML(hasHTMLContent, true, resp.StatusCode) && resp.URL != "" {
 		out = append(out, "html: "+resp.URL)
 	}
 
+	// Emitir la cipher suite negociada para que el pipeline marque las débiles
+	if line := buildHTTPXTLSLine(resp); line != "" {
+		out = append(out, line)
+	}
+
+	// Emitir las cookies recibidas para revisar sus atributos de seguridad
+	if line := buildHTTPXCookieLine(resp); line != "" {
+		out = append(out, line)
+	}
+
+	// Emitir las cabeceras anti-framing de las páginas HTML servidas por HTTPS
+	if line := buildHTTPXFramingLine(resp); line != "" {
+		out = append(out, line)
+	}
+
 	// Crear keyFindings con información relevante
 	keyFindings := extractKeyFindings(resp)
 	for _, finding := range keyFindings {
@@ -340,6 +368,170 @@ func processHTTPXJSON(line string) []string {
 	return out
 }
 
+// httpxResolvedIPs devuelve las IPs de los registros A de la respuesta y el
+// campo host cuando es una IP, sin repetir y en ese orden.
+func httpxResolvedIPs(resp httpxJSONResponse) []string {
+	seen := make(map[string]struct{})
+	var ips []string
+	for _, candidate := range append(append([]string{}, resp.A...), resp.Host) {
+		candidate = strings.TrimSpace(candidate)
+		if net.ParseIP(candidate) == nil {
+			continue
+		}
+		if _, dup := seen[candidate]; dup {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		ips = append(ips, candidate)
+	}
+	return ips
+}
+
+func buildHTTPXTLSLine(resp httpxJSONResponse) string {
+	if resp.TLS == nil || resp.URL == "" {
+		return ""
+	}
+	cipher := strings.TrimSpace(resp.TLS.Cipher)
+	if cipher == "" {
+		return ""
+	}
+	payload, err := json.Marshal(map[string]string{
+		"url":     resp.URL,
+		"version": strings.TrimSpace(resp.TLS.Version),
+		"cipher":  cipher,
+	})
+	if err != nil {
+		return ""
+	}
+	return "tls: " + string(payload)
+}
+
+// httpxSetCookies extrae las cabeceras Set-Cookie de la respuesta. httpx
+// normaliza los nombres de cabecera (set_cookie) y agrupa valores repetidos
+// en una lista.
+func httpxSetCookies(header map[string]any) []string {
+	var cookies []string
+	for key, value := range header {
+		normalized := strings.ReplaceAll(strings.ToLower(key), "-", "_")
+		if normalized != "set_cookie" {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if trimmed := strings.TrimSpace(v); trimmed != "" {
+				cookies = append(cookies, trimmed)
+			}
+		case []any:
+			for _, item := range v {
+				if str, ok := item.(string); ok && strings.TrimSpace(str) != "" {
+					cookies = append(cookies, strings.TrimSpace(str))
+				}
+			}
+		}
+	}
+	return cookies
+}
+
+func buildHTTPXCookieLine(resp httpxJSONResponse) string {
+	if resp.URL == "" {
+		return ""
+	}
+	cookies := httpxSetCookies(resp.Header)
+	if len(cookies) == 0 {
+		return ""
+	}
+	payload, err := json.Marshal(map[string]any{
+		"url":        resp.URL,
+		"set_cookie": cookies,
+	})
+	if err != nil {
+		return ""
+	}
+	return "cookie: " + string(payload)
+}
+
+// httpxHeaderValue devuelve el valor de la cabecera indicada (con el nombre
+// normalizado por httpx, p. ej. x_frame_options). Los valores repetidos se
+// unen con ", ".
+func httpxHeaderValue(header map[string]any, name string) string {
+	var values []string
+	for key, value := range header {
+		if strings.ReplaceAll(strings.ToLower(key), "-", "_") != name {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if trimmed := strings.TrimSpace(v); trimmed != "" {
+				values = append(values, trimmed)
+			}
+		case []any:
+			for _, item := range v {
+				if str, ok := item.(string); ok && strings.TrimSpace(str) != "" {
+					values = append(values, strings.TrimSpace(str))
+				}
+			}
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// httpxSecurityHeaders son las cabeceras de seguridad cuya presencia se anota
+// en la línea de la ruta, con el nombre normalizado por httpx.
+var httpxSecurityHeaders = []string{
+	"strict_transport_security",
+	"content_security_policy",
+	"x_frame_options",
+	"x_content_type_options",
+}
+
+// buildHTTPXRouteLine devuelve la URL de la respuesta y, si httpx incluyó las
+// cabeceras (-irh), un corchete con las cabeceras de seguridad presentes, p.
+// ej. "https://a/ [headers: strict-transport-security,x-frame-options]". Sin
+// ninguna se anota "[headers: none]" para distinguirlo de una respuesta sin
+// cabeceras, que no lleva corchete.
+func buildHTTPXRouteLine(resp httpxJSONResponse) string {
+	if resp.Header == nil {
+		return resp.URL
+	}
+	var present []string
+	for _, name := range httpxSecurityHeaders {
+		if httpxHeaderValue(resp.Header, name) != "" {
+			present = append(present, strings.ReplaceAll(name, "_", "-"))
+		}
+	}
+	if len(present) == 0 {
+		return resp.URL + " [headers: none]"
+	}
+	return resp.URL + " [headers: " + strings.Join(present, ",") + "]"
+}
+
+// buildHTTPXFramingLine emite las cabeceras X-Frame-Options y
+// Content-Security-Policy de las páginas HTML servidas por HTTPS para que el
+// pipeline marque las candidatas a clickjacking.
+func buildHTTPXFramingLine(resp httpxJSONResponse) string {
+	if resp.URL == "" || resp.Header == nil {
+		return ""
+	}
+	if !strings.HasPrefix(strings.ToLower(resp.URL), "https://") {
+		return ""
+	}
+	if !strings.Contains(strings.ToLower(resp.ContentType), "text/html") {
+		return ""
+	}
+	data := map[string]string{"url": resp.URL}
+	if xfo := httpxHeaderValue(resp.Header, "x_frame_options"); xfo != "" {
+		data["x_frame_options"] = xfo
+	}
+	if csp := httpxHeaderValue(resp.Header, "content_security_policy"); csp != "" {
+		data["content_security_policy"] = csp
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return "framing: " + string(payload)
+}
+
 func extractKeyFindings(resp httpxJSONResponse) []string {
 	var findings []string
 
@@ -587,3 +779,28 @@ func stripANSIEscapeSequences(s string) string {
 	cleaned := ansiEscapeSequences.ReplaceAllString(s, "")
 	return strings.ReplaceAll(cleaned, "\x1b", "")
 }
+
+// httpxArgs devuelve los argumentos de httpx para la lista de URLs listPath.
+func httpxArgs(listPath string) []string {
+	// Usar directamente httpxMaxThreads - las herramientas de red pueden manejar
+	// muchos más threads que CPUs físicos debido a I/O bound operations
+	return []string{
+		"-l", listPath,
+		"-silent",
+		"-no-color",
+		"-timeout", "7",
+		"-retries", "1",
+		"-follow-redirects",
+		"-threads", strconv.Itoa(httpxMaxThreads),
+		"-rl", strconv.Itoa(httpxRateLimit),
+		"-x", "HEAD",
+		"-status-code",
+		"-title",
+		"-content-type",
+		"-json",
+		"-tls-grab",
+		"-irh",
+		"-nf",  // no-fallback: display both HTTP and HTTPS results
+		"-nfs", // no-fallback-scheme: respect input scheme (http/https)
+	}
+}
@@ -412,7 +412,7 @@ func TestHTTPXNormalizesOutput(t *testing.T) {
 	if err != nil {
 		t.Fatalf("new sink: %v", err)
 	}
-	sink.Start(1)
+	sink.Start(context.Background(), 1)
 	in := sink.In()
 	for _, line := range forwarded {
 		in <- line
@@ -672,6 +672,8 @@ func TestProcessHTTPXJSON(t *testing.T) {
 			want: []string{
 				"active: https://booking.avanzabus.com/comun/css/fonts/opensans-bold-webfont.woff",
 				"active: booking.avanzabus.com",
+				"active: ip: 52.209.141.168",
+				"active: ip: 52.51.81.104",
 				`active: keyFinding: {"type":"webserver","url":"https://booking.avanzabus.com/comun/css/fonts/opensans-bold-webfont.woff","value":"nginx/1.18.0 (Ubuntu)"}`,
 				`active: keyFinding: {"type":"technology","url":"https://booking.avanzabus.com/comun/css/fonts/opensans-bold-webfont.woff","value":"Nginx:1.18.0"}`,
 				`active: keyFinding: {"type":"technology","url":"https://booking.avanzabus.com/comun/css/fonts/opensans-bold-webfont.woff","value":"Ubuntu"}`,
@@ -684,11 +686,57 @@ func TestProcessHTTPXJSON(t *testing.T) {
 			want: []string{
 				"active: https://example.com/index.html",
 				"active: example.com",
+				"active: ip: 93.184.216.34",
 				"active: html: https://example.com/index.html",
 				`active: keyFinding: {"type":"webserver","url":"https://example.com/index.html","value":"Apache/2.4.41"}`,
 				`active: keyFinding: {"type":"title","url":"https://example.com/index.html","value":"Example Domain"}`,
 			},
 		},
+		{
+			name:  "handshake con cipher débil",
+			input: `{"url":"https://legacy.example.com","status_code":200,"content_type":"text/plain","failed":false,"tls":{"host":"legacy.example.com","tls_version":"tls10","cipher":"TLS_RSA_WITH_RC4_128_SHA"}}`,
+			want: []string{
+				"active: https://legacy.example.com",
+				"active: legacy.example.com",
+				`active: tls: {"cipher":"TLS_RSA_WITH_RC4_128_SHA","url":"https://legacy.example.com","version":"tls10"}`,
+			},
+		},
+		{
+			name:  "handshake con cipher robusto",
+			input: `{"url":"https://modern.example.com","status_code":200,"content_type":"text/plain","failed":false,"tls":{"host":"modern.example.com","tls_version":"tls13","cipher":"TLS_AES_128_GCM_SHA256"}}`,
+			want: []string{
+				"active: https://modern.example.com",
+				"active: modern.example.com",
+				`active: tls: {"cipher":"TLS_AES_128_GCM_SHA256","url":"https://modern.example.com","version":"tls13"}`,
+			},
+		},
+		{
+			name:  "cabeceras Set-Cookie",
+			input: `{"url":"https://app.example.com/login","status_code":200,"content_type":"text/plain","failed":false,"header":{"server":"nginx","set_cookie":["session=abc; Path=/","prefs=dark; Secure; HttpOnly; SameSite=Lax"]}}`,
+			want: []string{
+				"active: https://app.example.com/login [headers: none]",
+				"active: app.example.com",
+				`active: cookie: {"set_cookie":["session=abc; Path=/","prefs=dark; Secure; HttpOnly; SameSite=Lax"],"url":"https://app.example.com/login"}`,
+			},
+		},
+		{
+			name:  "página HTTPS con cabeceras anti-framing",
+			input: `{"url":"https://app.example.com/account","status_code":403,"content_type":"text/html","failed":false,"header":{"x_frame_options":"DENY","content_security_policy":"default-src 'self'"}}`,
+			want: []string{
+				"active: https://app.example.com/account [headers: content-security-policy,x-frame-options]",
+				"active: app.example.com",
+				`active: framing: {"content_security_policy":"default-src 'self'","url":"https://app.example.com/account","x_frame_options":"DENY"}`,
+			},
+		},
+		{
+			name:  "cabeceras de seguridad en mayúsculas",
+			input: `{"url":"https://secure.example.com/","status_code":200,"content_type":"application/json","failed":false,"header":{"Strict-Transport-Security":"max-age=63072000","X-Content-Type-Options":"nosniff"}}`,
+			want: []string{
+				"active: https://secure.example.com/ [headers: strict-transport-security,x-content-type-options]",
+				"active: secure.example.com",
+				`active: keyFinding: {"type":"content-type","url":"https://secure.example.com/","value":"application/json"}`,
+			},
+		},
 		{
 			name:  "request fallida",
 			input: `{"url":"https://down.example.com","status_code":0,"failed":true}`,
@@ -713,6 +761,52 @@ func TestProcessHTTPXJSON(t *testing.T) {
 	}
 }
 
+func TestHTTPXResolvedIPsBecomeIPArtifacts(t *testing.T) {
+	t.Parallel()
+
+	line := `{"url":"https://app.example.com/","status_code":200,"content_type":"text/plain","host":"93.184.216.34","a":["93.184.216.34","93.184.216.35"],"failed":false}`
+
+	dir := t.TempDir()
+	sink, err := pipeline.NewSink(dir, true, "example.com", "subdomains", pipeline.LineBufferSize(1))
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	for _, out := range normalizeHTTPXLine(line) {
+		sink.In() <- out
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "artifacts.jsonl"))
+	if err != nil {
+		t.Fatalf("open artifacts: %v", err)
+	}
+	defer file.Close()
+	reader, err := artifacts.NewReaderV2(file)
+	if err != nil {
+		t.Fatalf("reader: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("read artifacts: %v", err)
+	}
+	var ips []string
+	for _, art := range records {
+		if art.Type == "ip" {
+			if !art.Active {
+				t.Fatalf("httpx ip artifact should be active: %+v", art)
+			}
+			ips = append(ips, art.Value)
+		}
+	}
+	sort.Strings(ips)
+	if diff := cmp.Diff([]string{"93.184.216.34", "93.184.216.35"}, ips); diff != "" {
+		t.Fatalf("unexpected ip artifacts (-want +got):\n%s", diff)
+	}
+}
+
 func TestExtractKeyFindings(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -87,7 +87,7 @@ func classifyEndpoint(link string) classification {
 }
 
 func hasCompleteURLPrefix(link string) bool {
-	prefixes := []string{"http://", "https://", "file://", "ftp://", "ftps://", "//", "/", "./", "../"}
+	prefixes := []string{"http://", "https://", "ws://", "wss://", "file://", "ftp://", "ftps://", "//", "/", "./", "../"}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(link, prefix) {
 			return true
@@ -97,11 +97,17 @@ func hasCompleteURLPrefix(link string) bool {
 }
 
 var categoryPrefixes = map[routes.Category]string{
-	routes.CategoryMaps:  "maps",
-	routes.CategoryJSON:  "json",
-	routes.CategoryAPI:   "api",
-	routes.CategoryWASM:  "wasm",
-	routes.CategorySVG:   "svg",
-	routes.CategoryCrawl: "crawl",
-	routes.CategoryMeta:  "meta-route",
+	routes.CategoryMaps:          "maps",
+	routes.CategoryJSON:          "json",
+	routes.CategoryAPI:           "api",
+	routes.CategoryWASM:          "wasm",
+	routes.CategorySVG:           "svg",
+	routes.CategoryCrawl:         "crawl",
+	routes.CategoryMeta:          "meta-route",
+	routes.CategoryServerStatus:  "server-status",
+	routes.CategorySearchCluster: "search-cluster",
+	routes.CategoryIaCState:      "iac-state",
+	routes.CategoryMail:          "mail",
+	routes.CategorySourceCode:    "source-code",
+	routes.CategoryWebSocket:     "ws",
 }
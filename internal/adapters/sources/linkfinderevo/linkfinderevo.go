@@ -18,10 +18,31 @@ var (
 	// Permite inyección en tests.
 	findBin = runner.FindBin
 	runCmd  = runner.RunCommandWithDir
+	// rateLimiter se consulta antes de cada ejecución del binario.
+	rateLimiter runner.Limiter
 )
 
 const findingsDirName = "linkFindings"
 
+// ConfigureRetry hace que cada ejecución de GoLinkfinderEVO se reintente hasta
+// attempts veces con espera exponencial desde backoff si el binario termina
+// con error. attempts <= 1 restaura la ejecución sin reintentos.
+func ConfigureRetry(attempts int, backoff time.Duration) {
+	if attempts <= 1 {
+		runCmd = runner.RunCommandWithDir
+		return
+	}
+	runCmd = func(ctx context.Context, dir string, name string, args []string, out chan<- string) error {
+		return runner.RunCommandWithRetry(ctx, dir, name, args, out, attempts, backoff)
+	}
+}
+
+// ConfigureRateLimit fija el limitador compartido que se consulta antes de
+// cada ejecución de GoLinkfinderEVO. nil desactiva el límite.
+func ConfigureRateLimit(limiter runner.Limiter) {
+	rateLimiter = limiter
+}
+
 // Run ejecuta el binario GoLinkfinderEVO sobre HTML/JS/crawl activos,
 // agrega resultados, persiste artefactos y emite rutas clasificadas al sink.
 func Run(ctx context.Context, target string, outdir string, out chan<- string) error {
@@ -159,6 +180,14 @@ func Run(ctx context.Context, target string, outdir string, out chan<- string) e
 
 		args := buildArgs(absPath, target, rawPath, htmlPath, jsonPath, input.label)
 
+		if rateLimiter != nil {
+			if err := rateLimiter.Wait(ctx); err != nil {
+				recordError(&firstErr, err)
+				_ = os.RemoveAll(tmpDir)
+				break
+			}
+		}
+
 		// Drenaje de salida CLI para evitar bloqueo.
 		intermediate := make(chan string)
 		var wg sync.WaitGroup
@@ -26,6 +26,13 @@ func buildArgs(inputPath, target, rawPath, htmlPath, jsonPath, inputType string)
 	return args
 }
 
+// CommandArgs devuelve los argumentos con los que Run ejecuta GoLinkfinderEVO
+// sobre las entradas HTML de target, con marcadores en lugar de las rutas
+// temporales. -dry-run lo usa para mostrar el plan.
+func CommandArgs(target string) []string {
+	return buildArgs("<entrada>", target, "<findings.raw>", "<findings.html>", "<findings.json>", "html")
+}
+
 func recordError(first *error, candidate error) {
 	if candidate == nil {
 		return
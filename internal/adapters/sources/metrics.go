@@ -0,0 +1,238 @@
+package sources
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// metricsMaxBodySize limita la cantidad de scrape descargada por endpoint.
+const metricsMaxBodySize = 4 * 1024 * 1024
+
+var (
+	metricsHTTPTimeout  = 10 * time.Second
+	metricsClientLoader = func() *http.Client {
+		return newActiveHTTPClient(metricsHTTPTimeout)
+	}
+	metricsWorkerCount = 8
+	// metricsFetch descarga el scrape de un endpoint candidato. Se sustituye en
+	// los tests para evitar peticiones reales.
+	metricsFetch = fetchMetricsBody
+
+	// metricsPathSuffixes son los sufijos de ruta habituales de los endpoints de
+	// métricas Prometheus.
+	metricsPathSuffixes = []string{
+		"/metrics",
+		"/actuator/prometheus",
+		"/prometheus",
+		"/federate",
+	}
+
+	// metricsSensitivePrefixes marcan métricas que revelan detalles del proceso
+	// o del runtime (rutas, descriptores, versiones de Go, ...).
+	metricsSensitivePrefixes = []string{"process_", "go_"}
+	// metricsSensitiveKeywords marcan métricas propias que sugieren secretos.
+	metricsSensitiveKeywords = []string{"secret", "token", "password", "passwd", "apikey", "api_key", "credential", "private_key"}
+
+	metricsNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+)
+
+// PrometheusMetrics descarga los endpoints /metrics (y variantes) presentes
+// entre las rutas, confirma que devuelven un scrape Prometheus y emite las
+// métricas expuestas con el prefijo "active: metrics:", marcando las sensibles.
+func PrometheusMetrics(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: metrics skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isMetricsPath(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	workers := metricsWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, candidate := range candidates {
+		candidate := candidate
+		group.Go(func() error {
+			body, err := metricsFetch(groupCtx, candidate)
+			if err != nil {
+				return nil
+			}
+			names := parseMetricNames(body)
+			if len(names) == 0 {
+				return nil
+			}
+			sensitive := sensitiveMetricNames(names)
+			if sensitive == nil {
+				sensitive = []string{}
+			}
+			payload, err := json.Marshal(map[string]any{
+				"url":               candidate,
+				"metric_count":      len(names),
+				"sensitive_metrics": sensitive,
+			})
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: metrics: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isMetricsPath indica si la ruta apunta a un endpoint de métricas típico.
+func isMetricsPath(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	p := strings.TrimSuffix(strings.ToLower(u.Path), "/")
+	for _, suffix := range metricsPathSuffixes {
+		if strings.HasSuffix(p, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMetricNames extrae los nombres de métrica únicos y ordenados de un
+// scrape en formato de exposición de Prometheus. Solo se aceptan muestras con
+// nombre y valor válidos, de modo que un cuerpo HTML o de texto arbitrario no
+// produce resultados.
+func parseMetricNames(body string) []string {
+	names := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), metricsMaxBodySize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest := line, ""
+		if idx := strings.IndexAny(line, "{ \t"); idx >= 0 {
+			name, rest = line[:idx], line[idx:]
+		}
+		if !metricsNamePattern.MatchString(name) {
+			continue
+		}
+		if strings.HasPrefix(rest, "{") {
+			end := strings.LastIndex(rest, "}")
+			if end < 0 {
+				continue
+			}
+			rest = rest[end+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := strconv.ParseFloat(fields[0], 64); err != nil {
+			continue
+		}
+		names[name] = struct{}{}
+	}
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// sensitiveMetricNames devuelve las métricas que exponen detalles del proceso
+// o del runtime o cuyo nombre sugiere un secreto.
+func sensitiveMetricNames(names []string) []string {
+	var sensitive []string
+	for _, name := range names {
+		if isSensitiveMetric(name) {
+			sensitive = append(sensitive, name)
+		}
+	}
+	return sensitive
+}
+
+func isSensitiveMetric(name string) bool {
+	lower := strings.ToLower(name)
+	for _, prefix := range metricsSensitivePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	for _, keyword := range metricsSensitiveKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchMetricsBody(ctx context.Context, target string) (string, error) {
+	client := metricsClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: metricsHTTPTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, metricsMaxBodySize))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
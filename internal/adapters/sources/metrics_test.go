@@ -0,0 +1,119 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const metricsSampleBody = `# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+# HELP process_open_fds Number of open file descriptors.
+# TYPE process_open_fds gauge
+process_open_fds 17
+# TYPE http_requests_total counter
+http_requests_total{method="GET",code="200"} 1027
+http_requests_total{method="POST",code="500"} 3
+# TYPE vault_token_renewals_total counter
+vault_token_renewals_total 12
+request_duration_seconds_bucket{le="+Inf"} 144
+app_info{version="1.2.3"} NaN
+`
+
+func TestParseMetricNames(t *testing.T) {
+	got := parseMetricNames(metricsSampleBody)
+	want := []string{
+		"app_info",
+		"go_goroutines",
+		"http_requests_total",
+		"process_open_fds",
+		"request_duration_seconds_bucket",
+		"vault_token_renewals_total",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected metric names (-want +got):\n%s", diff)
+	}
+
+	if names := parseMetricNames("<html><head><title>Metrics</title></head><body>hello world</body></html>"); len(names) != 0 {
+		t.Fatalf("expected no metrics from an HTML body, got %v", names)
+	}
+}
+
+func TestSensitiveMetricNames(t *testing.T) {
+	got := sensitiveMetricNames(parseMetricNames(metricsSampleBody))
+	want := []string{"go_goroutines", "process_open_fds", "vault_token_renewals_total"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected sensitive metrics (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrometheusMetricsEmitsScrapeSummary(t *testing.T) {
+	bodies := map[string]string{
+		"https://example.com/metrics":                 metricsSampleBody,
+		"https://app.example.com/actuator/prometheus": "# TYPE http_requests_total counter\nhttp_requests_total 5\n",
+		"https://static.example.com/metrics":          "<html>Not a scrape</html>",
+	}
+	originalFetch := metricsFetch
+	metricsFetch = func(_ context.Context, target string) (string, error) {
+		body, ok := bodies[target]
+		if !ok {
+			return "", errors.New("status 404")
+		}
+		return body, nil
+	}
+	t.Cleanup(func() { metricsFetch = originalFetch })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/metrics", Up: true},
+		{Type: "route", Value: "https://app.example.com/actuator/prometheus", Up: true},
+		{Type: "route", Value: "https://static.example.com/metrics", Up: true},
+		{Type: "route", Value: "https://example.com/about", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := PrometheusMetrics(context.Background(), dir, out); err != nil {
+		t.Fatalf("PrometheusMetrics: %v", err)
+	}
+	close(out)
+
+	type result struct {
+		URL              string   `json:"url"`
+		MetricCount      int      `json:"metric_count"`
+		SensitiveMetrics []string `json:"sensitive_metrics"`
+	}
+	got := make(map[string]result)
+	for line := range out {
+		if !strings.HasPrefix(line, "active: metrics: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r result
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: metrics: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got[r.URL] = r
+	}
+
+	want := map[string]result{
+		"https://example.com/metrics": {
+			URL:              "https://example.com/metrics",
+			MetricCount:      6,
+			SensitiveMetrics: []string{"go_goroutines", "process_open_fds", "vault_token_renewals_total"},
+		},
+		"https://app.example.com/actuator/prometheus": {
+			URL:              "https://app.example.com/actuator/prometheus",
+			MetricCount:      1,
+			SensitiveMetrics: []string{},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected metrics results (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,143 @@
+package sources
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// nucleiMaxLineSize limita cada línea del JSONL de Nuclei, que incluye la
+// petición y la respuesta completas cuando se ejecuta con -irr.
+const nucleiMaxLineSize = 8 * 1024 * 1024
+
+// nucleiResult son los campos de una línea del JSONL de Nuclei (-jsonl) que
+// se trasladan al informe.
+type nucleiResult struct {
+	TemplateID  string `json:"template-id"`
+	MatchedAt   string `json:"matched-at"`
+	Host        string `json:"host"`
+	MatcherName string `json:"matcher-name"`
+	Info        struct {
+		Name           string `json:"name"`
+		Severity       string `json:"severity"`
+		Description    string `json:"description"`
+		Remediation    string `json:"remediation"`
+		Classification struct {
+			CWEID json.RawMessage `json:"cwe-id"`
+		} `json:"classification"`
+	} `json:"info"`
+}
+
+// ImportNuclei lee el JSONL que Nuclei genera con -jsonl y emite cada
+// resultado con el prefijo "active: finding:" para que llegue al análisis
+// como hallazgo de seguridad. Las líneas que no son JSON o no tienen
+// template-id y matched-at se omiten sin abortar la importación; al terminar
+// se emite una línea meta con el recuento.
+func ImportNuclei(ctx context.Context, path string, out chan<- string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("nuclei: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), nucleiMaxLineSize)
+	imported, skipped := 0, 0
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		payload, ok := nucleiFindingPayload(line)
+		if !ok {
+			skipped++
+			continue
+		}
+		out <- "active: finding: " + payload
+		imported++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("nuclei %s: %w", path, err)
+	}
+	out <- fmt.Sprintf("active: meta: nuclei importados=%d omitidos=%d (%s)", imported, skipped, path)
+	return nil
+}
+
+// nucleiFindingPayload convierte una línea de Nuclei en el JSON que espera el
+// handler finding:. Devuelve false si la línea no es un resultado válido.
+func nucleiFindingPayload(line string) (string, bool) {
+	var result nucleiResult
+	if err := json.Unmarshal([]byte(line), &result); err != nil {
+		return "", false
+	}
+	templateID := strings.TrimSpace(result.TemplateID)
+	matchedAt := strings.TrimSpace(result.MatchedAt)
+	if matchedAt == "" {
+		matchedAt = strings.TrimSpace(result.Host)
+	}
+	if templateID == "" || matchedAt == "" {
+		return "", false
+	}
+	payload := map[string]string{
+		"source":      "nuclei",
+		"rule":        templateID,
+		"name":        strings.TrimSpace(result.Info.Name),
+		"severity":    nucleiSeverity(result.Info.Severity),
+		"matched_at":  matchedAt,
+		"description": strings.TrimSpace(result.Info.Description),
+		"remediation": strings.TrimSpace(result.Info.Remediation),
+		"matcher":     strings.TrimSpace(result.MatcherName),
+		"cwe":         nucleiCWE(result.Info.Classification.CWEID),
+	}
+	for key, value := range payload {
+		if value == "" {
+			delete(payload, key)
+		}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// nucleiSeverity traduce la severidad de Nuclei a la escala del informe
+// (critical, high, medium, low). info y unknown quedan como low.
+func nucleiSeverity(severity string) string {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "critical":
+		return "critical"
+	case "high":
+		return "high"
+	case "medium":
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// nucleiCWE devuelve el primer CWE de la clasificación, que Nuclei emite como
+// cadena o como lista según la versión de la plantilla.
+func nucleiCWE(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		if len(list) == 0 {
+			return ""
+		}
+		return strings.ToUpper(strings.TrimSpace(list[0]))
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return strings.ToUpper(strings.TrimSpace(single))
+	}
+	return ""
+}
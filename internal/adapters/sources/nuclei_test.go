@@ -0,0 +1,80 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImportNucleiEmitsFindingsAndSkipsMalformedLines(t *testing.T) {
+	jsonl := strings.Join([]string{
+		`{"template-id":"git-config","info":{"name":"Git Config File","severity":"medium","description":"Exposed .git/config","classification":{"cwe-id":["cwe-200"]}},"matched-at":"https://example.com/.git/config","host":"https://example.com"}`,
+		`not json at all`,
+		`{"info":{"name":"Missing template id","severity":"high"},"matched-at":"https://example.com/"}`,
+		``,
+		`{"template-id":"tech-detect","matcher-name":"nginx","info":{"name":"Wappalyzer Technology Detection","severity":"info"},"host":"example.com:443"}`,
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "nuclei.jsonl")
+	if err := os.WriteFile(path, []byte(jsonl), 0o644); err != nil {
+		t.Fatalf("write jsonl: %v", err)
+	}
+
+	out := make(chan string, 10)
+	if err := ImportNuclei(context.Background(), path, out); err != nil {
+		t.Fatalf("ImportNuclei: %v", err)
+	}
+	close(out)
+
+	var got []map[string]string
+	var meta []string
+	for line := range out {
+		payload, ok := strings.CutPrefix(line, "active: finding: ")
+		if !ok {
+			meta = append(meta, line)
+			continue
+		}
+		var decoded map[string]string
+		if err := json.Unmarshal([]byte(payload), &decoded); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, decoded)
+	}
+
+	want := []map[string]string{
+		{
+			"source":      "nuclei",
+			"rule":        "git-config",
+			"name":        "Git Config File",
+			"severity":    "medium",
+			"matched_at":  "https://example.com/.git/config",
+			"description": "Exposed .git/config",
+			"cwe":         "CWE-200",
+		},
+		{
+			"source":     "nuclei",
+			"rule":       "tech-detect",
+			"name":       "Wappalyzer Technology Detection",
+			"severity":   "low",
+			"matched_at": "example.com:443",
+			"matcher":    "nginx",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected findings (-want +got):\n%s", diff)
+	}
+	if len(meta) != 1 || !strings.Contains(meta[0], "importados=2 omitidos=2") {
+		t.Fatalf("expected a summary meta line, got %v", meta)
+	}
+}
+
+func TestImportNucleiMissingFile(t *testing.T) {
+	out := make(chan string, 1)
+	if err := ImportNuclei(context.Background(), filepath.Join(t.TempDir(), "missing.jsonl"), out); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,157 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// oidcMaxDocumentSize limita el tamaño del documento de discovery.
+	oidcMaxDocumentSize = 1024 * 1024
+)
+
+var (
+	oidcHTTPTimeout  = 10 * time.Second
+	oidcClientLoader = func() *http.Client {
+		return newActiveHTTPClient(oidcHTTPTimeout)
+	}
+)
+
+// oidcDocument contiene los campos del documento de discovery OpenID Connect
+// que describen la infraestructura de autenticación.
+type oidcDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+	RegistrationEndpoint  string `json:"registration_endpoint"`
+	IntrospectionEndpoint string `json:"introspection_endpoint"`
+	RevocationEndpoint    string `json:"revocation_endpoint"`
+}
+
+// OIDCDiscovery descarga los documentos /.well-known/openid-configuration
+// descubiertos entre las rutas y emite el issuer y los endpoints declarados con
+// el prefijo "active: oidc:".
+func OIDCDiscovery(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: oidc skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isOIDCDiscoveryURL(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	client := oidcClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: oidcHTTPTimeout}
+	}
+
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		doc, err := fetchOIDCDocument(ctx, client, candidate)
+		if err != nil {
+			out <- fmt.Sprintf("active: meta: oidc %s: %v", candidate, err)
+			continue
+		}
+		payload, err := json.Marshal(map[string]any{
+			"url":       candidate,
+			"issuer":    doc.Issuer,
+			"endpoints": doc.endpoints(),
+		})
+		if err != nil {
+			continue
+		}
+		out <- "active: oidc: " + string(payload)
+	}
+	return nil
+}
+
+// isOIDCDiscoveryURL indica si la URL apunta a un documento de discovery OIDC.
+func isOIDCDiscoveryURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimSuffix(strings.ToLower(u.Path), "/"), oidcDiscoveryPath)
+}
+
+func fetchOIDCDocument(ctx context.Context, client *http.Client, target string) (oidcDocument, error) {
+	var doc oidcDocument
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return doc, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return doc, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return doc, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxDocumentSize))
+	if err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return doc, fmt.Errorf("documento de discovery inválido: %w", err)
+	}
+	if strings.TrimSpace(doc.Issuer) == "" {
+		return doc, errors.New("documento de discovery sin issuer")
+	}
+	return doc, nil
+}
+
+// endpoints devuelve los endpoints declarados, indexados por su clave en el
+// documento de discovery. Los vacíos se omiten.
+func (d oidcDocument) endpoints() map[string]string {
+	endpoints := make(map[string]string)
+	add := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			endpoints[key] = value
+		}
+	}
+	add("authorization_endpoint", d.AuthorizationEndpoint)
+	add("token_endpoint", d.TokenEndpoint)
+	add("userinfo_endpoint", d.UserinfoEndpoint)
+	add("jwks_uri", d.JWKSURI)
+	add("end_session_endpoint", d.EndSessionEndpoint)
+	add("registration_endpoint", d.RegistrationEndpoint)
+	add("introspection_endpoint", d.IntrospectionEndpoint)
+	add("revocation_endpoint", d.RevocationEndpoint)
+	return endpoints
+}
@@ -0,0 +1,120 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestIsOIDCDiscoveryURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://auth.example.com/.well-known/openid-configuration":         true,
+		"https://example.com/realms/main/.well-known/openid-configuration/": true,
+		"https://example.com/.well-known/security.txt":                      false,
+		"https://example.com/openid-configuration":                          false,
+	}
+	for input, want := range cases {
+		if got := isOIDCDiscoveryURL(input); got != want {
+			t.Errorf("isOIDCDiscoveryURL(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestOIDCDiscoveryExtractsEndpoints(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                   srvURL,
+			"authorization_endpoint":   srvURL + "/oauth2/authorize",
+			"token_endpoint":           srvURL + "/oauth2/token",
+			"jwks_uri":                 srvURL + "/oauth2/keys",
+			"response_types_supported": []string{"code"},
+		})
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	originalLoader := oidcClientLoader
+	oidcClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { oidcClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/.well-known/openid-configuration", Up: true},
+		{Type: "route", Value: srv.URL + "/index.html", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := OIDCDiscovery(context.Background(), dir, out); err != nil {
+		t.Fatalf("OIDCDiscovery: %v", err)
+	}
+	close(out)
+
+	var lines []string
+	for line := range out {
+		lines = append(lines, line)
+	}
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "active: oidc: ") {
+		t.Fatalf("unexpected output: %v", lines)
+	}
+	var got struct {
+		URL       string            `json:"url"`
+		Issuer    string            `json:"issuer"`
+		Endpoints map[string]string `json:"endpoints"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[0], "active: oidc: ")), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.URL != srv.URL+"/.well-known/openid-configuration" || got.Issuer != srv.URL {
+		t.Fatalf("unexpected url/issuer: %#v", got)
+	}
+	want := map[string]string{
+		"authorization_endpoint": srv.URL + "/oauth2/authorize",
+		"token_endpoint":         srv.URL + "/oauth2/token",
+		"jwks_uri":               srv.URL + "/oauth2/keys",
+	}
+	if diff := cmp.Diff(want, got.Endpoints); diff != "" {
+		t.Fatalf("unexpected endpoints (-want +got):\n%s", diff)
+	}
+}
+
+func TestOIDCDiscoveryReportsNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	originalLoader := oidcClientLoader
+	oidcClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { oidcClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/.well-known/openid-configuration", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := OIDCDiscovery(context.Background(), dir, out); err != nil {
+		t.Fatalf("OIDCDiscovery: %v", err)
+	}
+	close(out)
+
+	for line := range out {
+		if strings.HasPrefix(line, "active: oidc: ") {
+			t.Fatalf("did not expect an oidc line for a 404: %q", line)
+		}
+		if !strings.Contains(line, "status 404") {
+			t.Fatalf("expected a meta line reporting the 404, got %q", line)
+		}
+	}
+}
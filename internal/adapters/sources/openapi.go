@@ -0,0 +1,299 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/adapters/routes"
+)
+
+const (
+	// openapiMaxSpecSize limita el tamaño de especificación que se descarga.
+	openapiMaxSpecSize = 5 * 1024 * 1024
+)
+
+var (
+	openapiHTTPTimeout  = 15 * time.Second
+	openapiClientLoader = func() *http.Client {
+		return newActiveHTTPClient(openapiHTTPTimeout)
+	}
+
+	openapiSpecNames = []string{"openapi", "swagger", "api-docs", "api_docs", "apidocs"}
+	openapiHTTPVerbs = map[string]struct{}{
+		"get": {}, "put": {}, "post": {}, "delete": {}, "options": {}, "head": {}, "patch": {}, "trace": {},
+	}
+)
+
+// openapiDocument contiene los campos relevantes de especificaciones Swagger 2.0
+// y OpenAPI 3.x para reconstruir las rutas declaradas.
+type openapiDocument struct {
+	Swagger  string                    `json:"swagger" yaml:"swagger"`
+	OpenAPI  string                    `json:"openapi" yaml:"openapi"`
+	Host     string                    `json:"host" yaml:"host"`
+	BasePath string                    `json:"basePath" yaml:"basePath"`
+	Schemes  []string                  `json:"schemes" yaml:"schemes"`
+	Servers  []openapiServer           `json:"servers" yaml:"servers"`
+	Paths    map[string]map[string]any `json:"paths" yaml:"paths"`
+}
+
+type openapiServer struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// openapiRoute es una ruta declarada en la especificación junto con sus métodos.
+type openapiRoute struct {
+	URL     string
+	Methods []string
+}
+
+// OpenAPI descarga las especificaciones Swagger/OpenAPI descubiertas entre las
+// rutas activas y emite las rutas declaradas en ellas usando el prefijo
+// "active: openapi:" para que se registren con metadata {"from_openapi": true}.
+// También analiza los ficheros de esquema GraphQL (SDL) y RAML: sus tipos,
+// operaciones o recursos se resumen con el prefijo "active: apischema:" y los
+// recursos RAML se emiten además como rutas.
+func OpenAPI(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.UpOnly)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: openapi skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var specs []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !(isOpenAPISpecURL(candidate) || isParseableAPISchema(candidate)) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		specs = append(specs, candidate)
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	client := openapiClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: openapiHTTPTimeout}
+	}
+
+	for _, specURL := range specs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		body, err := fetchOpenAPIBody(ctx, client, specURL)
+		if err != nil {
+			out <- fmt.Sprintf("active: meta: openapi %s: %v", specURL, err)
+			continue
+		}
+		var declared []openapiRoute
+		switch format, _ := routes.APISchemaFormat(specURL); format {
+		case routes.APISchemaGraphQL:
+			schema, err := parseGraphQLSchema(body)
+			if err != nil {
+				out <- fmt.Sprintf("active: meta: openapi %s: %v", specURL, err)
+				continue
+			}
+			emitAPISchema(out, map[string]any{
+				"url":        specURL,
+				"format":     format,
+				"types":      schema.Types,
+				"operations": schema.Operations,
+			})
+			continue
+		case routes.APISchemaRAML:
+			declared, err = parseRAMLRoutes(specURL, body)
+			if err == nil {
+				emitAPISchema(out, map[string]any{
+					"url":        specURL,
+					"format":     format,
+					"path_count": len(declared),
+				})
+			}
+		default:
+			declared, err = parseOpenAPIRoutes(specURL, body)
+		}
+		if err != nil {
+			out <- fmt.Sprintf("active: meta: openapi %s: %v", specURL, err)
+			continue
+		}
+		for _, route := range declared {
+			payload, err := json.Marshal(map[string]any{
+				"url":     route.URL,
+				"spec":    specURL,
+				"methods": route.Methods,
+			})
+			if err != nil {
+				continue
+			}
+			out <- "active: openapi: " + string(payload)
+		}
+	}
+	return nil
+}
+
+// isParseableAPISchema indica si la URL apunta a un fichero de esquema cuyo
+// contenido se sabe analizar (GraphQL SDL o RAML).
+func isParseableAPISchema(raw string) bool {
+	format, ok := routes.APISchemaFormat(raw)
+	return ok && (format == routes.APISchemaGraphQL || format == routes.APISchemaRAML)
+}
+
+func emitAPISchema(out chan<- string, summary map[string]any) {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return
+	}
+	out <- "active: apischema: " + string(payload)
+}
+
+// isOpenAPISpecURL indica si la URL apunta a un documento Swagger/OpenAPI.
+func isOpenAPISpecURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	lowerPath := strings.ToLower(u.Path)
+	base := path.Base(lowerPath)
+	ext := path.Ext(base)
+	switch ext {
+	case ".json", ".yaml", ".yml", "":
+	default:
+		return false
+	}
+	for _, name := range openapiSpecNames {
+		if strings.Contains(base, name) {
+			return true
+		}
+	}
+	// Springdoc/Springfox publican la especificación sin extensión: /v3/api-docs
+	return strings.HasSuffix(lowerPath, "/api-docs")
+}
+
+func fetchOpenAPIBody(ctx context.Context, client *http.Client, specURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, specURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml;q=0.9, */*;q=0.5")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, openapiMaxSpecSize))
+}
+
+// parseOpenAPIRoutes extrae las rutas declaradas en una especificación y las
+// resuelve contra el servidor declarado o, en su defecto, contra la URL de la
+// propia especificación.
+func parseOpenAPIRoutes(specURL string, body []byte) ([]openapiRoute, error) {
+	var doc openapiDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		if yamlErr := yaml.Unmarshal(body, &doc); yamlErr != nil {
+			return nil, fmt.Errorf("especificación inválida: %w", err)
+		}
+	}
+	if doc.Swagger == "" && doc.OpenAPI == "" {
+		return nil, errors.New("documento sin versión swagger/openapi")
+	}
+	if len(doc.Paths) == 0 {
+		return nil, nil
+	}
+
+	spec, err := url.Parse(specURL)
+	if err != nil {
+		return nil, err
+	}
+	base := openapiBaseURL(spec, doc)
+
+	keys := make([]string, 0, len(doc.Paths))
+	for key := range doc.Paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	routes := make([]openapiRoute, 0, len(keys))
+	for _, key := range keys {
+		trimmed := strings.TrimSpace(key)
+		if !strings.HasPrefix(trimmed, "/") {
+			continue
+		}
+		var methods []string
+		for method := range doc.Paths[key] {
+			lower := strings.ToLower(method)
+			if _, ok := openapiHTTPVerbs[lower]; ok {
+				methods = append(methods, strings.ToUpper(lower))
+			}
+		}
+		sort.Strings(methods)
+		routes = append(routes, openapiRoute{
+			URL:     strings.TrimSuffix(base, "/") + trimmed,
+			Methods: methods,
+		})
+	}
+	return routes, nil
+}
+
+func openapiBaseURL(spec *url.URL, doc openapiDocument) string {
+	origin := &url.URL{Scheme: spec.Scheme, Host: spec.Host}
+
+	// OpenAPI 3.x: servers[].url (puede ser relativo a la especificación)
+	for _, server := range doc.Servers {
+		raw := strings.TrimSpace(server.URL)
+		if raw == "" || strings.Contains(raw, "{") {
+			continue
+		}
+		ref, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		resolved := spec.ResolveReference(ref)
+		if resolved.Scheme != "http" && resolved.Scheme != "https" {
+			continue
+		}
+		resolved.RawQuery = ""
+		resolved.Fragment = ""
+		return resolved.String()
+	}
+
+	// Swagger 2.0: schemes + host + basePath
+	if host := strings.TrimSpace(doc.Host); host != "" {
+		origin.Host = host
+		for _, scheme := range doc.Schemes {
+			scheme = strings.ToLower(strings.TrimSpace(scheme))
+			if scheme == spec.Scheme || scheme == "https" {
+				origin.Scheme = scheme
+				break
+			}
+		}
+	}
+	basePath := strings.TrimSpace(doc.BasePath)
+	if basePath != "" && basePath != "/" {
+		origin.Path = "/" + strings.Trim(basePath, "/")
+	}
+	return origin.String()
+}
@@ -0,0 +1,169 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/core/pipeline"
+)
+
+const fakeOpenAPIDoc = `{
+  "openapi": "3.0.1",
+  "info": {"title": "Fake", "version": "1.0"},
+  "servers": [{"url": "/api/v1"}],
+  "paths": {
+    "/users": {"get": {}, "post": {}},
+    "/users/{id}": {"get": {}, "delete": {}, "parameters": []},
+    "/health": {"get": {}}
+  }
+}`
+
+func TestParseOpenAPIRoutesSwagger2(t *testing.T) {
+	doc := `swagger: "2.0"
+host: api.example.com
+basePath: /v2
+schemes: [https]
+paths:
+  /pets:
+    get: {}
+  /pets/{petId}:
+    put: {}
+`
+	routes, err := parseOpenAPIRoutes("https://docs.example.com/swagger.yaml", []byte(doc))
+	if err != nil {
+		t.Fatalf("parseOpenAPIRoutes: %v", err)
+	}
+	want := []openapiRoute{
+		{URL: "https://api.example.com/v2/pets", Methods: []string{"GET"}},
+		{URL: "https://api.example.com/v2/pets/{petId}", Methods: []string{"PUT"}},
+	}
+	if diff := cmp.Diff(want, routes); diff != "" {
+		t.Fatalf("unexpected routes (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseOpenAPIRoutesRejectsNonSpec(t *testing.T) {
+	if _, err := parseOpenAPIRoutes("https://example.com/openapi.json", []byte(`{"paths": {"/x": {}}}`)); err == nil {
+		t.Fatalf("expected error for document without swagger/openapi version")
+	}
+}
+
+func TestIsOpenAPISpecURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/openapi.json":      true,
+		"https://example.com/docs/swagger.yaml": true,
+		"https://example.com/v3/api-docs":       true,
+		"https://example.com/swagger-ui.html":   false,
+		"https://example.com/static/app.js":     false,
+		"https://example.com/api/v1/users.json": false,
+	}
+	for input, want := range cases {
+		if got := isOpenAPISpecURL(input); got != want {
+			t.Errorf("isOpenAPISpecURL(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestOpenAPIExtractsRoutes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openapi.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fakeOpenAPIDoc))
+	}))
+	defer srv.Close()
+
+	originalLoader := openapiClientLoader
+	openapiClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { openapiClientLoader = originalLoader })
+
+	inputDir := t.TempDir()
+	specURL := srv.URL + "/openapi.json"
+	writeArtifactsFile(t, inputDir, []artifacts.Artifact{
+		{Type: "route", Value: specURL, Up: true},
+		{Type: "route", Value: srv.URL + "/index.html", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := OpenAPI(context.Background(), inputDir, out); err != nil {
+		t.Fatalf("OpenAPI returned error: %v", err)
+	}
+	close(out)
+
+	var lines []string
+	var urls []string
+	for line := range out {
+		lines = append(lines, line)
+		payload := strings.TrimPrefix(line, "active: openapi: ")
+		var data struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal([]byte(payload), &data); err != nil {
+			t.Fatalf("unexpected line %q: %v", line, err)
+		}
+		urls = append(urls, data.URL)
+	}
+	wantURLs := []string{
+		srv.URL + "/api/v1/health",
+		srv.URL + "/api/v1/users",
+		srv.URL + "/api/v1/users/{id}",
+	}
+	if diff := cmp.Diff(wantURLs, urls); diff != "" {
+		t.Fatalf("unexpected extracted urls (-want +got):\n%s", diff)
+	}
+
+	outputDir := t.TempDir()
+	sink, err := pipeline.NewSink(outputDir, true, "127.0.0.1", "subdomains", pipeline.LineBufferSize(1))
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	for _, line := range lines {
+		sink.In() <- line
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("close sink: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(outputDir, "artifacts.jsonl"))
+	if err != nil {
+		t.Fatalf("open artifacts: %v", err)
+	}
+	defer file.Close()
+	reader, err := artifacts.NewReaderV2(file)
+	if err != nil {
+		t.Fatalf("reader: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("read artifacts: %v", err)
+	}
+
+	found := 0
+	for _, art := range records {
+		if art.Type != "route" {
+			continue
+		}
+		if flag, _ := art.Metadata["from_openapi"].(bool); !flag {
+			t.Fatalf("route %q missing from_openapi metadata: %#v", art.Value, art.Metadata)
+		}
+		if spec, _ := art.Metadata["spec"].(string); spec != specURL {
+			t.Fatalf("route %q has unexpected spec metadata: %#v", art.Value, art.Metadata["spec"])
+		}
+		found++
+	}
+	if found != len(wantURLs) {
+		t.Fatalf("expected %d openapi routes, got %d: %#v", len(wantURLs), found, records)
+	}
+}
@@ -0,0 +1,171 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const (
+	// phpinfoMaxBodySize limita la cantidad de cuerpo inspeccionada en busca de
+	// la firma de phpinfo().
+	phpinfoMaxBodySize = 1024 * 1024
+
+	phpinfoMatchBody = "body"
+)
+
+var (
+	phpinfoHTTPTimeout  = 10 * time.Second
+	phpinfoClientLoader = func() *http.Client {
+		return newActiveHTTPClient(phpinfoHTTPTimeout)
+	}
+	phpinfoWorkerCount = 8
+	// phpinfoFetch descarga el cuerpo de una ruta candidata. Se sustituye en los
+	// tests para evitar peticiones reales.
+	phpinfoFetch = fetchPHPInfoBody
+
+	// phpinfoFileNames son los nombres de fichero que suelen exponer phpinfo().
+	phpinfoFileNames = map[string]struct{}{
+		"phpinfo.php":  {},
+		"info.php":     {},
+		"php_info.php": {},
+		"php-info.php": {},
+		"phpinfo":      {},
+	}
+
+	phpinfoTitlePattern   = regexp.MustCompile(`(?i)<title>[^<]*phpinfo\(\)\s*</title>`)
+	phpinfoVersionPattern = regexp.MustCompile(`PHP Version\s*(?:</t[dh]>\s*<td[^>]*>\s*)?([0-9]+\.[0-9]+[0-9A-Za-z.+~-]*)`)
+)
+
+// PHPInfoPages detecta páginas phpinfo() expuestas. Solo se descargan las rutas
+// con nombres típicos (phpinfo.php, info.php, ...) y solo se emiten las que
+// responden con la firma de phpinfo() en el cuerpo; un nombre típico que
+// devuelve 404 o no responde no es un hallazgo. Los hallazgos se emiten con el
+// prefijo "active: phpinfo:".
+func PHPInfoPages(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: phpinfo skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isPHPInfoPath(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	workers := phpinfoWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, candidate := range candidates {
+		candidate := candidate
+		group.Go(func() error {
+			body, err := phpinfoFetch(groupCtx, candidate)
+			if err != nil {
+				return nil
+			}
+			ok, version := phpinfoSignature(body)
+			if !ok {
+				return nil
+			}
+			data := map[string]string{"url": candidate, "match": phpinfoMatchBody}
+			if version != "" {
+				data["php_version"] = version
+			}
+			payload, err := json.Marshal(data)
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: phpinfo: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isPHPInfoPath indica si el último segmento de la ruta es un nombre típico de
+// página phpinfo().
+func isPHPInfoPath(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	_, ok := phpinfoFileNames[strings.ToLower(path.Base(u.Path))]
+	return ok
+}
+
+// phpinfoSignature indica si el cuerpo corresponde a la salida de phpinfo() y
+// devuelve la versión de PHP declarada, si aparece.
+func phpinfoSignature(body string) (bool, string) {
+	if !phpinfoTitlePattern.MatchString(body) {
+		return false, ""
+	}
+	if m := phpinfoVersionPattern.FindStringSubmatch(body); len(m) == 2 {
+		return true, m[1]
+	}
+	return true, ""
+}
+
+func fetchPHPInfoBody(ctx context.Context, target string) (string, error) {
+	client := phpinfoClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: phpinfoHTTPTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, phpinfoMaxBodySize))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,99 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const phpinfoSampleBody = `<!DOCTYPE html><html><head><title>PHP 8.1.2 - phpinfo()</title></head>
+<body><table><tr class="h"><td><h1 class="p">PHP Version 8.1.2-1ubuntu2.14</h1></td></tr></table></body></html>`
+
+func TestPHPInfoSignature(t *testing.T) {
+	ok, version := phpinfoSignature(phpinfoSampleBody)
+	if !ok || version != "8.1.2-1ubuntu2.14" {
+		t.Fatalf("phpinfoSignature = (%v, %q), want (true, %q)", ok, version, "8.1.2-1ubuntu2.14")
+	}
+	if ok, _ := phpinfoSignature(`<html><head><title>Login</title></head><body>PHP Version required</body></html>`); ok {
+		t.Fatalf("expected clean page not to match the phpinfo signature")
+	}
+}
+
+func TestPHPInfoPagesEmitsOnlyConfirmedBodies(t *testing.T) {
+	bodies := map[string]string{
+		"https://example.com/phpinfo.php":     `<html><head><title>phpinfo()</title></head><body><td class="e">PHP Version </td><td class="v">7.4.33 </td></body></html>`,
+		"https://example.com/tools/info.php":  `<html><head><title>Info</title></head><body>Nada que ver</body></html>`,
+		"https://example.com/tools/debug.php": phpinfoSampleBody,
+	}
+	var mu sync.Mutex
+	var fetched []string
+	originalFetch := phpinfoFetch
+	phpinfoFetch = func(_ context.Context, target string) (string, error) {
+		mu.Lock()
+		fetched = append(fetched, target)
+		mu.Unlock()
+		body, ok := bodies[target]
+		if !ok {
+			return "", errors.New("status 404")
+		}
+		return body, nil
+	}
+	t.Cleanup(func() { phpinfoFetch = originalFetch })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: "https://example.com/phpinfo.php", Up: true},
+		{Type: "route", Value: "https://example.com/info.php", Up: true},
+		{Type: "route", Value: "https://example.com/tools/info.php", Up: true},
+		{Type: "route", Value: "https://example.com/tools/debug.php", Up: true},
+		{Type: "route", Value: "https://example.com/index.php", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := PHPInfoPages(context.Background(), dir, out); err != nil {
+		t.Fatalf("PHPInfoPages: %v", err)
+	}
+	close(out)
+
+	type result struct {
+		URL        string `json:"url"`
+		Match      string `json:"match"`
+		PHPVersion string `json:"php_version"`
+	}
+	var got []result
+	for line := range out {
+		if !strings.HasPrefix(line, "active: phpinfo: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r result
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: phpinfo: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, r)
+	}
+
+	want := []result{
+		{URL: "https://example.com/phpinfo.php", Match: "body", PHPVersion: "7.4.33"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected phpinfo results (-want +got):\n%s", diff)
+	}
+
+	sort.Strings(fetched)
+	wantFetched := []string{
+		"https://example.com/info.php",
+		"https://example.com/phpinfo.php",
+		"https://example.com/tools/info.php",
+	}
+	if diff := cmp.Diff(wantFetched, fetched); diff != "" {
+		t.Fatalf("unexpected fetched candidates (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+package sources
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"passive-rec/internal/adapters/sources/linkfinderevo"
+	"passive-rec/internal/core/runner"
+)
+
+// defaultActiveConcurrency es el máximo de peticiones activas simultáneas si
+// no se configura otro valor con ConfigureActiveProbing.
+const defaultActiveConcurrency = 20
+
+// Prober limita las peticiones de las comprobaciones activas: como máximo
+// concurrency en vuelo a la vez (entre todos los steps) y, por host, una
+// espera mínima de delay entre el inicio de dos peticiones consecutivas.
+type Prober struct {
+	slots chan struct{}
+	delay time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// NewProber crea un Prober. concurrency <= 0 no limita la concurrencia y
+// delay <= 0 no espera entre peticiones al mismo host.
+func NewProber(concurrency int, delay time.Duration) *Prober {
+	p := &Prober{delay: delay, next: make(map[string]time.Time)}
+	if concurrency > 0 {
+		p.slots = make(chan struct{}, concurrency)
+	}
+	return p
+}
+
+// Acquire espera el turno de host y un hueco de concurrencia. La función
+// devuelta libera el hueco y debe llamarse al terminar la petición.
+func (p *Prober) Acquire(ctx context.Context, host string) (func(), error) {
+	if p == nil {
+		return func() {}, nil
+	}
+	if wait := p.reserve(host); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+	if p.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() { once.Do(func() { <-p.slots }) }, nil
+}
+
+// Do ejecuta fn respetando los límites del Prober para host.
+func (p *Prober) Do(ctx context.Context, host string, fn func() error) error {
+	release, err := p.Acquire(ctx, host)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+// reserve reserva el siguiente turno de host y devuelve cuánto hay que
+// esperar hasta él.
+func (p *Prober) reserve(host string) time.Duration {
+	if p.delay <= 0 {
+		return 0
+	}
+	host = strings.ToLower(strings.TrimSpace(host))
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	at := p.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	p.next[host] = at.Add(p.delay)
+	return at.Sub(now)
+}
+
+var (
+	activeProberMu sync.RWMutex
+	activeProber   = NewProber(defaultActiveConcurrency, 0)
+)
+
+// ConfigureActiveProbing sustituye el Prober compartido por todas las
+// comprobaciones activas que usan newActiveHTTPClient.
+func ConfigureActiveProbing(concurrency int, delay time.Duration) {
+	activeProberMu.Lock()
+	activeProber = NewProber(concurrency, delay)
+	activeProberMu.Unlock()
+}
+
+func currentActiveProber() *Prober {
+	activeProberMu.RLock()
+	defer activeProberMu.RUnlock()
+	return activeProber
+}
+
+var (
+	rateLimiterMu sync.RWMutex
+	rateLimiter   runner.Limiter
+)
+
+// ConfigureRateLimit fija el limitador que las fuentes activas consultan antes
+// de cada petición HTTP (newActiveHTTPClient) y de cada ejecución de httpx,
+// dnsx, subjs y GoLinkfinderEVO. nil desactiva el límite.
+func ConfigureRateLimit(limiter runner.Limiter) {
+	rateLimiterMu.Lock()
+	rateLimiter = limiter
+	rateLimiterMu.Unlock()
+	linkfinderevo.ConfigureRateLimit(limiter)
+}
+
+func currentRateLimiter() runner.Limiter {
+	rateLimiterMu.RLock()
+	defer rateLimiterMu.RUnlock()
+	return rateLimiter
+}
+
+// waitRateLimit espera el turno del limitador configurado, si lo hay.
+func waitRateLimit(ctx context.Context) error {
+	if limiter := currentRateLimiter(); limiter != nil {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
+
+// proberTransport pasa cada petición por el limitador y el Prober. El hueco
+// de concurrencia se mantiene hasta que se cierra el cuerpo de la respuesta.
+type proberTransport struct {
+	base    http.RoundTripper
+	prober  *Prober
+	limiter runner.Limiter
+}
+
+func (t *proberTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	release, err := t.prober.Acquire(req.Context(), req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,187 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProberRespectsConcurrencyCap(t *testing.T) {
+	const limit = 3
+	prober := NewProber(limit, 0)
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := prober.Do(context.Background(), "example.com", func() error {
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					seen := atomic.LoadInt32(&maxInFlight)
+					if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > limit {
+		t.Fatalf("expected at most %d checks in flight, got %d", limit, got)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got < 2 {
+		t.Fatalf("expected checks to run concurrently, max in flight was %d", got)
+	}
+}
+
+func TestProberDelaysConsecutiveRequestsToSameHost(t *testing.T) {
+	const delay = 30 * time.Millisecond
+	prober := NewProber(0, delay)
+
+	var mu sync.Mutex
+	starts := make(map[string][]time.Time)
+	var wg sync.WaitGroup
+	for _, host := range []string{"a.example.com", "A.example.com", "a.example.com", "b.example.com"} {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			_ = prober.Do(context.Background(), host, func() error {
+				mu.Lock()
+				key := "a"
+				if host == "b.example.com" {
+					key = "b"
+				}
+				starts[key] = append(starts[key], time.Now())
+				mu.Unlock()
+				return nil
+			})
+		}(host)
+	}
+	wg.Wait()
+
+	if len(starts["a"]) != 3 {
+		t.Fatalf("expected 3 checks on a.example.com, got %d", len(starts["a"]))
+	}
+	first, last := starts["a"][0], starts["a"][0]
+	for _, ts := range starts["a"] {
+		if ts.Before(first) {
+			first = ts
+		}
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	// Tres peticiones al mismo host (sin distinguir mayúsculas) necesitan al
+	// menos dos esperas completas.
+	if span := last.Sub(first); span < 2*delay-5*time.Millisecond {
+		t.Fatalf("expected same-host checks to be spaced by %s, span was %s", delay, span)
+	}
+	if len(starts["b"]) != 1 || starts["b"][0].Sub(first) >= delay {
+		t.Fatalf("expected other hosts not to wait, b started %s after a", starts["b"][0].Sub(first))
+	}
+}
+
+func TestProberAcquireHonoursContext(t *testing.T) {
+	prober := NewProber(1, 0)
+	release, err := prober.Acquire(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := prober.Acquire(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while the only slot is busy, got %v", err)
+	}
+}
+
+func TestProberTransportReleasesSlotOnBodyClose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	prober := NewProber(1, 0)
+	client := &http.Client{Transport: &proberTransport{base: http.DefaultTransport, prober: prober}}
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			cancel()
+			t.Fatalf("NewRequest: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			cancel()
+			t.Fatalf("request %d: %v", i, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		cancel()
+	}
+}
+
+// countingLimiter cuenta las llamadas a Wait sin esperar nunca.
+type countingLimiter struct {
+	calls atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.calls.Add(1)
+	return ctx.Err()
+}
+
+func TestActiveSourcesWaitOnRateLimiter(t *testing.T) {
+	limiter := &countingLimiter{}
+	ConfigureRateLimit(limiter)
+	t.Cleanup(func() { ConfigureRateLimit(nil) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := newActiveHTTPClient(time.Second)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	if got := limiter.calls.Load(); got != 3 {
+		t.Fatalf("expected one Wait per HTTP request, got %d", got)
+	}
+
+	originalBatch, originalWorkers := httpxBatchSize, httpxWorkerCount
+	t.Cleanup(func() { httpxBatchSize, httpxWorkerCount = originalBatch, originalWorkers })
+	httpxBatchSize, httpxWorkerCount = 2, 2
+
+	limiter.calls.Store(0)
+	runCmd := func(ctx context.Context, name string, args []string, out chan<- string) error { return nil }
+	inputWriter := func(lines []string) (string, func(), error) { return "/tmp/fake", func() {}, nil }
+	if err := runHTTPXWorkers(context.Background(), "httpx", []string{"a", "b", "c", "d", "e"}, make(chan string), runCmd, inputWriter); err != nil {
+		t.Fatalf("run workers: %v", err)
+	}
+	if got := limiter.calls.Load(); got != 3 {
+		t.Fatalf("expected one Wait per httpx batch, got %d", got)
+	}
+}
@@ -0,0 +1,166 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+var (
+	protocolsHTTPTimeout  = 10 * time.Second
+	protocolsClientLoader = func() *http.Client {
+		// Sin ForceAttemptHTTP2 un transporte con TLSClientConfig propio no
+		// ofrece h2 en el ALPN y todos los orígenes parecerían HTTP/1.1.
+		transport := newActiveTransport()
+		transport.ForceAttemptHTTP2 = true
+		return &http.Client{
+			Transport: &proberTransport{base: transport, prober: currentActiveProber()},
+			Timeout:   protocolsHTTPTimeout,
+		}
+	}
+	protocolsWorkerCount = 8
+	// protocolsProbe conecta con un origen y devuelve el protocolo negociado.
+	// Se sustituye en los tests para evitar conexiones reales.
+	protocolsProbe = probeHTTPProtocol
+)
+
+// httpProtocol resume los protocolos que ofrece un origen HTTPS: el negociado
+// por ALPN (h2, http/1.1) y si anuncia HTTP/3 mediante Alt-Svc.
+type httpProtocol struct {
+	ALPN string
+	H3   bool
+}
+
+// HTTPProtocols conecta con los orígenes HTTPS de las rutas activas y emite con
+// el prefijo "active: protocol:" el protocolo negociado por ALPN y si anuncian
+// HTTP/3, para fingerprinting y el resumen de infraestructura.
+func HTTPProtocols(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.ActiveOnly)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: protocols skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var origins []string
+	for _, value := range values {
+		origin := httpsOrigin(artifacts.ExtractRouteBase(value))
+		if origin == "" {
+			continue
+		}
+		if _, ok := seen[origin]; ok {
+			continue
+		}
+		seen[origin] = struct{}{}
+		origins = append(origins, origin)
+	}
+	if len(origins) == 0 {
+		return nil
+	}
+
+	workers := protocolsWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+	client := protocolsClientLoader()
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, origin := range origins {
+		origin := origin
+		group.Go(func() error {
+			proto, err := protocolsProbe(groupCtx, client, origin)
+			if err != nil || proto.ALPN == "" {
+				return nil
+			}
+			payload, err := json.Marshal(map[string]any{
+				"url":  origin,
+				"alpn": proto.ALPN,
+				"h3":   proto.H3,
+			})
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: protocol: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// httpsOrigin devuelve el origen https://host[:port]/ de la ruta o "" si no
+// es HTTPS (sin TLS no hay negociación ALPN).
+func httpsOrigin(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || !strings.EqualFold(u.Scheme, "https") {
+		return ""
+	}
+	return "https://" + strings.ToLower(u.Host) + "/"
+}
+
+func probeHTTPProtocol(ctx context.Context, client *http.Client, origin string) (httpProtocol, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, origin, nil)
+	if err != nil {
+		return httpProtocol{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return httpProtocol{}, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	proto := httpProtocol{H3: altSvcAdvertisesH3(resp.Header.Values("Alt-Svc"))}
+	if resp.TLS != nil {
+		proto.ALPN = resp.TLS.NegotiatedProtocol
+	}
+	if proto.ALPN == "" {
+		// Sin ALPN el servidor habla HTTP/1.x; se normaliza al identificador
+		// ALPN para que el resumen no distinga ambos casos.
+		if resp.ProtoMajor == 2 {
+			proto.ALPN = "h2"
+		} else {
+			proto.ALPN = "http/1.1"
+		}
+	}
+	return proto, nil
+}
+
+// altSvcAdvertisesH3 indica si alguna cabecera Alt-Svc anuncia HTTP/3 (h3 o
+// sus borradores h3-29, h3-Q050, ...).
+func altSvcAdvertisesH3(values []string) bool {
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			name := strings.TrimSpace(entry)
+			if idx := strings.Index(name, "="); idx >= 0 {
+				name = name[:idx]
+			}
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "h3" || strings.HasPrefix(name, "h3-") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestHTTPProtocolsRecordsNegotiatedALPN(t *testing.T) {
+	h2 := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", `h3=":443"; ma=86400`)
+	}))
+	h2.EnableHTTP2 = true
+	h2.StartTLS()
+	defer h2.Close()
+
+	h1 := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer h1.Close()
+
+	// Ambos servidores usan el mismo certificado de prueba, así que el cliente
+	// de h2 (con HTTP/2 habilitado) confía en los dos.
+	originalLoader := protocolsClientLoader
+	protocolsClientLoader = h2.Client
+	t.Cleanup(func() { protocolsClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: h2.URL + "/login", Active: true, Up: true},
+		{Type: "route", Value: h2.URL + "/about", Active: true, Up: true},
+		{Type: "route", Value: h1.URL + "/", Active: true, Up: true},
+		{Type: "route", Value: "http://example.com/", Active: true, Up: true},
+		{Type: "route", Value: "https://passive.example.com/", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := HTTPProtocols(context.Background(), dir, out); err != nil {
+		t.Fatalf("HTTPProtocols: %v", err)
+	}
+	close(out)
+
+	type result struct {
+		URL  string `json:"url"`
+		ALPN string `json:"alpn"`
+		H3   bool   `json:"h3"`
+	}
+	got := make(map[string]result)
+	for line := range out {
+		if !strings.HasPrefix(line, "active: protocol: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r result
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: protocol: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got[r.URL] = r
+	}
+
+	want := map[string]result{
+		h2.URL + "/": {URL: h2.URL + "/", ALPN: "h2", H3: true},
+		h1.URL + "/": {URL: h1.URL + "/", ALPN: "http/1.1"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected protocol results (-want +got):\n%s", diff)
+	}
+}
+
+func TestAltSvcAdvertisesH3(t *testing.T) {
+	cases := map[string]bool{
+		`h3=":443"; ma=86400`:                true,
+		`h2=":443", h3-29=":443"; ma=3600`:   true,
+		`h2=":443"; ma=2592000`:              false,
+		`clear`:                              false,
+		`quic=":443"; ma=2592000; v="46,43"`: false,
+	}
+	for header, want := range cases {
+		if got := altSvcAdvertisesH3([]string{header}); got != want {
+			t.Fatalf("altSvcAdvertisesH3(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
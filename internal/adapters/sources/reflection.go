@@ -0,0 +1,163 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+const (
+	// reflectionMaxBodySize limita la cantidad de cuerpo que se inspecciona en
+	// busca del canario.
+	reflectionMaxBodySize = 2 * 1024 * 1024
+)
+
+var (
+	reflectionHTTPTimeout  = 10 * time.Second
+	reflectionClientLoader = func() *http.Client {
+		return newActiveHTTPClient(reflectionHTTPTimeout)
+	}
+	reflectionWorkerCount = 8
+	// reflectionCanary es el valor inyectado en cada parámetro. Incluye
+	// caracteres que una salida correctamente escapada nunca devolvería tal cual.
+	reflectionCanary = `prc4n4ry"'<x>`
+)
+
+// ReflectedParams sustituye, uno a uno, el valor de cada parámetro de las rutas
+// activas con query string por un canario y comprueba si la respuesta lo
+// refleja sin escapar. Los hallazgos se emiten con el prefijo
+// "active: reflection:" como precursores de XSS.
+func ReflectedParams(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.UpOnly)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: reflection skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	type probe struct {
+		route string
+		param string
+	}
+
+	seen := make(map[string]struct{})
+	var probes []probe
+	for _, value := range values {
+		route := artifacts.ExtractRouteBase(value)
+		if route == "" {
+			continue
+		}
+		for _, param := range queryParamNames(route) {
+			key := route + "\x00" + param
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			probes = append(probes, probe{route: route, param: param})
+		}
+	}
+	if len(probes) == 0 {
+		return nil
+	}
+
+	client := reflectionClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: reflectionHTTPTimeout}
+	}
+
+	workers := reflectionWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, p := range probes {
+		p := p
+		group.Go(func() error {
+			if !reflectsCanary(groupCtx, client, p.route, p.param) {
+				return nil
+			}
+			payload, err := json.Marshal(map[string]string{
+				"url":   p.route,
+				"param": p.param,
+			})
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: reflection: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// queryParamNames devuelve los nombres de parámetro de la query string de la
+// ruta, ordenados y sin duplicados.
+func queryParamNames(raw string) []string {
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return nil
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil && len(query) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// reflectsCanary solicita la ruta con el parámetro indicado sustituido por el
+// canario e indica si el cuerpo de la respuesta lo contiene sin escapar.
+func reflectsCanary(ctx context.Context, client *http.Client, route, param string) bool {
+	u, err := url.Parse(route)
+	if err != nil {
+		return false
+	}
+	query := u.Query()
+	query.Set(param, reflectionCanary)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, reflectionMaxBodySize))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(body), reflectionCanary)
+}
@@ -0,0 +1,94 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestQueryParamNames(t *testing.T) {
+	got := queryParamNames("https://example.com/search?q=1&lang=es&q=2")
+	want := []string{"lang", "q"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected params (-want +got):\n%s", diff)
+	}
+	if got := queryParamNames("https://example.com/plain"); len(got) != 0 {
+		t.Fatalf("expected no params, got %v", got)
+	}
+}
+
+func TestReflectedParamsDetectsUnescapedReflection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/reflect":
+			// Refleja el parámetro q tal cual.
+			_, _ = w.Write([]byte("<p>Resultados para " + r.URL.Query().Get("q") + "</p>"))
+		case "/escape":
+			_, _ = w.Write([]byte("<p>Resultados para " + html.EscapeString(r.URL.Query().Get("q")) + "</p>"))
+		default:
+			_, _ = w.Write([]byte("<p>sin resultados</p>"))
+		}
+	}))
+	defer srv.Close()
+
+	originalLoader := reflectionClientLoader
+	reflectionClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { reflectionClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/reflect?q=test&page=1", Up: true},
+		{Type: "route", Value: srv.URL + "/escape?q=test", Up: true},
+		{Type: "route", Value: srv.URL + "/ignore?q=test", Up: true},
+		{Type: "route", Value: srv.URL + "/reflect", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := ReflectedParams(context.Background(), dir, out); err != nil {
+		t.Fatalf("ReflectedParams: %v", err)
+	}
+	close(out)
+
+	type result struct {
+		URL   string `json:"url"`
+		Param string `json:"param"`
+	}
+	var got []result
+	for line := range out {
+		if !strings.HasPrefix(line, "active: reflection: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r result
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: reflection: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, r)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Param < got[j].Param })
+
+	want := []result{{URL: srv.URL + "/reflect?q=test&page=1", Param: "q"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected reflections (-want +got):\n%s", diff)
+	}
+}
+
+func TestReflectedParamsMissingArtifacts(t *testing.T) {
+	out := make(chan string, 1)
+	if err := ReflectedParams(context.Background(), t.TempDir(), out); err != nil {
+		t.Fatalf("ReflectedParams: %v", err)
+	}
+	close(out)
+	if line := <-out; line != "active: meta: reflection skipped (missing artifacts.jsonl)" {
+		t.Fatalf("unexpected line: %q", line)
+	}
+}
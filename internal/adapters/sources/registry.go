@@ -0,0 +1,72 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SourceFunc es una fuente que, dado un target, emite líneas en out hasta
+// terminar o hasta que ctx se cancele. Es la misma forma que Subfinder o
+// Assetfinder.
+type SourceFunc func(ctx context.Context, target string, out chan<- string) error
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]SourceFunc)
+)
+
+// Register añade una fuente externa al registro para que pueda pedirse en
+// -tools con name (sin distinguir mayúsculas). Está pensado para llamarse
+// desde un main propio antes de app.Run; las herramientas integradas tienen
+// prioridad sobre una fuente registrada con el mismo nombre. Hace panic si name
+// está vacío, fn es nil o el nombre ya estaba registrado.
+func Register(name string, fn SourceFunc) {
+	key := normalizeSourceName(name)
+	if key == "" {
+		panic("sources: Register con nombre vacío")
+	}
+	if fn == nil {
+		panic(fmt.Sprintf("sources: Register de %q con SourceFunc nil", name))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[key]; dup {
+		panic(fmt.Sprintf("sources: Register llamado dos veces para %q", key))
+	}
+	registry[key] = fn
+}
+
+// Lookup devuelve la fuente registrada con name, o false si no existe.
+func Lookup(name string) (SourceFunc, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fn, ok := registry[normalizeSourceName(name)]
+	return fn, ok
+}
+
+// Registered devuelve los nombres de las fuentes registradas, ordenados.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Unregister elimina una fuente del registro. Permite a los tests (y a quien
+// recargue fuentes) registrar de nuevo el mismo nombre.
+func Unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, normalizeSourceName(name))
+}
+
+func normalizeSourceName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,43 @@
+package sources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterAndLookup(t *testing.T) {
+	called := false
+	Register(" My-Source ", func(ctx context.Context, target string, out chan<- string) error {
+		called = true
+		return nil
+	})
+	t.Cleanup(func() { Unregister("my-source") })
+
+	fn, ok := Lookup("MY-SOURCE")
+	if !ok {
+		t.Fatalf("expected registered source to be found")
+	}
+	if err := fn(context.Background(), "example.com", nil); err != nil || !called {
+		t.Fatalf("expected registered function to run, err=%v called=%v", err, called)
+	}
+	if got := Registered(); !reflect.DeepEqual(got, []string{"my-source"}) {
+		t.Fatalf("unexpected registered names: %v", got)
+	}
+	if _, ok := Lookup("other"); ok {
+		t.Fatalf("unexpected lookup hit for unregistered name")
+	}
+}
+
+func TestRegisterPanicsOnDuplicate(t *testing.T) {
+	noop := func(ctx context.Context, target string, out chan<- string) error { return nil }
+	Register("dup-source", noop)
+	t.Cleanup(func() { Unregister("dup-source") })
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on duplicate registration")
+		}
+	}()
+	Register("DUP-SOURCE", noop)
+}
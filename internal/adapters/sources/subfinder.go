@@ -5,5 +5,10 @@ import (
 )
 
 func Subfinder(ctx context.Context, target string, out chan<- string) error {
-	return runSimpleSingleBin(ctx, "subfinder", []string{"-d", target, "-silent"}, out)
+	return runSimpleSingleBin(ctx, "subfinder", subfinderArgs(target), out)
+}
+
+// subfinderArgs devuelve los argumentos de subfinder para target.
+func subfinderArgs(target string) []string {
+	return []string{"-d", target, "-silent"}
 }
@@ -2,7 +2,6 @@ package sources
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"net/http"
 	"os"
@@ -13,7 +12,6 @@ import (
 
 	"passive-rec/internal/adapters/artifacts"
 	"passive-rec/internal/core/runner"
-	"passive-rec/internal/platform/config"
 )
 
 var (
@@ -24,14 +22,7 @@ var (
 	subjsWorkerCount  = runtime.NumCPU() * 4
 	subjsHTTPTimeout  = 15 * time.Second
 	subjsClientLoader = func() *http.Client {
-		transport := &http.Transport{
-			Proxy:                 http.ProxyFromEnvironment,
-			ResponseHeaderTimeout: 10 * time.Second,
-		}
-		if pool := config.CustomRootCAs(); pool != nil {
-			transport.TLSClientConfig = &tls.Config{RootCAs: pool}
-		}
-		return &http.Client{Transport: transport, Timeout: subjsHTTPTimeout}
+		return newActiveHTTPClient(subjsHTTPTimeout)
 	}
 )
 
@@ -58,6 +49,10 @@ func SubJS(ctx context.Context, outdir string, out chan<- string) error {
 		return nil
 	}
 
+	if err := waitRateLimit(ctx); err != nil {
+		return err
+	}
+
 	tmpPath, cleanup, err := artifacts.WriteTempInput("subjs", inputs)
 	if err != nil {
 		return err
@@ -67,7 +62,7 @@ func SubJS(ctx context.Context, outdir string, out chan<- string) error {
 	intermediate := make(chan string)
 	runErr := make(chan error, 1)
 	go func() {
-		runErr <- subjsRunCmd(ctx, bin, []string{"-i", tmpPath}, intermediate)
+		runErr <- subjsRunCmd(ctx, bin, subjsArgs(tmpPath), intermediate)
 		close(intermediate)
 	}()
 
@@ -238,3 +233,8 @@ func doJSRequest(ctx context.Context, client *http.Client, method, url string) (
 	resp.Body.Close()
 	return resp.StatusCode, nil
 }
+
+// subjsArgs devuelve los argumentos de subjs para la lista de URLs listPath.
+func subjsArgs(listPath string) []string {
+	return []string{"-i", listPath}
+}
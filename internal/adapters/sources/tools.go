@@ -0,0 +1,62 @@
+package sources
+
+import (
+	"strings"
+
+	"passive-rec/internal/adapters/sources/linkfinderevo"
+)
+
+// ToolBinaries asocia cada herramienta del pipeline con los binarios externos
+// que la implementan, con los mismos nombres que requirements.txt
+// (cmd/install-deps). Las herramientas nativas (rdap, crtsh, dedupe, ...) no
+// aparecen. La comparten -dry-run y cmd/self-test.
+var ToolBinaries = map[string][]string{
+	"amass":         {"amass"},
+	"subfinder":     {"subfinder"},
+	"assetfinder":   {"assetfinder"},
+	"waybackurls":   {"waybackurls"},
+	"gau":           {"gau", "getallurls"},
+	"httpx":         {"httpx", "httpx-toolkit"},
+	"dnsx":          {"dnsx"},
+	"subjs":         {"subjs"},
+	"linkfinderevo": {"GoLinkfinderEVO"},
+}
+
+// toolListPlaceholder sustituye en ToolCommands a los ficheros temporales con
+// la lista de entradas que las fuentes generan al ejecutarse.
+const toolListPlaceholder = "<lista>"
+
+// ToolCommands devuelve las líneas de comandos que la herramienta ejecutaría
+// con el binario bin para target, una por ejecución, o nil si es nativa. Las
+// fuentes que reciben una lista de entradas la muestran como "<lista>".
+func ToolCommands(tool, bin, target string, active bool) []string {
+	var runs [][]string
+	switch tool {
+	case "amass":
+		runs = append(runs, amassArgs(target, false))
+		if active {
+			runs = append(runs, amassArgs(target, true))
+		}
+	case "subfinder":
+		runs = append(runs, subfinderArgs(target))
+	case "assetfinder":
+		runs = append(runs, assetfinderArgs(target))
+	case "waybackurls", "gau":
+		runs = append(runs, []string{target})
+	case "httpx":
+		runs = append(runs, httpxArgs(toolListPlaceholder))
+	case "dnsx":
+		runs = append(runs, dnsxArgs(toolListPlaceholder))
+	case "subjs":
+		runs = append(runs, subjsArgs(toolListPlaceholder))
+	case "linkfinderevo":
+		runs = append(runs, linkfinderevo.CommandArgs(target))
+	default:
+		return nil
+	}
+	commands := make([]string, 0, len(runs))
+	for _, args := range runs {
+		commands = append(commands, bin+" "+strings.Join(args, " "))
+	}
+	return commands
+}
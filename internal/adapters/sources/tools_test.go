@@ -0,0 +1,40 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToolCommands(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		tool   string
+		bin    string
+		active bool
+		want   []string
+	}{
+		{"amass", "amass", false, []string{"amass enum -passive -d example.com"}},
+		{"amass", "amass", true, []string{"amass enum -passive -d example.com", "amass enum -d example.com"}},
+		{"assetfinder", "assetfinder", false, []string{"assetfinder --subs-only example.com"}},
+		{"dnsx", "dnsx", true, []string{"dnsx -all -json -l <lista>"}},
+		{"subjs", "subjs", true, []string{"subjs -i <lista>"}},
+		{"crtsh", "", false, nil},
+	}
+	for _, tc := range cases {
+		if diff := cmp.Diff(tc.want, ToolCommands(tc.tool, tc.bin, "example.com", tc.active)); diff != "" {
+			t.Errorf("ToolCommands(%s, active=%t) mismatch (-want +got):\n%s", tc.tool, tc.active, diff)
+		}
+	}
+}
+
+func TestToolBinariesCoverEveryCommandTool(t *testing.T) {
+	t.Parallel()
+
+	for tool, bins := range ToolBinaries {
+		if len(ToolCommands(tool, bins[0], "example.com", true)) == 0 {
+			t.Errorf("tool %q has binaries but no command line", tool)
+		}
+	}
+}
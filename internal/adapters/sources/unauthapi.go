@@ -0,0 +1,151 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/adapters/routes"
+)
+
+// unauthAPIMaxBodySize limita el cuerpo leído de cada endpoint de API.
+const unauthAPIMaxBodySize = 512 * 1024
+
+var (
+	unauthAPIHTTPTimeout  = 10 * time.Second
+	unauthAPIClientLoader = func() *http.Client {
+		return newActiveHTTPClient(unauthAPIHTTPTimeout)
+	}
+	unauthAPIWorkerCount = 8
+)
+
+// UnauthenticatedAPI solicita sin credenciales las rutas clasificadas como API
+// y emite con el prefijo "active: unauthapi:" las que responden 200 con un
+// cuerpo JSON no vacío, candidatas a exponer datos sin autenticación.
+func UnauthenticatedAPI(ctx context.Context, outdir string, out chan<- string) error {
+	values, err := artifacts.CollectValues(outdir, "route", artifacts.AnyState)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			out <- "active: meta: unauthapi skipped (missing artifacts.jsonl)"
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]struct{})
+	var candidates []string
+	for _, value := range values {
+		candidate := artifacts.ExtractRouteBase(value)
+		if candidate == "" || !isAPIRoute(candidate) {
+			continue
+		}
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	client := unauthAPIClientLoader()
+	if client == nil {
+		client = &http.Client{Timeout: unauthAPIHTTPTimeout}
+	}
+	workers := unauthAPIWorkerCount
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, candidate := range candidates {
+		candidate := candidate
+		group.Go(func() error {
+			contentType, ok := probeUnauthAPI(groupCtx, client, candidate)
+			if !ok {
+				return nil
+			}
+			payload, err := json.Marshal(map[string]string{
+				"url":          candidate,
+				"content_type": contentType,
+			})
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			select {
+			case out <- "active: unauthapi: " + string(payload):
+			case <-groupCtx.Done():
+				return groupCtx.Err()
+			}
+			return nil
+		})
+	}
+	return group.Wait()
+}
+
+// isAPIRoute indica si la clasificación de rutas considera la URL un endpoint
+// de API.
+func isAPIRoute(raw string) bool {
+	for _, category := range routes.DetectCategories(raw) {
+		if category == routes.CategoryAPI {
+			return true
+		}
+	}
+	return false
+}
+
+// probeUnauthAPI solicita el endpoint sin cabeceras de autenticación y
+// devuelve su Content-Type si responde 200 con datos JSON.
+func probeUnauthAPI(ctx context.Context, client *http.Client, target string) (string, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", false
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.Contains(strings.ToLower(contentType), "json") {
+		return "", false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, unauthAPIMaxBodySize))
+	if err != nil {
+		return "", false
+	}
+	return contentType, hasJSONData(body)
+}
+
+// hasJSONData indica si el cuerpo es JSON válido con contenido: los documentos
+// vacíos ({}, [], null) no revelan datos.
+func hasJSONData(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if !json.Valid(trimmed) {
+		return false
+	}
+	switch string(trimmed) {
+	case "{}", "[]", "null", `""`:
+		return false
+	}
+	return true
+}
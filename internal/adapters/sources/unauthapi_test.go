@@ -0,0 +1,74 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestUnauthenticatedAPIFlagsJSONResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected Authorization header on %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/api/v1/users":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id":1,"email":"alice@example.com"}]`))
+		case "/api/v1/admin":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+		case "/api/v1/empty":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	originalLoader := unauthAPIClientLoader
+	unauthAPIClientLoader = func() *http.Client { return srv.Client() }
+	t.Cleanup(func() { unauthAPIClientLoader = originalLoader })
+
+	dir := t.TempDir()
+	writeArtifactsFile(t, dir, []artifacts.Artifact{
+		{Type: "route", Value: srv.URL + "/api/v1/users", Up: true},
+		{Type: "route", Value: srv.URL + "/api/v1/admin", Up: true},
+		{Type: "route", Value: srv.URL + "/api/v1/empty", Up: true},
+		{Type: "route", Value: srv.URL + "/about", Up: true},
+	})
+
+	out := make(chan string, 10)
+	if err := UnauthenticatedAPI(context.Background(), dir, out); err != nil {
+		t.Fatalf("UnauthenticatedAPI: %v", err)
+	}
+	close(out)
+
+	var got []string
+	for line := range out {
+		if !strings.HasPrefix(line, "active: unauthapi: ") {
+			t.Fatalf("unexpected line: %q", line)
+		}
+		var r struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "active: unauthapi: ")), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		got = append(got, r.URL)
+	}
+
+	want := []string{srv.URL + "/api/v1/users"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected unauthapi findings (-want +got):\n%s", diff)
+	}
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"fmt"
+	"iter"
 	"time"
 
 	"passive-rec/internal/adapters/artifacts"
@@ -13,6 +14,7 @@ type Analyzer struct {
 	header    artifacts.HeaderV2
 	options   AnalysisOptions
 	stats     ArtifactStats
+	cache     *techCache
 }
 
 // NewAnalyzer crea una nueva instancia del analizador.
@@ -25,6 +27,25 @@ func NewAnalyzer(arts []artifacts.Artifact, header artifacts.HeaderV2, opts Anal
 	}
 }
 
+// NewAnalyzerFromIterator crea un analizador alimentado por un iterador de
+// artefactos, para consumidores que leen el manifiesto en streaming (ver
+// artifacts.ReaderV2.Each) sin cargarlo antes en un slice. Con
+// IncludeActiveOnly o IncludePassiveOnly solo se retienen los artefactos de ese
+// estado, ya que el análisis recorre varias veces los que conserva.
+func NewAnalyzerFromIterator(seq iter.Seq[artifacts.Artifact], header artifacts.HeaderV2, opts AnalysisOptions) *Analyzer {
+	var arts []artifacts.Artifact
+	for art := range seq {
+		if opts.IncludeActiveOnly && !art.Active {
+			continue
+		}
+		if opts.IncludePassiveOnly && art.Active {
+			continue
+		}
+		arts = append(arts, art)
+	}
+	return NewAnalyzer(arts, header, opts)
+}
+
 // NewAnalyzerFromArtifacts crea un analizador con opciones por defecto.
 func NewAnalyzerFromArtifacts(arts []artifacts.Artifact) *Analyzer {
 	return NewAnalyzer(arts, artifacts.HeaderV2{}, DefaultAnalysisOptions())
@@ -41,7 +62,11 @@ func (a *Analyzer) Analyze() (*Report, error) {
 
 	// Análisis de tecnología
 	if a.options.EnableTechDetection {
-		report.TechStack = a.detectTechnology()
+		stack, err := a.detectTechnologyCached()
+		if err != nil {
+			return nil, err
+		}
+		report.TechStack = stack
 	}
 
 	// Análisis de superficie de ataque
@@ -86,6 +111,7 @@ func (a *Analyzer) buildSummary() Summary {
 		ArtifactsByType:   a.stats.ByType,
 		ArtifactsByStatus: a.stats.ByStatus,
 		ToolsUsed:         a.stats.UniqueTools,
+		ToolBreakdown:     a.stats.ByToolType,
 	}
 
 	// Top tools
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestNewAnalyzerFromIteratorFiltersByState(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "domain", Value: "example.com"},
+		{Type: "domain", Value: "app.example.com", Active: true},
+		{Type: "route", Value: "https://app.example.com/login", Active: true},
+	}
+	seq := func(yield func(artifacts.Artifact) bool) {
+		for _, art := range arts {
+			if !yield(art) {
+				return
+			}
+		}
+	}
+
+	all := NewAnalyzerFromIterator(seq, artifacts.HeaderV2{}, AnalysisOptions{})
+	if len(all.artifacts) != 3 {
+		t.Fatalf("expected 3 artifacts without filters, got %d", len(all.artifacts))
+	}
+
+	active := NewAnalyzerFromIterator(seq, artifacts.HeaderV2{}, AnalysisOptions{IncludeActiveOnly: true})
+	if len(active.artifacts) != 2 || active.stats.Active != 2 {
+		t.Fatalf("expected only the 2 active artifacts, got %+v", active.artifacts)
+	}
+	for _, art := range active.artifacts {
+		if !art.Active {
+			t.Fatalf("unexpected passive artifact %q", art.Value)
+		}
+	}
+}
+
+func TestBuildSummaryToolBreakdown(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "domain", Value: "example.com", Tool: "crtsh"},
+		{Type: "domain", Value: "app.example.com", Tool: "subfinder", Tools: []string{"subfinder", "crtsh"}},
+		{Type: "route", Value: "https://app.example.com/", Tool: "httpx", Types: []string{"route", "html"}},
+		{Type: "route", Value: "https://example.com/legacy"},
+	}
+	analyzer := NewAnalyzer(arts, artifacts.HeaderV2{}, AnalysisOptions{})
+	summary := analyzer.buildSummary()
+
+	want := map[string]map[string]int{
+		"crtsh":     {"domain": 2},
+		"subfinder": {"domain": 1},
+		"httpx":     {"route": 1, "html": 1},
+		"unknown":   {"route": 1},
+	}
+	if diff := cmp.Diff(want, summary.ToolBreakdown); diff != "" {
+		t.Fatalf("unexpected tool breakdown (-want +got):\n%s", diff)
+	}
+
+	md := GenerateMarkdownReport(&Report{Summary: summary})
+	if !strings.Contains(md, "| crtsh | domain | 2 |") || !strings.Contains(md, "| unknown | route | 1 |") {
+		t.Fatalf("markdown report missing tool breakdown rows:\n%s", md)
+	}
+}
@@ -0,0 +1,319 @@
+package analysis
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/certs"
+)
+
+// caaAuthority relaciona una CA con los identificadores que usa en registros
+// CAA y con cómo aparece en el emisor de sus certificados. Names son
+// fragmentos del emisor ya normalizados (minúsculas, solo alfanuméricos);
+// Intermediates son CN exactos de intermedias cuyo emisor no nombra a la CA
+// (Let's Encrypt firma como "R3", "E5", ...).
+type caaAuthority struct {
+	Name          string
+	Domains       []string
+	Names         []string
+	Intermediates []string
+}
+
+// caaAuthorities son las CA reconocidas por el análisis CAA. Se puede
+// ampliar añadiendo entradas.
+var caaAuthorities = []caaAuthority{
+	{
+		Name:          "Let's Encrypt",
+		Domains:       []string{"letsencrypt.org"},
+		Names:         []string{"letsencrypt", "isrg"},
+		Intermediates: []string{"r3", "r4", "r10", "r11", "r12", "r13", "r14", "e1", "e2", "e5", "e6", "e7", "e8", "e9"},
+	},
+	{Name: "DigiCert", Domains: []string{"digicert.com", "symantec.com", "geotrust.com", "rapidssl.com", "thawte.com"}, Names: []string{"digicert", "geotrust", "rapidssl", "thawte", "symantec"}},
+	{Name: "Sectigo", Domains: []string{"sectigo.com", "comodoca.com", "comodo.com", "usertrust.com", "trust-provider.com"}, Names: []string{"sectigo", "comodo", "usertrust"}},
+	{Name: "ZeroSSL", Domains: []string{"zerossl.com", "sectigo.com"}, Names: []string{"zerossl"}},
+	{Name: "Google Trust Services", Domains: []string{"pki.goog", "google.com"}, Names: []string{"googletrustservices", "gts"}},
+	{Name: "Amazon", Domains: []string{"amazon.com", "amazontrust.com", "awstrust.com", "amazonaws.com"}, Names: []string{"amazon"}},
+	{Name: "GlobalSign", Domains: []string{"globalsign.com"}, Names: []string{"globalsign"}},
+	{Name: "GoDaddy", Domains: []string{"godaddy.com", "starfieldtech.com"}, Names: []string{"godaddy", "starfield"}},
+	{Name: "Entrust", Domains: []string{"entrust.net", "affirmtrust.com"}, Names: []string{"entrust", "affirmtrust"}},
+	{Name: "Buypass", Domains: []string{"buypass.com", "buypass.no"}, Names: []string{"buypass"}},
+	{Name: "SSL.com", Domains: []string{"ssl.com"}, Names: []string{"sslcom"}},
+	{Name: "Microsoft", Domains: []string{"microsoft.com"}, Names: []string{"microsoft"}},
+}
+
+// caaPolicy son las CA autorizadas por los registros CAA issue/issuewild de
+// un dominio registrable. Un conjunto vacío (issue ";") prohíbe emitir.
+type caaPolicy struct {
+	Allowed map[string]struct{}
+	Records []string
+}
+
+// analyzeCAA señala certificados emitidos por una CA que no figura en los
+// registros CAA del dominio registrable de sus nombres. Los dominios sin
+// registros CAA (o solo con iodef) no se evalúan: la ausencia de CAA permite
+// cualquier CA. Un emisor reconocido en caaAuthorities genera un hallazgo
+// medium; uno desconocido, low.
+func (a *Analyzer) analyzeCAA(findings *SecurityFindings) {
+	policies := collectCAAPolicies(a.FilterArtifacts("dns"))
+	if len(policies) == 0 {
+		return
+	}
+
+	type violation struct {
+		domain, issuer, name string
+		authority            *caaAuthority
+	}
+	seen := make(map[string]struct{})
+	var violations []violation
+	for _, art := range a.FilterArtifacts("certificate") {
+		record, err := certs.Parse(art.Value)
+		if err != nil || record.Issuer == "" {
+			continue
+		}
+		for _, name := range record.AllNames() {
+			domain := caaRegistrableDomain(name)
+			policy, ok := policies[domain]
+			if !ok {
+				continue
+			}
+			authority, authorized := issuerAuthorized(record.Issuer, policy.Allowed)
+			if authorized {
+				continue
+			}
+			key := domain + "|" + record.Issuer
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			violations = append(violations, violation{domain: domain, issuer: record.Issuer, name: name, authority: authority})
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].domain != violations[j].domain {
+			return violations[i].domain < violations[j].domain
+		}
+		return violations[i].issuer < violations[j].issuer
+	})
+
+	for _, v := range violations {
+		policy := policies[v.domain]
+		allowed := make([]string, 0, len(policy.Allowed))
+		for ca := range policy.Allowed {
+			allowed = append(allowed, ca)
+		}
+		sort.Strings(allowed)
+		authorizedList := strings.Join(allowed, ", ")
+		if authorizedList == "" {
+			authorizedList = "none"
+		}
+
+		severity := "low"
+		issuerLabel := v.issuer
+		if v.authority != nil {
+			severity = "medium"
+			issuerLabel = v.authority.Name + " (" + v.issuer + ")"
+		}
+		evidence := []string{v.domain, "certificate name: " + v.name, "issuer: " + v.issuer}
+		for _, rec := range policy.Records {
+			evidence = append(evidence, "CAA "+rec)
+		}
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "CAA-001",
+			Category:    "misconfiguration",
+			Title:       "Certificate Issued by CA Not Authorized in CAA",
+			Description: "A certificate for " + v.name + " was issued by " + issuerLabel + ", which is not listed in the CAA records of " + v.domain + " (authorized: " + authorizedList + "). It may predate the CAA policy, come from a forgotten provider or indicate mis-issuance.",
+			Severity:    severity,
+			Evidence:    evidence,
+			Location:    v.domain,
+			Remediation: "Confirm the certificate is legitimate; revoke it if not, or add the CA to the CAA records of " + v.domain + " if it is an approved provider.",
+		})
+	}
+}
+
+// collectCAAPolicies agrupa por dominio registrable las CA autorizadas en los
+// registros CAA de los artefactos dns, tanto relaciones con tipo CAA como
+// respuestas JSON de dnsx con la clave "caa".
+func collectCAAPolicies(dnsArtifacts []artifacts.Artifact) map[string]*caaPolicy {
+	policies := make(map[string]*caaPolicy)
+	add := func(host, raw string) {
+		domain := caaRegistrableDomain(host)
+		if domain == "" {
+			return
+		}
+		tag, ca, ok := parseCAAValue(raw)
+		if !ok || (tag != "issue" && tag != "issuewild") {
+			return
+		}
+		policy := policies[domain]
+		if policy == nil {
+			policy = &caaPolicy{Allowed: make(map[string]struct{})}
+			policies[domain] = policy
+		}
+		if ca != "" {
+			policy.Allowed[ca] = struct{}{}
+		}
+		entry := tag + " " + strconv.Quote(ca)
+		for _, existing := range policy.Records {
+			if existing == entry {
+				return
+			}
+		}
+		policy.Records = append(policy.Records, entry)
+	}
+
+	for _, art := range dnsArtifacts {
+		if rel, ok := parseDNSRelation(art); ok && rel.Type == "CAA" {
+			add(rel.Host, rel.Value)
+			continue
+		}
+		raw := GetArtifactMetadataString(art, "raw")
+		if raw == "" {
+			raw = art.Value
+		}
+		host, values := parseDNSXCAA(raw)
+		for _, value := range values {
+			add(host, value)
+		}
+	}
+	for _, policy := range policies {
+		sort.Strings(policy.Records)
+	}
+	return policies
+}
+
+// parseDNSXCAA extrae host y registros CAA de una respuesta JSON de dnsx.
+// Los registros pueden venir como texto ("0 issue \"letsencrypt.org\"") o como
+// objetos con tag y value.
+func parseDNSXCAA(raw string) (string, []string) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "{") {
+		return "", nil
+	}
+	var payload struct {
+		Host string `json:"host"`
+		CAA  []any  `json:"caa"`
+	}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil || len(payload.CAA) == 0 {
+		return "", nil
+	}
+	var values []string
+	for _, item := range payload.CAA {
+		switch v := item.(type) {
+		case string:
+			values = append(values, v)
+		case map[string]any:
+			tag, _ := v["tag"].(string)
+			value, _ := v["value"].(string)
+			if tag != "" {
+				values = append(values, tag+" "+strconv.Quote(value))
+			}
+		}
+	}
+	return payload.Host, values
+}
+
+// parseCAAValue interpreta un registro CAA en formato de zona ("0 issue
+// \"ca.example; params\"", con o sin flags y comillas) y devuelve la etiqueta
+// y el dominio de la CA sin parámetros.
+func parseCAAValue(raw string) (tag, ca string, ok bool) {
+	fields := strings.Fields(strings.TrimSpace(raw))
+	if len(fields) > 0 {
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	tag = strings.ToLower(fields[0])
+	value := strings.Trim(strings.Join(fields[1:], " "), `"`)
+	if idx := strings.Index(value, ";"); idx >= 0 {
+		value = value[:idx]
+	}
+	return tag, strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "."), true
+}
+
+// issuerAuthorized indica si el emisor corresponde a alguna CA de allowed.
+// Devuelve también la CA reconocida en el emisor, si la hay. Si el emisor no
+// es de una CA conocida, basta con que nombre la etiqueta principal de algún
+// dominio autorizado ("digicert" para digicert.com).
+func issuerAuthorized(issuer string, allowed map[string]struct{}) (*caaAuthority, bool) {
+	authority := matchIssuerAuthority(issuer)
+	if authority != nil {
+		for _, domain := range authority.Domains {
+			if _, ok := allowed[domain]; ok {
+				return authority, true
+			}
+		}
+		return authority, false
+	}
+	normalized := normalizeIssuerName(issuer)
+	for domain := range allowed {
+		label := strings.SplitN(domain, ".", 2)[0]
+		if label := normalizeIssuerName(label); label != "" && strings.Contains(normalized, label) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// matchIssuerAuthority identifica la CA del emisor por su nombre o por el CN
+// exacto de una intermedia conocida.
+func matchIssuerAuthority(issuer string) *caaAuthority {
+	normalized := normalizeIssuerName(issuer)
+	commonName := normalizeIssuerName(issuerCommonName(issuer))
+	for i := range caaAuthorities {
+		authority := &caaAuthorities[i]
+		for _, name := range authority.Names {
+			if strings.Contains(normalized, name) {
+				return authority
+			}
+		}
+		for _, intermediate := range authority.Intermediates {
+			if commonName == intermediate {
+				return authority
+			}
+		}
+	}
+	return nil
+}
+
+// issuerCommonName devuelve el CN de un emisor con formato de DN ("C=US,
+// O=Let's Encrypt, CN=R3") o el emisor completo si no lo tiene.
+func issuerCommonName(issuer string) string {
+	for _, part := range strings.Split(issuer, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "CN") {
+			return value
+		}
+	}
+	return issuer
+}
+
+func normalizeIssuerName(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func caaRegistrableDomain(host string) string {
+	host = strings.TrimPrefix(normalizeHostname(host), "*.")
+	if host == "" {
+		return ""
+	}
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return registrable
+}
@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func caaArtifact(host, value string) artifacts.Artifact {
+	return artifacts.Artifact{
+		Type:     "dns",
+		Value:    host + " [CAA] " + value,
+		Metadata: map[string]any{"host": host, "type": "CAA", "value": value},
+	}
+}
+
+func certArtifact(value string) artifacts.Artifact {
+	return artifacts.Artifact{Type: "certificate", Value: value}
+}
+
+func TestAnalyzeCAAFlagsUnauthorizedIssuers(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		caaArtifact("example.com", `0 issue "letsencrypt.org"`),
+		caaArtifact("example.com", `0 iodef "mailto:security@example.com"`),
+		// Let's Encrypt firma como "R3": está autorizada aunque el emisor no la nombre.
+		certArtifact(`{"common_name":"www.example.com","issuer":"C=US, O=Let's Encrypt, CN=R3"}`),
+		certArtifact(`{"common_name":"api.example.com","issuer":"R11"}`),
+		certArtifact(`{"common_name":"shop.example.com","issuer":"C=US, O=DigiCert Inc, CN=DigiCert TLS RSA SHA256 2020 CA1"}`),
+		certArtifact(`{"common_name":"old.example.com","issuer":"CN=Internal Corp CA"}`),
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeCAA(findings)
+
+	if len(findings.Findings) != 2 {
+		t.Fatalf("expected 2 CAA findings, got %+v", findings.Findings)
+	}
+	digicert := findings.Findings[0]
+	if digicert.ID != "CAA-001" || digicert.Severity != "medium" || digicert.Location != "example.com" {
+		t.Fatalf("unexpected DigiCert finding: %+v", digicert)
+	}
+	wantEvidence := []string{
+		"example.com",
+		"certificate name: shop.example.com",
+		"issuer: C=US, O=DigiCert Inc, CN=DigiCert TLS RSA SHA256 2020 CA1",
+		`CAA issue "letsencrypt.org"`,
+	}
+	if diff := cmp.Diff(wantEvidence, digicert.Evidence); diff != "" {
+		t.Fatalf("unexpected evidence (-want +got):\n%s", diff)
+	}
+	if unknown := findings.Findings[1]; unknown.Severity != "low" || unknown.Evidence[2] != "issuer: CN=Internal Corp CA" {
+		t.Fatalf("expected the unknown issuer as low, got %+v", unknown)
+	}
+}
+
+func TestAnalyzeCAASkipsDomainsWithoutCAA(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		caaArtifact("example.com", `0 iodef "mailto:security@example.com"`),
+		{
+			Type:     "dns",
+			Value:    "other.org",
+			Metadata: map[string]any{"raw": `{"host":"other.org","caa":["0 issue \"digicert.com; cansignhttpexchanges=yes\""]}`},
+		},
+		certArtifact(`{"common_name":"www.example.com","issuer":"CN=Some Other CA"}`),
+		certArtifact(`{"dns_names":["other.org","*.other.org"],"issuer":"CN=GeoTrust TLS RSA CA G1"}`),
+		certArtifact(`{"common_name":"nocaa.net","issuer":"CN=Anything"}`),
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeCAA(findings)
+
+	if len(findings.Findings) != 0 {
+		t.Fatalf("expected no CAA findings, got %+v", findings.Findings)
+	}
+}
+
+func TestParseCAAValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		raw, tag, ca string
+	}{
+		{`0 issue "letsencrypt.org"`, "issue", "letsencrypt.org"},
+		{`128 issuewild "DigiCert.com; cansignhttpexchanges=yes"`, "issuewild", "digicert.com"},
+		{`issue pki.goog`, "issue", "pki.goog"},
+		{`0 issue ";"`, "issue", ""},
+	}
+	for _, tc := range cases {
+		tag, ca, ok := parseCAAValue(tc.raw)
+		if !ok || tag != tc.tag || ca != tc.ca {
+			t.Errorf("parseCAAValue(%q) = %q, %q, %v; want %q, %q", tc.raw, tag, ca, ok, tc.tag, tc.ca)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Detectores de tecnología cuyos resultados por artefacto se cachean.
+const (
+	techDetectorJavaScript = "javascript"
+	techDetectorCSS        = "css"
+	techDetectorCMS        = "cms"
+	techDetectorCDN        = "cdn"
+	techDetectorServer     = "server"
+)
+
+// techCacheVersion invalida las caches escritas con reglas de detección
+// anteriores. Debe incrementarse al cambiar cualquier *TechHits.
+const techCacheVersion = 1
+
+// techHit es una tecnología detectada en un único artefacto. Accumulate indica
+// si el artefacto se añade como evidencia cuando la tecnología ya se detectó.
+type techHit struct {
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	Confidence string `json:"confidence"`
+	Accumulate bool   `json:"accumulate,omitempty"`
+}
+
+// techCache guarda en disco las tecnologías detectadas por artefacto, con
+// clave el hash del detector y del valor analizado, para que las ejecuciones
+// siguientes sobre un manifiesto casi idéntico solo analicen lo nuevo.
+type techCache struct {
+	path    string
+	entries map[string][]techHit
+	used    map[string]struct{}
+	hits    int
+	misses  int
+}
+
+type techCacheFile struct {
+	Version int                  `json:"version"`
+	Entries map[string][]techHit `json:"entries"`
+}
+
+// loadTechCache abre la cache de path. Un fichero inexistente, ilegible o de
+// otra versión produce una cache vacía: la cache solo acelera, nunca es
+// necesaria para obtener el resultado.
+func loadTechCache(path string) *techCache {
+	cache := &techCache{
+		path:    path,
+		entries: make(map[string][]techHit),
+		used:    make(map[string]struct{}),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	var file techCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != techCacheVersion {
+		return cache
+	}
+	if file.Entries != nil {
+		cache.entries = file.Entries
+	}
+	return cache
+}
+
+// lookup devuelve las tecnologías cacheadas para el valor o las calcula con
+// detect y las guarda.
+func (c *techCache) lookup(detector, value string, detect func(string) []techHit) []techHit {
+	key := techCacheKey(detector, value)
+	c.used[key] = struct{}{}
+	if hits, ok := c.entries[key]; ok {
+		c.hits++
+		return hits
+	}
+	c.misses++
+	hits := detect(value)
+	c.entries[key] = hits
+	return hits
+}
+
+// save escribe en disco las entradas usadas en esta ejecución, descartando las
+// de artefactos que ya no aparecen para que la cache no crezca sin límite.
+func (c *techCache) save() error {
+	entries := make(map[string][]techHit, len(c.used))
+	for key := range c.used {
+		entries[key] = c.entries[key]
+	}
+	data, err := json.Marshal(techCacheFile{Version: techCacheVersion, Entries: entries})
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	return nil
+}
+
+func techCacheKey(detector, value string) string {
+	sum := sha256.Sum256([]byte(detector + "\x00" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// techHits aplica detect al valor, consultando la cache si está habilitada.
+func (a *Analyzer) techHits(detector, value string, detect func(string) []techHit) []techHit {
+	if a.cache == nil {
+		return detect(value)
+	}
+	return a.cache.lookup(detector, value, detect)
+}
+
+// detectTechnologyCached ejecuta la detección de tecnologías usando la cache
+// de options.CachePath y la persiste al terminar.
+func (a *Analyzer) detectTechnologyCached() (*TechStack, error) {
+	if a.options.CachePath == "" {
+		return a.detectTechnology(), nil
+	}
+	a.cache = loadTechCache(a.options.CachePath)
+	defer func() { a.cache = nil }()
+	stack := a.detectTechnology()
+	if err := a.cache.save(); err != nil {
+		return stack, fmt.Errorf("analysis cache: %w", err)
+	}
+	return stack, nil
+}
@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func techCacheFixture() []artifacts.Artifact {
+	return []artifacts.Artifact{
+		{Type: "resource", Subtype: "javascript", Value: "https://example.com/static/jquery-3.6.0.min.js", Active: true},
+		{Type: "resource", Subtype: "javascript", Value: "https://example.com/static/react.production.min.js"},
+		{Type: "resource", Subtype: "javascript", Value: "https://www.googletagmanager.com/gtag/js"},
+		{Type: "resource", Subtype: "css", Value: "https://cdn.jsdelivr.net/npm/bootstrap@5/dist/css/bootstrap.min.css"},
+		{Type: "route", Value: "https://example.com/wp-content/themes/site/style.css"},
+		{Type: "route", Value: "https://d111111abcdef8.cloudfront.net/app.js"},
+		{Type: "route", Value: "https://example.com/login", Active: true, Metadata: map[string]any{"server": "nginx/1.25.3"}},
+	}
+}
+
+// sortTechnologies compara las listas del stack sin depender del orden del mapa.
+var sortTechnologies = cmpopts.SortSlices(func(a, b Technology) bool { return a.Name < b.Name })
+
+func techOnlyOptions(cachePath string) AnalysisOptions {
+	return AnalysisOptions{EnableTechDetection: true, CachePath: cachePath}
+}
+
+func TestTechCacheHitsSkipRecomputation(t *testing.T) {
+	t.Parallel()
+
+	cachePath := filepath.Join(t.TempDir(), "reports", "analysis-cache.json")
+	arts := techCacheFixture()
+
+	uncached, err := NewAnalyzer(arts, artifacts.HeaderV2{}, techOnlyOptions("")).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze without cache: %v", err)
+	}
+
+	first := NewAnalyzer(arts, artifacts.HeaderV2{}, techOnlyOptions(cachePath))
+	first.cache = loadTechCache(cachePath)
+	firstStack := first.detectTechnology()
+	if first.cache.hits != 0 || first.cache.misses == 0 {
+		t.Fatalf("expected only misses on a cold cache, got hits=%d misses=%d", first.cache.hits, first.cache.misses)
+	}
+	if err := first.cache.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if diff := cmp.Diff(uncached.TechStack, firstStack, sortTechnologies); diff != "" {
+		t.Fatalf("cold cache changed the tech stack (-want +got):\n%s", diff)
+	}
+
+	second := NewAnalyzer(arts, artifacts.HeaderV2{}, techOnlyOptions(cachePath))
+	second.cache = loadTechCache(cachePath)
+	calls := 0
+	countingDetect := func(value string) []techHit {
+		calls++
+		return javaScriptTechHits(value)
+	}
+	for _, art := range second.FilterBySubtype("resource", "javascript") {
+		second.techHits(techDetectorJavaScript, art.Value, countingDetect)
+	}
+	if calls != 0 {
+		t.Fatalf("expected cached artifacts to skip detection, got %d calls", calls)
+	}
+	secondStack := second.detectTechnology()
+	if second.cache.misses != 0 {
+		t.Fatalf("expected no misses on a warm cache, got %d", second.cache.misses)
+	}
+	if diff := cmp.Diff(uncached.TechStack, secondStack, sortTechnologies); diff != "" {
+		t.Fatalf("warm cache changed the tech stack (-want +got):\n%s", diff)
+	}
+}
+
+func TestTechCacheMissesComputeFreshResults(t *testing.T) {
+	t.Parallel()
+
+	cachePath := filepath.Join(t.TempDir(), "analysis-cache.json")
+	arts := techCacheFixture()
+	if _, err := NewAnalyzer(arts, artifacts.HeaderV2{}, techOnlyOptions(cachePath)).Analyze(); err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	grown := append(techCacheFixture(),
+		artifacts.Artifact{Type: "resource", Subtype: "javascript", Value: "https://example.com/static/vue.global.js"},
+	)
+	analyzer := NewAnalyzer(grown, artifacts.HeaderV2{}, techOnlyOptions(cachePath))
+	analyzer.cache = loadTechCache(cachePath)
+	got := analyzer.detectTechnology()
+	if analyzer.cache.misses != 1 {
+		t.Fatalf("expected exactly 1 miss for the new artifact, got %d (hits=%d)", analyzer.cache.misses, analyzer.cache.hits)
+	}
+
+	want, err := NewAnalyzer(grown, artifacts.HeaderV2{}, techOnlyOptions("")).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze without cache: %v", err)
+	}
+	if diff := cmp.Diff(want.TechStack, got, sortTechnologies); diff != "" {
+		t.Fatalf("cached analysis differs from a fresh one (-want +got):\n%s", diff)
+	}
+}
+
+func TestTechCacheIgnoresCorruptFile(t *testing.T) {
+	t.Parallel()
+
+	cachePath := filepath.Join(t.TempDir(), "analysis-cache.json")
+	if err := os.WriteFile(cachePath, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	report, err := NewAnalyzer(techCacheFixture(), artifacts.HeaderV2{}, techOnlyOptions(cachePath)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if len(report.TechStack.JavaScript) == 0 {
+		t.Fatalf("expected tech detection to run with a corrupt cache")
+	}
+	if cache := loadTechCache(cachePath); len(cache.entries) == 0 {
+		t.Fatalf("expected the corrupt cache to be rewritten")
+	}
+}
@@ -0,0 +1,246 @@
+package analysis
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// dmarcPrefix es la etiqueta bajo la que se publica el registro DMARC de un
+// dominio (RFC 7489).
+const dmarcPrefix = "_dmarc."
+
+// analyzeEmailSecurity resume los registros SPF y DMARC del target a partir de
+// los TXT de los artefactos dns: los de <target> para SPF y los de
+// _dmarc.<target> para DMARC. Devuelve nil si no hay registros TXT de
+// ninguno de los dos nombres: sin resolución no se puede afirmar que falten.
+func (a *Analyzer) analyzeEmailSecurity() *EmailSecurity {
+	domain := normalizeHostname(a.header.Target)
+	if domain == "" {
+		return nil
+	}
+	txt := collectTXTRecords(a.FilterArtifacts("dns"))
+	domainTXT, dmarcTXT := txt[domain], txt[dmarcPrefix+domain]
+	if len(domainTXT) == 0 && len(dmarcTXT) == 0 {
+		return nil
+	}
+
+	posture := &EmailSecurity{Domain: domain}
+	for _, record := range domainTXT {
+		if isSPFRecord(record) {
+			posture.SPF = append(posture.SPF, record)
+		}
+	}
+	if len(posture.SPF) == 1 {
+		posture.SPFAll = spfAllQualifier(posture.SPF[0])
+	}
+	for _, record := range dmarcTXT {
+		if isDMARCRecord(record) {
+			posture.DMARC = append(posture.DMARC, record)
+		}
+	}
+	if len(posture.DMARC) > 0 {
+		posture.DMARCPolicy = dmarcPolicy(posture.DMARC[0])
+	}
+	return posture
+}
+
+// analyzeEmailFindings genera los hallazgos de la postura SPF/DMARC del
+// target: SPF ausente (medium), varios registros SPF (medium, el receptor
+// los trata como error permanente), SPF con +all (high), DMARC ausente
+// (medium) y DMARC con p=none (low).
+func (a *Analyzer) analyzeEmailFindings(findings *SecurityFindings) {
+	posture := a.analyzeEmailSecurity()
+	if posture == nil {
+		return
+	}
+	domain := posture.Domain
+	dmarcHost := dmarcPrefix + domain
+
+	switch {
+	case len(posture.SPF) == 0:
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "EML-001",
+			Category:    "misconfiguration",
+			Title:       "Missing SPF Record",
+			Description: "None of the TXT records of " + domain + " is an SPF policy (v=spf1); receivers cannot tell which servers may send mail for the domain, which eases spoofing.",
+			Severity:    "medium",
+			Evidence:    []string{domain},
+			Location:    domain,
+			Remediation: "Publish a single TXT record on " + domain + " listing the authorized senders and ending in -all or ~all.",
+		})
+	case len(posture.SPF) > 1:
+		evidence := []string{domain}
+		for _, record := range posture.SPF {
+			evidence = append(evidence, "TXT "+record)
+		}
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "EML-002",
+			Category:    "misconfiguration",
+			Title:       "Multiple SPF Records",
+			Description: domain + " publishes several SPF records; RFC 7208 treats this as a permanent error, so receivers ignore the SPF policy altogether.",
+			Severity:    "medium",
+			Evidence:    evidence,
+			Location:    domain,
+			Remediation: "Merge the SPF records of " + domain + " into a single v=spf1 TXT record.",
+		})
+	case posture.SPFAll == "+all":
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "EML-003",
+			Category:    "misconfiguration",
+			Title:       "SPF Allows Any Sender",
+			Description: "The SPF record of " + domain + " ends in +all, which authorizes every server on the Internet to send mail for the domain.",
+			Severity:    "high",
+			Evidence:    []string{domain, "TXT " + posture.SPF[0]},
+			Location:    domain,
+			Remediation: "Replace +all with -all (or ~all while rolling out) in the SPF record of " + domain + ".",
+		})
+	}
+
+	switch {
+	case len(posture.DMARC) == 0:
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "EML-004",
+			Category:    "misconfiguration",
+			Title:       "Missing DMARC Record",
+			Description: "No DMARC policy (v=DMARC1) was found at " + dmarcHost + "; receivers get no instructions for mail that fails SPF/DKIM and the owner gets no reports.",
+			Severity:    "medium",
+			Evidence:    []string{dmarcHost},
+			Location:    domain,
+			Remediation: "Publish a TXT record at " + dmarcHost + ", starting with p=none and a rua address, then move to quarantine or reject.",
+		})
+	case posture.DMARCPolicy == "none":
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "EML-005",
+			Category:    "misconfiguration",
+			Title:       "DMARC Policy Set to None",
+			Description: "The DMARC record at " + dmarcHost + " uses p=none: failures are only reported, and spoofed mail is still delivered.",
+			Severity:    "low",
+			Evidence:    []string{dmarcHost, "TXT " + posture.DMARC[0]},
+			Location:    domain,
+			Remediation: "Once the reports show legitimate mail passing, raise the policy of " + dmarcHost + " to p=quarantine or p=reject.",
+		})
+	}
+}
+
+// collectTXTRecords agrupa por host los registros TXT de los artefactos dns,
+// tanto relaciones con tipo TXT como respuestas JSON de dnsx con la clave
+// "txt". Los valores conservan mayúsculas; se eliminan las comillas y se
+// unen las cadenas de un TXT partido.
+func collectTXTRecords(dnsArtifacts []artifacts.Artifact) map[string][]string {
+	records := make(map[string][]string)
+	seen := make(map[string]struct{})
+	add := func(host, value string) {
+		host = normalizeHostname(host)
+		value = unquoteTXT(value)
+		if host == "" || value == "" {
+			return
+		}
+		key := host + "\x00" + value
+		if _, dup := seen[key]; dup {
+			return
+		}
+		seen[key] = struct{}{}
+		records[host] = append(records[host], value)
+	}
+
+	for _, art := range dnsArtifacts {
+		if rel, ok := parseTXTRelation(art); ok {
+			add(rel.Host, rel.Value)
+			continue
+		}
+		raw := GetArtifactMetadataString(art, "raw")
+		if raw == "" {
+			raw = art.Value
+		}
+		host, values := parseDNSXTXT(raw)
+		for _, value := range values {
+			add(host, value)
+		}
+	}
+	for host := range records {
+		sort.Strings(records[host])
+	}
+	return records
+}
+
+// parseTXTRelation extrae una relación TXT sin normalizar su valor (a
+// diferencia de parseDNSRelation, que lo trata como un nombre de host).
+func parseTXTRelation(art artifacts.Artifact) (dnsRelation, bool) {
+	var rel dnsRelation
+	if err := json.Unmarshal([]byte(art.Value), &rel); err != nil || rel.Type == "" {
+		rel = dnsRelation{
+			Host:  GetArtifactMetadataString(art, "host"),
+			Type:  GetArtifactMetadataString(art, "type"),
+			Value: GetArtifactMetadataString(art, "value"),
+		}
+	}
+	if !strings.EqualFold(strings.TrimSpace(rel.Type), "TXT") {
+		return dnsRelation{}, false
+	}
+	return rel, strings.TrimSpace(rel.Host) != "" && strings.TrimSpace(rel.Value) != ""
+}
+
+// parseDNSXTXT extrae host y registros TXT de una respuesta JSON de dnsx.
+func parseDNSXTXT(raw string) (string, []string) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "{") {
+		return "", nil
+	}
+	var payload struct {
+		Host string   `json:"host"`
+		TXT  []string `json:"txt"`
+	}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return "", nil
+	}
+	return payload.Host, payload.TXT
+}
+
+// unquoteTXT elimina las comillas de un TXT en formato de zona y une sus
+// cadenas ("v=spf1 " "-all" -> v=spf1 -all).
+func unquoteTXT(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && len(value) >= 2 {
+		parts := strings.Split(value[1:len(value)-1], `" "`)
+		value = strings.Join(parts, "")
+	}
+	return strings.TrimSpace(value)
+}
+
+func isSPFRecord(record string) bool {
+	lower := strings.ToLower(record)
+	return lower == "v=spf1" || strings.HasPrefix(lower, "v=spf1 ")
+}
+
+func isDMARCRecord(record string) bool {
+	tag, _, _ := strings.Cut(record, ";")
+	return strings.EqualFold(strings.ReplaceAll(tag, " ", ""), "v=DMARC1")
+}
+
+// spfAllQualifier devuelve el mecanismo all del registro con su calificador
+// explícito ("+all", "-all", "~all", "?all") o "" si no lo tiene.
+func spfAllQualifier(record string) string {
+	for _, term := range strings.Fields(strings.ToLower(record)) {
+		switch term {
+		case "all", "+all":
+			return "+all"
+		case "-all", "~all", "?all":
+			return term
+		}
+	}
+	return ""
+}
+
+// dmarcPolicy devuelve la etiqueta p= del registro DMARC en minúsculas.
+func dmarcPolicy(record string) string {
+	for _, tag := range strings.Split(record, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(tag), "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "p") {
+			return strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
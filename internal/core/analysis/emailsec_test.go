@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func txtArtifact(host, value string) artifacts.Artifact {
+	return artifacts.Artifact{
+		Type:     "dns",
+		Value:    host + " [TXT] " + value,
+		Metadata: map[string]any{"host": host, "type": "TXT", "value": value},
+	}
+}
+
+func emailFindingIDs(t *testing.T, arts []artifacts.Artifact) map[string]Finding {
+	t.Helper()
+	findings := &SecurityFindings{}
+	NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeEmailFindings(findings)
+	byID := make(map[string]Finding, len(findings.Findings))
+	for _, f := range findings.Findings {
+		byID[f.ID] = f
+	}
+	return byID
+}
+
+func TestAnalyzeEmailSecurityMatchesDMARCToTheTargetHost(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		txtArtifact("example.com", `"v=spf1 include:_spf.google.com " "~all"`),
+		txtArtifact("example.com", "google-site-verification=abc"),
+		// Un DMARC publicado en el propio dominio no cuenta: va en _dmarc.
+		txtArtifact("example.com", "v=DMARC1; p=reject"),
+		// El _dmarc de un subdominio tampoco es el del target.
+		txtArtifact("_dmarc.mail.example.com", "v=DMARC1; p=reject"),
+		{
+			Type:     "dns",
+			Value:    `{"host":"_dmarc.example.com","txt":["v=DMARC1; p=None; rua=mailto:dmarc@example.com"]}`,
+			Metadata: map[string]any{"raw": `{"host":"_dmarc.example.com","txt":["v=DMARC1; p=None; rua=mailto:dmarc@example.com"]}`},
+		},
+	}
+	analyzer := NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions())
+
+	want := &EmailSecurity{
+		Domain:      "example.com",
+		SPF:         []string{"v=spf1 include:_spf.google.com ~all"},
+		SPFAll:      "~all",
+		DMARC:       []string{"v=DMARC1; p=None; rua=mailto:dmarc@example.com"},
+		DMARCPolicy: "none",
+	}
+	if diff := cmp.Diff(want, analyzer.analyzeEmailSecurity()); diff != "" {
+		t.Fatalf("unexpected email posture (-want +got):\n%s", diff)
+	}
+
+	findings := emailFindingIDs(t, arts)
+	if len(findings) != 1 || findings["EML-005"].Severity != "low" {
+		t.Fatalf("expected only the p=none finding, got %+v", findings)
+	}
+}
+
+func TestAnalyzeEmailFindingsFlagsSPFAndDMARCProblems(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		arts []artifacts.Artifact
+		want map[string]string // ID -> severidad
+	}{
+		{
+			name: "missing both",
+			arts: []artifacts.Artifact{txtArtifact("example.com", "MS=ms12345")},
+			want: map[string]string{"EML-001": "medium", "EML-004": "medium"},
+		},
+		{
+			name: "plus all",
+			arts: []artifacts.Artifact{
+				txtArtifact("example.com", "v=spf1 ip4:192.0.2.0/24 +all"),
+				txtArtifact("_dmarc.example.com", "v=DMARC1; p=reject"),
+			},
+			want: map[string]string{"EML-003": "high"},
+		},
+		{
+			name: "multiple spf",
+			arts: []artifacts.Artifact{
+				txtArtifact("example.com", "v=spf1 include:_spf.google.com -all"),
+				txtArtifact("example.com", "v=spf1 include:spf.protection.outlook.com -all"),
+				txtArtifact("_dmarc.example.com", "v=DMARC1; p=quarantine"),
+			},
+			want: map[string]string{"EML-002": "medium"},
+		},
+		{
+			name: "healthy",
+			arts: []artifacts.Artifact{
+				txtArtifact("example.com", "v=spf1 -all"),
+				txtArtifact("_dmarc.example.com", "v=DMARC1; p=reject"),
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "no txt data",
+			arts: []artifacts.Artifact{txtArtifact("other.org", "v=spf1 +all")},
+			want: map[string]string{},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := make(map[string]string)
+			for id, f := range emailFindingIDs(t, tc.arts) {
+				got[id] = f.Severity
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeInfrastructureIncludesEmailSecurity(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{txtArtifact("example.com", "v=spf1 -all")}
+	infra := NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeInfrastructure()
+	if infra.EmailSecurity == nil || infra.EmailSecurity.SPFAll != "-all" {
+		t.Fatalf("expected the SPF posture in the infrastructure, got %+v", infra.EmailSecurity)
+	}
+
+	if infra := NewAnalyzer(nil, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeInfrastructure(); infra.EmailSecurity != nil {
+		t.Fatalf("expected no email posture without TXT records, got %+v", infra.EmailSecurity)
+	}
+}
@@ -24,6 +24,12 @@ func (a *Analyzer) analyzeInfrastructure() *Infrastructure {
 	// Inferir hosting provider
 	a.inferHostingProvider(infra)
 
+	// Resumir protocolos HTTP
+	a.analyzeProtocols(infra)
+
+	// Postura SPF/DMARC del target
+	infra.EmailSecurity = a.analyzeEmailSecurity()
+
 	return infra
 }
 
@@ -111,6 +117,24 @@ func (a *Analyzer) analyzeDNS(infra *Infrastructure) {
 	}
 }
 
+// analyzeProtocols cuenta los orígenes por protocolo negociado (metadato
+// "alpn" de los dominios) y los que anuncian HTTP/3.
+func (a *Analyzer) analyzeProtocols(infra *Infrastructure) {
+	for _, art := range a.FilterArtifacts("domain") {
+		alpn := strings.ToLower(strings.TrimSpace(GetArtifactMetadataString(art, "alpn")))
+		if alpn == "" {
+			continue
+		}
+		if infra.Protocols == nil {
+			infra.Protocols = make(map[string]int)
+		}
+		infra.Protocols[alpn]++
+		if h3, _ := art.Metadata["h3"].(bool); h3 {
+			infra.HTTP3++
+		}
+	}
+}
+
 // analyzeRDAP extrae información RDAP.
 func (a *Analyzer) analyzeRDAP(infra *Infrastructure) {
 	rdapArtifacts := a.FilterArtifacts("rdap")
@@ -0,0 +1,40 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestAnalyzeProtocolsSummarizesALPN(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "domain", Value: "example.com", Active: true, Metadata: map[string]any{"alpn": "h2", "h3": true}},
+		{Type: "domain", Value: "api.example.com", Active: true, Metadata: map[string]any{"alpn": "h2", "h3": false}},
+		{Type: "domain", Value: "legacy.example.com", Active: true, Metadata: map[string]any{"alpn": "http/1.1"}},
+		{Type: "domain", Value: "www.example.com", Active: true},
+		{Type: "route", Value: "https://example.com/login", Active: true},
+	}
+	report, err := NewAnalyzer(arts, artifacts.HeaderV2{}, AnalysisOptions{EnableInfrastructure: true}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if diff := cmp.Diff(map[string]int{"h2": 2, "http/1.1": 1}, report.Infrastructure.Protocols); diff != "" {
+		t.Fatalf("unexpected protocol counts (-want +got):\n%s", diff)
+	}
+	if report.Infrastructure.HTTP3 != 1 {
+		t.Fatalf("expected 1 origin advertising HTTP/3, got %d", report.Infrastructure.HTTP3)
+	}
+
+	md := GenerateMarkdownReport(report)
+	for _, want := range []string{"### HTTP Protocols", "- **h2:** 2 origins\n- **http/1.1:** 1 origins", "HTTP/3 advertised (Alt-Svc):** 1 origins"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -24,6 +25,10 @@ func GenerateMarkdownReport(report *Report) string {
 		md.WriteString(fmt.Sprintf("**Scan Duration:** %s\n\n", report.Duration))
 	}
 
+	if report.Since != "" {
+		md.WriteString(fmt.Sprintf("**Window:** only artifacts discovered since %s\n\n", report.Since))
+	}
+
 	md.WriteString("---\n\n")
 
 	// Executive Summary
@@ -102,6 +107,44 @@ func writeSummary(md *strings.Builder, summary *Summary) {
 		}
 		md.WriteString("\n")
 	}
+
+	if len(summary.ToolBreakdown) > 0 {
+		md.WriteString("### Artifacts by Tool and Type\n\n")
+		md.WriteString("| Tool | Type | Count |\n")
+		md.WriteString("|------|------|-------|\n")
+		for _, row := range SortedToolBreakdown(summary.ToolBreakdown) {
+			md.WriteString(fmt.Sprintf("| %s | %s | %d |\n", row.Tool, row.Type, row.Count))
+		}
+		md.WriteString("\n")
+	}
+}
+
+// ToolTypeCount es una fila del desglose de artefactos por herramienta y tipo.
+type ToolTypeCount struct {
+	Tool  string
+	Type  string
+	Count int
+}
+
+// SortedToolBreakdown aplana el desglose herramienta → tipo → cantidad en filas
+// ordenadas por herramienta y, dentro de cada una, por cantidad descendente.
+func SortedToolBreakdown(breakdown map[string]map[string]int) []ToolTypeCount {
+	var rows []ToolTypeCount
+	for tool, types := range breakdown {
+		for typ, count := range types {
+			rows = append(rows, ToolTypeCount{Tool: tool, Type: typ, Count: count})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Tool != rows[j].Tool {
+			return rows[i].Tool < rows[j].Tool
+		}
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Type < rows[j].Type
+	})
+	return rows
 }
 
 func writeInsights(md *strings.Builder, insights []Insight) {
@@ -378,6 +421,17 @@ func writeInfrastructure(md *strings.Builder, infra *Infrastructure) {
 		md.WriteString("\n")
 	}
 
+	// Email Security
+	if email := infra.EmailSecurity; email != nil {
+		md.WriteString("### Email Security\n\n")
+		md.WriteString(fmt.Sprintf("- **SPF:** %s\n", markdownRecords(email.SPF)))
+		md.WriteString(fmt.Sprintf("- **DMARC (_dmarc.%s):** %s\n", email.Domain, markdownRecords(email.DMARC)))
+		if email.DMARCPolicy != "" {
+			md.WriteString(fmt.Sprintf("- **DMARC policy:** %s\n", email.DMARCPolicy))
+		}
+		md.WriteString("\n")
+	}
+
 	// DNS
 	if len(infra.Nameservers) > 0 {
 		md.WriteString("### DNS Configuration\n\n")
@@ -397,6 +451,18 @@ func writeInfrastructure(md *strings.Builder, infra *Infrastructure) {
 		md.WriteString("\n")
 	}
 
+	// HTTP Protocols
+	if len(infra.Protocols) > 0 {
+		md.WriteString("### HTTP Protocols\n\n")
+		for _, proto := range sortedProtocols(infra.Protocols) {
+			md.WriteString(fmt.Sprintf("- **%s:** %d origins\n", proto, infra.Protocols[proto]))
+		}
+		if infra.HTTP3 > 0 {
+			md.WriteString(fmt.Sprintf("- **HTTP/3 advertised (Alt-Svc):** %d origins\n", infra.HTTP3))
+		}
+		md.WriteString("\n")
+	}
+
 	// DNS Records
 	if len(infra.DNSRecords) > 0 {
 		md.WriteString("**DNS Records:**\n\n")
@@ -413,6 +479,28 @@ func writeInfrastructure(md *strings.Builder, infra *Infrastructure) {
 	}
 }
 
+// sortedProtocols ordena los protocolos de más a menos moderno (h2 antes que
+// http/1.1) y el resto alfabéticamente.
+func sortedProtocols(protocols map[string]int) []string {
+	rank := map[string]int{"h3": 0, "h2": 1, "http/1.1": 2}
+	list := make([]string, 0, len(protocols))
+	for proto := range protocols {
+		list = append(list, proto)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		ri, okI := rank[list[i]]
+		rj, okJ := rank[list[j]]
+		switch {
+		case okI && okJ:
+			return ri < rj
+		case okI != okJ:
+			return okI
+		}
+		return list[i] < list[j]
+	})
+	return list
+}
+
 func writeAssetInventory(md *strings.Builder, assets *AssetInventory) {
 	md.WriteString("### Domains & Subdomains\n\n")
 	md.WriteString(fmt.Sprintf("- **Total Domains:** %d\n", assets.TotalDomains))
@@ -567,3 +655,15 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// markdownRecords muestra registros DNS como código, o "not found".
+func markdownRecords(records []string) string {
+	if len(records) == 0 {
+		return "_not found_"
+	}
+	quoted := make([]string, 0, len(records))
+	for _, record := range records {
+		quoted = append(quoted, "`"+record+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,49 @@
+package analysis
+
+// analyzeScannerFindings convierte en hallazgos los resultados importados de
+// escáneres externos (artefactos finding registrados por el handler finding:,
+// p. ej. Nuclei). La regla (template-id) es el ID del hallazgo y matched_at su
+// ubicación; los resultados repetidos de la misma regla y ubicación se
+// agrupan.
+func (a *Analyzer) analyzeScannerFindings(findings *SecurityFindings) {
+	seen := make(map[string]struct{})
+	for _, art := range a.FilterArtifacts("finding") {
+		if art.Subtype == "gf" || art.Subtype == "secret" {
+			continue
+		}
+		rule := GetArtifactMetadataString(art, "rule")
+		location := GetArtifactMetadataString(art, "matched_at")
+		if rule == "" || location == "" {
+			continue
+		}
+		key := rule + "\x00" + location
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		title := GetArtifactMetadataString(art, "name")
+		if title == "" {
+			title = rule
+		}
+		description := GetArtifactMetadataString(art, "description")
+		if description == "" {
+			description = "Reported by " + art.Subtype + " template " + rule + " at " + location + "."
+		}
+		evidence := []string{location}
+		if matcher := GetArtifactMetadataString(art, "matcher"); matcher != "" {
+			evidence = append(evidence, "matcher: "+matcher)
+		}
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          rule,
+			Category:    "vulnerability",
+			Title:       title,
+			Description: description,
+			Severity:    GetArtifactMetadataString(art, "severity"),
+			Evidence:    evidence,
+			Location:    location,
+			CWE:         GetArtifactMetadataString(art, "cwe"),
+			Remediation: GetArtifactMetadataString(art, "remediation"),
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestAnalyzeScannerFindingsMapsNucleiResults(t *testing.T) {
+	t.Parallel()
+
+	gitConfig := artifacts.Artifact{
+		Type:    "finding",
+		Subtype: "nuclei",
+		Types:   []string{"git-config"},
+		Value:   "git-config https://example.com/.git/config",
+		Active:  true,
+		Metadata: map[string]any{
+			"rule":        "git-config",
+			"name":        "Git Config File",
+			"severity":    "medium",
+			"matched_at":  "https://example.com/.git/config",
+			"description": "Exposed .git/config",
+			"cwe":         "CWE-200",
+		},
+	}
+	passiveCopy := gitConfig
+	passiveCopy.Active = false
+	arts := []artifacts.Artifact{
+		gitConfig,
+		passiveCopy,
+		{
+			Type:     "finding",
+			Subtype:  "nuclei",
+			Value:    "tech-detect:nginx example.com:443",
+			Metadata: map[string]any{"rule": "tech-detect", "matcher": "nginx", "severity": "low", "matched_at": "example.com:443"},
+		},
+		// Los hallazgos de gf tienen su propio análisis.
+		{Type: "finding", Subtype: "gf", Value: "app.js -> token", Metadata: map[string]any{"rule": "token", "matched_at": "app.js"}},
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeScannerFindings(findings)
+
+	want := []Finding{
+		{
+			ID:          "git-config",
+			Category:    "vulnerability",
+			Title:       "Git Config File",
+			Description: "Exposed .git/config",
+			Severity:    "medium",
+			Evidence:    []string{"https://example.com/.git/config"},
+			Location:    "https://example.com/.git/config",
+			CWE:         "CWE-200",
+		},
+		{
+			ID:          "tech-detect",
+			Category:    "vulnerability",
+			Title:       "tech-detect",
+			Description: "Reported by nuclei template tech-detect at example.com:443.",
+			Severity:    "low",
+			Evidence:    []string{"example.com:443", "matcher: nginx"},
+			Location:    "example.com:443",
+		},
+	}
+	if diff := cmp.Diff(want, findings.Findings); diff != "" {
+		t.Fatalf("unexpected findings (-want +got):\n%s", diff)
+	}
+}
@@ -2,7 +2,11 @@ package analysis
 
 import (
 	"encoding/json"
+	"net/url"
+	"sort"
 	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
 )
 
 // analyzeSecurityFindings analiza hallazgos de seguridad.
@@ -18,6 +22,24 @@ func (a *Analyzer) analyzeSecurityFindings() *SecurityFindings {
 	// Generar hallazgos generales
 	a.generateSecurityFindings(findings)
 
+	// Detectar contenido mixto (páginas HTTPS con subrecursos HTTP)
+	a.analyzeMixedContent(findings)
+
+	// Cabeceras de seguridad ausentes en las rutas sondeadas por httpx
+	a.analyzeSecurityHeaders(findings)
+
+	// Candidatos a subdomain takeover (CNAME hacia servicios reclamables)
+	a.analyzeTakeover(findings)
+
+	// Certificados emitidos por CA no autorizadas en los registros CAA
+	a.analyzeCAA(findings)
+
+	// Postura SPF/DMARC del target (registros TXT)
+	a.analyzeEmailFindings(findings)
+
+	// Resultados importados de escáneres externos (Nuclei)
+	a.analyzeScannerFindings(findings)
+
 	// Contar por severidad
 	for _, f := range findings.Findings {
 		switch f.Severity {
@@ -264,3 +286,177 @@ func (a *Analyzer) generateSecurityFindings(findings *SecurityFindings) {
 		}
 	}
 }
+
+// analyzeMixedContent correlaciona las páginas HTML servidas por HTTPS con los
+// subrecursos (js e imágenes) que el mismo host sirve por HTTP. El manifiesto
+// no registra qué página referencia cada recurso, así que la correlación se
+// hace por host: un recurso HTTP de otro host (por ejemplo un CDN) no se
+// atribuye a ninguna página. Solo cuentan los subrecursos activos que
+// respondieron; las rutas, y en particular las URLs HTTP históricas de
+// wayback/gau, no indican que ninguna página las cargue. Se genera un hallazgo
+// por host y no se hace nada si no hay artefactos HTML.
+func (a *Analyzer) analyzeMixedContent(findings *SecurityFindings) {
+	pages := a.FilterBySubtype("resource", "html")
+	if len(pages) == 0 {
+		return
+	}
+
+	httpsPages := make(map[string][]string)
+	for _, page := range pages {
+		if scheme, host, rawURL := splitArtifactURL(page); scheme == "https" && host != "" {
+			httpsPages[host] = append(httpsPages[host], rawURL)
+		}
+	}
+	if len(httpsPages) == 0 {
+		return
+	}
+
+	var subresources []artifacts.Artifact
+	subresources = append(subresources, a.FilterBySubtype("resource", "javascript")...)
+	subresources = append(subresources, a.FilterBySubtype("resource", "image")...)
+
+	insecure := make(map[string][]string)
+	for _, res := range subresources {
+		if !res.Active || !res.Up {
+			continue
+		}
+		scheme, host, rawURL := splitArtifactURL(res)
+		if scheme != "http" {
+			continue
+		}
+		if _, ok := httpsPages[host]; !ok {
+			continue
+		}
+		insecure[host] = append(insecure[host], rawURL)
+	}
+
+	hosts := make([]string, 0, len(insecure))
+	for host := range insecure {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		pageURLs := uniqueSorted(httpsPages[host])
+		evidence := append(pageURLs, uniqueSorted(insecure[host])...)
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "MIX-001",
+			Category:    "misconfiguration",
+			Title:       "Mixed Content",
+			Description: "HTTPS pages on " + host + " are served alongside subresources loaded over plain HTTP from the same host, which can be intercepted or modified in transit.",
+			Severity:    "medium",
+			Evidence:    evidence,
+			Location:    pageURLs[0],
+			CWE:         "CWE-319",
+			Remediation: "Serve every subresource over HTTPS (or use protocol-relative URLs) and consider a Content-Security-Policy with upgrade-insecure-requests.",
+		})
+	}
+}
+
+// analyzeSecurityHeaders genera hallazgos por las cabeceras de seguridad
+// ausentes según la metadata "security_headers" de las rutas activas: HSTS en
+// endpoints HTTPS y Content-Security-Policy en cualquiera. Las rutas sin esa
+// metadata no se inspeccionaron y no cuentan como ausentes.
+func (a *Analyzer) analyzeSecurityHeaders(findings *SecurityFindings) {
+	var missingHSTS, missingCSP []string
+	for _, route := range a.FilterArtifacts("route") {
+		if !route.Active {
+			continue
+		}
+		headers, ok := routeSecurityHeaders(route)
+		if !ok {
+			continue
+		}
+		scheme, _, rawURL := splitArtifactURL(route)
+		if scheme == "https" && !headers["strict-transport-security"] {
+			missingHSTS = append(missingHSTS, rawURL)
+		}
+		if !headers["content-security-policy"] {
+			missingCSP = append(missingCSP, rawURL)
+		}
+	}
+
+	if len(missingHSTS) > 0 {
+		evidence := uniqueSorted(missingHSTS)
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "HDR-001",
+			Category:    "misconfiguration",
+			Title:       "Missing HSTS Header",
+			Description: "HTTPS endpoints do not send Strict-Transport-Security, so browsers may be downgraded to plain HTTP on first visit.",
+			Severity:    "low",
+			Evidence:    evidence,
+			Location:    evidence[0],
+			CWE:         "CWE-319",
+			Remediation: "Send Strict-Transport-Security with a long max-age (and includeSubDomains where possible) on every HTTPS response.",
+		})
+	}
+	if len(missingCSP) > 0 {
+		evidence := uniqueSorted(missingCSP)
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "HDR-002",
+			Category:    "misconfiguration",
+			Title:       "Missing Content-Security-Policy Header",
+			Description: "Responses are served without a Content-Security-Policy, removing a key mitigation against cross-site scripting and content injection.",
+			Severity:    "medium",
+			Evidence:    evidence,
+			Location:    evidence[0],
+			CWE:         "CWE-693",
+			Remediation: "Define a restrictive Content-Security-Policy (default-src 'self' and explicit sources for scripts and styles).",
+		})
+	}
+}
+
+// routeSecurityHeaders devuelve la presencia de cada cabecera de seguridad de
+// la metadata de la ruta, tanto recién construida (map[string]bool) como leída
+// del manifiesto (map[string]any). Los nombres se pasan a minúsculas.
+func routeSecurityHeaders(route artifacts.Artifact) (map[string]bool, bool) {
+	raw, ok := GetArtifactMetadata(route, "security_headers")
+	if !ok {
+		return nil, false
+	}
+	headers := make(map[string]bool)
+	switch v := raw.(type) {
+	case map[string]bool:
+		for name, present := range v {
+			headers[strings.ToLower(name)] = present
+		}
+	case map[string]any:
+		for name, value := range v {
+			present, _ := value.(bool)
+			headers[strings.ToLower(name)] = present
+		}
+	default:
+		return nil, false
+	}
+	return headers, true
+}
+
+// splitArtifactURL devuelve el esquema, el host (en minúsculas y sin puerto) y
+// la URL de un artefacto, descartando anotaciones como " [200]".
+func splitArtifactURL(art artifacts.Artifact) (scheme, host, rawURL string) {
+	fields := strings.Fields(art.Value)
+	if len(fields) == 0 {
+		return "", "", ""
+	}
+	rawURL = fields[0]
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", ""
+	}
+	return strings.ToLower(parsed.Scheme), strings.ToLower(parsed.Hostname()), rawURL
+}
+
+// uniqueSorted devuelve los valores sin duplicados y ordenados.
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, dup := seen[value]; dup {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestAnalyzeMixedContentCorrelatesByHost(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "resource", Subtype: "html", Value: "https://app.example.com/index.html", Active: true},
+		{Type: "resource", Subtype: "html", Value: "https://secure.example.com/", Active: true},
+		{Type: "resource", Subtype: "javascript", Value: "http://app.example.com/static/app.js", Active: true, Up: true},
+		{Type: "resource", Subtype: "image", Value: "http://app.example.com/logo.png", Active: true, Up: true},
+		{Type: "route", Value: "http://app.example.com/api/status [200]", Active: true, Up: true},
+		{Type: "resource", Subtype: "javascript", Value: "http://app.example.com/static/old.js"},
+		{Type: "resource", Subtype: "javascript", Value: "https://app.example.com/static/vendor.js", Active: true, Up: true},
+		{Type: "resource", Subtype: "javascript", Value: "http://cdn.thirdparty.net/lib.js", Active: true, Up: true},
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzerFromArtifacts(arts).analyzeMixedContent(findings)
+
+	if len(findings.Findings) != 1 {
+		t.Fatalf("expected a single mixed content finding, got %+v", findings.Findings)
+	}
+	finding := findings.Findings[0]
+	if finding.ID != "MIX-001" || finding.Severity != "medium" {
+		t.Fatalf("unexpected finding: %+v", finding)
+	}
+	if finding.Location != "https://app.example.com/index.html" {
+		t.Fatalf("unexpected location %q", finding.Location)
+	}
+	wantEvidence := []string{
+		"https://app.example.com/index.html",
+		"http://app.example.com/logo.png",
+		"http://app.example.com/static/app.js",
+	}
+	if diff := cmp.Diff(wantEvidence, finding.Evidence); diff != "" {
+		t.Fatalf("unexpected evidence (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnalyzeMixedContentIgnoresPassiveArchiveRoutes(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "resource", Subtype: "html", Value: "https://app.example.com/index.html", Active: true, Up: true},
+		{Type: "route", Value: "http://app.example.com/old/page.php?id=1", Tool: "waybackurls", Up: true},
+		{Type: "resource", Subtype: "javascript", Value: "http://app.example.com/static/legacy.js", Tool: "gau", Up: true},
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzerFromArtifacts(arts).analyzeMixedContent(findings)
+
+	if len(findings.Findings) != 0 {
+		t.Fatalf("passive archive URLs should not produce mixed content findings, got %+v", findings.Findings)
+	}
+}
+
+func TestAnalyzeMixedContentSkipsWithoutHTML(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "route", Value: "https://app.example.com/"},
+		{Type: "resource", Subtype: "javascript", Value: "http://app.example.com/app.js"},
+	}
+	report, err := NewAnalyzerFromArtifacts(arts).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	for _, finding := range report.Security.Findings {
+		if finding.ID == "MIX-001" {
+			t.Fatalf("unexpected mixed content finding without HTML pages: %+v", finding)
+		}
+	}
+}
+
+func TestAnalyzeSecurityHeadersReportsMissingHeaders(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "route", Value: "https://app.example.com/", Active: true, Metadata: map[string]any{
+			"security_headers": map[string]any{"Strict-Transport-Security": false, "Content-Security-Policy": true},
+		}},
+		{Type: "route", Value: "https://api.example.com/", Active: true, Metadata: map[string]any{
+			"security_headers": map[string]bool{"strict-transport-security": true, "content-security-policy": false},
+		}},
+		{Type: "route", Value: "http://legacy.example.com/", Active: true, Metadata: map[string]any{
+			"security_headers": map[string]any{"strict-transport-security": false, "content-security-policy": false},
+		}},
+		{Type: "route", Value: "https://unprobed.example.com/", Active: true},
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzerFromArtifacts(arts).analyzeSecurityHeaders(findings)
+
+	got := make(map[string]Finding)
+	for _, finding := range findings.Findings {
+		got[finding.ID] = finding
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected HSTS and CSP findings, got %+v", findings.Findings)
+	}
+	if hsts := got["HDR-001"]; hsts.Severity != "low" || !cmp.Equal(hsts.Evidence, []string{"https://app.example.com/"}) {
+		t.Fatalf("unexpected HSTS finding: %+v", hsts)
+	}
+	wantCSP := []string{"http://legacy.example.com/", "https://api.example.com/"}
+	if csp := got["HDR-002"]; csp.Severity != "medium" || !cmp.Equal(csp.Evidence, wantCSP) {
+		t.Fatalf("unexpected CSP finding: %+v", csp)
+	}
+}
@@ -0,0 +1,203 @@
+package analysis
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// takeoverFingerprint identifica un servicio cuyo CNAME queda reclamable por
+// terceros cuando el recurso de destino se elimina. Pattern es un glob de
+// path.Match sobre el nombre del CNAME ("*" también abarca puntos).
+type takeoverFingerprint struct {
+	Service string
+	Pattern string
+}
+
+// takeoverFingerprints son los destinos CNAME conocidos por permitir
+// subdomain takeover. Se puede ampliar añadiendo entradas.
+var takeoverFingerprints = []takeoverFingerprint{
+	{Service: "GitHub Pages", Pattern: "*.github.io"},
+	{Service: "AWS S3", Pattern: "*.s3.amazonaws.com"},
+	{Service: "AWS S3", Pattern: "*.s3.*.amazonaws.com"},
+	{Service: "AWS S3", Pattern: "*.s3-website*.amazonaws.com"},
+	{Service: "Heroku", Pattern: "*.herokudns.com"},
+	{Service: "Heroku", Pattern: "*.herokuapp.com"},
+	{Service: "Azure App Service", Pattern: "*.azurewebsites.net"},
+	{Service: "Azure Cloud Services", Pattern: "*.cloudapp.net"},
+	{Service: "Azure Traffic Manager", Pattern: "*.trafficmanager.net"},
+	{Service: "Azure Blob Storage", Pattern: "*.blob.core.windows.net"},
+	{Service: "Bitbucket", Pattern: "*.bitbucket.io"},
+	{Service: "Shopify", Pattern: "*.myshopify.com"},
+	{Service: "Pantheon", Pattern: "*.pantheonsite.io"},
+	{Service: "Surge.sh", Pattern: "*.surge.sh"},
+	{Service: "Ghost", Pattern: "*.ghost.io"},
+	{Service: "Zendesk", Pattern: "*.zendesk.com"},
+}
+
+// dnsRelation es la parte de un artefacto dns que necesita el análisis de
+// takeover: el JSON que generan dnsx y handleRelation.
+type dnsRelation struct {
+	Host  string `json:"host"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// analyzeTakeover señala candidatos a subdomain takeover: dominios
+// descubiertos con un CNAME hacia un servicio de takeoverFingerprints cuyas
+// rutas sondeadas no respondieron con 2xx. Los hosts sin rutas sondeadas no se
+// señalan (no hay evidencia de que el recurso falte), ni tampoco los CNAME que
+// apuntan a un host del scope.
+func (a *Analyzer) analyzeTakeover(findings *SecurityFindings) {
+	dnsArtifacts := a.FilterArtifacts("dns")
+	if len(dnsArtifacts) == 0 {
+		return
+	}
+
+	domains := make(map[string]struct{})
+	for _, art := range a.FilterArtifacts("domain") {
+		if fields := strings.Fields(art.Value); len(fields) > 0 {
+			domains[normalizeHostname(fields[0])] = struct{}{}
+		}
+	}
+
+	// Por host: si alguna ruta se sondeó y si alguna respondió 2xx.
+	probed := make(map[string]bool)
+	healthy := make(map[string]bool)
+	for _, route := range a.FilterArtifacts("route") {
+		if !route.Active {
+			continue
+		}
+		_, host, _ := splitArtifactURL(route)
+		status, ok := routeStatus(route)
+		if host == "" || !ok {
+			continue
+		}
+		probed[host] = true
+		if status >= 200 && status < 300 {
+			healthy[host] = true
+		}
+	}
+
+	type candidate struct {
+		host, target string
+		fp           takeoverFingerprint
+	}
+	seen := make(map[string]struct{})
+	var candidates []candidate
+	for _, art := range dnsArtifacts {
+		rel, ok := parseDNSRelation(art)
+		if !ok || rel.Type != "CNAME" {
+			continue
+		}
+		if _, ok := domains[rel.Host]; !ok {
+			continue
+		}
+		if !probed[rel.Host] || healthy[rel.Host] {
+			continue
+		}
+		if a.hostInScope(rel.Value, domains) {
+			continue
+		}
+		fp, ok := matchTakeoverFingerprint(rel.Value)
+		if !ok {
+			continue
+		}
+		key := rel.Host + " " + rel.Value
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		candidates = append(candidates, candidate{host: rel.Host, target: rel.Value, fp: fp})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].host != candidates[j].host {
+			return candidates[i].host < candidates[j].host
+		}
+		return candidates[i].target < candidates[j].target
+	})
+
+	for _, c := range candidates {
+		findings.Findings = append(findings.Findings, Finding{
+			ID:          "TKO-001",
+			Category:    "vulnerability",
+			Title:       "Potential Subdomain Takeover",
+			Description: c.host + " is a CNAME to " + c.target + " (" + c.fp.Service + ") and none of its probed routes returned a 2xx response; the resource behind the CNAME may no longer exist and could be claimed by a third party.",
+			Severity:    "high",
+			Evidence:    []string{c.host, "CNAME " + c.target, "fingerprint: " + c.fp.Service + " (" + c.fp.Pattern + ")"},
+			Location:    c.host,
+			Remediation: "Remove the dangling CNAME record or reclaim the resource on " + c.fp.Service + ".",
+		})
+	}
+}
+
+// parseDNSRelation extrae host, tipo y valor de un artefacto dns, del JSON del
+// valor o, si no lo es, de la metadata. Los nombres se normalizan.
+func parseDNSRelation(art artifacts.Artifact) (dnsRelation, bool) {
+	var rel dnsRelation
+	if err := json.Unmarshal([]byte(art.Value), &rel); err != nil {
+		rel = dnsRelation{
+			Host:  GetArtifactMetadataString(art, "host"),
+			Type:  GetArtifactMetadataString(art, "type"),
+			Value: GetArtifactMetadataString(art, "value"),
+		}
+	}
+	rel.Host = normalizeHostname(rel.Host)
+	rel.Type = strings.ToUpper(strings.TrimSpace(rel.Type))
+	rel.Value = normalizeHostname(rel.Value)
+	return rel, rel.Host != "" && rel.Value != ""
+}
+
+// hostInScope indica si host es el target, un subdominio suyo o un dominio
+// descubierto.
+func (a *Analyzer) hostInScope(host string, domains map[string]struct{}) bool {
+	if _, ok := domains[host]; ok {
+		return true
+	}
+	target := normalizeHostname(a.header.Target)
+	return target != "" && (host == target || strings.HasSuffix(host, "."+target))
+}
+
+func matchTakeoverFingerprint(host string) (takeoverFingerprint, bool) {
+	for _, fp := range takeoverFingerprints {
+		if ok, _ := path.Match(fp.Pattern, host); ok {
+			return fp, true
+		}
+	}
+	return takeoverFingerprint{}, false
+}
+
+// routeStatus devuelve el código HTTP de una ruta sondeada, de la metadata
+// "status" o de la anotación " [404]" del valor.
+func routeStatus(route artifacts.Artifact) (int, bool) {
+	if raw, ok := GetArtifactMetadata(route, "status"); ok {
+		switch v := raw.(type) {
+		case int:
+			return v, true
+		case float64:
+			return int(v), true
+		case string:
+			if status, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return status, true
+			}
+		}
+	}
+	fields := strings.Fields(route.Value)
+	if len(fields) < 2 || !strings.HasPrefix(fields[1], "[") {
+		return 0, false
+	}
+	status, err := strconv.Atoi(strings.Trim(fields[1], "[]"))
+	if err != nil {
+		return 0, false
+	}
+	return status, true
+}
+
+func normalizeHostname(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func cnameArtifact(host, target string) artifacts.Artifact {
+	return artifacts.Artifact{
+		Type:  "dns",
+		Value: `{"host":"` + host + `","type":"CNAME","value":"` + target + `","raw":"` + host + ` --> CNAME --> ` + target + `"}`,
+		Metadata: map[string]any{
+			"host":  host,
+			"type":  "CNAME",
+			"value": target,
+		},
+	}
+}
+
+func TestAnalyzeTakeoverFlagsDanglingCNAME(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "domain", Value: "blog.example.com"},
+		{Type: "domain", Value: "www.example.com"},
+		cnameArtifact("blog.example.com", "Example.GitHub.io."),
+		cnameArtifact("www.example.com", "example.herokudns.com"),
+		{Type: "route", Value: "https://blog.example.com/", Active: true, Metadata: map[string]any{"status": float64(404)}},
+		{Type: "route", Value: "https://www.example.com/", Active: true, Metadata: map[string]any{"status": float64(200)}},
+	}
+	findings := &SecurityFindings{}
+	NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).analyzeTakeover(findings)
+
+	if len(findings.Findings) != 1 {
+		t.Fatalf("expected a single takeover finding, got %+v", findings.Findings)
+	}
+	finding := findings.Findings[0]
+	if finding.ID != "TKO-001" || finding.Severity != "high" || finding.Location != "blog.example.com" {
+		t.Fatalf("unexpected finding: %+v", finding)
+	}
+	wantEvidence := []string{"blog.example.com", "CNAME example.github.io", "fingerprint: GitHub Pages (*.github.io)"}
+	if diff := cmp.Diff(wantEvidence, finding.Evidence); diff != "" {
+		t.Fatalf("unexpected evidence (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnalyzeTakeoverSkipsInScopeAndUnprobedHosts(t *testing.T) {
+	t.Parallel()
+
+	arts := []artifacts.Artifact{
+		{Type: "domain", Value: "docs.example.com"},
+		{Type: "domain", Value: "example.github.io"},
+		{Type: "domain", Value: "shop.example.com"},
+		// El destino del CNAME es un dominio descubierto (en scope).
+		cnameArtifact("docs.example.com", "example.github.io"),
+		{Type: "route", Value: "https://docs.example.com/ [404]", Active: true},
+		// Sin rutas sondeadas no hay evidencia de que el recurso falte.
+		cnameArtifact("shop.example.com", "example.myshopify.com"),
+		// Host que no aparece entre los dominios.
+		cnameArtifact("old.example.com", "old-bucket.s3.amazonaws.com"),
+		{Type: "route", Value: "https://old.example.com/ [404]", Active: true},
+	}
+	report, err := NewAnalyzer(arts, artifacts.HeaderV2{Target: "example.com"}, DefaultAnalysisOptions()).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	for _, finding := range report.Security.Findings {
+		if finding.ID == "TKO-001" {
+			t.Fatalf("unexpected takeover finding: %+v", finding)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"path"
+	"sort"
 	"strings"
 )
 
@@ -51,246 +52,100 @@ func (a *Analyzer) detectTechnology() *TechStack {
 
 // detectJavaScript detecta librerías y frameworks JavaScript.
 func (a *Analyzer) detectJavaScript(stack *TechStack) {
-	jsArtifacts := a.FilterBySubtype("resource", "javascript")
-
 	detectedLibs := make(map[string]*Technology)
-
-	for _, art := range jsArtifacts {
-		value := strings.ToLower(art.Value)
-		basename := strings.ToLower(path.Base(value))
-
-		// jQuery
-		if strings.Contains(basename, "jquery") {
-			if _, exists := detectedLibs["jQuery"]; !exists {
-				detectedLibs["jQuery"] = &Technology{
-					Name:       "jQuery",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["jQuery"].Evidence = append(detectedLibs["jQuery"].Evidence, art.Value)
-			}
-		}
-
-		// React
-		if strings.Contains(basename, "react") {
-			if _, exists := detectedLibs["React"]; !exists {
-				detectedLibs["React"] = &Technology{
-					Name:       "React",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["React"].Evidence = append(detectedLibs["React"].Evidence, art.Value)
-			}
-		}
-
-		// Vue.js
-		if strings.Contains(basename, "vue") {
-			if _, exists := detectedLibs["Vue.js"]; !exists {
-				detectedLibs["Vue.js"] = &Technology{
-					Name:       "Vue.js",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["Vue.js"].Evidence = append(detectedLibs["Vue.js"].Evidence, art.Value)
-			}
-		}
-
-		// Angular
-		if strings.Contains(basename, "angular") {
-			if _, exists := detectedLibs["Angular"]; !exists {
-				detectedLibs["Angular"] = &Technology{
-					Name:       "Angular",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["Angular"].Evidence = append(detectedLibs["Angular"].Evidence, art.Value)
-			}
-		}
-
-		// Bootstrap JS
-		if strings.Contains(basename, "bootstrap") {
-			if _, exists := detectedLibs["Bootstrap"]; !exists {
-				detectedLibs["Bootstrap"] = &Technology{
-					Name:       "Bootstrap",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["Bootstrap"].Evidence = append(detectedLibs["Bootstrap"].Evidence, art.Value)
-			}
-		}
-
-		// Lodash/Underscore
-		if strings.Contains(basename, "lodash") || strings.Contains(basename, "underscore") {
-			name := "Lodash"
-			if strings.Contains(basename, "underscore") {
-				name = "Underscore.js"
-			}
-			if _, exists := detectedLibs[name]; !exists {
-				detectedLibs[name] = &Technology{
-					Name:       name,
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs[name].Evidence = append(detectedLibs[name].Evidence, art.Value)
-			}
-		}
-
-		// Moment.js
-		if strings.Contains(basename, "moment") {
-			if _, exists := detectedLibs["Moment.js"]; !exists {
-				detectedLibs["Moment.js"] = &Technology{
-					Name:       "Moment.js",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["Moment.js"].Evidence = append(detectedLibs["Moment.js"].Evidence, art.Value)
-			}
-		}
-
-		// Chart.js
-		if strings.Contains(basename, "chart") {
-			if _, exists := detectedLibs["Chart.js"]; !exists {
-				detectedLibs["Chart.js"] = &Technology{
-					Name:       "Chart.js",
-					Evidence:   []string{art.Value},
-					Confidence: "medium",
-				}
-			} else {
-				detectedLibs["Chart.js"].Evidence = append(detectedLibs["Chart.js"].Evidence, art.Value)
-			}
-		}
-
-		// D3.js
-		if strings.Contains(basename, "d3") {
-			if _, exists := detectedLibs["D3.js"]; !exists {
-				detectedLibs["D3.js"] = &Technology{
-					Name:       "D3.js",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedLibs["D3.js"].Evidence = append(detectedLibs["D3.js"].Evidence, art.Value)
-			}
-		}
-
-		// Google Analytics
-		if strings.Contains(value, "google-analytics") || strings.Contains(value, "gtag") || strings.Contains(value, "ga.js") {
-			if _, exists := detectedLibs["Google Analytics"]; !exists {
-				detectedLibs["Google Analytics"] = &Technology{
-					Name:       "Google Analytics",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			}
-		}
+	for _, art := range a.FilterBySubtype("resource", "javascript") {
+		mergeTechHits(detectedLibs, a.techHits(techDetectorJavaScript, art.Value, javaScriptTechHits), art.Value)
 	}
 
 	// Convertir map a slices
-	for _, tech := range detectedLibs {
-		// Limitar evidencia a los primeros 3 ejemplos
-		if len(tech.Evidence) > 3 {
-			tech.Evidence = tech.Evidence[:3]
-		}
-
+	for _, tech := range collectTechnologies(detectedLibs) {
 		// Categorizar
 		if tech.Name == "React" || tech.Name == "Vue.js" || tech.Name == "Angular" {
-			stack.Frameworks = append(stack.Frameworks, *tech)
+			stack.Frameworks = append(stack.Frameworks, tech)
 		} else if tech.Name == "Google Analytics" {
-			stack.Analytics = append(stack.Analytics, *tech)
+			stack.Analytics = append(stack.Analytics, tech)
 		} else {
-			stack.JavaScript = append(stack.JavaScript, *tech)
+			stack.JavaScript = append(stack.JavaScript, tech)
 		}
 	}
 }
 
-// detectCSS detecta frameworks CSS.
-func (a *Analyzer) detectCSS(stack *TechStack) {
-	cssArtifacts := a.FilterBySubtype("resource", "css")
-
-	detectedCSS := make(map[string]*Technology)
-
-	for _, art := range cssArtifacts {
-		value := strings.ToLower(art.Value)
-		basename := strings.ToLower(path.Base(value))
-
-		// Bootstrap
-		if strings.Contains(basename, "bootstrap") {
-			if _, exists := detectedCSS["Bootstrap"]; !exists {
-				detectedCSS["Bootstrap"] = &Technology{
-					Name:       "Bootstrap",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedCSS["Bootstrap"].Evidence = append(detectedCSS["Bootstrap"].Evidence, art.Value)
-			}
-		}
+// javaScriptTechHits devuelve las librerías JavaScript que sugiere la URL de
+// un recurso.
+func javaScriptTechHits(raw string) []techHit {
+	value := strings.ToLower(raw)
+	basename := strings.ToLower(path.Base(value))
 
-		// Tailwind
-		if strings.Contains(basename, "tailwind") {
-			if _, exists := detectedCSS["Tailwind CSS"]; !exists {
-				detectedCSS["Tailwind CSS"] = &Technology{
-					Name:       "Tailwind CSS",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedCSS["Tailwind CSS"].Evidence = append(detectedCSS["Tailwind CSS"].Evidence, art.Value)
-			}
+	var hits []techHit
+	if strings.Contains(basename, "jquery") {
+		hits = append(hits, techHit{Name: "jQuery", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "react") {
+		hits = append(hits, techHit{Name: "React", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "vue") {
+		hits = append(hits, techHit{Name: "Vue.js", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "angular") {
+		hits = append(hits, techHit{Name: "Angular", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "bootstrap") {
+		hits = append(hits, techHit{Name: "Bootstrap", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "lodash") || strings.Contains(basename, "underscore") {
+		name := "Lodash"
+		if strings.Contains(basename, "underscore") {
+			name = "Underscore.js"
 		}
+		hits = append(hits, techHit{Name: name, Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "moment") {
+		hits = append(hits, techHit{Name: "Moment.js", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "chart") {
+		hits = append(hits, techHit{Name: "Chart.js", Confidence: "medium", Accumulate: true})
+	}
+	if strings.Contains(basename, "d3") {
+		hits = append(hits, techHit{Name: "D3.js", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(value, "google-analytics") || strings.Contains(value, "gtag") || strings.Contains(value, "ga.js") {
+		hits = append(hits, techHit{Name: "Google Analytics", Confidence: "high"})
+	}
+	return hits
+}
 
-		// Foundation
-		if strings.Contains(basename, "foundation") {
-			if _, exists := detectedCSS["Foundation"]; !exists {
-				detectedCSS["Foundation"] = &Technology{
-					Name:       "Foundation",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedCSS["Foundation"].Evidence = append(detectedCSS["Foundation"].Evidence, art.Value)
-			}
-		}
+// detectCSS detecta frameworks CSS.
+func (a *Analyzer) detectCSS(stack *TechStack) {
+	detectedCSS := make(map[string]*Technology)
+	for _, art := range a.FilterBySubtype("resource", "css") {
+		mergeTechHits(detectedCSS, a.techHits(techDetectorCSS, art.Value, cssTechHits), art.Value)
+	}
+	stack.CSS = append(stack.CSS, collectTechnologies(detectedCSS)...)
+}
 
-		// Bulma
-		if strings.Contains(basename, "bulma") {
-			if _, exists := detectedCSS["Bulma"]; !exists {
-				detectedCSS["Bulma"] = &Technology{
-					Name:       "Bulma",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			} else {
-				detectedCSS["Bulma"].Evidence = append(detectedCSS["Bulma"].Evidence, art.Value)
-			}
-		}
+// cssTechHits devuelve los frameworks CSS que sugiere la URL de una hoja de
+// estilos.
+func cssTechHits(raw string) []techHit {
+	value := strings.ToLower(raw)
+	basename := strings.ToLower(path.Base(value))
 
-		// Font Awesome
-		if strings.Contains(value, "font-awesome") || strings.Contains(value, "fontawesome") {
-			if _, exists := detectedCSS["Font Awesome"]; !exists {
-				detectedCSS["Font Awesome"] = &Technology{
-					Name:       "Font Awesome",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			}
-		}
+	var hits []techHit
+	if strings.Contains(basename, "bootstrap") {
+		hits = append(hits, techHit{Name: "Bootstrap", Confidence: "high", Accumulate: true})
 	}
-
-	// Convertir map a slice
-	for _, tech := range detectedCSS {
-		if len(tech.Evidence) > 3 {
-			tech.Evidence = tech.Evidence[:3]
-		}
-		stack.CSS = append(stack.CSS, *tech)
+	if strings.Contains(basename, "tailwind") {
+		hits = append(hits, techHit{Name: "Tailwind CSS", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "foundation") {
+		hits = append(hits, techHit{Name: "Foundation", Confidence: "high", Accumulate: true})
+	}
+	if strings.Contains(basename, "bulma") {
+		hits = append(hits, techHit{Name: "Bulma", Confidence: "high", Accumulate: true})
 	}
+	if strings.Contains(value, "font-awesome") || strings.Contains(value, "fontawesome") {
+		hits = append(hits, techHit{Name: "Font Awesome", Confidence: "high"})
+	}
+	return hits
 }
 
 // detectCMS detecta CMS y plataformas.
@@ -301,103 +156,66 @@ func (a *Analyzer) detectCMS(stack *TechStack) {
 	allArtifacts := append(routes, htmlPages...)
 
 	detectedCMS := make(map[string]*Technology)
-
 	for _, art := range allArtifacts {
-		value := strings.ToLower(art.Value)
-
-		// WordPress
-		if strings.Contains(value, "wp-content") || strings.Contains(value, "wp-includes") || strings.Contains(value, "wp-admin") {
-			if _, exists := detectedCMS["WordPress"]; !exists {
-				detectedCMS["WordPress"] = &Technology{
-					Name:       "WordPress",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			}
-		}
+		mergeTechHits(detectedCMS, a.techHits(techDetectorCMS, art.Value, cmsTechHits), art.Value)
+	}
+	stack.CMS = append(stack.CMS, collectTechnologies(detectedCMS)...)
+}
 
-		// Drupal
-		if strings.Contains(value, "/sites/default") || strings.Contains(value, "/modules/") || strings.Contains(value, "drupal") {
-			if _, exists := detectedCMS["Drupal"]; !exists {
-				detectedCMS["Drupal"] = &Technology{
-					Name:       "Drupal",
-					Evidence:   []string{art.Value},
-					Confidence: "medium",
-				}
-			}
-		}
+// cmsTechHits devuelve los CMS y plataformas que sugiere una ruta o página.
+func cmsTechHits(raw string) []techHit {
+	value := strings.ToLower(raw)
 
-		// Joomla
-		if strings.Contains(value, "/components/com_") || strings.Contains(value, "joomla") {
-			if _, exists := detectedCMS["Joomla"]; !exists {
-				detectedCMS["Joomla"] = &Technology{
-					Name:       "Joomla",
-					Evidence:   []string{art.Value},
-					Confidence: "medium",
-				}
-			}
-		}
-
-		// Shopify
-		if strings.Contains(value, "shopify") || strings.Contains(value, "myshopify.com") {
-			if _, exists := detectedCMS["Shopify"]; !exists {
-				detectedCMS["Shopify"] = &Technology{
-					Name:       "Shopify",
-					Evidence:   []string{art.Value},
-					Confidence: "high",
-				}
-			}
-		}
+	var hits []techHit
+	if strings.Contains(value, "wp-content") || strings.Contains(value, "wp-includes") || strings.Contains(value, "wp-admin") {
+		hits = append(hits, techHit{Name: "WordPress", Confidence: "high"})
 	}
-
-	for _, tech := range detectedCMS {
-		if len(tech.Evidence) > 3 {
-			tech.Evidence = tech.Evidence[:3]
-		}
-		stack.CMS = append(stack.CMS, *tech)
+	if strings.Contains(value, "/sites/default") || strings.Contains(value, "/modules/") || strings.Contains(value, "drupal") {
+		hits = append(hits, techHit{Name: "Drupal", Confidence: "medium"})
 	}
+	if strings.Contains(value, "/components/com_") || strings.Contains(value, "joomla") {
+		hits = append(hits, techHit{Name: "Joomla", Confidence: "medium"})
+	}
+	if strings.Contains(value, "shopify") || strings.Contains(value, "myshopify.com") {
+		hits = append(hits, techHit{Name: "Shopify", Confidence: "high"})
+	}
+	return hits
+}
+
+// cdnPatterns asocia fragmentos de URL con el CDN que delatan.
+var cdnPatterns = map[string]string{
+	"cloudflare": "Cloudflare",
+	"akamai":     "Akamai",
+	"fastly":     "Fastly",
+	"cdn.":       "Generic CDN",
+	"cloudfront": "Amazon CloudFront",
+	"googleapis": "Google Cloud CDN",
+	"jsdelivr":   "jsDelivr",
+	"unpkg":      "unpkg",
+	"cdnjs":      "cdnjs",
 }
 
 // detectCDN detecta uso de CDN.
 func (a *Analyzer) detectCDN(stack *TechStack) {
-	routes := a.FilterArtifacts("route")
-
 	detectedCDN := make(map[string]*Technology)
-
-	cdnPatterns := map[string]string{
-		"cloudflare": "Cloudflare",
-		"akamai":     "Akamai",
-		"fastly":     "Fastly",
-		"cdn.":       "Generic CDN",
-		"cloudfront": "Amazon CloudFront",
-		"googleapis": "Google Cloud CDN",
-		"jsdelivr":   "jsDelivr",
-		"unpkg":      "unpkg",
-		"cdnjs":      "cdnjs",
+	for _, art := range a.FilterArtifacts("route") {
+		mergeTechHits(detectedCDN, a.techHits(techDetectorCDN, art.Value, cdnTechHits), art.Value)
 	}
+	stack.CDN = append(stack.CDN, collectTechnologies(detectedCDN)...)
+}
 
-	for _, art := range routes {
-		value := strings.ToLower(art.Value)
-
-		for pattern, name := range cdnPatterns {
-			if strings.Contains(value, pattern) {
-				if _, exists := detectedCDN[name]; !exists {
-					detectedCDN[name] = &Technology{
-						Name:       name,
-						Evidence:   []string{art.Value},
-						Confidence: "high",
-					}
-				}
-			}
-		}
-	}
+// cdnTechHits devuelve los CDN que sugiere una ruta, ordenados por nombre.
+func cdnTechHits(raw string) []techHit {
+	value := strings.ToLower(raw)
 
-	for _, tech := range detectedCDN {
-		if len(tech.Evidence) > 3 {
-			tech.Evidence = tech.Evidence[:3]
+	var hits []techHit
+	for pattern, name := range cdnPatterns {
+		if strings.Contains(value, pattern) {
+			hits = append(hits, techHit{Name: name, Confidence: "high"})
 		}
-		stack.CDN = append(stack.CDN, *tech)
 	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Name < hits[j].Name })
+	return hits
 }
 
 // detectDeprecated detecta tecnologías obsoletas.
@@ -451,49 +269,74 @@ func (a *Analyzer) detectDeprecated(stack *TechStack) {
 // detectServers detecta servidores web desde metadata.
 func (a *Analyzer) detectServers(stack *TechStack) {
 	// Buscar en metadata de artifacts activos (httpx típicamente agrega server headers)
-	activeArtifacts := a.FilterActive()
-
 	detectedServers := make(map[string]*Technology)
-
-	for _, art := range activeArtifacts {
+	for _, art := range a.FilterActive() {
 		if server := GetArtifactMetadataString(art, "server"); server != "" {
-			serverLower := strings.ToLower(server)
-
-			// Normalizar nombres
-			name := server
-			confidence := "medium"
-
-			if strings.Contains(serverLower, "nginx") {
-				name = "Nginx"
-				confidence = "high"
-			} else if strings.Contains(serverLower, "apache") {
-				name = "Apache"
-				confidence = "high"
-			} else if strings.Contains(serverLower, "iis") {
-				name = "Microsoft IIS"
-				confidence = "high"
-			} else if strings.Contains(serverLower, "cloudflare") {
-				name = "Cloudflare"
-				confidence = "high"
-			} else if strings.Contains(serverLower, "litespeed") {
-				name = "LiteSpeed"
-				confidence = "high"
-			}
+			mergeTechHits(detectedServers, a.techHits(techDetectorServer, server, serverTechHits), server)
+		}
+	}
+	stack.Servers = append(stack.Servers, collectTechnologies(detectedServers)...)
+}
+
+// serverTechHits normaliza la cabecera Server en el servidor web que anuncia.
+func serverTechHits(server string) []techHit {
+	serverLower := strings.ToLower(server)
+
+	// Normalizar nombres
+	name := server
+	confidence := "medium"
+
+	if strings.Contains(serverLower, "nginx") {
+		name = "Nginx"
+		confidence = "high"
+	} else if strings.Contains(serverLower, "apache") {
+		name = "Apache"
+		confidence = "high"
+	} else if strings.Contains(serverLower, "iis") {
+		name = "Microsoft IIS"
+		confidence = "high"
+	} else if strings.Contains(serverLower, "cloudflare") {
+		name = "Cloudflare"
+		confidence = "high"
+	} else if strings.Contains(serverLower, "litespeed") {
+		name = "LiteSpeed"
+		confidence = "high"
+	}
 
-			if _, exists := detectedServers[name]; !exists {
-				detectedServers[name] = &Technology{
-					Name:       name,
-					Version:    extractVersion(server),
-					Evidence:   []string{server},
-					Confidence: confidence,
-				}
+	return []techHit{{Name: name, Version: extractVersion(server), Confidence: confidence}}
+}
+
+// mergeTechHits incorpora al mapa las tecnologías detectadas en un artefacto.
+// La primera aparición crea la entrada con evidence como evidencia; las
+// siguientes solo la amplían si la detección acumula evidencias.
+func mergeTechHits(detected map[string]*Technology, hits []techHit, evidence string) {
+	for _, hit := range hits {
+		if tech, exists := detected[hit.Name]; exists {
+			if hit.Accumulate {
+				tech.Evidence = append(tech.Evidence, evidence)
 			}
+			continue
+		}
+		detected[hit.Name] = &Technology{
+			Name:       hit.Name,
+			Version:    hit.Version,
+			Evidence:   []string{evidence},
+			Confidence: hit.Confidence,
 		}
 	}
+}
 
-	for _, tech := range detectedServers {
-		stack.Servers = append(stack.Servers, *tech)
+// collectTechnologies convierte el mapa en una lista, limitando la evidencia
+// a los primeros 3 ejemplos.
+func collectTechnologies(detected map[string]*Technology) []Technology {
+	list := make([]Technology, 0, len(detected))
+	for _, tech := range detected {
+		if len(tech.Evidence) > 3 {
+			tech.Evidence = tech.Evidence[:3]
+		}
+		list = append(list, *tech)
 	}
+	return list
 }
 
 // extractVersion intenta extraer la versión de un string.
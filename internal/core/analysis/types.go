@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"strings"
 	"time"
 
 	"passive-rec/internal/adapters/artifacts"
@@ -13,6 +14,9 @@ type Report struct {
 	ScanDate   time.Time `json:"scan_date"`
 	Duration   string    `json:"duration,omitempty"`
 	ReportDate time.Time `json:"report_date"`
+	// Since, si no está vacío, indica que el informe solo cubre los
+	// artefactos descubiertos desde esa fecha (RFC3339).
+	Since string `json:"since,omitempty"`
 
 	// Estadísticas generales
 	Summary Summary `json:"summary"`
@@ -38,6 +42,9 @@ type Summary struct {
 	ArtifactsByStatus map[string]int `json:"artifacts_by_status"`
 	ToolsUsed         []string       `json:"tools_used"`
 	TopTools          []ToolStat     `json:"top_tools,omitempty"`
+	// ToolBreakdown cuenta, por herramienta, los artefactos de cada tipo que
+	// aportó. Los artefactos sin herramienta se agrupan en "unknown".
+	ToolBreakdown map[string]map[string]int `json:"tool_breakdown,omitempty"`
 }
 
 // ToolStat representa estadísticas de una herramienta.
@@ -149,6 +156,26 @@ type Infrastructure struct {
 	// Hosting
 	HostingProvider string `json:"hosting_provider,omitempty"`
 	EmailProvider   string `json:"email_provider,omitempty"`
+
+	// Protocolos HTTP: orígenes por protocolo negociado (ALPN) y cuántos
+	// anuncian HTTP/3.
+	Protocols map[string]int `json:"protocols,omitempty"`
+	HTTP3     int            `json:"http3,omitempty"`
+
+	// Correo: registros SPF y DMARC del target. Nil si no se resolvieron
+	// sus registros TXT.
+	EmailSecurity *EmailSecurity `json:"email_security,omitempty"`
+}
+
+// EmailSecurity resume la postura SPF/DMARC del dominio objetivo.
+type EmailSecurity struct {
+	Domain string   `json:"domain"`
+	SPF    []string `json:"spf,omitempty"`     // Registros v=spf1 del dominio (más de uno es un error)
+	SPFAll string   `json:"spf_all,omitempty"` // Mecanismo all con su calificador: -all, ~all, ?all o +all
+	DMARC  []string `json:"dmarc,omitempty"`   // Registros v=DMARC1 de _dmarc.<dominio>
+	// DMARCPolicy es la etiqueta p= del primer registro DMARC: none,
+	// quarantine o reject.
+	DMARCPolicy string `json:"dmarc_policy,omitempty"`
 }
 
 // IPInfo representa información de una IP.
@@ -269,6 +296,10 @@ type AnalysisOptions struct {
 	IncludePassiveOnly bool
 	IncludeActiveOnly  bool
 	MaxDepth           int
+
+	// CachePath es el fichero donde se cachean las tecnologías detectadas por
+	// artefacto entre ejecuciones. Vacío desactiva la cache.
+	CachePath string
 }
 
 // DefaultAnalysisOptions retorna las opciones por defecto.
@@ -285,6 +316,7 @@ func DefaultAnalysisOptions() AnalysisOptions {
 		IncludePassiveOnly:     false,
 		IncludeActiveOnly:      false,
 		MaxDepth:               -1, // Sin límite
+		CachePath:              "", // Sin cache
 	}
 }
 
@@ -297,16 +329,18 @@ type ArtifactStats struct {
 	BySubtype   map[string]int
 	ByStatus    map[string]int
 	ByTool      map[string]int
+	ByToolType  map[string]map[string]int
 	UniqueTools []string
 }
 
 // ComputeStats calcula estadísticas de un conjunto de artefactos.
 func ComputeStats(artifacts []artifacts.Artifact) ArtifactStats {
 	stats := ArtifactStats{
-		ByType:    make(map[string]int),
-		BySubtype: make(map[string]int),
-		ByStatus:  make(map[string]int),
-		ByTool:    make(map[string]int),
+		ByType:     make(map[string]int),
+		BySubtype:  make(map[string]int),
+		ByStatus:   make(map[string]int),
+		ByTool:     make(map[string]int),
+		ByToolType: make(map[string]map[string]int),
 	}
 
 	toolSet := make(map[string]struct{})
@@ -348,6 +382,7 @@ func ComputeStats(artifacts []artifacts.Artifact) ArtifactStats {
 				toolSet[tool] = struct{}{}
 			}
 		}
+		countToolTypes(stats.ByToolType, art)
 	}
 
 	// Construir lista de herramientas únicas
@@ -357,3 +392,41 @@ func ComputeStats(artifacts []artifacts.Artifact) ArtifactStats {
 
 	return stats
 }
+
+// countToolTypes suma el artefacto a cada combinación herramienta/tipo. Cada
+// herramienta y tipo cuenta una sola vez por artefacto aunque aparezca tanto
+// en Tool como en Tools (o en Type y Types).
+func countToolTypes(byToolType map[string]map[string]int, art artifacts.Artifact) {
+	tools := uniqueNonEmpty(append([]string{art.Tool}, art.Tools...))
+	if len(tools) == 0 {
+		tools = []string{"unknown"}
+	}
+	types := uniqueNonEmpty(append([]string{art.Type}, art.Types...))
+	for _, tool := range tools {
+		counts := byToolType[tool]
+		if counts == nil {
+			counts = make(map[string]int)
+			byToolType[tool] = counts
+		}
+		for _, typ := range types {
+			counts[typ]++
+		}
+	}
+}
+
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
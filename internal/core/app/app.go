@@ -3,38 +3,48 @@ package app
 import (
 	"context"
 	"errors"
+	"io"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/adapters/export"
+	"passive-rec/internal/adapters/kafka"
 	"passive-rec/internal/adapters/sources"
+	"passive-rec/internal/adapters/sources/linkfinderevo"
 	"passive-rec/internal/core/materializer"
 	"passive-rec/internal/core/pipeline"
 	"passive-rec/internal/core/runner"
 	"passive-rec/internal/platform/config"
+	apperrors "passive-rec/internal/platform/errors"
 	"passive-rec/internal/platform/logx"
+	"passive-rec/internal/platform/netutil"
+	"passive-rec/internal/platform/out"
 )
 
 type sink interface {
-	Start(workers int)
+	Start(ctx context.Context, workers int)
 	In() chan<- string
 	Flush()
 	Close() error
+	LastError() error
 	SetStepRecorder(pipeline.StepRecorder)
 }
 
 var (
-	sinkFactory = func(outdir string, active bool, target string, scopeMode string, lineBuffer int) (sink, error) {
-		return pipeline.NewSinkWithConfig(pipeline.SinkConfig{
-			Outdir:     outdir,
-			Active:     active,
-			Target:     target,
-			ScopeMode:  scopeMode,
-			LineBuffer: lineBuffer,
-		})
+	sinkFactory = func(cfg pipeline.SinkConfig) (sink, error) {
+		return pipeline.NewSinkWithConfig(cfg)
+	}
+	kafkaProducerFactory = func(brokers []string, topic string) (pipeline.ArtifactProducer, error) {
+		return kafka.NewProducer(brokers, topic)
 	}
+	// uploadOutput sube el directorio de salida al destino de -upload.
+	uploadOutput        = export.UploadDir
 	sourceSubfinder     = sources.Subfinder
 	sourceAssetfinder   = sources.Assetfinder
 	sourceRDAP          = sources.RDAP
@@ -47,14 +57,111 @@ var (
 	sourceSubJS         = sources.SubJS
 	sourceLinkFinderEVO = sources.LinkFinderEVO
 	sourceDNSX          = sources.DNSX
+	sourceOpenAPI       = sources.OpenAPI
+	sourceBackups       = sources.BackupArchives
+	sourceReflection    = sources.ReflectedParams
+	sourceOIDC          = sources.OIDCDiscovery
+	sourcePHPInfo       = sources.PHPInfoPages
+	sourceDependencies  = sources.DependencyManifests
+	sourceUnauthAPI     = sources.UnauthenticatedAPI
+	sourceMetrics       = sources.PrometheusMetrics
+	sourceDNSWildcard   = sources.DNSWildcard
+	sourceProtocols     = sources.HTTPProtocols
+	sourceFavicon       = sources.FaviconHashes
+	sourceImportNuclei  = sources.ImportNuclei
+
+	// streamOutput es el destino del stream gzip de -stdout-stream.
+	streamOutput io.Writer = os.Stdout
 )
 
-func Run(cfg *config.Config) error {
-	outDir, err := prepareOutputDir(cfg.OutDir, cfg.Target)
+const configSnapshotName = "config.snapshot.json"
+
+// warnEmptyScope avisa cuando -exclude-scope cubre el propio target: el scope
+// efectivo queda vacío y el sink descartará todos los dominios y rutas.
+func warnEmptyScope(cfg *config.Config) {
+	if len(cfg.ExcludeScope) == 0 || cfg.ScopeFile != "" {
+		return
+	}
+	if netutil.NewScope(cfg.Target, cfg.Scope, cfg.ExcludeScope...).Empty() {
+		logx.Warn("Las exclusiones de scope cubren el target; no se registrará ningún dominio", logx.Fields{
+			"target":        cfg.Target,
+			"exclude_scope": strings.Join(cfg.ExcludeScope, ","),
+		})
+	}
+}
+
+// uploadResults sube el directorio de salida a cfg.Upload. Un fallo solo se
+// registra: no debe ocultar el resultado del escaneo.
+func uploadResults(cfg *config.Config) {
+	if cfg.Upload == "" {
+		return
+	}
+	ctx, stop := runContext()
+	defer stop()
+	start := time.Now()
+	if err := uploadOutput(ctx, cfg.OutDir, cfg.Upload); err != nil {
+		logx.Error("Fallo subir resultados", logx.Fields{"destination": cfg.Upload, "error": err.Error()})
+		return
+	}
+	logx.Info("Resultados subidos", logx.Fields{
+		"destination": cfg.Upload,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+}
+
+// importNucleiResults vuelca en el sink los resultados del JSONL de
+// -nuclei-import para que el informe los incluya como hallazgos. Un fallo solo
+// se registra: el resto del escaneo sigue siendo válido.
+func importNucleiResults(ctx context.Context, cfg *config.Config, s sink, metrics *pipelineMetrics) {
+	if cfg.NucleiImport == "" {
+		return
+	}
+	out, done := toolInputChannel(ctx, s, "nuclei", "", metrics)
+	err := sourceImportNuclei(ctx, cfg.NucleiImport, out)
+	done()
+	if err != nil {
+		logx.Warn("Fallo importar resultados de Nuclei", logx.Fields{"file": cfg.NucleiImport, "error": err.Error()})
+	}
+}
+
+// runContext crea el contexto de la ejecución, que se cancela con SIGINT o
+// SIGTERM. Es una variable para poder sustituirlo en tests.
+var runContext = func() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func Run(cfg *config.Config) (runErr error) {
+	if cfg.TargetsFile != "" {
+		return runTargets(cfg)
+	}
+	runStart := time.Now()
+	if err := materializer.ValidateTypeDirs(cfg.TypeDirs); err != nil {
+		return err
+	}
+	requested, ordered, unknown := normalizeRequestedTools(cfg)
+	if len(unknown) > 0 {
+		return unknownToolsError(unknown)
+	}
+	if cfg.DryRun {
+		return dryRun(cfg, requested, ordered)
+	}
+	outDir, err := prepareOutputDir(cfg.OutDir, cfg.Target, cfg.OutDirTemplate)
 	if err != nil {
 		return err
 	}
 	cfg.OutDir = outDir
+	out.SetWriteBOM(cfg.WriteBOM)
+	sources.ConfigureActiveProbing(cfg.ActiveConcurrency, cfg.ActiveHostDelay)
+	sources.ConfigureRateLimit(runner.NewRateLimiter(cfg.RateLimit))
+	sources.ConfigureHTTPXRetry(cfg.Retries+1, cfg.RetryBackoff)
+	linkfinderevo.ConfigureRetry(cfg.Retries+1, cfg.RetryBackoff)
+	configureTrackingParams(cfg)
+	artifacts.SetCollapseTrailingSlash(cfg.CollapseTrailingSlash)
+	if err := writeConfigSnapshot(cfg); err != nil {
+		logx.Warn("Fallo escribir snapshot de configuración", logx.Fields{"error": err.Error()})
+	}
+
+	warnEmptyScope(cfg)
 
 	// Clamp de workers por robustez (evita Start(0)).
 	workers := cfg.Workers
@@ -62,14 +169,44 @@ func Run(cfg *config.Config) error {
 		workers = 1
 	}
 
-	sink, err := sinkFactory(cfg.OutDir, cfg.Active, cfg.Target, cfg.Scope, pipeline.LineBufferSize(workers))
+	// El contexto se crea antes que el sink para que stop se ejecute después
+	// de sink.Close: cancelarlo antes haría descartar las líneas en cola.
+	ctx, stop := runContext()
+	defer stop()
+
+	producer, err := newArtifactProducer(cfg)
+	if err != nil {
+		return err
+	}
+	sink, err := sinkFactory(pipeline.SinkConfig{
+		Outdir:            cfg.OutDir,
+		Active:            cfg.Active,
+		Target:            cfg.Target,
+		ScopeMode:         cfg.Scope,
+		LineBuffer:        pipeline.LineBufferSizeWith(workers, cfg.LineBufferPerWorker, cfg.LineBufferMin),
+		LineBufferMin:     cfg.LineBufferMin,
+		MinConfidence:     cfg.MinConfidence,
+		Producer:          producer,
+		FlushRetries:      cfg.FlushRetries,
+		ScopeFile:         cfg.ScopeFile,
+		ExcludeScope:      cfg.ExcludeScope,
+		DedupWindow:       cfg.DedupWindow,
+		Resume:            cfg.Resume,
+		ArtifactTTL:       cfg.ArtifactTTL,
+		PreferWrapperTool: cfg.PreferWrapperTool,
+		ProgressInterval:  cfg.ProgressInterval,
+	})
 	if err != nil {
+		if producer != nil {
+			_ = producer.Close()
+		}
 		return err
 	}
+	// Registrado antes que sink.Close para que la subida vea artifacts.jsonl,
+	// run.json y el informe ya cerrados.
+	defer uploadResults(cfg)
 	defer sink.Close()
-	sink.Start(workers)
-
-	requested, ordered, unknown := normalizeRequestedTools(cfg)
+	sink.Start(ctx, workers)
 
 	cachePath := cachePathFor(cfg.OutDir)
 	execCache, err := loadExecutionCache(cachePath)
@@ -88,7 +225,6 @@ func Run(cfg *config.Config) error {
 		defer logx.SetOutput(nil)
 	}
 
-	ctx := context.Background()
 	runHash := computeRunHash(cfg, ordered)
 
 	// Inicializar checkpoint manager
@@ -137,12 +273,7 @@ func Run(cfg *config.Config) error {
 	}
 
 	buildStepsStart := time.Now()
-	var steps []toolStep
-	for _, name := range ordered {
-		if step, ok := defaultSteps[name]; ok {
-			steps = append(steps, step)
-		}
-	}
+	steps := buildSteps(ordered)
 	buildStepsDuration := time.Since(buildStepsStart)
 	logx.Trace("Orquestador: armado de steps", logx.Fields{
 		"duration_ms": buildStepsDuration.Milliseconds(),
@@ -166,6 +297,13 @@ func Run(cfg *config.Config) error {
 
 	metrics := newPipelineMetrics()
 	opts.metrics = metrics
+	// run.json se escribe también si la ejecución falla o se cancela a medias.
+	defer func() {
+		sink.Flush()
+		if err := writeRunSummary(cfg, sink, metrics, runStart, time.Now(), runErr); err != nil {
+			logx.Warn("Fallo escribir resumen de ejecución", logx.Fields{"error": err.Error()})
+		}
+	}()
 	sink.SetStepRecorder(metrics)
 	originalHTTPXHook := sources.HTTPXInputsHook
 	sources.HTTPXInputsHook = func(count int) {
@@ -190,12 +328,30 @@ func Run(cfg *config.Config) error {
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		logx.Warn("Ejecución cancelada", logx.Fields{"error": err.Error()})
+		return err
+	}
+
+	importNucleiResults(ctx, cfg, sink, metrics)
 	sink.Flush()
-	executePostProcessing(ctx, cfg, sink, bar, unknown)
+	executePostProcessing(ctx, cfg, sink, bar)
 	sink.Flush()
+	if err := sink.LastError(); err != nil {
+		logx.Warn("Fallo escribir artefactos", logx.Fields{"error": err.Error()})
+	}
 
-	if err := materializer.Materialize(cfg.OutDir); err != nil {
-		return err
+	// Con -stdout-stream los artefactos ya se emiten por stdout: no se
+	// materializan los ficheros .passive/.active por tipo.
+	if !cfg.StdoutStream {
+		if err := materializer.MaterializeWithDirs(cfg.OutDir, cfg.TypeDirs); err != nil {
+			return err
+		}
+	}
+	if cfg.SplitByType {
+		if err := artifacts.SplitByType(cfg.OutDir); err != nil {
+			logx.Warn("Fallo dividir artefactos por tipo", logx.Fields{"error": err.Error()})
+		}
 	}
 
 	// Eliminar checkpoint al completar exitosamente
@@ -211,6 +367,18 @@ func Run(cfg *config.Config) error {
 	return nil
 }
 
+// unknownToolsError describe las herramientas de -tools que no son integradas
+// ni están en el registro de fuentes, junto con las disponibles.
+func unknownToolsError(unknown []string) error {
+	available := append(append([]string(nil), defaultToolOrder...), sources.Registered()...)
+	return apperrors.NewConfigurationError(
+		"tools",
+		strings.Join(unknown, ","),
+		"herramientas desconocidas: "+strings.Join(unknown, ", "),
+		"herramientas disponibles: "+strings.Join(available, ", "),
+	)
+}
+
 func normalizeRequestedTools(cfg *config.Config) (map[string]bool, []string, []string) {
 	normalizeTool := func(name string) string {
 		return strings.ToLower(strings.TrimSpace(name))
@@ -231,11 +399,18 @@ func normalizeRequestedTools(cfg *config.Config) (map[string]bool, []string, []s
 	if (requested["waybackurls"] || requested["gau"]) && !requested["dedupe"] {
 		requested["dedupe"] = true
 	}
+	// -unauth-api-probe basta para pedir el sondeo sin autenticación.
+	if cfg.UnauthAPIProbe {
+		requested[toolUnauthAPI] = true
+	}
 
 	known := make(map[string]struct{}, len(defaultToolOrder))
 	for _, tool := range defaultToolOrder {
 		known[tool] = struct{}{}
 	}
+	for _, tool := range sources.Registered() {
+		known[tool] = struct{}{}
+	}
 
 	var ordered []string
 	seenOrdered := make(map[string]bool, len(defaultToolOrder))
@@ -248,16 +423,17 @@ func normalizeRequestedTools(cfg *config.Config) (map[string]bool, []string, []s
 		}
 	}
 
-	// Añadir desconocidas manteniendo el orden en que las pidió el usuario.
+	// Añadir las fuentes registradas y las desconocidas manteniendo el orden en
+	// que las pidió el usuario.
 	var unknown []string
 	for _, tool := range normalizedOrder {
 		if seenOrdered[tool] {
 			continue
 		}
+		ordered = append(ordered, tool)
+		seenOrdered[tool] = true
 		if _, ok := known[tool]; !ok {
-			ordered = append(ordered, tool)
 			unknown = append(unknown, tool)
-			seenOrdered[tool] = true
 		}
 	}
 
@@ -301,15 +477,103 @@ func runWithTimeout(parent context.Context, seconds int, fn func(context.Context
 	}
 }
 
-func prepareOutputDir(baseOutDir, target string) (string, error) {
-	sanitized := sanitizeTargetDir(target)
-	finalOutDir := filepath.Join(baseOutDir, sanitized)
+// writeConfigSnapshot guarda la configuración efectiva en
+// <outdir>/config.snapshot.json para poder reproducir la ejecución.
+func writeConfigSnapshot(cfg *config.Config) error {
+	f, err := os.Create(filepath.Join(cfg.OutDir, configSnapshotName))
+	if err != nil {
+		return err
+	}
+	if err := cfg.Snapshot(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// configureTrackingParams activa la eliminación de parámetros de tracking en
+// las rutas si la configuración lo pide.
+func configureTrackingParams(cfg *config.Config) {
+	if !cfg.StripTracking {
+		artifacts.SetTrackingParams(nil)
+		return
+	}
+	params := cfg.TrackingParams
+	if len(params) == 0 {
+		params = artifacts.DefaultTrackingParams
+	}
+	artifacts.SetTrackingParams(params)
+}
+
+// newArtifactProducer crea el productor de Kafka cuando la configuración
+// indica brokers y topic y, con StdoutStream, el stream NDJSON gzip hacia
+// stdout. Devuelve nil si el streaming está desactivado.
+func newArtifactProducer(cfg *config.Config) (pipeline.ArtifactProducer, error) {
+	var stdout pipeline.ArtifactProducer
+	if cfg.StdoutStream {
+		stdout = pipeline.NewGzipNDJSONProducer(streamOutput)
+	}
+	if len(cfg.KafkaBrokers) == 0 && strings.TrimSpace(cfg.KafkaTopic) == "" {
+		return stdout, nil
+	}
+	if len(cfg.KafkaBrokers) == 0 || strings.TrimSpace(cfg.KafkaTopic) == "" {
+		return nil, errors.New("kafka: se requieren -kafka-brokers y -kafka-topic")
+	}
+	producer, err := kafkaProducerFactory(cfg.KafkaBrokers, cfg.KafkaTopic)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.JoinProducers(producer, stdout), nil
+}
+
+// outDirNow permite fijar el reloj usado al resolver OutDirTemplate en tests.
+var outDirNow = time.Now
+
+func prepareOutputDir(baseOutDir, target, template string) (string, error) {
+	finalOutDir := resolveOutputDir(baseOutDir, target, template)
 	if err := os.MkdirAll(finalOutDir, 0o755); err != nil {
 		return "", err
 	}
 	return finalOutDir, nil
 }
 
+// resolveOutputDir devuelve el directorio de salida del target dentro de
+// baseOutDir, sin crearlo.
+func resolveOutputDir(baseOutDir, target, template string) string {
+	if strings.TrimSpace(template) != "" {
+		return filepath.Join(baseOutDir, resolveOutDirTemplate(template, target, outDirNow()))
+	}
+	return filepath.Join(baseOutDir, sanitizeTargetDir(target))
+}
+
+// resolveOutDirTemplate sustituye los placeholders {target}, {date} y {time}
+// de la plantilla. {target} conserva los puntos del dominio pero elimina
+// esquema y separadores de ruta para no escapar del directorio base.
+func resolveOutDirTemplate(template, target string, now time.Time) string {
+	replacer := strings.NewReplacer(
+		"{target}", templateTargetName(target),
+		"{date}", now.Format("2006-01-02"),
+		"{time}", now.Format("150405"),
+	)
+	return filepath.Clean(filepath.FromSlash(replacer.Replace(strings.TrimSpace(template))))
+}
+
+func templateTargetName(target string) string {
+	trimmed := strings.TrimSpace(target)
+	if strings.Contains(trimmed, "://") {
+		if u, err := url.Parse(trimmed); err == nil && u.Hostname() != "" {
+			trimmed = u.Hostname()
+		}
+	}
+	trimmed = strings.Trim(trimmed, "/")
+	replacer := strings.NewReplacer("/", "_", "\\", "_", ":", "_", "..", "_")
+	trimmed = strings.Trim(replacer.Replace(trimmed), "_.")
+	if trimmed == "" {
+		return "passive_rec"
+	}
+	return trimmed
+}
+
 func sanitizeTargetDir(target string) string {
 	trimmed := strings.TrimSpace(target)
 	if trimmed == "" {
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/url"
 	"os"
@@ -14,9 +18,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/adapters/sources"
 	"passive-rec/internal/core/pipeline"
 	"passive-rec/internal/core/runner"
 	"passive-rec/internal/platform/config"
+	apperrors "passive-rec/internal/platform/errors"
 )
 
 func TestRunWithTimeoutDefault(t *testing.T) {
@@ -89,6 +95,17 @@ func TestNormalizeRequestedToolsTrimsAndDeduplicates(t *testing.T) {
 	}
 }
 
+func TestNormalizeRequestedToolsAddsUnauthAPIWhenProbeEnabled(t *testing.T) {
+	cfg := &config.Config{Tools: []string{"httpx"}, UnauthAPIProbe: true}
+
+	_, ordered, _ := normalizeRequestedTools(cfg)
+
+	wantOrdered := selectFromOrder(defaultToolOrder, "httpx", toolUnauthAPI)
+	if diff := cmp.Diff(wantOrdered, ordered); diff != "" {
+		t.Fatalf("unexpected ordered tools (-want +got):\n%s", diff)
+	}
+}
+
 func selectFromOrder(order []string, names ...string) []string {
 	include := make(map[string]struct{}, len(names))
 	for _, name := range names {
@@ -118,8 +135,8 @@ func TestRunFlushesBeforeReportForDeferredSources(t *testing.T) {
 		flushes int
 	)
 
-	sinkFactory = func(outdir string, active bool, target string, scopeMode string, lineBuffer int) (sink, error) {
-		ts, err := newTestSink(outdir)
+	sinkFactory = func(sinkCfg pipeline.SinkConfig) (sink, error) {
+		ts, err := newTestSink(sinkCfg.Outdir)
 		if err != nil {
 			return nil, err
 		}
@@ -166,6 +183,166 @@ func TestRunFlushesBeforeReportForDeferredSources(t *testing.T) {
 	}
 }
 
+func TestWriteConfigSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Target:          "example.com",
+		OutDir:          dir,
+		Tools:           []string{"subfinder"},
+		CensysAPISecret: "topsecret",
+	}
+
+	if err := writeConfigSnapshot(cfg); err != nil {
+		t.Fatalf("writeConfigSnapshot: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, configSnapshotName))
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	if !strings.Contains(string(data), `"target": "example.com"`) {
+		t.Fatalf("snapshot missing target:\n%s", data)
+	}
+	if strings.Contains(string(data), "topsecret") {
+		t.Fatalf("snapshot leaks censys secret:\n%s", data)
+	}
+}
+
+func TestPrepareOutputDirResolvesTemplate(t *testing.T) {
+	originalNow := outDirNow
+	outDirNow = func() time.Time { return time.Date(2024, 6, 1, 9, 30, 5, 0, time.UTC) }
+	t.Cleanup(func() { outDirNow = originalNow })
+
+	base := t.TempDir()
+	got, err := prepareOutputDir(base, "https://example.com/", "results/{target}/{date}/{time}")
+	if err != nil {
+		t.Fatalf("prepareOutputDir: %v", err)
+	}
+	want := filepath.Join(base, "results", "example.com", "2024-06-01", "093005")
+	if got != want {
+		t.Fatalf("unexpected outdir: got %q want %q", got, want)
+	}
+	info, err := os.Stat(got)
+	if err != nil {
+		t.Fatalf("stat outdir: %v", err)
+	}
+	if !info.IsDir() {
+		t.Fatalf("expected %q to be a directory", got)
+	}
+}
+
+func TestPrepareOutputDirDefaultsWithoutTemplate(t *testing.T) {
+	base := t.TempDir()
+	got, err := prepareOutputDir(base, "example.com", "")
+	if err != nil {
+		t.Fatalf("prepareOutputDir: %v", err)
+	}
+	if want := filepath.Join(base, "example_com"); got != want {
+		t.Fatalf("unexpected outdir: got %q want %q", got, want)
+	}
+}
+
+func TestResolveOutDirTemplateStaysInsideBase(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	got := resolveOutDirTemplate("{target}/{date}", "../../etc", now)
+	if strings.HasPrefix(got, "..") {
+		t.Fatalf("template escaped the base dir: %q", got)
+	}
+}
+
+type stubProducer struct{}
+
+func (stubProducer) Produce(context.Context, []byte, []byte) error { return nil }
+func (stubProducer) Close() error                                  { return nil }
+
+func TestNewArtifactProducerRequiresBrokersAndTopic(t *testing.T) {
+	originalFactory := kafkaProducerFactory
+	t.Cleanup(func() { kafkaProducerFactory = originalFactory })
+
+	var gotBrokers []string
+	var gotTopic string
+	kafkaProducerFactory = func(brokers []string, topic string) (pipeline.ArtifactProducer, error) {
+		gotBrokers, gotTopic = brokers, topic
+		return stubProducer{}, nil
+	}
+
+	producer, err := newArtifactProducer(&config.Config{})
+	if err != nil || producer != nil {
+		t.Fatalf("expected streaming disabled by default, got %v, %v", producer, err)
+	}
+
+	if _, err := newArtifactProducer(&config.Config{KafkaTopic: "artifacts"}); err == nil {
+		t.Fatalf("expected error when brokers are missing")
+	}
+
+	producer, err = newArtifactProducer(&config.Config{KafkaBrokers: []string{"kafka:9092"}, KafkaTopic: "artifacts"})
+	if err != nil {
+		t.Fatalf("newArtifactProducer: %v", err)
+	}
+	if producer == nil {
+		t.Fatalf("expected producer when brokers and topic are set")
+	}
+	if diff := cmp.Diff([]string{"kafka:9092"}, gotBrokers); diff != "" || gotTopic != "artifacts" {
+		t.Fatalf("unexpected factory args: brokers diff %s topic %q", diff, gotTopic)
+	}
+}
+
+func TestRunStdoutStreamEmitsGzipArtifacts(t *testing.T) {
+	originalHTTPX := sourceHTTPX
+	originalOutput := streamOutput
+	t.Cleanup(func() {
+		sourceHTTPX = originalHTTPX
+		streamOutput = originalOutput
+	})
+
+	var stream bytes.Buffer
+	streamOutput = &stream
+	sourceHTTPX = func(ctx context.Context, outdir string, out chan<- string) error {
+		out <- "active: https://app.example.com/login"
+		return nil
+	}
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Target:       "example.com",
+		OutDir:       dir,
+		Workers:      1,
+		Active:       true,
+		Tools:        []string{"httpx"},
+		StdoutStream: true,
+	}
+	if err := Run(cfg); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	gz, err := gzip.NewReader(&stream)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	found := false
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var art artifacts.Artifact
+		if err := json.Unmarshal(scanner.Bytes(), &art); err != nil {
+			t.Fatalf("decode NDJSON line %q: %v", scanner.Text(), err)
+		}
+		if art.Type == "route" && art.Value == "https://app.example.com/login" && art.Active {
+			found = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected streamed route artifact")
+	}
+
+	routesDir := filepath.Join(cfg.OutDir, "routes")
+	if _, err := os.Stat(routesDir); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected per-type files to be suppressed, stat %q: %v", routesDir, err)
+	}
+}
+
 func TestComputeStepTimeoutUsesBaseAndDynamicCalculator(t *testing.T) {
 	state := &pipelineState{DedupedDomains: make([]string, 300)}
 	opts := orchestratorOptions{cfg: &config.Config{TimeoutS: 150, Workers: 3}}
@@ -299,6 +476,31 @@ func TestExecuteStepRespectsPreconditions(t *testing.T) {
 	}
 }
 
+func TestExecuteStepSkipsUnauthAPIWithoutProbeFlag(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	sink, err := newTestSink(dir)
+	if err != nil {
+		t.Fatalf("newTestSink: %v", err)
+	}
+
+	step := defaultSteps[toolUnauthAPI]
+	cfg := &config.Config{Active: true}
+	opts := orchestratorOptions{
+		cfg:       cfg,
+		sink:      sink,
+		requested: map[string]bool{toolUnauthAPI: true},
+	}
+	if task, ok := executeStep(ctx, step, &pipelineState{}, opts); ok || task != nil {
+		t.Fatalf("expected unauthapi to be skipped without -unauth-api-probe")
+	}
+
+	cfg.UnauthAPIProbe = true
+	if task, ok := executeStep(ctx, step, &pipelineState{}, opts); !ok || task == nil {
+		t.Fatalf("expected unauthapi to run with -unauth-api-probe and --active")
+	}
+}
+
 func TestExecuteStepHandlesErrors(t *testing.T) {
 	ctx := context.Background()
 	dir := t.TempDir()
@@ -434,7 +636,7 @@ func newTestSink(outdir string) (*testSink, error) {
 	}, nil
 }
 
-func (s *testSink) Start(workers int) {
+func (s *testSink) Start(ctx context.Context, workers int) {
 	// No background workers required for the test sink.
 }
 
@@ -532,6 +734,10 @@ func (s *testSink) Close() error {
 	return nil
 }
 
+func (s *testSink) LastError() error {
+	return nil
+}
+
 func (s *testSink) SetStepRecorder(rec pipeline.StepRecorder) {
 	s.recorder = rec
 }
@@ -585,3 +791,136 @@ func appendLine(path, line string) {
 		panic(err)
 	}
 }
+
+func TestRunResolvesRegisteredSources(t *testing.T) {
+	sources.Register("fake-source", func(ctx context.Context, target string, out chan<- string) error {
+		out <- "custom." + target
+		out <- "https://custom." + target + "/login"
+		return nil
+	})
+	t.Cleanup(func() { sources.Unregister("fake-source") })
+
+	cfg := &config.Config{
+		Target:  "example.com",
+		OutDir:  t.TempDir(),
+		Workers: 1,
+		Tools:   []string{"Fake-Source"},
+	}
+	if err := Run(cfg); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(cfg.OutDir, "artifacts.jsonl"))
+	if err != nil {
+		t.Fatalf("open manifest: %v", err)
+	}
+	defer f.Close()
+	reader, err := artifacts.NewReaderV2(f)
+	if err != nil {
+		t.Fatalf("NewReaderV2: %v", err)
+	}
+	list, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	found := map[string]bool{}
+	for _, art := range list {
+		if art.Tool == "fake-source" {
+			found[art.Type+" "+art.Value] = true
+		}
+	}
+	for _, want := range []string{"domain custom.example.com", "route https://custom.example.com/login"} {
+		if !found[want] {
+			t.Fatalf("expected %q from the registered source, got %+v", want, list)
+		}
+	}
+}
+
+func TestRunSplitsArtifactsByType(t *testing.T) {
+	sources.Register("split-source", func(ctx context.Context, target string, out chan<- string) error {
+		out <- "split." + target
+		out <- "https://split." + target + "/login"
+		return nil
+	})
+	t.Cleanup(func() { sources.Unregister("split-source") })
+
+	cfg := &config.Config{
+		Target:      "example.com",
+		OutDir:      t.TempDir(),
+		Workers:     1,
+		Tools:       []string{"split-source"},
+		SplitByType: true,
+	}
+	if err := Run(cfg); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	for _, name := range []string{"domain.jsonl", "route.jsonl"} {
+		if _, err := os.Stat(filepath.Join(cfg.OutDir, "artifacts", name)); err != nil {
+			t.Fatalf("expected %s in the split directory: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cfg.OutDir, "artifacts", "certificate.jsonl")); !os.IsNotExist(err) {
+		t.Fatalf("expected no file for a type without artifacts, got %v", err)
+	}
+}
+
+func TestRunRejectsUnknownTools(t *testing.T) {
+	cfg := &config.Config{
+		Target:  "example.com",
+		OutDir:  t.TempDir(),
+		Workers: 1,
+		Tools:   []string{"subfinder", "nope"},
+	}
+	err := Run(cfg)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown tool")
+	}
+	var cfgErr *apperrors.ConfigurationError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "tools" {
+		t.Fatalf("expected a tools ConfigurationError, got %v", err)
+	}
+	for _, want := range []string{"nope", "subfinder", "linkfinderevo"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error to mention %q, got %v", want, err)
+		}
+	}
+}
+
+func TestRunUploadsOutputAfterClosingSink(t *testing.T) {
+	sources.Register("upload-source", func(ctx context.Context, target string, out chan<- string) error {
+		out <- "up." + target
+		return nil
+	})
+	t.Cleanup(func() { sources.Unregister("upload-source") })
+
+	var uploadedDir, uploadedDst string
+	var seen []string
+	original := uploadOutput
+	uploadOutput = func(ctx context.Context, dir, dst string) error {
+		uploadedDir, uploadedDst = dir, dst
+		for _, name := range []string{"artifacts.jsonl", "run.json"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				seen = append(seen, name)
+			}
+		}
+		return errors.New("access denied")
+	}
+	t.Cleanup(func() { uploadOutput = original })
+
+	cfg := &config.Config{
+		Target:  "example.com",
+		OutDir:  t.TempDir(),
+		Workers: 1,
+		Tools:   []string{"upload-source"},
+		Upload:  "s3://results/scans",
+	}
+	if err := Run(cfg); err != nil {
+		t.Fatalf("an upload failure must not change the run result, got %v", err)
+	}
+	if uploadedDir != cfg.OutDir || uploadedDst != "s3://results/scans" {
+		t.Fatalf("upload called with (%q, %q), want (%q, %q)", uploadedDir, uploadedDst, cfg.OutDir, "s3://results/scans")
+	}
+	if diff := cmp.Diff([]string{"artifacts.jsonl", "run.json"}, seen); diff != "" {
+		t.Fatalf("expected the output to be written before uploading (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"passive-rec/internal/adapters/sources"
+	"passive-rec/internal/core/runner"
+	"passive-rec/internal/platform/config"
+	apperrors "passive-rec/internal/platform/errors"
+)
+
+var (
+	// dryRunOutput y dryRunFindBin permiten capturar el plan de -dry-run y
+	// simular binarios presentes o ausentes en tests.
+	dryRunOutput  io.Writer = os.Stdout
+	dryRunFindBin           = runner.FindBin
+)
+
+// plannedStep es una línea del plan de -dry-run.
+type plannedStep struct {
+	Name    string
+	Group   string
+	Binary  string // binario encontrado o, si falta, los nombres buscados
+	Status  string
+	Missing bool
+	// Commands son las líneas de comandos que ejecutaría; vacío si se omite
+	// o es nativa.
+	Commands []string
+}
+
+// dryRun valida target y directorio de salida, resuelve las herramientas que
+// se ejecutarían y escribe el plan en dryRunOutput sin crear el sink ni
+// escribir nada en disco. Devuelve error si falta el binario de alguna
+// herramienta que se ejecutaría, salvo con DryRunAllowMissing.
+func dryRun(cfg *config.Config, requested map[string]bool, ordered []string) error {
+	if err := validateDryRunTarget(cfg.Target); err != nil {
+		return err
+	}
+	outDir := resolveOutputDir(cfg.OutDir, cfg.Target, cfg.OutDirTemplate)
+	if err := checkOutputDir(outDir); err != nil {
+		return err
+	}
+
+	plan := planSteps(cfg, requested, buildSteps(ordered))
+	fmt.Fprintf(dryRunOutput, "Plan de ejecución para %s (salida: %s)\n", cfg.Target, outDir)
+	tw := tabwriter.NewWriter(dryRunOutput, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "HERRAMIENTA\tGRUPO\tBINARIO\tESTADO")
+	var missing []error
+	for _, step := range plan {
+		group := step.Group
+		if group == "" {
+			group = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", step.Name, group, step.Binary, step.Status)
+		if step.Missing {
+			missing = append(missing, apperrors.NewMissingBinaryError(sources.ToolBinaries[step.Name][0]))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, step := range plan {
+		for _, command := range step.Commands {
+			fmt.Fprintf(dryRunOutput, "  %s: %s\n", step.Name, command)
+		}
+	}
+
+	if len(missing) == 0 {
+		fmt.Fprintln(dryRunOutput, "Todos los binarios necesarios están disponibles.")
+		return nil
+	}
+	fmt.Fprintf(dryRunOutput, "Faltan %d binarios; instálalos con: go run ./cmd/install-deps\n", len(missing))
+	if cfg.DryRunAllowMissing {
+		return nil
+	}
+	return fmt.Errorf("dry-run: %w", errors.Join(missing...))
+}
+
+// planSteps decide para cada step si se ejecutaría y si su binario está en
+// el PATH, con los mismos criterios estáticos que shouldRunStep, y qué
+// comandos lanzaría.
+func planSteps(cfg *config.Config, requested map[string]bool, steps []toolStep) []plannedStep {
+	domainScope := strings.ToLower(strings.TrimSpace(cfg.Scope)) == "domain"
+	plan := make([]plannedStep, 0, len(steps))
+	for _, step := range steps {
+		if !requested[step.Name] {
+			continue
+		}
+		planned := plannedStep{Name: step.Name, Group: step.Group, Binary: "(nativa)", Status: "OK"}
+		candidates := sources.ToolBinaries[step.Name]
+		if len(candidates) > 0 {
+			planned.Binary = strings.Join(candidates, "|")
+		}
+		switch {
+		case domainScope && isSubdomainEnumerationTool(step.Name):
+			planned.Status = "omitida (scope=domain)"
+		case step.RequiresActive && !cfg.Active:
+			planned.Status = "omitida (requiere -active)"
+		case len(candidates) > 0:
+			bin, ok := dryRunFindBin(candidates...)
+			if ok {
+				planned.Binary = bin
+			} else {
+				bin = candidates[0]
+				planned.Status = "FALTA"
+				planned.Missing = true
+			}
+			planned.Commands = sources.ToolCommands(step.Name, bin, cfg.Target, cfg.Active)
+		}
+		plan = append(plan, planned)
+	}
+	return plan
+}
+
+// buildSteps traduce las herramientas ordenadas a steps del pipeline: los
+// predefinidos y, después, las fuentes registradas con sources.Register.
+func buildSteps(ordered []string) []toolStep {
+	var steps []toolStep
+	for _, name := range ordered {
+		if step, ok := defaultSteps[name]; ok {
+			steps = append(steps, step)
+		} else if fn, ok := sources.Lookup(name); ok {
+			steps = append(steps, registeredSourceStep(name, fn))
+		}
+	}
+	return steps
+}
+
+func validateDryRunTarget(target string) error {
+	trimmed := strings.TrimSpace(target)
+	if trimmed == "" {
+		return apperrors.NewConfigurationError("target", target, "target vacío", "indica el dominio con -target example.com")
+	}
+	if strings.ContainsAny(trimmed, " \t\r\n") {
+		return apperrors.NewConfigurationError("target", target, "el target no puede contener espacios", "indica un único dominio, ej: -target example.com")
+	}
+	return nil
+}
+
+// checkOutputDir comprueba que dir pueda crearse: el primer ancestro que
+// existe debe ser un directorio. No crea nada.
+func checkOutputDir(dir string) error {
+	current := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(current)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("outdir %q: %s no es un directorio", dir, current)
+			}
+			return nil
+		}
+		// ENOTDIR: un componente intermedio es un fichero; se detecta al
+		// llegar a él.
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, syscall.ENOTDIR) {
+			return fmt.Errorf("outdir %q: %w", dir, err)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil
+		}
+		current = parent
+	}
+}
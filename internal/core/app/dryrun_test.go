@@ -0,0 +1,122 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"passive-rec/internal/core/pipeline"
+	"passive-rec/internal/platform/config"
+	apperrors "passive-rec/internal/platform/errors"
+)
+
+func stubDryRun(t *testing.T, installed ...string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	originalOutput, originalFind, originalSink := dryRunOutput, dryRunFindBin, sinkFactory
+	dryRunOutput = &buf
+	dryRunFindBin = func(names ...string) (string, bool) {
+		for _, name := range names {
+			for _, bin := range installed {
+				if name == bin {
+					return name, true
+				}
+			}
+		}
+		return "", false
+	}
+	sinkFactory = func(pipeline.SinkConfig) (sink, error) {
+		t.Fatal("dry-run must not create the sink")
+		return nil, nil
+	}
+	t.Cleanup(func() {
+		dryRunOutput, dryRunFindBin, sinkFactory = originalOutput, originalFind, originalSink
+	})
+	return &buf
+}
+
+func TestRunDryRunReportsMissingBinaries(t *testing.T) {
+	buf := stubDryRun(t, "subfinder", "httpx-toolkit")
+	base := t.TempDir()
+	cfg := &config.Config{
+		Target: "example.com",
+		OutDir: base,
+		Tools:  []string{"subfinder", "crtsh", "httpx", "subjs", "gau"},
+		Active: true,
+		DryRun: true,
+	}
+
+	err := Run(cfg)
+	var missing *apperrors.MissingBinaryError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected a missing binary error, got %v", err)
+	}
+	for _, bin := range []string{"subjs", "gau"} {
+		if !strings.Contains(err.Error(), "'"+bin+"'") {
+			t.Fatalf("expected %s to be reported as missing, got %v", bin, err)
+		}
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"subfinder", "httpx-toolkit", "(nativa)", "gau|getallurls", "FALTA", "go run ./cmd/install-deps",
+		"  subfinder: subfinder -d example.com -silent\n",
+		"  httpx: httpx-toolkit -l <lista> -silent",
+		"  gau: gau example.com\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in the plan:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(base, "example_com")); !os.IsNotExist(err) {
+		t.Fatalf("dry-run must not create the output directory, stat err=%v", err)
+	}
+}
+
+func TestRunDryRunAllowMissingAndSkipsInactiveTools(t *testing.T) {
+	buf := stubDryRun(t)
+	cfg := &config.Config{
+		Target:             "example.com",
+		OutDir:             t.TempDir(),
+		Tools:              []string{"httpx", "crtsh"},
+		DryRun:             true,
+		DryRunAllowMissing: false,
+	}
+
+	// Sin -active httpx no se ejecutaría, así que su binario no es necesario.
+	if err := Run(cfg); err != nil {
+		t.Fatalf("expected no error when only inactive tools lack binaries, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "omitida (requiere -active)") {
+		t.Fatalf("expected httpx to be reported as skipped:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "httpx: ") {
+		t.Fatalf("skipped tools must not list a command:\n%s", buf.String())
+	}
+
+	cfg.Active = true
+	cfg.DryRunAllowMissing = true
+	if err := Run(cfg); err != nil {
+		t.Fatalf("expected -dry-run-allow-missing to ignore missing binaries, got %v", err)
+	}
+}
+
+func TestRunDryRunValidatesTargetAndOutDir(t *testing.T) {
+	stubDryRun(t)
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg := &config.Config{Target: "example.com", OutDir: file, Tools: []string{"crtsh"}, DryRun: true}
+	if err := Run(cfg); err == nil || !strings.Contains(err.Error(), "no es un directorio") {
+		t.Fatalf("expected an outdir error, got %v", err)
+	}
+
+	cfg = &config.Config{Target: "  ", OutDir: t.TempDir(), Tools: []string{"crtsh"}, DryRun: true}
+	if err := Run(cfg); err == nil || !strings.Contains(err.Error(), "target") {
+		t.Fatalf("expected a target error, got %v", err)
+	}
+}
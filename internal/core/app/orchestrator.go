@@ -12,10 +12,12 @@ import (
 	"time"
 
 	"passive-rec/internal/adapters/report"
+	"passive-rec/internal/adapters/sources"
 	"passive-rec/internal/core/pipeline"
 	"passive-rec/internal/core/runner"
 	"passive-rec/internal/platform/config"
 	"passive-rec/internal/platform/logx"
+	"passive-rec/internal/platform/netutil"
 )
 
 type stepFunc func(context.Context, *pipelineState, orchestratorOptions) error
@@ -56,7 +58,17 @@ const (
 	toolSubJS         = "subjs"
 	toolLinkFinderEVO = "linkfinderevo"
 	toolDNSX          = "dnsx"
-	toolUnknown       = "unknown"
+	toolOpenAPI       = "openapi"
+	toolBackups       = "backups"
+	toolReflection    = "reflection"
+	toolOIDC          = "oidc"
+	toolPHPInfo       = "phpinfo"
+	toolDependencies  = "dependencies"
+	toolUnauthAPI     = "unauthapi"
+	toolMetrics       = "metrics"
+	toolDNSWildcard   = "dnswildcard"
+	toolProtocols     = "protocols"
+	toolFavicon       = "favicon"
 )
 
 type orchestratorOptions struct {
@@ -138,6 +150,14 @@ func toolInputChannel(ctx context.Context, s sink, tool, _ string, _ *pipelineMe
 // --- Definición del pipeline -----------------------------------------------------
 
 var defaultPipeline = []toolStep{
+	// Va primero para que los subdominios de una zona comodín se marquen al
+	// registrarse.
+	{
+		Name:                toolDNSWildcard,
+		Run:                 stepDNSWildcard,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: dnswildcard skipped (requires --active)",
+	},
 	{Name: toolAmass, Group: "subdomain-sources", Run: stepAmass},
 	{Name: toolSubfinder, Group: "subdomain-sources", Run: stepSubfinder},
 	{Name: toolAssetfinder, Group: "subdomain-sources", Run: stepAssetfinder},
@@ -186,6 +206,67 @@ var defaultPipeline = []toolStep{
 		SkipInactiveMessage: "meta: linkfinderevo skipped (requires --active)",
 		Timeout:             timeoutLinkFinderEVO,
 	},
+	{
+		Name:                toolOpenAPI,
+		Run:                 stepOpenAPI,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: openapi skipped (requires --active)",
+	},
+	{
+		Name:                toolBackups,
+		Run:                 stepBackups,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: backups skipped (requires --active)",
+	},
+	{
+		Name:                toolReflection,
+		Run:                 stepReflection,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: reflection skipped (requires --active)",
+	},
+	{
+		Name:                toolOIDC,
+		Run:                 stepOIDC,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: oidc skipped (requires --active)",
+	},
+	{
+		Name:                toolPHPInfo,
+		Run:                 stepPHPInfo,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: phpinfo skipped (requires --active)",
+	},
+	{
+		Name:                toolDependencies,
+		Run:                 stepDependencies,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: dependencies skipped (requires --active)",
+	},
+	{
+		Name:                toolUnauthAPI,
+		Run:                 stepUnauthAPI,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: unauthapi skipped (requires --active)",
+		Precondition:        requireUnauthAPIProbe,
+	},
+	{
+		Name:                toolMetrics,
+		Run:                 stepMetrics,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: metrics skipped (requires --active)",
+	},
+	{
+		Name:                toolProtocols,
+		Run:                 stepProtocols,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: protocols skipped (requires --active)",
+	},
+	{
+		Name:                toolFavicon,
+		Run:                 stepFavicon,
+		RequiresActive:      true,
+		SkipInactiveMessage: "meta: favicon skipped (requires --active)",
+	},
 }
 
 var (
@@ -520,6 +601,22 @@ func buildStepMap(steps []toolStep) map[string]toolStep {
 	return m
 }
 
+// registeredSourceStep construye el step de una fuente del registro de
+// sources: recibe el target y emite sus líneas etiquetadas con su nombre.
+func registeredSourceStep(name string, fn sources.SourceFunc) toolStep {
+	return toolStep{
+		Name: name,
+		Run: func(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+			if opts.metrics != nil {
+				opts.metrics.RecordInputs(name, "", 1)
+			}
+			input, done := toolInputChannel(ctx, opts.sink, name, "", opts.metrics)
+			defer done()
+			return fn(ctx, opts.cfg.Target, input)
+		},
+	}
+}
+
 func requireDedupedDomains(message string) preconditionFunc {
 	return func(state *pipelineState, _ orchestratorOptions) (bool, string) {
 		if len(state.DedupedDomains) == 0 {
@@ -529,6 +626,15 @@ func requireDedupedDomains(message string) preconditionFunc {
 	}
 }
 
+// requireUnauthAPIProbe omite el sondeo sin autenticación salvo que se
+// habilite expresamente con -unauth-api-probe.
+func requireUnauthAPIProbe(_ *pipelineState, opts orchestratorOptions) (bool, string) {
+	if opts.cfg == nil || !opts.cfg.UnauthAPIProbe {
+		return false, "meta: unauthapi skipped (requires -unauth-api-probe)"
+	}
+	return true, ""
+}
+
 // --- Steps ----------------------------------------------------------------------
 
 func stepAmass(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
@@ -540,6 +646,15 @@ func stepAmass(ctx context.Context, _ *pipelineState, opts orchestratorOptions)
 	return sourceAmass(ctx, opts.cfg.Target, input, opts.cfg.Active)
 }
 
+func stepDNSWildcard(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolDNSWildcard, "", opts.metrics)
+	err := sourceDNSWildcard(ctx, opts.cfg.Target, input)
+	done()
+	// La zona wildcard debe estar marcada antes de procesar los subdominios.
+	opts.sink.Flush()
+	return err
+}
+
 func stepSubfinder(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
 	if opts.metrics != nil {
 		opts.metrics.RecordInputs(toolSubfinder, "subdomain-sources", 1)
@@ -638,7 +753,18 @@ func stepDNSX(ctx context.Context, state *pipelineState, opts orchestratorOption
 	}
 	input, done := toolInputChannel(ctx, opts.sink, toolDNSX, "", opts.metrics)
 	defer done()
-	return sourceDNSX(ctx, state.DedupedDomains, opts.cfg.OutDir, input)
+	return sourceDNSX(ctx, dnsxDomains(state.DedupedDomains, opts.cfg.Target), opts.cfg.OutDir, input)
+}
+
+// dnsxDomains añade _dmarc.<target> a los dominios que resuelve dnsx: el
+// registro DMARC no cuelga de ningún dominio descubierto y el análisis de
+// correo lo necesita para no darlo por ausente.
+func dnsxDomains(domains []string, target string) []string {
+	target = netutil.NormalizeDomain(target)
+	if target == "" {
+		return domains
+	}
+	return append(append(make([]string, 0, len(domains)+1), domains...), "_dmarc."+target)
 }
 
 func stepSubJS(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
@@ -653,6 +779,66 @@ func stepLinkFinderEVO(ctx context.Context, _ *pipelineState, opts orchestratorO
 	return sourceLinkFinderEVO(ctx, opts.cfg.Target, opts.cfg.OutDir, input)
 }
 
+func stepOpenAPI(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolOpenAPI, "", opts.metrics)
+	defer done()
+	return sourceOpenAPI(ctx, opts.cfg.OutDir, input)
+}
+
+func stepBackups(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolBackups, "", opts.metrics)
+	defer done()
+	return sourceBackups(ctx, opts.cfg.OutDir, input)
+}
+
+func stepReflection(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolReflection, "", opts.metrics)
+	defer done()
+	return sourceReflection(ctx, opts.cfg.OutDir, input)
+}
+
+func stepOIDC(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolOIDC, "", opts.metrics)
+	defer done()
+	return sourceOIDC(ctx, opts.cfg.OutDir, input)
+}
+
+func stepPHPInfo(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolPHPInfo, "", opts.metrics)
+	defer done()
+	return sourcePHPInfo(ctx, opts.cfg.OutDir, input)
+}
+
+func stepDependencies(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolDependencies, "", opts.metrics)
+	defer done()
+	return sourceDependencies(ctx, opts.cfg.OutDir, input)
+}
+
+func stepUnauthAPI(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolUnauthAPI, "", opts.metrics)
+	defer done()
+	return sourceUnauthAPI(ctx, opts.cfg.OutDir, input)
+}
+
+func stepMetrics(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolMetrics, "", opts.metrics)
+	defer done()
+	return sourceMetrics(ctx, opts.cfg.OutDir, input)
+}
+
+func stepProtocols(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolProtocols, "", opts.metrics)
+	defer done()
+	return sourceProtocols(ctx, opts.cfg.OutDir, input)
+}
+
+func stepFavicon(ctx context.Context, _ *pipelineState, opts orchestratorOptions) error {
+	input, done := toolInputChannel(ctx, opts.sink, toolFavicon, "", opts.metrics)
+	defer done()
+	return sourceFavicon(ctx, opts.cfg.OutDir, input)
+}
+
 // --- Timeouts dependientes del input -------------------------------------------
 
 func timeoutWaybackurls(state *pipelineState, opts orchestratorOptions) int {
@@ -712,11 +898,9 @@ func timeoutLinkFinderEVO(state *pipelineState, opts orchestratorOptions) int {
 	return timeout
 }
 
-// --- Unknown tools & cache messaging --------------------------------------------
-
-func executePostProcessing(ctx context.Context, cfg *config.Config, sink sink, bar *progressBar, unknown []string) {
-	notifyUnknownTools(sink, bar, unknown)
+// --- Post-proceso & cache messaging --------------------------------------------
 
+func executePostProcessing(ctx context.Context, cfg *config.Config, sink sink, bar *progressBar) {
 	if cfg.Report {
 		if err := report.GenerateV2(ctx, cfg); err != nil {
 			logx.Warn("Fallo generar reportes", logx.Fields{"error": err.Error()})
@@ -735,17 +919,6 @@ func executePostProcessing(ctx context.Context, cfg *config.Config, sink sink, b
 	}
 }
 
-func notifyUnknownTools(sink sink, bar *progressBar, unknown []string) {
-	for _, tool := range unknown {
-		if sink != nil {
-			sink.In() <- pipeline.WrapWithTool(toolUnknown, fmt.Sprintf("meta: unknown tool: %s", tool))
-		}
-		if bar != nil {
-			bar.StepDone(tool, "desconocido")
-		}
-	}
-}
-
 func announceCacheReuse(step toolStep, opts orchestratorOptions, completedAt time.Time) {
 	emitWithTool(opts, step.Name, fmt.Sprintf("meta: %s reutilizado desde cache%s", step.Name, cacheAgeSuffix(completedAt)))
 	if opts.bar != nil {
@@ -27,10 +27,11 @@ func newNoopSink() *noopSink {
 	return &noopSink{ch: make(chan string, 10)}
 }
 
-func (s *noopSink) Start(int)                             {}
+func (s *noopSink) Start(context.Context, int)            {}
 func (s *noopSink) In() chan<- string                     { return s.ch }
 func (s *noopSink) Flush()                                {}
 func (s *noopSink) Close() error                          { close(s.ch); return nil }
+func (s *noopSink) LastError() error                      { return nil }
 func (s *noopSink) SetStepRecorder(pipeline.StepRecorder) {}
 
 func TestRunPipelineConcurrentGroupProgress(t *testing.T) {
@@ -132,7 +133,7 @@ func TestRunPipelineConcurrentSourcesDedupesSink(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewSink: %v", err)
 	}
-	sink.Start(4)
+	sink.Start(context.Background(), 4)
 	t.Cleanup(func() {
 		sink.Flush()
 		_ = sink.Close()
@@ -316,6 +317,20 @@ func TestStepDedupePreparesAndDNSXRunsWhenActive(t *testing.T) {
 	}
 }
 
+func TestDNSXDomainsAddsTargetDMARCLookup(t *testing.T) {
+	domains := []string{"one.example.com"}
+	got := dnsxDomains(domains, "Example.com")
+	if diff := cmp.Diff([]string{"one.example.com", "_dmarc.example.com"}, got); diff != "" {
+		t.Fatalf("unexpected dnsx domains (-want +got):\n%s", diff)
+	}
+	if len(domains) != 1 {
+		t.Fatalf("dnsxDomains must not modify the deduped domains, got %v", domains)
+	}
+	if diff := cmp.Diff(domains, dnsxDomains(domains, "")); diff != "" {
+		t.Fatalf("expected the domains unchanged without a target (-want +got):\n%s", diff)
+	}
+}
+
 func writeOrchestratorArtifacts(t *testing.T, outdir string, artifactsList []artifacts.Artifact) {
 	t.Helper()
 	path := filepath.Join(outdir, "artifacts.jsonl")
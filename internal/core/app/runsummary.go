@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/core/pipeline"
+	"passive-rec/internal/platform/config"
+)
+
+const runSummaryName = "run.json"
+
+// Estados de una herramienta en run.json.
+const (
+	runToolOK      = "ok"
+	runToolFailed  = "failed"
+	runToolSkipped = "skipped"
+)
+
+// Estados de la ejecución completa en run.json.
+const (
+	runStatusCompleted = "completed"
+	runStatusCancelled = "cancelled"
+	runStatusFailed    = "failed"
+)
+
+// runSummary es el contenido de <outdir>/run.json: un resumen legible por
+// máquina de lo ocurrido en la ejecución.
+type runSummary struct {
+	Target          string           `json:"target"`
+	OutDir          string           `json:"outdir"`
+	Status          string           `json:"status"`
+	Error           string           `json:"error,omitempty"`
+	Start           string           `json:"start"`
+	End             string           `json:"end"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Tools           []runToolEntry   `json:"tools"`
+	ArtifactsByType map[string]int   `json:"artifacts_by_type"`
+	Handlers        []runHandlerStat `json:"handlers,omitempty"`
+}
+
+type runToolEntry struct {
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Detail          string  `json:"detail,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+}
+
+type runHandlerStat struct {
+	Name      string `json:"name"`
+	Count     uint64 `json:"count"`
+	TotalNS   int64  `json:"total_ns"`
+	AverageNS int64  `json:"average_ns"`
+}
+
+// handlerMetricsSource lo implementan los sinks que exponen las métricas de
+// sus handlers (pipeline.Sink).
+type handlerMetricsSource interface {
+	HandlerMetrics() []pipeline.HandlerMetric
+}
+
+// writeRunSummary escribe <outdir>/run.json con los tiempos de la ejecución,
+// el estado de cada herramienta, los artefactos por tipo del manifiesto y las
+// métricas de los handlers del sink. runErr determina el estado global: nil es
+// una ejecución completa y un error de contexto, una cancelada.
+func writeRunSummary(cfg *config.Config, s sink, metrics *pipelineMetrics, start, end time.Time, runErr error) error {
+	summary := runSummary{
+		Target:          cfg.Target,
+		OutDir:          cfg.OutDir,
+		Status:          runStatusCompleted,
+		Start:           start.UTC().Format(time.RFC3339Nano),
+		End:             end.UTC().Format(time.RFC3339Nano),
+		DurationSeconds: secondsWithMillis(end.Sub(start)),
+		Tools:           runToolEntries(metrics),
+	}
+	if runErr != nil {
+		summary.Status = runStatusFailed
+		if isCancellation(runErr) {
+			summary.Status = runStatusCancelled
+		}
+		summary.Error = runErr.Error()
+	}
+
+	counts, err := countArtifactsByType(cfg.OutDir)
+	if err != nil {
+		return err
+	}
+	summary.ArtifactsByType = counts
+
+	if source, ok := s.(handlerMetricsSource); ok {
+		for _, metric := range source.HandlerMetrics() {
+			summary.Handlers = append(summary.Handlers, runHandlerStat{
+				Name:      metric.Name,
+				Count:     metric.Count,
+				TotalNS:   metric.Total.Nanoseconds(),
+				AverageNS: metric.Average.Nanoseconds(),
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cfg.OutDir, runSummaryName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// runToolEntries traduce las métricas de cada step al estado de run.json. Las
+// herramientas sin binario (runner.ErrMissingBinary) o saltadas por cache o
+// precondiciones cuentan como "skipped", no como fallos.
+func runToolEntries(metrics *pipelineMetrics) []runToolEntry {
+	summaries := metrics.Summaries()
+	entries := make([]runToolEntry, 0, len(summaries))
+	for _, metric := range summaries {
+		entry := runToolEntry{
+			Name:            metric.Name,
+			DurationSeconds: secondsWithMillis(metric.Duration),
+		}
+		switch {
+		case metric.Skipped:
+			entry.Status = runToolSkipped
+			entry.Detail = metric.SkipReason
+		case metric.Status == "ok":
+			entry.Status = runToolOK
+		case metric.Status == "faltante":
+			entry.Status = runToolSkipped
+			entry.Detail = "missing binary"
+		default:
+			entry.Status = runToolFailed
+			entry.Detail = metric.Status
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// countArtifactsByType cuenta los artefactos del manifiesto de outdir por
+// tipo. Un manifiesto inexistente devuelve un mapa vacío.
+func countArtifactsByType(outdir string) (map[string]int, error) {
+	counts := make(map[string]int)
+	f, err := os.Open(filepath.Join(outdir, "artifacts.jsonl"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return counts, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		return counts, nil
+	}
+
+	reader, err := artifacts.NewReaderV2(f)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		art, err := reader.ReadArtifact()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		counts[art.Type]++
+	}
+	return counts, nil
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"passive-rec/internal/core/pipeline"
+	"passive-rec/internal/core/runner"
+	"passive-rec/internal/platform/config"
+)
+
+func readRunSummary(t *testing.T, outdir string) runSummary {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(outdir, runSummaryName))
+	if err != nil {
+		t.Fatalf("read run summary: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("decode run summary: %v", err)
+	}
+	return summary
+}
+
+func toolStatuses(summary runSummary) map[string]string {
+	statuses := make(map[string]string, len(summary.Tools))
+	for _, tool := range summary.Tools {
+		statuses[tool.Name] = tool.Status
+	}
+	return statuses
+}
+
+func TestRunWritesRunSummary(t *testing.T) {
+	originalSinkFactory := sinkFactory
+	originalSubfinder := sourceSubfinder
+	originalAssetfinder := sourceAssetfinder
+	t.Cleanup(func() {
+		sinkFactory = originalSinkFactory
+		sourceSubfinder = originalSubfinder
+		sourceAssetfinder = originalAssetfinder
+	})
+
+	sinkFactory = func(sinkCfg pipeline.SinkConfig) (sink, error) {
+		return newTestSink(sinkCfg.Outdir)
+	}
+	sourceSubfinder = func(ctx context.Context, target string, out chan<- string) error {
+		out <- "api.example.com"
+		out <- "www.example.com"
+		return nil
+	}
+	sourceAssetfinder = func(ctx context.Context, target string, out chan<- string) error {
+		return runner.ErrMissingBinary
+	}
+
+	cfg := &config.Config{
+		Target:  "example.com",
+		OutDir:  t.TempDir(),
+		Workers: 1,
+		Tools:   []string{"subfinder", "assetfinder"},
+	}
+	if err := Run(cfg); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	summary := readRunSummary(t, cfg.OutDir)
+	if summary.Target != "example.com" || summary.OutDir != cfg.OutDir {
+		t.Fatalf("unexpected target/outdir: %+v", summary)
+	}
+	if summary.Status != runStatusCompleted {
+		t.Fatalf("expected status %q, got %q", runStatusCompleted, summary.Status)
+	}
+	start, err := time.Parse(time.RFC3339Nano, summary.Start)
+	if err != nil {
+		t.Fatalf("parse start: %v", err)
+	}
+	end, err := time.Parse(time.RFC3339Nano, summary.End)
+	if err != nil {
+		t.Fatalf("parse end: %v", err)
+	}
+	if end.Before(start) {
+		t.Fatalf("expected end after start, got %s < %s", end, start)
+	}
+
+	statuses := toolStatuses(summary)
+	if statuses["subfinder"] != runToolOK {
+		t.Fatalf("expected subfinder ok, got %v", statuses)
+	}
+	if statuses["assetfinder"] != runToolSkipped {
+		t.Fatalf("expected assetfinder skipped for missing binary, got %v", statuses)
+	}
+	if got := summary.ArtifactsByType["domain"]; got < 2 {
+		t.Fatalf("expected the subfinder domains to be counted, got %d (%v)", got, summary.ArtifactsByType)
+	}
+}
+
+func TestRunWritesRunSummaryWhenCancelled(t *testing.T) {
+	originalSinkFactory := sinkFactory
+	originalSubfinder := sourceSubfinder
+	originalRunContext := runContext
+	t.Cleanup(func() {
+		sinkFactory = originalSinkFactory
+		sourceSubfinder = originalSubfinder
+		runContext = originalRunContext
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runContext = func() (context.Context, context.CancelFunc) { return ctx, cancel }
+	sinkFactory = func(sinkCfg pipeline.SinkConfig) (sink, error) {
+		return newTestSink(sinkCfg.Outdir)
+	}
+	sourceSubfinder = func(ctx context.Context, target string, out chan<- string) error {
+		out <- "api.example.com"
+		cancel()
+		return ctx.Err()
+	}
+
+	cfg := &config.Config{
+		Target:  "example.com",
+		OutDir:  t.TempDir(),
+		Workers: 1,
+		Tools:   []string{"subfinder"},
+	}
+	if err := Run(cfg); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	summary := readRunSummary(t, cfg.OutDir)
+	if summary.Status != runStatusCancelled {
+		t.Fatalf("expected status %q, got %q", runStatusCancelled, summary.Status)
+	}
+	if statuses := toolStatuses(summary); statuses["subfinder"] != runToolFailed {
+		t.Fatalf("expected subfinder failed, got %v", statuses)
+	}
+	if summary.ArtifactsByType == nil {
+		t.Fatalf("expected artifacts_by_type to be present")
+	}
+}
+
+type metricsSink struct {
+	*testSink
+	metrics []pipeline.HandlerMetric
+}
+
+func (s *metricsSink) HandlerMetrics() []pipeline.HandlerMetric { return s.metrics }
+
+func TestWriteRunSummaryIncludesHandlerMetrics(t *testing.T) {
+	dir := t.TempDir()
+	ts, err := newTestSink(dir)
+	if err != nil {
+		t.Fatalf("newTestSink: %v", err)
+	}
+	s := &metricsSink{testSink: ts, metrics: []pipeline.HandlerMetric{
+		{Name: "handleDomain", Count: 4, Total: 8 * time.Millisecond, Average: 2 * time.Millisecond},
+	}}
+	cfg := &config.Config{Target: "example.com", OutDir: dir}
+	start := time.Now()
+
+	if err := writeRunSummary(cfg, s, newPipelineMetrics(), start, start.Add(time.Second), nil); err != nil {
+		t.Fatalf("writeRunSummary: %v", err)
+	}
+
+	summary := readRunSummary(t, dir)
+	if summary.DurationSeconds != 1 {
+		t.Fatalf("expected duration 1s, got %v", summary.DurationSeconds)
+	}
+	want := runHandlerStat{Name: "handleDomain", Count: 4, TotalNS: 8_000_000, AverageNS: 2_000_000}
+	if len(summary.Handlers) != 1 || summary.Handlers[0] != want {
+		t.Fatalf("unexpected handlers: %+v", summary.Handlers)
+	}
+}
@@ -5,14 +5,16 @@ import (
 	"errors"
 
 	"passive-rec/internal/core/runner"
+	apperrors "passive-rec/internal/platform/errors"
 )
 
 func classifyStepError(err error) string {
 	if err == nil {
 		return "ok"
 	}
+	var missing *apperrors.MissingBinaryError
 	switch {
-	case errors.Is(err, runner.ErrMissingBinary):
+	case errors.Is(err, runner.ErrMissingBinary), errors.As(err, &missing):
 		return "faltante"
 	case errors.Is(err, context.DeadlineExceeded):
 		return "timeout"
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"passive-rec/internal/platform/config"
+	"passive-rec/internal/platform/logx"
+)
+
+const targetsSummaryName = "targets.json"
+
+// targetsSummary es el contenido de <outdir>/targets.json: el resultado de
+// cada target de -targets-file y los artefactos agregados de todos ellos. El
+// detalle de cada ejecución sigue en el run.json de su directorio.
+type targetsSummary struct {
+	TargetsFile     string           `json:"targets_file"`
+	Start           string           `json:"start"`
+	End             string           `json:"end"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Completed       int              `json:"completed"`
+	Failed          int              `json:"failed"`
+	Targets         []targetRunEntry `json:"targets"`
+	ArtifactsByType map[string]int   `json:"artifacts_by_type"`
+}
+
+type targetRunEntry struct {
+	Target          string         `json:"target"`
+	OutDir          string         `json:"outdir"`
+	Status          string         `json:"status"`
+	Error           string         `json:"error,omitempty"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	ArtifactsByType map[string]int `json:"artifacts_by_type"`
+}
+
+// runTargets ejecuta el pipeline completo para cada target de
+// cfg.TargetsFile, cada uno en <outdir>/<target_sanitizado> como con -target.
+// Un target fallido no detiene los demás; una cancelación (SIGINT/SIGTERM) sí.
+// Con -upload, cada target se sube bajo <destino>/<target_sanitizado>.
+func runTargets(cfg *config.Config) error {
+	targets, err := readTargetsFile(cfg.TargetsFile)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("targets-file %s no contiene targets", cfg.TargetsFile)
+	}
+	if err := os.MkdirAll(cfg.OutDir, 0o755); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	summary := targetsSummary{
+		TargetsFile:     cfg.TargetsFile,
+		Targets:         make([]targetRunEntry, 0, len(targets)),
+		ArtifactsByType: make(map[string]int),
+	}
+	var cancelErr error
+	for i, target := range targets {
+		targetCfg := *cfg
+		targetCfg.Target = target
+		targetCfg.TargetsFile = ""
+		if cfg.Upload != "" {
+			targetCfg.Upload = strings.TrimSuffix(cfg.Upload, "/") + "/" + sanitizeTargetDir(target)
+		}
+		logx.Info("Escaneando target", logx.Fields{
+			"target":   target,
+			"progreso": fmt.Sprintf("%d/%d", i+1, len(targets)),
+		})
+
+		targetStart := time.Now()
+		runErr := Run(&targetCfg)
+		entry := targetRunEntry{
+			Target:          target,
+			OutDir:          targetCfg.OutDir,
+			Status:          runStatusCompleted,
+			DurationSeconds: secondsWithMillis(time.Since(targetStart)),
+		}
+		if runErr != nil {
+			entry.Status = runStatusFailed
+			if isCancellation(runErr) {
+				entry.Status = runStatusCancelled
+			}
+			entry.Error = runErr.Error()
+			summary.Failed++
+			logx.Warn("Fallo escanear target", logx.Fields{"target": target, "error": runErr.Error()})
+		} else {
+			summary.Completed++
+		}
+		if counts, err := countArtifactsByType(targetCfg.OutDir); err == nil {
+			entry.ArtifactsByType = counts
+			for typ, n := range counts {
+				summary.ArtifactsByType[typ] += n
+			}
+		}
+		summary.Targets = append(summary.Targets, entry)
+		if entry.Status == runStatusCancelled {
+			cancelErr = runErr
+			break
+		}
+	}
+
+	end := time.Now()
+	summary.Start = start.UTC().Format(time.RFC3339Nano)
+	summary.End = end.UTC().Format(time.RFC3339Nano)
+	summary.DurationSeconds = secondsWithMillis(end.Sub(start))
+	if err := writeTargetsSummary(cfg.OutDir, summary); err != nil {
+		logx.Warn("Fallo escribir resumen de targets", logx.Fields{"error": err.Error()})
+	}
+	logx.Info("Escaneo de targets completado", logx.Fields{
+		"targets":     len(targets),
+		"completed":   summary.Completed,
+		"failed":      summary.Failed,
+		"artifacts":   sumCounts(summary.ArtifactsByType),
+		"duration_ms": end.Sub(start).Milliseconds(),
+	})
+
+	if cancelErr != nil {
+		return cancelErr
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d de %d targets fallaron (ver %s)", summary.Failed, len(targets), filepath.Join(cfg.OutDir, targetsSummaryName))
+	}
+	return nil
+}
+
+// readTargetsFile lee un target por línea, omitiendo líneas vacías y
+// comentarios (#). Los targets que comparten directorio de salida se
+// ejecutan una sola vez.
+func readTargetsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("targets-file: %w", err)
+	}
+	defer f.Close()
+
+	var targets []string
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dir := sanitizeTargetDir(line)
+		if _, dup := seen[dir]; dup {
+			logx.Warn("Target duplicado en targets-file", logx.Fields{"target": line})
+			continue
+		}
+		seen[dir] = struct{}{}
+		targets = append(targets, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("targets-file %s: %w", path, err)
+	}
+	return targets, nil
+}
+
+func writeTargetsSummary(outdir string, summary targetsSummary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(outdir, targetsSummaryName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func sumCounts(counts map[string]int) int {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"passive-rec/internal/core/pipeline"
+	"passive-rec/internal/platform/config"
+)
+
+func TestRunTargetsFileScansEachTargetIntoItsOwnDir(t *testing.T) {
+	originalSinkFactory := sinkFactory
+	originalSubfinder := sourceSubfinder
+	t.Cleanup(func() {
+		sinkFactory = originalSinkFactory
+		sourceSubfinder = originalSubfinder
+	})
+
+	sinkFactory = func(sinkCfg pipeline.SinkConfig) (sink, error) {
+		if sinkCfg.Target == "broken.example" {
+			return nil, errors.New("sink unavailable")
+		}
+		return newTestSink(sinkCfg.Outdir)
+	}
+	var scanned []string
+	sourceSubfinder = func(ctx context.Context, target string, out chan<- string) error {
+		scanned = append(scanned, target)
+		out <- "www." + target
+		return nil
+	}
+
+	base := t.TempDir()
+	targetsPath := filepath.Join(t.TempDir(), "targets.txt")
+	content := "# clientes\nexample.com\n\n  broken.example  \nexample.org\nexample.com\n"
+	if err := os.WriteFile(targetsPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write targets: %v", err)
+	}
+
+	cfg := &config.Config{
+		TargetsFile: targetsPath,
+		OutDir:      base,
+		Workers:     1,
+		Tools:       []string{"subfinder"},
+	}
+	err := Run(cfg)
+	if err == nil || !strings.Contains(err.Error(), "1 de 3 targets fallaron") {
+		t.Fatalf("expected the failed target to be reported, got %v", err)
+	}
+
+	if want := []string{"example.com", "example.org"}; !reflect.DeepEqual(scanned, want) {
+		t.Fatalf("expected the remaining targets to run after the failure, got %v", scanned)
+	}
+	for _, dir := range []string{"example_com", "example_org"} {
+		if summary := readRunSummary(t, filepath.Join(base, dir)); summary.Status != runStatusCompleted {
+			t.Fatalf("%s: expected a completed run, got %+v", dir, summary)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(base, targetsSummaryName))
+	if err != nil {
+		t.Fatalf("read targets summary: %v", err)
+	}
+	var summary targetsSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("decode targets summary: %v", err)
+	}
+	if summary.Completed != 2 || summary.Failed != 1 || len(summary.Targets) != 3 {
+		t.Fatalf("unexpected aggregate: %+v", summary)
+	}
+	if broken := summary.Targets[1]; broken.Target != "broken.example" || broken.Status != runStatusFailed || broken.Error != "sink unavailable" {
+		t.Fatalf("unexpected entry for the failed target: %+v", broken)
+	}
+	if got := summary.ArtifactsByType["domain"]; got < 2 {
+		t.Fatalf("expected domains aggregated across targets, got %v", summary.ArtifactsByType)
+	}
+}
+
+func TestReadTargetsFileRejectsEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("# nada\n\n"), 0o644); err != nil {
+		t.Fatalf("write targets: %v", err)
+	}
+	cfg := &config.Config{TargetsFile: path, OutDir: t.TempDir(), Tools: []string{"subfinder"}}
+	if err := Run(cfg); err == nil || !strings.Contains(err.Error(), "no contiene targets") {
+		t.Fatalf("expected an empty targets-file error, got %v", err)
+	}
+}
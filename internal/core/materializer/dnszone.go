@@ -0,0 +1,150 @@
+package materializer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// dnsZoneType es la clave de type_dirs que reubica el resumen estilo
+// zonefile; dnsZoneSubdir y dnsZoneSummaryName son su directorio por defecto
+// (relativo a outdir) y su nombre.
+const (
+	dnsZoneType        = "dns"
+	dnsZoneSubdir      = "dns"
+	dnsZoneSummaryName = "summary.zone"
+)
+
+// dnsNameTypes son los tipos de registro cuyo valor es un nombre de host y se
+// escribe como FQDN (con punto final).
+var dnsNameTypes = map[string]struct{}{
+	"CNAME": {},
+	"NS":    {},
+	"PTR":   {},
+	"MX":    {},
+}
+
+type dnsZoneRecord struct {
+	host  string
+	typ   string
+	value string
+}
+
+// FormatDNSZone convierte artefactos "dns" en registros al estilo BIND
+// ("host. IN TIPO valor") agrupados por host. Los hosts y, dentro de cada
+// host, los registros se ordenan para que la salida sea estable; los
+// duplicados se descartan. Devuelve "" si no hay registros utilizables.
+func FormatDNSZone(list []artifacts.Artifact) string {
+	byHost := make(map[string][]dnsZoneRecord)
+	seen := make(map[dnsZoneRecord]struct{})
+	for _, art := range list {
+		record, ok := dnsZoneRecordFromArtifact(art)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[record]; dup {
+			continue
+		}
+		seen[record] = struct{}{}
+		byHost[record.host] = append(byHost[record.host], record)
+	}
+	if len(byHost) == 0 {
+		return ""
+	}
+
+	hosts := make([]string, 0, len(byHost))
+	for host := range byHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var b strings.Builder
+	for i, host := range hosts {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		records := byHost[host]
+		sort.Slice(records, func(i, j int) bool {
+			if records[i].typ != records[j].typ {
+				return records[i].typ < records[j].typ
+			}
+			return records[i].value < records[j].value
+		})
+		fmt.Fprintf(&b, "; %s\n", host)
+		for _, record := range records {
+			fmt.Fprintf(&b, "%s.\tIN\t%s\t%s\n", record.host, record.typ, record.value)
+		}
+	}
+	return b.String()
+}
+
+// dnsZoneRecordFromArtifact extrae host, tipo y valor de un artefacto "dns",
+// primero de los metadatos y, si faltan, del valor "host [TIPO] valor".
+func dnsZoneRecordFromArtifact(art artifacts.Artifact) (dnsZoneRecord, bool) {
+	host := metadataString(art.Metadata, "host")
+	typ := metadataString(art.Metadata, "type")
+	value := metadataString(art.Metadata, "value")
+	if host == "" || typ == "" || value == "" {
+		var ok bool
+		host, typ, value, ok = splitDNSValue(art.Value)
+		if !ok {
+			return dnsZoneRecord{}, false
+		}
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	typ = strings.ToUpper(typ)
+	if host == "" || typ == "" {
+		return dnsZoneRecord{}, false
+	}
+	if _, ok := dnsNameTypes[typ]; ok && !strings.HasSuffix(value, ".") {
+		value += "."
+	}
+	return dnsZoneRecord{host: host, typ: typ, value: value}, true
+}
+
+// splitDNSValue interpreta valores con el formato "host [TIPO] valor".
+func splitDNSValue(raw string) (host, typ, value string, ok bool) {
+	open := strings.Index(raw, " [")
+	if open <= 0 {
+		return "", "", "", false
+	}
+	closeIdx := strings.Index(raw[open:], "] ")
+	if closeIdx < 0 {
+		return "", "", "", false
+	}
+	closeIdx += open
+	host = strings.TrimSpace(raw[:open])
+	typ = strings.TrimSpace(raw[open+2 : closeIdx])
+	value = strings.TrimSpace(raw[closeIdx+2:])
+	if host == "" || typ == "" || value == "" {
+		return "", "", "", false
+	}
+	return host, typ, value, true
+}
+
+func metadataString(metadata map[string]any, key string) string {
+	if metadata == nil {
+		return ""
+	}
+	value, _ := metadata[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// writeDNSZoneSummary escribe summary.zone con los registros DNS en dns/ o en
+// el directorio de typeDirs["dns"], resuelto como el del resto de tipos. Si
+// no hay registros no se crea el fichero.
+func writeDNSZoneSummary(outdir string, typeDirs map[string]string, list []artifacts.Artifact) error {
+	content := FormatDNSZone(list)
+	if content == "" {
+		return nil
+	}
+	path := filepath.Join(resolveTypeDir(outdir, typeSubdir(typeDirs, dnsZoneType, dnsZoneSubdir)), dnsZoneSummaryName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -3,6 +3,7 @@ package materializer
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -184,6 +185,175 @@ var typeSpecs = map[string]fileSpec{
 		passiveUseRaw: false,
 		activeUseRaw:  false,
 	},
+	"server-status": {
+		subdir:        filepath.Join("routes", "server-status"),
+		passiveName:   "server-status.passive",
+		activeName:    "server-status.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"search-cluster": {
+		subdir:        filepath.Join("routes", "search-cluster"),
+		passiveName:   "search-cluster.passive",
+		activeName:    "search-cluster.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"iac-state": {
+		subdir:        filepath.Join("routes", "iac-state"),
+		passiveName:   "iac-state.passive",
+		activeName:    "iac-state.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"mail": {
+		subdir:        filepath.Join("routes", "mail"),
+		passiveName:   "mail.passive",
+		activeName:    "mail.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"websocket": {
+		subdir:        filepath.Join("routes", "websocket"),
+		passiveName:   "websocket.passive",
+		activeName:    "websocket.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"source-code": {
+		subdir:        filepath.Join("routes", "source-code"),
+		passiveName:   "source-code.passive",
+		activeName:    "source-code.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"apache-config": {
+		subdir:        filepath.Join("routes", "apache-config"),
+		passiveName:   "apache-config.passive",
+		activeName:    "apache-config.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"credential-file": {
+		subdir:        filepath.Join("routes", "credential-file"),
+		passiveName:   "credential-file.passive",
+		activeName:    "credential-file.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"actuator": {
+		subdir:        filepath.Join("routes", "actuator"),
+		passiveName:   "actuator.passive",
+		activeName:    "actuator.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"git": {
+		subdir:        filepath.Join("routes", "git"),
+		passiveName:   "git.passive",
+		activeName:    "git.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"well-known": {
+		subdir:        filepath.Join("routes", "well-known"),
+		passiveName:   "well-known.passive",
+		activeName:    "well-known.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"wordpress": {
+		subdir:        filepath.Join("routes", "wordpress"),
+		passiveName:   "wordpress.passive",
+		activeName:    "wordpress.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"internal-doc": {
+		subdir:        filepath.Join("routes", "internal-docs"),
+		passiveName:   "internal-docs.passive",
+		activeName:    "internal-docs.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"oidc": {
+		subdir:        filepath.Join("routes", "oidc"),
+		passiveName:   "oidc.passive",
+		activeName:    "oidc.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"phpinfo": {
+		subdir:        filepath.Join("routes", "phpinfo"),
+		passiveName:   "phpinfo.passive",
+		activeName:    "phpinfo.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"metrics": {
+		subdir:        filepath.Join("routes", "metrics"),
+		passiveName:   "metrics.passive",
+		activeName:    "metrics.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"favicon": {
+		subdir:        filepath.Join("routes", "favicon"),
+		passiveName:   "favicon.passive",
+		activeName:    "favicon.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"api-schema": {
+		subdir:        filepath.Join("routes", "api-schema"),
+		passiveName:   "api-schema.passive",
+		activeName:    "api-schema.active",
+		passiveMode:   writeModeURL,
+		activeMode:    writeModeURL,
+		passiveUseRaw: false,
+		activeUseRaw:  false,
+	},
+	"dependency": {
+		subdir:      "dependencies",
+		passiveName: "dependencies.passive",
+		activeName:  "dependencies.active",
+		passiveMode: writeModeRaw,
+		activeMode:  writeModeRaw,
+	},
 	"certificate": {
 		subdir:      "certs",
 		passiveName: "certs.passive",
@@ -197,6 +367,34 @@ var typeSpecs = map[string]fileSpec{
 		passiveMode: writeModeRaw,
 		activeMode:  writeModeRaw,
 	},
+	"dns-wildcard": {
+		subdir:      "dns",
+		passiveName: "wildcard.passive",
+		activeName:  "wildcard.active",
+		passiveMode: writeModeRaw,
+		activeMode:  writeModeRaw,
+	},
+	"ip": {
+		subdir:      "ips",
+		passiveName: "ips.passive",
+		activeName:  "ips.active",
+		passiveMode: writeModeRaw,
+		activeMode:  writeModeRaw,
+	},
+	"email": {
+		subdir:      "emails",
+		passiveName: "emails.passive",
+		activeName:  "emails.active",
+		passiveMode: writeModeRaw,
+		activeMode:  writeModeRaw,
+	},
+	"port": {
+		subdir:      "ports",
+		passiveName: "ports.passive",
+		activeName:  "ports.active",
+		passiveMode: writeModeRaw,
+		activeMode:  writeModeRaw,
+	},
 	"rdap": {
 		subdir:      "rdap",
 		passiveName: "rdap.passive",
@@ -207,9 +405,35 @@ var typeSpecs = map[string]fileSpec{
 // Materialize reconstruye los artefactos en ficheros .active/.passive a partir de
 // artifacts.jsonl. Si el manifiesto no existe, se devuelve un error.
 func Materialize(outdir string) error {
+	return MaterializeWithDirs(outdir, nil)
+}
+
+// ValidateTypeDirs comprueba que cada tipo de typeDirs tenga ficheros
+// materializados y que su directorio no esté vacío.
+func ValidateTypeDirs(typeDirs map[string]string) error {
+	for typ, dir := range typeDirs {
+		if _, ok := typeSpecs[typ]; !ok && typ != dnsZoneType {
+			return fmt.Errorf("materializer: tipo desconocido %q en type_dirs", typ)
+		}
+		if strings.TrimSpace(dir) == "" {
+			return fmt.Errorf("materializer: directorio vacío para el tipo %q en type_dirs", typ)
+		}
+	}
+	return nil
+}
+
+// MaterializeWithDirs funciona como Materialize pero permite reubicar los
+// ficheros de un tipo: typeDirs asocia el tipo de artefacto (p. ej.
+// "certificate") con el directorio que sustituye a su subdirectorio por
+// defecto; "dns" reubica el resumen summary.zone. Las rutas relativas se
+// resuelven dentro de outdir.
+func MaterializeWithDirs(outdir string, typeDirs map[string]string) error {
 	if strings.TrimSpace(outdir) == "" {
 		return errors.New("materializer: outdir vacío")
 	}
+	if err := ValidateTypeDirs(typeDirs); err != nil {
+		return err
+	}
 
 	exists, err := artifacts.Exists(outdir)
 	if err != nil {
@@ -250,6 +474,7 @@ func Materialize(outdir string) error {
 			return pair
 		}
 		pair := &writerPair{}
+		spec.subdir = typeSubdir(typeDirs, typ, spec.subdir)
 		if spec.passiveName != "" && spec.passiveMode != writeModeNone {
 			pair.passive = newFileWriter(outdir, spec.subdir, spec.passiveName, spec.passiveMode)
 			allWriters = append(allWriters, pair.passive)
@@ -262,11 +487,12 @@ func Materialize(outdir string) error {
 		return pair
 	}
 
+	var dnsRecords []artifacts.Artifact
 	for {
 		art, err := reader.ReadArtifact()
 		if err != nil {
 			// EOF es esperado al finalizar el archivo
-			if err.Error() == "EOF" {
+			if errors.Is(err, io.EOF) {
 				break
 			}
 			return fmt.Errorf("leer artifact: %w", err)
@@ -277,6 +503,9 @@ func Materialize(outdir string) error {
 		if art.Type == "" || art.Value == "" {
 			continue
 		}
+		if art.Type == "dns" {
+			dnsRecords = append(dnsRecords, art)
+		}
 
 		for _, typ := range artifactTypes(art) {
 			spec, ok := typeSpecs[typ]
@@ -300,7 +529,25 @@ func Materialize(outdir string) error {
 		}
 	}
 
-	return nil
+	return writeDNSZoneSummary(outdir, typeDirs, dnsRecords)
+}
+
+// typeSubdir devuelve el directorio de typ: el de typeDirs si lo reubica o
+// def en otro caso.
+func typeSubdir(typeDirs map[string]string, typ, def string) string {
+	if dir, ok := typeDirs[typ]; ok {
+		return strings.TrimSpace(dir)
+	}
+	return def
+}
+
+// resolveTypeDir resuelve subdir dentro de outdir; las rutas absolutas se
+// usan tal cual.
+func resolveTypeDir(outdir, subdir string) string {
+	if filepath.IsAbs(subdir) {
+		return subdir
+	}
+	return filepath.Join(outdir, subdir)
 }
 
 func artifactTypes(art artifacts.Artifact) []string {
@@ -344,11 +591,7 @@ func (w *fileWriter) ensure() error {
 	if w == nil || w.writer != nil {
 		return nil
 	}
-	targetDir := w.outdir
-	if w.subdir != "" {
-		targetDir = filepath.Join(targetDir, w.subdir)
-	}
-	writer, err := out.New(targetDir, w.name)
+	writer, err := out.New(resolveTypeDir(w.outdir, w.subdir), w.name)
 	if err != nil {
 		return err
 	}
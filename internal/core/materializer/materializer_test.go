@@ -0,0 +1,144 @@
+package materializer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func writeManifest(t *testing.T, dir string, list []artifacts.Artifact) {
+	t.Helper()
+	writer := artifacts.NewWriterV2(filepath.Join(dir, "artifacts.jsonl"), "example.com")
+	if err := writer.WriteArtifacts(list); err != nil {
+		t.Fatalf("write artifacts: %v", err)
+	}
+}
+
+func TestMaterializeWithDirsRemapsType(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	absDomains := filepath.Join(t.TempDir(), "engagement-domains")
+	writeManifest(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "app.example.com", Up: true},
+		{Type: "certificate", Value: `{"common_name":"app.example.com"}`, Up: true},
+	})
+
+	typeDirs := map[string]string{
+		"certificate": filepath.Join("custom", "certs"),
+		"domain":      absDomains,
+	}
+	if err := MaterializeWithDirs(dir, typeDirs); err != nil {
+		t.Fatalf("MaterializeWithDirs: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "custom", "certs", "certs.passive"))
+	if err != nil {
+		t.Fatalf("read remapped certs: %v", err)
+	}
+	if !strings.Contains(string(data), "app.example.com") {
+		t.Fatalf("unexpected remapped certs contents: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "certs")); !os.IsNotExist(err) {
+		t.Fatalf("expected default certs directory to be unused, stat err: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(absDomains, "domains.passive")); err != nil {
+		t.Fatalf("expected domains in absolute directory: %v", err)
+	}
+}
+
+func TestValidateTypeDirsRejectsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateTypeDirs(map[string]string{"certificate": "certs-x"}); err != nil {
+		t.Fatalf("unexpected error for known type: %v", err)
+	}
+	if err := ValidateTypeDirs(map[string]string{"cert": "certs-x"}); err == nil {
+		t.Fatalf("expected error for unknown type")
+	}
+}
+
+func TestFormatDNSZoneGroupsRecordsByHost(t *testing.T) {
+	t.Parallel()
+
+	list := []artifacts.Artifact{
+		{Type: "dns", Value: "www.example.com [CNAME] app.example.com", Metadata: map[string]any{
+			"host": "www.example.com", "type": "CNAME", "value": "app.example.com", "relationship": "cname_record",
+		}},
+		{Type: "dns", Value: "app.example.com [A] 203.0.113.10", Metadata: map[string]any{
+			"host": "app.example.com", "type": "A", "value": "203.0.113.10",
+		}},
+		{Type: "dns", Value: "example.com [MX] 10 mail.example.com"},
+		{Type: "dns", Value: "app.example.com [A] 203.0.113.9"},
+		{Type: "dns", Value: "app.example.com [A] 203.0.113.10"},
+		{Type: "dns", Value: "unparseable"},
+	}
+
+	want := strings.Join([]string{
+		"; app.example.com",
+		"app.example.com.\tIN\tA\t203.0.113.10",
+		"app.example.com.\tIN\tA\t203.0.113.9",
+		"",
+		"; example.com",
+		"example.com.\tIN\tMX\t10 mail.example.com.",
+		"",
+		"; www.example.com",
+		"www.example.com.\tIN\tCNAME\tapp.example.com.",
+		"",
+	}, "\n")
+	if got := FormatDNSZone(list); got != want {
+		t.Fatalf("unexpected zone:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMaterializeWritesDNSZoneSummary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeManifest(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "example.com", Up: true},
+		{Type: "dns", Value: "example.com [A] 203.0.113.1", Up: true, Metadata: map[string]any{
+			"host": "example.com", "type": "A", "value": "203.0.113.1",
+		}},
+	})
+
+	if err := Materialize(dir); err != nil {
+		t.Fatalf("Materialize: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "dns", "summary.zone"))
+	if err != nil {
+		t.Fatalf("read summary.zone: %v", err)
+	}
+	if want := "; example.com\nexample.com.\tIN\tA\t203.0.113.1\n"; string(data) != want {
+		t.Fatalf("unexpected summary.zone: %q", data)
+	}
+}
+
+func TestMaterializeWithDirsRemapsDNSZoneSummary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeManifest(t, dir, []artifacts.Artifact{
+		{Type: "dns", Value: "example.com [A] 203.0.113.1", Up: true, Metadata: map[string]any{
+			"host": "example.com", "type": "A", "value": "203.0.113.1",
+		}},
+	})
+
+	if err := MaterializeWithDirs(dir, map[string]string{"dns": filepath.Join("custom", "zones")}); err != nil {
+		t.Fatalf("MaterializeWithDirs: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "custom", "zones", "summary.zone"))
+	if err != nil {
+		t.Fatalf("read remapped summary.zone: %v", err)
+	}
+	if want := "; example.com\nexample.com.\tIN\tA\t203.0.113.1\n"; string(data) != want {
+		t.Fatalf("unexpected summary.zone: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dns", "summary.zone")); !os.IsNotExist(err) {
+		t.Fatalf("expected the default dns/summary.zone to be unused, stat err: %v", err)
+	}
+}
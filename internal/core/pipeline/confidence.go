@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// ArtifactConfidence devuelve la confianza (0-1) declarada en
+// Metadata["confidence"]. El segundo valor es false si el artefacto no declara
+// confianza o el valor no es numérico.
+func ArtifactConfidence(art artifacts.Artifact) (float64, bool) {
+	raw, ok := art.Metadata["confidence"]
+	if !ok {
+		return 0, false
+	}
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Confianza que se asigna a los artefactos que no la declaran, según cómo se
+// obtuvieron: confirmado por un probe activo, visto solo en fuentes pasivas o
+// sondeado sin respuesta válida.
+const (
+	confidenceActiveUp   = 1.0
+	confidencePassive    = 0.5
+	confidenceActiveDown = 0.25
+)
+
+// effectiveConfidence devuelve la confianza declarada del artefacto o, si no
+// la declara, la derivada de su estado activo.
+func effectiveConfidence(art artifacts.Artifact) float64 {
+	if confidence, ok := ArtifactConfidence(art); ok {
+		return confidence
+	}
+	switch {
+	case !art.Active:
+		return confidencePassive
+	case art.Up:
+		return confidenceActiveUp
+	default:
+		return confidenceActiveDown
+	}
+}
+
+// confidenceStore descarta los artefactos cuya confianza (declarada o derivada,
+// ver effectiveConfidence) es menor que el mínimo configurado. Los de tipo
+// "meta" se registran siempre. Los descartes se cuentan por tipo para
+// resumirlos en meta al cerrar el sink.
+type confidenceStore struct {
+	ArtifactStore
+	min float64
+
+	mu      sync.Mutex
+	dropped map[string]int
+}
+
+func newConfidenceStore(inner ArtifactStore, min float64) *confidenceStore {
+	return &confidenceStore{ArtifactStore: inner, min: min, dropped: make(map[string]int)}
+}
+
+func (s *confidenceStore) Record(tool string, artifact artifacts.Artifact) {
+	if artifact.Type != "meta" {
+		if effectiveConfidence(artifact) < s.min {
+			s.mu.Lock()
+			s.dropped[artifact.Type]++
+			s.mu.Unlock()
+			return
+		}
+	}
+	s.ArtifactStore.Record(tool, artifact)
+}
+
+// recordDropSummary registra en meta cuántos artefactos se descartaron por
+// tipo. No hace nada si no hubo descartes.
+func (s *confidenceStore) recordDropSummary() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for typ, count := range s.dropped {
+		s.ArtifactStore.Record("", artifacts.Artifact{
+			Type:  "meta",
+			Value: fmt.Sprintf("min-confidence: %d artefactos %s descartados (confianza < %.2f)", count, typ, s.min),
+			Up:    true,
+			Metadata: map[string]any{
+				"dropped":        count,
+				"artifact_type":  typ,
+				"min_confidence": s.min,
+			},
+		})
+	}
+}
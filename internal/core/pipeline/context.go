@@ -1,5 +1,7 @@
 package pipeline
 
+import "strings"
+
 type Context struct {
 	S     *Sink
 	Store ArtifactStore
@@ -14,6 +16,14 @@ func (c *Context) ScopeAllowsDomain(domain string) bool {
 	return c.S.scopeAllowsDomain(domain)
 }
 
+// ScopeAllowsIP verifica si una dirección IP está dentro del scope.
+func (c *Context) ScopeAllowsIP(ip string) bool {
+	if c == nil || c.S == nil {
+		return true
+	}
+	return c.S.scopeAllowsIP(ip)
+}
+
 // ScopeAllowsRoute verifica si una ruta está dentro del scope.
 func (c *Context) ScopeAllowsRoute(route string) bool {
 	if c == nil || c.S == nil {
@@ -22,6 +32,17 @@ func (c *Context) ScopeAllowsRoute(route string) bool {
 	return c.S.scopeAllowsRoute(route)
 }
 
+// AttributeTool devuelve la herramienta a la que se atribuye un artefacto cuyo
+// origen se deduce del propio mensaje (p. ej. "[amass] ..."). Si el sink se
+// creó con PreferWrapperTool y la línea llegó envuelta con InWithTool, gana
+// la herramienta del wrapper; en otro caso se infiere del mensaje.
+func (c *Context) AttributeTool(wrapper, message string) string {
+	if wrapper = strings.TrimSpace(wrapper); wrapper != "" && c != nil && c.S != nil && c.S.preferWrapperTool {
+		return wrapper
+	}
+	return inferToolFromMessage(message)
+}
+
 // InActiveMode retorna true si el sink está en modo activo.
 func (c *Context) InActiveMode() bool {
 	if c == nil || c.S == nil {
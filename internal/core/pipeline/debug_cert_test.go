@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,7 +17,7 @@ func TestDebugGFFinding(t *testing.T) {
 		t.Fatalf("NewSink: %v", err)
 	}
 
-	sink.Start(1)
+	sink.Start(context.Background(), 1)
 	payload := map[string]any{
 		"resource": "https://example.com/app.js",
 		"line":     99,
@@ -1,6 +1,9 @@
 package pipeline
 
-import "sync"
+import (
+	"container/list"
+	"sync"
+)
 
 // Deduplicator es la interfaz para sistemas de deduplicación.
 type Deduplicator interface {
@@ -20,9 +23,23 @@ type Deduplicator interface {
 // Memory usage comparison for 1 million unique 50-byte strings:
 //   - Dedupe (map):        ~100-150 MB (exact, no false positives)
 //   - BloomDedupe (0.01):  ~1.2 MB (probabilistic, ~1% false positive rate)
+//
+// With a window (see NewDedupeWithWindow) the deduplicator keeps at most that
+// many keys across all namespaces and evicts the least recently seen one when
+// the window is full. Evicted keys may be emitted again, but memory stays
+// bounded on very large runs.
 type Dedupe struct {
 	mu   sync.Mutex
 	seen map[string]map[string]struct{}
+
+	window int
+	order  *list.List
+	index  map[dedupeEntry]*list.Element
+}
+
+type dedupeEntry struct {
+	space string
+	key   string
 }
 
 // NewDedupe creates an empty deduplicator ready for use.
@@ -30,6 +47,36 @@ func NewDedupe() *Dedupe {
 	return &Dedupe{seen: make(map[string]map[string]struct{})}
 }
 
+// NewDedupeWithWindow creates a deduplicator that remembers at most window
+// keys (LRU). A window <= 0 behaves like NewDedupe (unbounded).
+func NewDedupeWithWindow(window int) *Dedupe {
+	if window <= 0 {
+		return NewDedupe()
+	}
+	return &Dedupe{
+		window: window,
+		order:  list.New(),
+		index:  make(map[dedupeEntry]*list.Element, window),
+	}
+}
+
+// Len returns the number of keys currently remembered.
+func (d *Dedupe) Len() int {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.window > 0 {
+		return len(d.index)
+	}
+	total := 0
+	for _, bucket := range d.seen {
+		total += len(bucket)
+	}
+	return total
+}
+
 // Seen marks the key within the specified namespace and returns true if it was already seen.
 // The namespace parameter allows maintaining separate deduplication sets (e.g., "domain:passive"
 // vs "domain:active") to avoid cross-contamination between different artifact types.
@@ -39,6 +86,9 @@ func (d *Dedupe) Seen(space, key string) bool {
 	}
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	if d.window > 0 {
+		return d.seenWindowed(dedupeEntry{space: space, key: key})
+	}
 	bucket := d.seen[space]
 	if bucket == nil {
 		bucket = make(map[string]struct{})
@@ -51,6 +101,22 @@ func (d *Dedupe) Seen(space, key string) bool {
 	return false
 }
 
+// seenWindowed implements Seen for the bounded (LRU) mode. It must be called
+// with d.mu held.
+func (d *Dedupe) seenWindowed(entry dedupeEntry) bool {
+	if elem, ok := d.index[entry]; ok {
+		d.order.MoveToFront(elem)
+		return true
+	}
+	d.index[entry] = d.order.PushFront(entry)
+	for d.order.Len() > d.window {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.index, oldest.Value.(dedupeEntry))
+	}
+	return false
+}
+
 const (
 	keyspaceDomainPassive  = "domain:passive"
 	keyspaceDomainActive   = "domain:active"
@@ -84,6 +150,22 @@ const (
 	keyspaceDocActive      = "route:doc:active"
 	keyspaceArchivePassive = "route:archive:passive"
 	keyspaceArchiveActive  = "route:archive:active"
+	keyspaceStatusPassive  = "route:server-status:passive"
+	keyspaceStatusActive   = "route:server-status:active"
+	keyspaceSearchPassive  = "route:search-cluster:passive"
+	keyspaceSearchActive   = "route:search-cluster:active"
+	keyspaceIaCPassive     = "route:iac-state:passive"
+	keyspaceIaCActive      = "route:iac-state:active"
+	keyspaceMailPassive    = "route:mail:passive"
+	keyspaceMailActive     = "route:mail:active"
+	keyspaceSourcePassive  = "route:source-code:passive"
+	keyspaceSourceActive   = "route:source-code:active"
+	keyspaceWSPassive      = "route:websocket:passive"
+	keyspaceWSActive       = "route:websocket:active"
 	keyspaceCertPassive    = "cert:passive"
 	keyspaceCertActive     = "cert:active"
+	keyspaceIPPassive      = "ip:passive"
+	keyspaceIPActive       = "ip:active"
+	keyspaceEmailPassive   = "email:passive"
+	keyspaceEmailActive    = "email:active"
 )
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDedupeWindowBoundsMemory(t *testing.T) {
+	t.Parallel()
+
+	d := NewDedupeWithWindow(100)
+	for i := 0; i < 10000; i++ {
+		d.Seen(keyspaceRoutePassive, fmt.Sprintf("https://example.com/%d", i))
+		if got := d.Len(); got > 100 {
+			t.Fatalf("window exceeded after %d keys: %d", i+1, got)
+		}
+	}
+	if got := d.Len(); got != 100 {
+		t.Fatalf("expected 100 remembered keys, got %d", got)
+	}
+
+	// Las claves más antiguas se han olvidado y vuelven a emitirse.
+	if d.Seen(keyspaceRoutePassive, "https://example.com/0") {
+		t.Fatal("evicted key should be reported as new")
+	}
+}
+
+func TestDedupeWindowCatchesRecentDuplicates(t *testing.T) {
+	t.Parallel()
+
+	d := NewDedupeWithWindow(3)
+	for _, key := range []string{"a", "b", "c"} {
+		if d.Seen(keyspaceDomainPassive, key) {
+			t.Fatalf("first sight of %q reported as duplicate", key)
+		}
+	}
+	// Tocar "a" la convierte en la más reciente: "b" es la que se expulsa.
+	if !d.Seen(keyspaceDomainPassive, "a") {
+		t.Fatal("recent duplicate \"a\" not caught")
+	}
+	if d.Seen(keyspaceDomainPassive, "d") {
+		t.Fatal("new key \"d\" reported as duplicate")
+	}
+	if !d.Seen(keyspaceDomainPassive, "a") || !d.Seen(keyspaceDomainPassive, "c") {
+		t.Fatal("keys within the window should still be caught")
+	}
+	if d.Seen(keyspaceDomainPassive, "b") {
+		t.Fatal("least recently seen key \"b\" should have been evicted")
+	}
+
+	// Los keyspaces siguen siendo independientes.
+	if d.Seen(keyspaceDomainActive, "a") {
+		t.Fatal("keyspaces must not share entries")
+	}
+}
+
+func TestNewDedupeWithWindowZeroIsUnbounded(t *testing.T) {
+	t.Parallel()
+
+	d := NewDedupeWithWindow(0)
+	for i := 0; i < 500; i++ {
+		d.Seen(keyspaceRoutePassive, fmt.Sprintf("k%d", i))
+	}
+	if got := d.Len(); got != 500 {
+		t.Fatalf("expected 500 remembered keys, got %d", got)
+	}
+	if !d.Seen(keyspaceRoutePassive, "k0") {
+		t.Fatal("unbounded dedupe should remember every key")
+	}
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleAPISchema procesa líneas "apischema: {json}" con el resumen de un
+// fichero de esquema de API expuesto (GraphQL SDL, RAML) y lo registra como
+// artefacto "api-schema" con su formato y los tipos, operaciones o número de
+// recursos declarados.
+func handleAPISchema(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "apischema:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL        string   `json:"url"`
+		Format     string   `json:"format"`
+		Types      []string `json:"types"`
+		Operations []string `json:"operations"`
+		PathCount  int      `json:"path_count"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{
+		"format":   strings.ToLower(strings.TrimSpace(data.Format)),
+		"severity": "medium",
+	}
+	if len(data.Types) > 0 {
+		metadata["types"] = data.Types
+	}
+	if len(data.Operations) > 0 {
+		metadata["operations"] = data.Operations
+	}
+	if data.PathCount > 0 {
+		metadata["path_count"] = data.PathCount
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "api-schema",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -0,0 +1,49 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleBackup procesa líneas "backup: {json}" con el tamaño anunciado por un
+// archivo de backup expuesto y lo adjunta a la ruta. Los archivos de gran
+// tamaño se marcan como de alto valor.
+func handleBackup(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "backup:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL       string `json:"url"`
+		Size      int64  `json:"size"`
+		HighValue bool   `json:"high_value"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" || data.Size < 0 {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{"size": data.Size}
+	if data.HighValue {
+		metadata["high_value"] = true
+		metadata["severity"] = "high"
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "route",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -34,7 +34,7 @@ func writeCertLine(ctx *Context, line string, isActive bool, tool string) {
 	}
 	filtered := record
 	if filtered.CommonName != "" {
-		domain := netutil.NormalizeDomain(filtered.CommonName)
+		domain := certNameScopeDomain(filtered.CommonName)
 		if domain == "" || !ctx.S.scopeAllowsDomain(domain) {
 			filtered.CommonName = ""
 		}
@@ -42,7 +42,7 @@ func writeCertLine(ctx *Context, line string, isActive bool, tool string) {
 	if len(filtered.DNSNames) > 0 {
 		names := make([]string, 0, len(filtered.DNSNames))
 		for _, name := range filtered.DNSNames {
-			domain := netutil.NormalizeDomain(name)
+			domain := certNameScopeDomain(name)
 			if domain == "" {
 				continue
 			}
@@ -121,3 +121,14 @@ func writeCertLine(ctx *Context, line string, isActive bool, tool string) {
 		Metadata: meta,
 	})
 }
+
+// certNameScopeDomain normaliza un nombre del certificado para comprobar el
+// scope. Los comodines ("*.example.com") se evalúan por su dominio base, de
+// modo que se conservan si la base está en scope.
+func certNameScopeDomain(name string) string {
+	trimmed := strings.TrimSpace(name)
+	if base, ok := strings.CutPrefix(trimmed, "*."); ok {
+		trimmed = base
+	}
+	return netutil.NormalizeDomain(trimmed)
+}
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleCookie procesa líneas "cookie: {json}" con las cabeceras Set-Cookie de
+// una respuesta activa y marca en la ruta las cookies a las que les faltan los
+// atributos Secure, HttpOnly o SameSite.
+func handleCookie(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "cookie:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL       string   `json:"url"`
+		SetCookie []string `json:"set_cookie"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" || len(data.SetCookie) == 0 {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	issues := cookieIssues(data.SetCookie)
+	if len(issues) == 0 {
+		return true
+	}
+	names := make([]string, 0, len(issues))
+	for name := range issues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "route",
+		Value:  base,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"insecure_cookies": names,
+			"cookie_issues":    issues,
+		},
+	})
+	return true
+}
+
+// cookieIssues devuelve, por nombre de cookie, los atributos de seguridad que
+// faltan ("secure", "httponly", "samesite"). Las cookies correctas se omiten.
+func cookieIssues(headers []string) map[string][]string {
+	issues := make(map[string][]string)
+	for _, header := range headers {
+		cookie, err := http.ParseSetCookie(strings.TrimSpace(header))
+		if err != nil || cookie.Name == "" {
+			continue
+		}
+		var missing []string
+		if !cookie.Secure {
+			missing = append(missing, "secure")
+		}
+		if !cookie.HttpOnly {
+			missing = append(missing, "httponly")
+		}
+		if cookie.SameSite == 0 {
+			missing = append(missing, "samesite")
+		}
+		if len(missing) > 0 {
+			issues[cookie.Name] = missing
+		}
+	}
+	return issues
+}
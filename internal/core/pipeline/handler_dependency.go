@@ -0,0 +1,61 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleDependency procesa líneas "dependency: {json}" con una dependencia
+// declarada en un manifiesto expuesto (package.json, requirements.txt) y la
+// registra como artefacto "dependency". El valor sigue el formato purl
+// (pkg:npm/lodash@4.17.21) para que el análisis de EOL/CVE lo consuma directo.
+func handleDependency(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "dependency:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		Name      string `json:"name"`
+		Version   string `json:"version"`
+		Ecosystem string `json:"ecosystem"`
+		Manifest  string `json:"manifest"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	name := strings.TrimSpace(data.Name)
+	ecosystem := strings.ToLower(strings.TrimSpace(data.Ecosystem))
+	if name == "" || ecosystem == "" {
+		return true
+	}
+	manifest := artifacts.ExtractRouteBase(data.Manifest)
+	if manifest != "" && !ctx.ScopeAllowsRoute(manifest) {
+		return true
+	}
+	version := strings.TrimSpace(data.Version)
+	value := "pkg:" + ecosystem + "/" + name
+	if version != "" {
+		value += "@" + version
+	}
+	metadata := map[string]any{
+		"name":      name,
+		"version":   version,
+		"ecosystem": ecosystem,
+	}
+	if manifest != "" {
+		metadata["manifest"] = manifest
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "dependency",
+		Value:    value,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -105,7 +105,7 @@ func handleRDAP(ctx *Context, line string, isActive bool, tool string) bool {
 		Value:  content,
 		Active: false,
 		Up:     true,
-		Tool:   inferToolFromMessage(content),
+		Tool:   ctx.AttributeTool(tool, content),
 	})
 	return true
 }
@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+// handleDNSWildcard procesa líneas "dnswildcard: {json}" emitidas cuando un
+// subdominio aleatorio de la zona resuelve. Registra la zona como artefacto
+// "dns-wildcard" y la marca en el sink para que los subdominios procesados a
+// continuación lleven wildcard_resolved: true y puedan ponderarse a la baja.
+func handleDNSWildcard(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "dnswildcard:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		Zone      string   `json:"zone"`
+		Addresses []string `json:"addresses"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	zone := netutil.NormalizeDomain(data.Zone)
+	if zone == "" || !ctx.ScopeAllowsDomain(zone) {
+		return true
+	}
+	ctx.S.markWildcardZone(zone)
+	metadata := map[string]any{}
+	if len(data.Addresses) > 0 {
+		metadata["addresses"] = data.Addresses
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "dns-wildcard",
+		Value:    zone,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
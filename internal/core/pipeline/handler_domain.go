@@ -23,6 +23,12 @@ func handleDomain(ctx *Context, line string, isActive bool, tool string) bool {
 	if trimmed != key {
 		metadata["raw"] = trimmed
 	}
+	if env := netutil.DetectEnvironment(key); env != "" {
+		metadata["environment"] = env
+	}
+	if ctx.S.underWildcardZone(key) {
+		metadata["wildcard_resolved"] = true
+	}
 	if ctx.Dedup != nil {
 		_ = ctx.Dedup.Seen(keyspaceDomainPassive, key)
 		if isActive {
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+// handleEmail registra las direcciones de correo que emiten las herramientas
+// ("email: admin@example.com"). La dirección se normaliza con
+// netutil.NormalizeEmail (sin mailto:, en minúsculas) y se descarta si no es
+// válida o si su dominio queda fuera del scope.
+func handleEmail(ctx *Context, line string, isActive bool, tool string) bool {
+	value := strings.TrimSpace(strings.TrimPrefix(line, "email:"))
+	if value == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	recordEmail(ctx, value, isActive, tool)
+	return true
+}
+
+// recordMetaEmails extrae las direcciones de correo presentes en el texto de
+// un mensaje meta, que de otro modo solo quedarían en meta.passive.
+func recordMetaEmails(ctx *Context, content string, isActive bool, tool string) {
+	for _, email := range netutil.ExtractEmails(content) {
+		recordEmail(ctx, email, isActive, tool)
+	}
+}
+
+func recordEmail(ctx *Context, raw string, isActive bool, tool string) {
+	email, domain, ok := netutil.NormalizeEmail(raw)
+	if !ok {
+		return
+	}
+	if !ctx.ScopeAllowsDomain(domain) {
+		return
+	}
+	if ctx.Dedup != nil {
+		keyspace := keyspaceEmailPassive
+		if isActive {
+			keyspace = keyspaceEmailActive
+		}
+		_ = ctx.Dedup.Seen(keyspace, email)
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "email",
+		Value:    email,
+		Active:   isActive,
+		Up:       true,
+		Metadata: map[string]any{"domain": domain},
+	})
+}
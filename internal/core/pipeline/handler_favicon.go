@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"strconv"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleFavicon procesa líneas "favicon: <url> <hash>" con el hash mmh3 de un
+// favicon descargado y lo registra como artefacto "favicon" (también ruta),
+// con el hash en el metadato "favicon_hash" para agrupar hosts que comparten
+// icono.
+func handleFavicon(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "favicon:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	fields := strings.Fields(payload)
+	if len(fields) != 2 {
+		return true
+	}
+	hash, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(fields[0])
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "favicon",
+		Types:  []string{"route"},
+		Value:  base,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"favicon_hash": hash,
+		},
+	})
+	return true
+}
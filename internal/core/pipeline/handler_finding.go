@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleFinding registra los hallazgos de escáneres externos (p. ej. Nuclei)
+// como artefactos finding con el subtipo del escáner. Como en gffinding:, la
+// regla (template-id) pasa a Types; matched_at se valida contra el scope
+// (URL o host:puerto).
+func handleFinding(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "finding:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		Source      string `json:"source"`
+		Rule        string `json:"rule"`
+		Name        string `json:"name"`
+		Severity    string `json:"severity"`
+		MatchedAt   string `json:"matched_at"`
+		Description string `json:"description"`
+		Remediation string `json:"remediation"`
+		Matcher     string `json:"matcher"`
+		CWE         string `json:"cwe"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	rule := strings.TrimSpace(data.Rule)
+	matchedAt := strings.TrimSpace(data.MatchedAt)
+	if rule == "" || matchedAt == "" {
+		return true
+	}
+	if strings.Contains(matchedAt, "://") {
+		if base := artifacts.ExtractRouteBase(matchedAt); base != "" {
+			matchedAt = base
+		}
+	}
+	if !ctx.ScopeAllowsRoute(matchedAt) {
+		return true
+	}
+	source := strings.ToLower(strings.TrimSpace(data.Source))
+	if source == "" {
+		source = "external"
+	}
+	metadata := map[string]any{
+		"rule":       rule,
+		"matched_at": matchedAt,
+		"severity":   normalizeFindingSeverity(data.Severity),
+	}
+	for key, value := range map[string]string{
+		"name":        data.Name,
+		"description": data.Description,
+		"remediation": data.Remediation,
+		"matcher":     data.Matcher,
+		"cwe":         data.CWE,
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			metadata[key] = value
+		}
+	}
+	value := rule + " " + matchedAt
+	if matcher, ok := metadata["matcher"].(string); ok {
+		value = rule + ":" + matcher + " " + matchedAt
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "finding",
+		Subtype:  source,
+		Types:    []string{rule},
+		Value:    value,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
+
+func normalizeFindingSeverity(severity string) string {
+	switch severity = strings.ToLower(strings.TrimSpace(severity)); severity {
+	case "critical", "high", "medium", "low":
+		return severity
+	default:
+		return "low"
+	}
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleFraming procesa líneas "framing: {json}" con las cabeceras
+// X-Frame-Options y Content-Security-Policy de una página HTTPS y marca la ruta
+// como candidata a clickjacking si no declara ninguna protección de framing.
+func handleFraming(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "framing:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL                   string `json:"url"`
+		XFrameOptions         string `json:"x_frame_options"`
+		ContentSecurityPolicy string `json:"content_security_policy"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" || !strings.HasPrefix(strings.ToLower(base), "https://") {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	if hasFramingProtection(data.XFrameOptions, data.ContentSecurityPolicy) {
+		return true
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "route",
+		Value:  base,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"clickjacking": true,
+			"severity":     "medium",
+		},
+	})
+	return true
+}
+
+// hasFramingProtection indica si la respuesta restringe quién puede incrustarla
+// en un frame: X-Frame-Options DENY/SAMEORIGIN/ALLOW-FROM o una directiva CSP
+// frame-ancestors.
+func hasFramingProtection(xFrameOptions, csp string) bool {
+	for _, value := range strings.Split(xFrameOptions, ",") {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "deny" || value == "sameorigin" || strings.HasPrefix(value, "allow-from") {
+			return true
+		}
+	}
+	for _, directive := range strings.Split(csp, ";") {
+		fields := strings.Fields(strings.ToLower(directive))
+		if len(fields) > 0 && fields[0] == "frame-ancestors" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"net"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+// handleIP registra las direcciones IP que emiten las herramientas al resolver
+// hosts ("ip: 93.184.216.34"). El valor se normaliza con
+// netutil.NormalizeDomain (minúsculas, sin corchetes ni puerto en IPv6) y se
+// descarta si no es una IP válida o queda fuera del scope.
+func handleIP(ctx *Context, line string, isActive bool, tool string) bool {
+	value := strings.TrimSpace(strings.TrimPrefix(line, "ip:"))
+	if value == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	normalized := netutil.NormalizeDomain(value)
+	if net.ParseIP(normalized) == nil {
+		return true
+	}
+	if !ctx.ScopeAllowsIP(normalized) {
+		return true
+	}
+	if ctx.Dedup != nil {
+		keyspace := keyspaceIPPassive
+		if isActive {
+			keyspace = keyspaceIPActive
+		}
+		_ = ctx.Dedup.Seen(keyspace, normalized)
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "ip",
+		Value:  normalized,
+		Active: isActive,
+		Up:     true,
+	})
+	return true
+}
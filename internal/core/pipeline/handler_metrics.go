@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleMetrics procesa líneas "metrics: {json}" emitidas al descargar un
+// endpoint de métricas Prometheus expuesto y lo registra como artefacto
+// "metrics". La severidad es alta si el scrape incluye métricas sensibles
+// (proceso, runtime o nombres que sugieren secretos) y media en otro caso.
+func handleMetrics(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "metrics:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL              string   `json:"url"`
+		MetricCount      int      `json:"metric_count"`
+		SensitiveMetrics []string `json:"sensitive_metrics"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	sensitive := make([]string, 0, len(data.SensitiveMetrics))
+	for _, name := range data.SensitiveMetrics {
+		if name = strings.TrimSpace(name); name != "" {
+			sensitive = append(sensitive, name)
+		}
+	}
+	severity := "medium"
+	if len(sensitive) > 0 {
+		severity = "high"
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "metrics",
+		Value:  base,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"metric_count":      data.MetricCount,
+			"sensitive_metrics": sensitive,
+			"severity":          severity,
+		},
+	})
+	return true
+}
@@ -20,7 +20,13 @@ func handleMeta(ctx *Context, line string, isActive bool, tool string) bool {
 		if content == "" {
 			return true
 		}
-		normalized := normalizeMetaContent(content)
+		// El meta se guarda ya redactado: la línea original no debe llegar al
+		// manifiesto ni a los destinos de streaming con el secreto en claro.
+		redacted, rules := redactMetaSecrets(content)
+		if len(rules) > 0 {
+			trimmed, _ = redactMetaSecrets(trimmed)
+		}
+		normalized := normalizeMetaContent(redacted)
 		if normalized == "" {
 			return true
 		}
@@ -29,11 +35,13 @@ func handleMeta(ctx *Context, line string, isActive bool, tool string) bool {
 			Value:  normalized,
 			Active: isActive,
 			Up:     true,
-			Tool:   inferToolFromMessage(normalized),
+			Tool:   ctx.AttributeTool(tool, normalized),
 			Metadata: map[string]any{
 				"raw": trimmed,
 			},
 		})
+		recordMetaSecrets(ctx, redacted, rules, isActive, tool)
+		recordMetaEmails(ctx, content, isActive, tool)
 		return true
 	}
 	return false
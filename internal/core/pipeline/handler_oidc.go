@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleOIDC procesa líneas "oidc: {json}" con el issuer y los endpoints de un
+// documento de discovery OpenID Connect y los registra como artefacto "oidc".
+func handleOIDC(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "oidc:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL       string            `json:"url"`
+		Issuer    string            `json:"issuer"`
+		Endpoints map[string]string `json:"endpoints"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{}
+	if issuer := strings.TrimSpace(data.Issuer); issuer != "" {
+		metadata["issuer"] = issuer
+	}
+	if len(data.Endpoints) > 0 {
+		metadata["endpoints"] = data.Endpoints
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "oidc",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleOpenAPI procesa líneas "openapi: {json}" con rutas declaradas en una
+// especificación Swagger/OpenAPI y las registra como rutas marcadas con
+// metadata {"from_openapi": true, "declared": true}. Las rutas solo están
+// declaradas, no se han sondeado, así que se registran con Up=false. Las URLs
+// sin base de ruta válida se descartan porque no se puede comprobar su scope.
+func handleOpenAPI(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "openapi:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL     string   `json:"url"`
+		Spec    string   `json:"spec"`
+		Methods []string `json:"methods"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	value := strings.TrimSpace(data.URL)
+	if value == "" {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(value)
+	if base == "" || !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{"from_openapi": true, "declared": true}
+	if spec := strings.TrimSpace(data.Spec); spec != "" {
+		metadata["spec"] = spec
+	}
+	if len(data.Methods) > 0 {
+		metadata["methods"] = data.Methods
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "route",
+		Value:    value,
+		Active:   isActive,
+		Up:       false,
+		Metadata: metadata,
+	})
+	return true
+}
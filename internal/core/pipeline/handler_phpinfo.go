@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handlePHPInfo procesa líneas "phpinfo: {json}" emitidas al detectar una
+// página phpinfo() expuesta y la registra como artefacto "phpinfo" con
+// severidad alta.
+func handlePHPInfo(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "phpinfo:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL        string `json:"url"`
+		Match      string `json:"match"`
+		PHPVersion string `json:"php_version"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{"severity": "high"}
+	if match := strings.TrimSpace(data.Match); match != "" {
+		metadata["match"] = match
+	}
+	if version := strings.TrimSpace(data.PHPVersion); version != "" {
+		metadata["php_version"] = version
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "phpinfo",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+// handlePort registra los puertos abiertos que reportan los escáneres de
+// puertos ("port: host:puerto[/proto] [servicio]", p. ej.
+// "port: 10.0.0.5:22/tcp ssh"). El host se normaliza con
+// netutil.NormalizeDomain y debe ser una IP o un dominio dentro del scope; el
+// protocolo por defecto es tcp. El valor host:puerto/proto hace que las líneas
+// repetidas se dedupliquen en el store.
+func handlePort(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "port:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	fields := strings.Fields(payload)
+	endpoint, protocol := fields[0], "tcp"
+	if i := strings.LastIndexByte(endpoint, '/'); i >= 0 {
+		endpoint, protocol = endpoint[:i], strings.ToLower(endpoint[i+1:])
+	}
+	if protocol != "tcp" && protocol != "udp" {
+		return true
+	}
+	rawHost, rawPort, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return true
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil || port < 1 || port > 65535 {
+		return true
+	}
+	host := netutil.NormalizeDomain(rawHost)
+	if host == "" {
+		return true
+	}
+	if net.ParseIP(host) != nil {
+		if !ctx.ScopeAllowsIP(host) {
+			return true
+		}
+	} else if !ctx.ScopeAllowsDomain(host) {
+		return true
+	}
+
+	metadata := map[string]any{
+		"host":     host,
+		"port":     port,
+		"protocol": protocol,
+	}
+	if len(fields) > 1 {
+		metadata["service"] = strings.ToLower(strings.Join(fields[1:], " "))
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "port",
+		Value:    net.JoinHostPort(host, strconv.Itoa(port)) + "/" + protocol,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/netutil"
+)
+
+// handleProtocol procesa líneas "protocol: {json}" con el protocolo negociado
+// por ALPN en un origen HTTPS y lo añade como metadato ("alpn" y "h3") al
+// dominio del origen, de donde lo resume el análisis de infraestructura. No
+// crea rutas: el origen solo se ha visto en el handshake TLS.
+func handleProtocol(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "protocol:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL  string `json:"url"`
+		ALPN string `json:"alpn"`
+		H3   bool   `json:"h3"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	host := netutil.NormalizeDomain(data.URL)
+	alpn := strings.ToLower(strings.TrimSpace(data.ALPN))
+	if host == "" || alpn == "" || net.ParseIP(host) != nil {
+		return true
+	}
+	if !ctx.ScopeAllowsDomain(host) {
+		return true
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "domain",
+		Value:  host,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"alpn": alpn,
+			"h3":   data.H3,
+		},
+	})
+	return true
+}
@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleReflection procesa líneas "reflection: {json}" emitidas cuando un
+// parámetro de la ruta se refleja sin escapar en la respuesta (precursor de
+// XSS) y lo adjunta a la ruta con severidad alta.
+func handleReflection(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "reflection:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL   string `json:"url"`
+		Param string `json:"param"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	param := strings.TrimSpace(data.Param)
+	if base == "" || param == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "route",
+		Value:  base,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"reflected_param": param,
+			"severity":        "high",
+		},
+	})
+	return true
+}
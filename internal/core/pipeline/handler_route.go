@@ -135,6 +135,40 @@ func handleMetaCategory(ctx *Context, line string, isActive bool, tool string) b
 	return HandleCategory(ctx, categorySpecs["meta-route"], line, isActive, tool)
 }
 
+func handleServerStatusCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["server-status"], line, isActive, tool)
+}
+
+func handleSearchClusterCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["search-cluster"], line, isActive, tool)
+}
+
+func handleIaCStateCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["iac-state"], line, isActive, tool)
+}
+
+func handleMailCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["mail"], line, isActive, tool)
+}
+
+func handleSourceCodeCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["source-code"], line, isActive, tool)
+}
+
+func handleWebSocketCategory(ctx *Context, line string, isActive bool, tool string) bool {
+	return HandleCategory(ctx, categorySpecs["websocket"], line, isActive, tool)
+}
+
+// webSocketCategoryHandler procesa las líneas "ws:<url>". Una URL ws://
+// sin etiquetar comparte el prefijo "ws:", así que se devuelve al fallback de
+// rutas, que la registra como ruta y la reenvía aquí ya etiquetada.
+func webSocketCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive bool, tool string) bool {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), "ws://") {
+		return false
+	}
+	return defaultCategoryHandler(ctx, spec, line, isActive, tool)
+}
+
 func handleHTML(ctx *Context, line string, isActive bool, tool string) bool {
 	return HandleCategory(ctx, categorySpecs["html"], line, isActive, tool)
 }
@@ -249,6 +283,9 @@ func routeCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive
 	if !(strings.Contains(base, "://") || strings.HasPrefix(base, "/") || strings.Contains(base, "/")) {
 		return false
 	}
+	// Los enlaces a documentación interna suelen apuntar a hosts de terceros
+	// (Confluence, SharePoint, Notion), por eso se registran antes del scope.
+	recordInternalDoc(ctx, base, isActive, tool)
 	if !ctx.S.scopeAllowsRoute(base) {
 		return true
 	}
@@ -256,10 +293,21 @@ func routeCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive
 	if trimmed != base {
 		metadata["raw"] = trimmed
 	}
+	if stripped := artifacts.TrackingParams(trimmed); len(stripped) > 0 {
+		metadata["tracking_params"] = stripped
+	}
+	// Solo la ruta genérica lleva los parámetros; los writers de categorías
+	// (js, css, imágenes...) no los necesitan.
+	if params := artifacts.QueryParamNames(base); len(params) > 0 {
+		metadata["params"] = params
+	}
 	if isActive {
 		if ctx.Dedup != nil {
 			_ = ctx.Dedup.Seen(keyspaceRoutePassive, base)
 		}
+		if headers, ok := parseActiveRouteHeaders(trimmed, base); ok {
+			metadata["security_headers"] = headers
+		}
 		if status, ok := parseActiveRouteStatus(trimmed, base); ok {
 			metadata["status"] = status
 			if status <= 0 || status >= 400 {
@@ -274,6 +322,14 @@ func routeCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive
 			}
 		}
 	}
+	// Los hallazgos de exposición se registran tras comprobar el estado activo:
+	// una ruta que devolvió 404 o 5xx no expone nada.
+	recordGitExposure(ctx, base, isActive, tool)
+	recordWellKnownAuth(ctx, base, isActive, tool)
+	recordWordPressIssue(ctx, base, isActive, tool)
+	recordApacheConfig(ctx, base, isActive, tool)
+	recordActuatorEndpoint(ctx, base, isActive, tool)
+	recordCredentialFile(ctx, base, isActive, tool)
 	if ctx.Dedup != nil {
 		keyspace := keyspaceRoutePassive
 		if isActive {
@@ -297,6 +353,9 @@ func routeCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive
 		hasSpecializedCategory = len(categories) > 0
 		writeRouteCategories(ctx, base, isActive, tool)
 	}
+	if isActive {
+		recordSearchClusterSignature(ctx, base, trimmed, tool)
+	}
 
 	// Solo crear el artifact "route" si NO tiene una categoría especializada
 	// Las categorías especializadas (image, css, etc.) ya fueron creadas por writeRouteCategories
@@ -315,6 +374,140 @@ func routeCategoryHandler(ctx *Context, spec CategorySpec, line string, isActive
 	return true
 }
 
+// recordInternalDoc registra un artefacto "internal-doc" de severidad media si
+// la ruta apunta a una plataforma de documentación interna.
+func recordInternalDoc(ctx *Context, route string, isActive bool, tool string) {
+	platform, ok := routes.DetectInternalDoc(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "internal-doc",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"platform": platform,
+			"severity": "medium",
+		},
+	})
+}
+
+// recordWordPressIssue registra como "wordpress" las rutas sensibles de
+// WordPress (enumeración de usuarios, copias de wp-config.php, xmlrpc.php...)
+// con el problema y su severidad.
+func recordWordPressIssue(ctx *Context, route string, isActive bool, tool string) {
+	issue, ok := routes.DetectWordPressIssue(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "wordpress",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"wp_issue": issue.Issue,
+			"severity": issue.Severity,
+		},
+	})
+}
+
+// recordApacheConfig registra como "apache-config" las rutas a .htaccess,
+// .htpasswd o .htdigest con su severidad (crítica si expone credenciales).
+func recordApacheConfig(ctx *Context, route string, isActive bool, tool string) {
+	file, ok := routes.DetectApacheConfig(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "apache-config",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"file":     file.File,
+			"severity": file.Severity,
+		},
+	})
+}
+
+// recordGitExposure registra como "git" las rutas dentro de un directorio
+// .git expuesto con el componente afectado. Todos son críticos: con los packs,
+// objetos y reflogs se puede reconstruir el repositorio completo.
+func recordGitExposure(ctx *Context, route string, isActive bool, tool string) {
+	component, ok := routes.DetectGitComponent(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "git",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"git_component": component,
+			"severity":      "critical",
+		},
+	})
+}
+
+// recordCredentialFile registra como "credential-file" las rutas a ficheros de
+// credenciales conocidos (.aws/credentials, claves SSH, .npmrc...), siempre
+// con severidad crítica.
+func recordCredentialFile(ctx *Context, route string, isActive bool, tool string) {
+	file, ok := routes.DetectCredentialFile(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "credential-file",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"file":     file,
+			"severity": "critical",
+		},
+	})
+}
+
+// recordActuatorEndpoint registra como "actuator" las rutas a endpoints
+// sensibles de Spring Boot Actuator (heapdump, env, mappings...) con la
+// severidad propia de cada endpoint.
+func recordActuatorEndpoint(ctx *Context, route string, isActive bool, tool string) {
+	endpoint, severity, ok := routes.DetectActuatorEndpoint(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "actuator",
+		Value:  route,
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"actuator_endpoint": endpoint,
+			"severity":          severity,
+		},
+	})
+}
+
+// recordWellKnownAuth registra como "well-known" las rutas /.well-known/ que
+// describen flujos de autenticación (change-password, OIDC, WebAuthn, ...).
+func recordWellKnownAuth(ctx *Context, route string, isActive bool, tool string) {
+	wellKnownPath, ok := routes.DetectWellKnownAuth(route)
+	if !ok {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "well-known",
+		Value:    route,
+		Active:   isActive,
+		Up:       true,
+		Metadata: map[string]any{"path": wellKnownPath},
+	})
+}
+
 func writeRouteCategories(ctx *Context, route string, isActive bool, tool string) {
 	if ctx == nil || ctx.S == nil || ctx.Store == nil {
 		return
@@ -358,6 +551,18 @@ func writeRouteCategories(ctx *Context, route string, isActive bool, tool string
 			HandleCategory(ctx, categorySpecs["doc"], "doc:"+route, isActive, tool)
 		case routes.CategoryArchives:
 			HandleCategory(ctx, categorySpecs["archive"], "archive:"+route, isActive, tool)
+		case routes.CategoryServerStatus:
+			HandleCategory(ctx, categorySpecs["server-status"], "server-status:"+route, isActive, tool)
+		case routes.CategorySearchCluster:
+			HandleCategory(ctx, categorySpecs["search-cluster"], "search-cluster:"+route, isActive, tool)
+		case routes.CategoryIaCState:
+			HandleCategory(ctx, categorySpecs["iac-state"], "iac-state:"+route, isActive, tool)
+		case routes.CategoryMail:
+			HandleCategory(ctx, categorySpecs["mail"], "mail:"+route, isActive, tool)
+		case routes.CategorySourceCode:
+			HandleCategory(ctx, categorySpecs["source-code"], "source-code:"+route, isActive, tool)
+		case routes.CategoryWebSocket:
+			HandleCategory(ctx, categorySpecs["websocket"], "ws:"+route, isActive, tool)
 		}
 	}
 }
@@ -527,5 +732,92 @@ func init() {
 			NormalizePassive: true,
 			CheckScope:       true,
 		},
+		"server-status": {
+			Name:             "server-status",
+			Prefix:           "server-status:",
+			PassiveKeyspace:  keyspaceStatusPassive,
+			ActiveKeyspace:   keyspaceStatusActive,
+			ArtifactType:     "server-status",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+		},
+		"search-cluster": {
+			Name:             "search-cluster",
+			Prefix:           "search-cluster:",
+			PassiveKeyspace:  keyspaceSearchPassive,
+			ActiveKeyspace:   keyspaceSearchActive,
+			ArtifactType:     "search-cluster",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+		},
+		"iac-state": {
+			Name:             "iac-state",
+			Prefix:           "iac-state:",
+			PassiveKeyspace:  keyspaceIaCPassive,
+			ActiveKeyspace:   keyspaceIaCActive,
+			ArtifactType:     "iac-state",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+		},
+		"mail": {
+			Name:             "mail",
+			Prefix:           "mail:",
+			PassiveKeyspace:  keyspaceMailPassive,
+			ActiveKeyspace:   keyspaceMailActive,
+			ArtifactType:     "mail",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+		},
+		"source-code": {
+			Name:             "source-code",
+			Prefix:           "source-code:",
+			PassiveKeyspace:  keyspaceSourcePassive,
+			ActiveKeyspace:   keyspaceSourceActive,
+			ArtifactType:     "source-code",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+		},
+		"websocket": {
+			Name:             "websocket",
+			Prefix:           "ws:",
+			PassiveKeyspace:  keyspaceWSPassive,
+			ActiveKeyspace:   keyspaceWSActive,
+			ArtifactType:     "websocket",
+			IncludeRouteType: true,
+			NormalizePassive: true,
+			CheckScope:       true,
+			Custom:           webSocketCategoryHandler,
+		},
 	}
 }
+
+// recordSearchClusterSignature registra como "search-cluster" las rutas activas
+// cuya respuesta (título de httpx) delata Elasticsearch, Kibana o Solr aunque
+// la ruta no coincida con los endpoints conocidos.
+func recordSearchClusterSignature(ctx *Context, base, line, tool string) {
+	for _, cat := range routes.DetectCategories(base) {
+		if cat == routes.CategorySearchCluster {
+			return
+		}
+	}
+	product, ok := routes.SearchClusterSignature(strings.TrimSpace(strings.TrimPrefix(line, base)))
+	if !ok {
+		return
+	}
+	if ctx.Dedup != nil && ctx.Dedup.Seen(keyspaceSearchActive, base) {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "search-cluster",
+		Types:    []string{"route"},
+		Value:    base,
+		Active:   true,
+		Up:       true,
+		Metadata: map[string]any{"product": product, "match": "signature"},
+	})
+}
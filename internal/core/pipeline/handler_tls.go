@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// weakCipherMarkers identifica familias de cipher suites consideradas débiles.
+// Se comparan contra el nombre normalizado (mayúsculas, '-' → '_').
+var weakCipherMarkers = []string{"RC4", "3DES", "DES_CBC3", "NULL", "EXPORT", "_EXP_", "_DES_", "ANON"}
+
+// handleTLS procesa líneas "tls: {json}" con el resultado del handshake de una
+// conexión activa y adjunta la cipher suite negociada a la ruta correspondiente.
+func handleTLS(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "tls:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL     string `json:"url"`
+		Version string `json:"version"`
+		Cipher  string `json:"cipher"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	cipher := strings.TrimSpace(data.Cipher)
+	if base == "" || cipher == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{"tls_cipher": cipher}
+	if version := strings.TrimSpace(data.Version); version != "" {
+		metadata["tls_version"] = version
+	}
+	if isWeakCipher(cipher) {
+		metadata["weak_cipher"] = cipher
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "route",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
+
+// isWeakCipher indica si la cipher suite pertenece a una familia débil
+// (RC4, 3DES, NULL, EXPORT, DES o anónimas).
+func isWeakCipher(name string) bool {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if normalized == "" {
+		return false
+	}
+	normalized = "_" + strings.NewReplacer("-", "_", " ", "_").Replace(normalized) + "_"
+	for _, marker := range weakCipherMarkers {
+		if strings.Contains(normalized, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// handleUnauthAPI procesa líneas "unauthapi: {json}" emitidas cuando un
+// endpoint de API responde 200 con datos JSON sin autenticación y marca la
+// ruta con metadata unauth_data y severidad alta.
+func handleUnauthAPI(ctx *Context, line string, isActive bool, tool string) bool {
+	payload := strings.TrimSpace(strings.TrimPrefix(line, "unauthapi:"))
+	if payload == "" {
+		return true
+	}
+	if ctx == nil || ctx.Store == nil {
+		return true
+	}
+	var data struct {
+		URL         string `json:"url"`
+		ContentType string `json:"content_type"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return true
+	}
+	base := artifacts.ExtractRouteBase(data.URL)
+	if base == "" {
+		return true
+	}
+	if !ctx.ScopeAllowsRoute(base) {
+		return true
+	}
+	metadata := map[string]any{
+		"unauth_data": true,
+		"severity":    "high",
+	}
+	if contentType := strings.TrimSpace(data.ContentType); contentType != "" {
+		metadata["content_type"] = contentType
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:     "route",
+		Value:    base,
+		Active:   isActive,
+		Up:       true,
+		Metadata: metadata,
+	})
+	return true
+}
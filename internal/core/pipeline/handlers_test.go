@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// handlerLookupCorpus reúne líneas representativas de las herramientas: con y
+// sin prefijo, con prefijos desconocidos y con mayúsculas.
+var handlerLookupCorpus = []string{
+	"dns: {\"host\":\"api.example.com\",\"type\":\"A\",\"value\":\"1.1.1.1\"}",
+	"meta: subfinder finished",
+	"meta-route: https://example.com/robots.txt",
+	"gffinding: {\"resource\":\"https://example.com/app.js\",\"line\":1}",
+	"rdap: {\"domain\":\"example.com\"}",
+	"js: https://example.com/static/app.js",
+	"html: https://example.com/index.html",
+	"maps: https://example.com/app.js.map",
+	"json: https://example.com/data.json",
+	"api: https://example.com/api/v1/users",
+	"wasm: https://example.com/module.wasm",
+	"svg: https://example.com/logo.svg",
+	"crawl: https://example.com/sitemap.xml",
+	"server-status: https://example.com/server-status",
+	"search-cluster: https://example.com:9200/_cat/indices",
+	"iac-state: https://example.com/terraform.tfstate",
+	"cert: {\"common_name\":\"example.com\"}",
+	"tls: {\"host\":\"example.com\"}",
+	"openapi: {\"url\":\"https://example.com/openapi.json\"}",
+	"cookie: {\"url\":\"https://example.com/\"}",
+	"framing: {\"url\":\"https://example.com/\"}",
+	"backup: {\"url\":\"https://example.com/site.zip\"}",
+	"reflection: {\"url\":\"https://example.com/?q=1\"}",
+	"oidc: {\"url\":\"https://example.com/.well-known/openid-configuration\"}",
+	"phpinfo: {\"url\":\"https://example.com/info.php\"}",
+	"dependency: {\"name\":\"lodash\",\"ecosystem\":\"npm\"}",
+	"unauthapi: {\"url\":\"https://example.com/api/users\"}",
+	"DNS: {\"host\":\"example.com\"}",
+	"https://example.com/login",
+	"http://example.com:8080/admin",
+	"app.example.com",
+	"1.2.3.4",
+	"unknown: value",
+	":leading colon",
+}
+
+// linearLookup reproduce la resolución previa al registro indexado: recorrer
+// todos los handlers con prefijo y quedarse con el primero cuyo prefijo
+// encabeza la línea.
+func linearLookup(handlers []Handler, line string) Handler {
+	lowered := strings.ToLower(line)
+	for _, h := range handlers {
+		if strings.HasPrefix(lowered, h.Prefix()) {
+			return h
+		}
+	}
+	return nil
+}
+
+func prefixHandlerList(r *HandlerRegistry) []Handler {
+	handlers := make([]Handler, 0, len(r.prefixHandlers))
+	for _, h := range r.prefixHandlers {
+		handlers = append(handlers, h)
+	}
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].Prefix() < handlers[j].Prefix() })
+	return handlers
+}
+
+func handlerName(h Handler) string {
+	if h == nil {
+		return ""
+	}
+	return h.Name()
+}
+
+func TestHandlerRegistryLookupMatchesLinearScan(t *testing.T) {
+	t.Parallel()
+
+	registry, err := buildHandlerRegistry(nil)
+	if err != nil {
+		t.Fatalf("buildHandlerRegistry: %v", err)
+	}
+	handlers := prefixHandlerList(registry)
+
+	for _, line := range handlerLookupCorpus {
+		want := handlerName(linearLookup(handlers, line))
+		got := handlerName(registry.Lookup(extractPrefix(line)))
+		if got != want {
+			t.Fatalf("line %q: indexed lookup = %q, linear scan = %q", line, got, want)
+		}
+	}
+}
+
+func TestHandlerRegistryKeepsFallbackOrder(t *testing.T) {
+	t.Parallel()
+
+	registry, err := buildHandlerRegistry(nil)
+	if err != nil {
+		t.Fatalf("buildHandlerRegistry: %v", err)
+	}
+	order, err := resolveFallbackOrder(nil)
+	if err != nil {
+		t.Fatalf("resolveFallbackOrder: %v", err)
+	}
+	fallbacks := registry.Fallbacks()
+	if len(fallbacks) != len(order) {
+		t.Fatalf("expected %d fallbacks, got %d", len(order), len(fallbacks))
+	}
+	for i, name := range order {
+		if got, want := fallbacks[i].Name(), fallbackHandlers[name].Name(); got != want {
+			t.Fatalf("fallback %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func BenchmarkHandlerLookup(b *testing.B) {
+	registry, err := buildHandlerRegistry(nil)
+	if err != nil {
+		b.Fatalf("buildHandlerRegistry: %v", err)
+	}
+	handlers := prefixHandlerList(registry)
+
+	b.Run("linear", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = linearLookup(handlers, handlerLookupCorpus[i%len(handlerLookupCorpus)])
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = registry.Lookup(extractPrefix(handlerLookupCorpus[i%len(handlerLookupCorpus)]))
+		}
+	})
+}
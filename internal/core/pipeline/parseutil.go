@@ -228,6 +228,55 @@ func parseActiveRouteStatus(fullLine, base string) (int, bool) {
 	return code, true
 }
 
+// securityHeaderNames son las cabeceras de seguridad que se anotan en la
+// metadata "security_headers" de las rutas activas.
+var securityHeaderNames = []string{
+	"strict-transport-security",
+	"content-security-policy",
+	"x-frame-options",
+	"x-content-type-options",
+}
+
+// parseActiveRouteHeaders busca entre los corchetes que siguen a base el de
+// cabeceras ("[headers: strict-transport-security,x-frame-options]") y
+// devuelve la presencia de cada cabecera de securityHeaderNames. Los nombres
+// se comparan sin distinguir mayúsculas y con '_' equivalente a '-'. Si la
+// línea no trae ese corchete devuelve false, para no tomar como ausentes las
+// cabeceras de una ruta que no se inspeccionó.
+func parseActiveRouteHeaders(fullLine, base string) (map[string]bool, bool) {
+	if base == "" || !strings.HasPrefix(fullLine, base) {
+		return nil, false
+	}
+	meta := strings.TrimSpace(strings.TrimPrefix(fullLine, base))
+	for meta != "" {
+		if meta[0] != '[' {
+			return nil, false
+		}
+		end := strings.IndexRune(meta, ']')
+		if end < 0 {
+			return nil, false
+		}
+		inside := strings.TrimSpace(meta[1:end])
+		meta = strings.TrimSpace(meta[end+1:])
+
+		label, list, found := strings.Cut(inside, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(label), "headers") {
+			continue
+		}
+		seen := make(map[string]struct{})
+		for _, name := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' }) {
+			seen[strings.ReplaceAll(strings.ToLower(name), "_", "-")] = struct{}{}
+		}
+		headers := make(map[string]bool, len(securityHeaderNames))
+		for _, name := range securityHeaderNames {
+			_, present := seen[name]
+			headers[name] = present
+		}
+		return headers, true
+	}
+	return nil, false
+}
+
 func isImageURL(raw string) bool {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
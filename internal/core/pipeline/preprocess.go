@@ -0,0 +1,42 @@
+package pipeline
+
+import "strings"
+
+// LinePreprocessor normaliza una línea cruda de una herramienta al protocolo de
+// prefijos del Sink ("active: ", "html:", rutas, dominios, ...). Puede devolver
+// varias líneas o ninguna para descartar la entrada.
+type LinePreprocessor func(line string) []string
+
+// RegisterPreprocessor asocia un preprocesador a la herramienta indicada (el
+// nombre usado en WrapWithTool/InWithTool). Las líneas de esa herramienta se
+// transforman antes de llegar a los handlers. Un fn nil elimina el registro.
+func (s *Sink) RegisterPreprocessor(tool string, fn LinePreprocessor) {
+	tool = strings.ToLower(strings.TrimSpace(tool))
+	if s == nil || tool == "" {
+		return
+	}
+	s.preMu.Lock()
+	defer s.preMu.Unlock()
+	if fn == nil {
+		delete(s.preprocessors, tool)
+		return
+	}
+	if s.preprocessors == nil {
+		s.preprocessors = make(map[string]LinePreprocessor)
+	}
+	s.preprocessors[tool] = fn
+}
+
+// preprocess aplica el preprocesador registrado para tool, si lo hay.
+func (s *Sink) preprocess(tool, line string) []string {
+	if tool == "" {
+		return []string{line}
+	}
+	s.preMu.RLock()
+	fn := s.preprocessors[strings.ToLower(tool)]
+	s.preMu.RUnlock()
+	if fn == nil {
+		return []string{line}
+	}
+	return fn(line)
+}
@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// ArtifactProducer publica artefactos en un bus de eventos externo (p.ej. un
+// topic de Kafka). La clave identifica el tipo de artefacto para que los
+// consumidores puedan particionar o filtrar por tipo.
+type ArtifactProducer interface {
+	Produce(ctx context.Context, key, value []byte) error
+	Close() error
+}
+
+// producerStore reenvía al productor cada artefacto registrado, además de
+// persistirlo en el store subyacente. Los errores de publicación no bloquean
+// el registro: se guarda el primero y se devuelve al cerrar.
+type producerStore struct {
+	ArtifactStore
+	producer ArtifactProducer
+
+	mu       sync.Mutex
+	firstErr error
+}
+
+func newProducerStore(inner ArtifactStore, producer ArtifactProducer) *producerStore {
+	return &producerStore{ArtifactStore: inner, producer: producer}
+}
+
+func (s *producerStore) Record(tool string, artifact artifacts.Artifact) {
+	s.ArtifactStore.Record(tool, artifact)
+
+	normalized, ok := artifacts.Normalize(tool, artifact)
+	if !ok {
+		return
+	}
+	value, err := json.Marshal(normalized)
+	if err == nil {
+		err = s.producer.Produce(context.Background(), []byte(normalized.Type), value)
+	}
+	if err != nil {
+		s.mu.Lock()
+		if s.firstErr == nil {
+			s.firstErr = err
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *producerStore) Close() error {
+	storeErr := s.ArtifactStore.Close()
+	producerErr := s.producer.Close()
+	s.mu.Lock()
+	publishErr := s.firstErr
+	s.mu.Unlock()
+	return errors.Join(storeErr, producerErr, publishErr)
+}
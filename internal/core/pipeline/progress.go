@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"time"
+
+	"passive-rec/internal/platform/logx"
+)
+
+// progressReport emite cada informe de progreso del Sink; sustituible en
+// tests.
+var progressReport = logx.Progress
+
+// startProgress lanza el informe periódico si SinkConfig.ProgressInterval es
+// positivo. Llamadas repetidas a Start no lanzan más de un informe.
+func (s *Sink) startProgress() {
+	if s.progressInterval <= 0 || s.progressStop != nil {
+		return
+	}
+	s.progressStop = make(chan struct{})
+	s.progressDone = make(chan struct{})
+	go s.runProgress(s.progressInterval, s.progressStop, s.progressDone)
+}
+
+// stopProgress detiene el informe periódico y espera a que termine.
+func (s *Sink) stopProgress() {
+	if s.progressStop == nil {
+		return
+	}
+	close(s.progressStop)
+	<-s.progressDone
+	s.progressStop = nil
+}
+
+func (s *Sink) runProgress(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	lastLines := s.processed.Load()
+	lastCounts := s.handlerCounts()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			lines := s.processed.Load()
+			counts := s.handlerCounts()
+			s.emitProgress(now.Sub(last), lines-lastLines, lines, counts, lastCounts)
+			last, lastLines, lastCounts = now, lines, counts
+		}
+	}
+}
+
+// emitProgress informa de las líneas procesadas, el backlog del canal y el
+// ritmo de cada handler en el intervalo. La ETA estima cuánto tardará en
+// vaciarse el backlog al ritmo actual; no cuenta líneas que aún no han
+// emitido las fuentes.
+func (s *Sink) emitProgress(elapsed time.Duration, delta, total uint64, counts, previous map[string]uint64) {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return
+	}
+	backlog := len(s.lines)
+	linesPerSecond := float64(delta) / seconds
+	counters := logx.Fields{
+		"lines":         total,
+		"backlog":       backlog,
+		"lines_per_sec": logx.Sprintf("%.1f", linesPerSecond),
+	}
+	if backlog > 0 && linesPerSecond > 0 {
+		eta := time.Duration(float64(backlog) / linesPerSecond * float64(time.Second))
+		counters["eta"] = logx.FormatDuration(eta)
+	}
+
+	rates := make([]logx.ProgressRate, 0, len(counts))
+	for name, count := range counts {
+		if count <= previous[name] {
+			continue
+		}
+		rates = append(rates, logx.ProgressRate{
+			Name:      name,
+			PerSecond: float64(count-previous[name]) / seconds,
+		})
+	}
+	progressReport("sink", counters, rates)
+}
+
+// handlerCounts devuelve las ejecuciones acumuladas por handler según
+// HandlerMetrics.
+func (s *Sink) handlerCounts() map[string]uint64 {
+	metrics := s.HandlerMetrics()
+	counts := make(map[string]uint64, len(metrics))
+	for _, metric := range metrics {
+		counts[metric.Name] = metric.Count
+	}
+	return counts
+}
@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"passive-rec/internal/platform/logx"
+)
+
+type progressCall struct {
+	counters logx.Fields
+	rates    []logx.ProgressRate
+}
+
+func captureProgress(t *testing.T) func() []progressCall {
+	t.Helper()
+	var (
+		mu    sync.Mutex
+		calls []progressCall
+	)
+	original := progressReport
+	progressReport = func(component string, counters logx.Fields, rates []logx.ProgressRate) {
+		if component != "sink" {
+			t.Errorf("unexpected progress component %q", component)
+		}
+		mu.Lock()
+		calls = append(calls, progressCall{counters: counters, rates: rates})
+		mu.Unlock()
+	}
+	t.Cleanup(func() { progressReport = original })
+	return func() []progressCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]progressCall(nil), calls...)
+	}
+}
+
+func TestSinkEmitProgressReportsBacklogAndHandlerRates(t *testing.T) {
+	calls := captureProgress(t)
+
+	sink, err := NewSinkWithConfig(SinkConfig{Outdir: t.TempDir(), Target: "example.com", ScopeMode: "subdomains", LineBuffer: 8})
+	if err != nil {
+		t.Fatalf("NewSinkWithConfig: %v", err)
+	}
+	sink.In() <- "app.example.com"
+	sink.In() <- "api.example.com"
+
+	previous := map[string]uint64{"handleDomain": 10, "handleRoute": 5}
+	counts := map[string]uint64{"handleDomain": 30, "handleRoute": 5, "handleCert": 4}
+	sink.emitProgress(2*time.Second, 40, 100, counts, previous)
+
+	got := calls()
+	if len(got) != 1 {
+		t.Fatalf("expected one progress report, got %d", len(got))
+	}
+	counters := got[0].counters
+	if counters["lines"] != uint64(100) || counters["backlog"] != 2 || counters["lines_per_sec"] != "20.0" {
+		t.Fatalf("unexpected counters: %v", counters)
+	}
+	if counters["eta"] != "100ms" {
+		t.Fatalf("expected the backlog ETA at 20 lines/s, got %v", counters["eta"])
+	}
+	rates := make(map[string]float64)
+	for _, rate := range got[0].rates {
+		rates[rate.Name] = rate.PerSecond
+	}
+	if len(rates) != 2 || rates["handleDomain"] != 10 || rates["handleCert"] != 2 {
+		t.Fatalf("expected only handlers active in the interval, got %v", got[0].rates)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestSinkProgressStopsOnClose(t *testing.T) {
+	calls := captureProgress(t)
+
+	sink, err := NewSinkWithConfig(SinkConfig{
+		Outdir:           t.TempDir(),
+		Target:           "example.com",
+		ScopeMode:        "subdomains",
+		LineBuffer:       8,
+		ProgressInterval: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewSinkWithConfig: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	sink.In() <- "app.example.com"
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(calls()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(calls()) == 0 {
+		t.Fatal("expected periodic progress reports while the sink runs")
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	reported := len(calls())
+	time.Sleep(20 * time.Millisecond)
+	if after := len(calls()); after != reported {
+		t.Fatalf("progress kept reporting after Close: %d -> %d", reported, after)
+	}
+}
+
+func TestSinkWithoutProgressIntervalDoesNotReport(t *testing.T) {
+	calls := captureProgress(t)
+
+	sink, err := NewSinkWithConfig(SinkConfig{Outdir: t.TempDir(), Target: "example.com", ScopeMode: "subdomains", LineBuffer: 8})
+	if err != nil {
+		t.Fatalf("NewSinkWithConfig: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	sink.In() <- "app.example.com"
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := calls(); len(got) != 0 {
+		t.Fatalf("expected no progress without an interval, got %v", got)
+	}
+}
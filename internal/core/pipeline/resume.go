@@ -0,0 +1,196 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"passive-rec/internal/adapters/artifacts"
+	"passive-rec/internal/platform/logx"
+)
+
+// artifactSeeder lo implementan los stores que aceptan artefactos de una
+// ejecución anterior tal cual, sin normalizarlos ni actualizar LastSeen.
+type artifactSeeder interface {
+	seed(artifact artifacts.Artifact)
+}
+
+// seed registra un artefacto previo conservando sus timestamps, herramientas
+// y ocurrencias. Si la clave ya existe no se modifica.
+func (s *jsonlStore) seed(artifact artifacts.Artifact) {
+	if s == nil {
+		return
+	}
+	key := artifacts.KeyFor(artifact)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.index[key]; exists {
+		return
+	}
+	rec := &artifactRecord{
+		Artifact:    artifact,
+		Tools:       make(map[string]struct{}),
+		Occurrences: artifact.Occurrences,
+		seq:         s.seq.Add(1),
+	}
+	rec.Artifact.Tools = nil
+	rec.Artifact.Occurrences = 0
+	for _, tool := range artifact.Tools {
+		rec.addTool(tool)
+	}
+	rec.addTool(artifact.Tool)
+	s.index[key] = rec
+	s.order = append(s.order, key)
+	s.dirty = true
+}
+
+func (s *shardedStore) seed(artifact artifacts.Artifact) {
+	if s == nil {
+		return
+	}
+	s.getShard(artifacts.KeyFor(artifact)).seed(artifact)
+}
+
+func (s *asyncStore) seed(artifact artifacts.Artifact) {
+	if seeder, ok := s.inner.(artifactSeeder); ok {
+		seeder.seed(artifact)
+	}
+}
+
+func (s *producerStore) seed(artifact artifacts.Artifact) {
+	if seeder, ok := s.ArtifactStore.(artifactSeeder); ok {
+		seeder.seed(artifact)
+	}
+}
+
+func (s *confidenceStore) seed(artifact artifacts.Artifact) {
+	if seeder, ok := s.ArtifactStore.(artifactSeeder); ok {
+		seeder.seed(artifact)
+	}
+}
+
+// errCorruptManifest indica que el manifiesto previo existe pero no se puede
+// decodificar.
+var errCorruptManifest = errors.New("manifiesto corrupto")
+
+// LoadExisting carga el manifiesto previo de path para que una ejecución
+// reanudada conserve los artefactos ya descubiertos (con sus ocurrencias y
+// timestamps) y solo añada los nuevos. Los valores cargados se marcan como
+// vistos en el deduplicador según su tipo y estado. Un manifiesto inexistente
+// o vacío no es un error; uno corrupto se descarta con un aviso y el sink
+// empieza desde cero. Debe llamarse antes de Start.
+func (s *Sink) LoadExisting(path string) error {
+	_, _, err := preloadArtifacts(s.artifacts, s.dedup, path, 0, time.Now().UTC())
+	return err
+}
+
+// preloadArtifacts carga en store y dedup el manifiesto previo de path. Con
+// ttl > 0 se descartan los artefactos cuyo LastSeen (o FirstSeen, si falta) es
+// anterior a now-ttl; los que no tienen timestamp se conservan. Devuelve el
+// número de artefactos cargados y descartados.
+func preloadArtifacts(store ArtifactStore, dedup Deduplicator, path string, ttl time.Duration, now time.Time) (loaded, expired int, err error) {
+	list, err := readManifest(path)
+	if err != nil {
+		if errors.Is(err, errCorruptManifest) {
+			logx.Warn("Manifiesto previo corrupto; se empieza desde cero", logx.Fields{"path": path, "error": err.Error()})
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	seeder, _ := store.(artifactSeeder)
+	for _, art := range list {
+		if ttl > 0 && artifactExpired(art, ttl, now) {
+			expired++
+			continue
+		}
+		if seeder != nil {
+			seeder.seed(art)
+		}
+		seedDedup(dedup, art)
+		loaded++
+	}
+	return loaded, expired, nil
+}
+
+// readManifest lee el manifiesto completo de path. Un fichero inexistente o
+// vacío devuelve una lista vacía; si no se puede decodificar se devuelve un
+// error que envuelve errCorruptManifest.
+func readManifest(path string) ([]artifacts.Artifact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		return nil, nil
+	}
+
+	reader, err := artifacts.NewReaderV2(f)
+	if err != nil {
+		return nil, fmt.Errorf("resume: %s: %w: %v", path, errCorruptManifest, err)
+	}
+	list, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("resume: %s: %w: %v", path, errCorruptManifest, err)
+	}
+	return list, nil
+}
+
+// seedDedup marca el valor del artefacto como visto en el keyspace que usan
+// los handlers para su tipo y estado (activo o pasivo).
+func seedDedup(dedup Deduplicator, art artifacts.Artifact) {
+	if dedup == nil {
+		return
+	}
+	passive, active := dedupKeyspaces(art.Type)
+	keyspace := passive
+	if art.Active {
+		keyspace = active
+	}
+	key := art.Value
+	if art.Type == "certificate" {
+		key, _ = art.Metadata["key"].(string)
+	}
+	if keyspace == "" || key == "" {
+		return
+	}
+	_ = dedup.Seen(keyspace, key)
+}
+
+// dedupKeyspaces devuelve los keyspaces pasivo y activo de un tipo de
+// artefacto, o vacíos si los handlers no lo deduplican.
+func dedupKeyspaces(typ string) (passive, active string) {
+	switch typ {
+	case "domain":
+		return keyspaceDomainPassive, keyspaceDomainActive
+	case "certificate":
+		return keyspaceCertPassive, keyspaceCertActive
+	case "ip":
+		return keyspaceIPPassive, keyspaceIPActive
+	case "email":
+		return keyspaceEmailPassive, keyspaceEmailActive
+	}
+	for _, spec := range categorySpecs {
+		if spec.ArtifactType == typ {
+			return spec.PassiveKeyspace, spec.ActiveKeyspace
+		}
+	}
+	return "", ""
+}
+
+// artifactExpired indica si el artefacto no se ha visto dentro de ttl.
+func artifactExpired(art artifacts.Artifact, ttl time.Duration, now time.Time) bool {
+	seen := art.LastSeen
+	if seen == "" {
+		seen = art.FirstSeen
+	}
+	ts, err := time.Parse(time.RFC3339, seen)
+	if err != nil {
+		return false
+	}
+	return now.Sub(ts) > ttl
+}
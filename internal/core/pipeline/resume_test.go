@@ -0,0 +1,204 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func writeResumeManifest(t *testing.T, dir string, list []artifacts.Artifact) {
+	t.Helper()
+	writer := artifacts.NewWriterV2(filepath.Join(dir, "artifacts.jsonl"), "example.com")
+	if err := writer.WriteArtifacts(list); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+}
+
+func TestSinkResumePrunesArtifactsOlderThanTTL(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	old := now.Add(-40 * 24 * time.Hour).Format(time.RFC3339)
+	recent := now.Add(-24 * time.Hour).Format(time.RFC3339)
+	writeResumeManifest(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "old.example.com", Tool: "subfinder", Up: true, Occurrences: 1, FirstSeen: old, LastSeen: old},
+		{Type: "domain", Value: "recent.example.com", Tool: "subfinder", Up: true, Occurrences: 4, FirstSeen: old, LastSeen: recent},
+		{Type: "domain", Value: "undated.example.com", Tool: "subfinder", Up: true, Occurrences: 1},
+	})
+
+	sink, err := NewSinkWithConfig(SinkConfig{
+		Outdir:      dir,
+		Target:      "example.com",
+		ScopeMode:   "subdomains",
+		LineBuffer:  LineBufferSize(1),
+		Resume:      true,
+		ArtifactTTL: 30 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewSinkWithConfig: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	sink.In() <- WrapWithTool("amass", "new.example.com")
+	sink.In() <- WrapWithTool("amass", "recent.example.com")
+	sink.Flush()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("sink close: %v", err)
+	}
+
+	arts := readArtifactsFile(t, filepath.Join(dir, "artifacts.jsonl"))
+	for _, art := range arts {
+		if art.Value == "old.example.com" {
+			t.Fatalf("expected old.example.com to be pruned, got %+v", art)
+		}
+	}
+	requireArtifact(t, arts, "domain", "new.example.com", false)
+	requireArtifact(t, arts, "domain", "undated.example.com", false)
+
+	kept := requireArtifact(t, arts, "domain", "recent.example.com", false)
+	if kept.FirstSeen != old {
+		t.Fatalf("expected first_seen %q to be preserved, got %q", old, kept.FirstSeen)
+	}
+	if kept.LastSeen == recent {
+		t.Fatalf("expected last_seen to be refreshed after re-recording, got %q", kept.LastSeen)
+	}
+	if kept.Occurrences != 5 {
+		t.Fatalf("expected occurrences to continue from 4 to 5, got %d", kept.Occurrences)
+	}
+}
+
+func TestSinkResumeWithoutTTLKeepsAllArtifacts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	old := time.Now().UTC().Add(-400 * 24 * time.Hour).Format(time.RFC3339)
+	writeResumeManifest(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "old.example.com", Tool: "subfinder", Up: true, Occurrences: 1, FirstSeen: old, LastSeen: old},
+	})
+
+	sink, err := NewSinkWithConfig(SinkConfig{
+		Outdir:     dir,
+		Target:     "example.com",
+		ScopeMode:  "subdomains",
+		LineBuffer: LineBufferSize(1),
+		Resume:     true,
+	})
+	if err != nil {
+		t.Fatalf("NewSinkWithConfig: %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	sink.In() <- WrapWithTool("amass", "new.example.com")
+	sink.Flush()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("sink close: %v", err)
+	}
+
+	arts := readArtifactsFile(t, filepath.Join(dir, "artifacts.jsonl"))
+	requireArtifact(t, arts, "domain", "old.example.com", false)
+	requireArtifact(t, arts, "domain", "new.example.com", false)
+}
+
+func TestArtifactExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ttl := 7 * 24 * time.Hour
+	cases := []struct {
+		name string
+		art  artifacts.Artifact
+		want bool
+	}{
+		{"recent last_seen", artifacts.Artifact{LastSeen: "2024-05-30T00:00:00Z"}, false},
+		{"stale last_seen", artifacts.Artifact{LastSeen: "2024-05-01T00:00:00Z"}, true},
+		{"falls back to first_seen", artifacts.Artifact{FirstSeen: "2024-05-01T00:00:00Z"}, true},
+		{"missing timestamps", artifacts.Artifact{}, false},
+		{"unparseable timestamp", artifacts.Artifact{LastSeen: "ayer"}, false},
+	}
+	for _, tc := range cases {
+		if got := artifactExpired(tc.art, ttl, now); got != tc.want {
+			t.Fatalf("%s: artifactExpired = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSinkLoadExistingSeedsStoreAndDedup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeResumeManifest(t, dir, []artifacts.Artifact{
+		{Type: "domain", Value: "app.example.com", Tool: "subfinder", Up: true, Occurrences: 3},
+		{Type: "route", Value: "https://app.example.com/login", Tool: "gau", Active: true, Up: true, Occurrences: 2},
+		{Type: "js", Value: "https://app.example.com/app.js", Tool: "gau", Up: true, Occurrences: 1},
+	})
+
+	outdir := t.TempDir()
+	sink, err := NewSink(outdir, false, "example.com", "subdomains", LineBufferSize(1))
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.LoadExisting(filepath.Join(dir, "artifacts.jsonl")); err != nil {
+		t.Fatalf("LoadExisting: %v", err)
+	}
+
+	seen := []struct{ keyspace, key string }{
+		{keyspaceDomainPassive, "app.example.com"},
+		{keyspaceRouteActive, "https://app.example.com/login"},
+		{keyspaceRoutePassive, "https://app.example.com/app.js"},
+	}
+	for _, tc := range seen {
+		if !sink.dedup.Seen(tc.keyspace, tc.key) {
+			t.Fatalf("expected %q to be pre-seeded in %s", tc.key, tc.keyspace)
+		}
+	}
+	if sink.dedup.Seen(keyspaceDomainActive, "app.example.com") {
+		t.Fatalf("passive domain should not be seeded in the active keyspace")
+	}
+
+	sink.Start(context.Background(), 1)
+	sink.In() <- WrapWithTool("amass", "app.example.com")
+	sink.Flush()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("sink close: %v", err)
+	}
+
+	arts := readArtifactsFile(t, filepath.Join(outdir, "artifacts.jsonl"))
+	domain := requireArtifact(t, arts, "domain", "app.example.com", false)
+	if domain.Occurrences != 4 {
+		t.Fatalf("expected occurrences to continue from 3 to 4, got %d", domain.Occurrences)
+	}
+	requireArtifact(t, arts, "route", "https://app.example.com/login", true)
+}
+
+func TestSinkResumeIgnoresCorruptManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "artifacts.jsonl"), []byte("{not json\n"), 0o644); err != nil {
+		t.Fatalf("write corrupt manifest: %v", err)
+	}
+
+	sink, err := NewSinkWithConfig(SinkConfig{
+		Outdir:     dir,
+		Target:     "example.com",
+		ScopeMode:  "subdomains",
+		LineBuffer: LineBufferSize(1),
+		Resume:     true,
+	})
+	if err != nil {
+		t.Fatalf("expected corrupt manifest to be ignored, got %v", err)
+	}
+	sink.Start(context.Background(), 1)
+	sink.In() <- WrapWithTool("amass", "new.example.com")
+	sink.Flush()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("sink close: %v", err)
+	}
+
+	arts := readArtifactsFile(t, filepath.Join(dir, "artifacts.jsonl"))
+	if len(arts) != 1 {
+		t.Fatalf("expected a fresh manifest with 1 artifact, got %+v", arts)
+	}
+	requireArtifact(t, arts, "domain", "new.example.com", false)
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+// metaSecretPatterns son expresiones de credenciales con formato reconocible
+// que no deberían aparecer en mensajes meta ni banners de herramientas.
+var metaSecretPatterns = []struct {
+	rule    string
+	pattern *regexp.Regexp
+}{
+	{rule: "aws-access-key", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{rule: "github-token", pattern: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{rule: "slack-token", pattern: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+	{rule: "google-api-key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{rule: "stripe-secret-key", pattern: regexp.MustCompile(`\bsk_live_[0-9A-Za-z]{24,}\b`)},
+	{rule: "private-key", pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+// redactMetaSecrets oculta con redactToken las credenciales reconocidas en el
+// texto y devuelve el texto redactado junto con las reglas que coincidieron,
+// ordenadas. Sin coincidencias, el texto se devuelve intacto.
+func redactMetaSecrets(text string) (string, []string) {
+	var rules []string
+	for _, candidate := range metaSecretPatterns {
+		if !candidate.pattern.MatchString(text) {
+			continue
+		}
+		rules = append(rules, candidate.rule)
+		text = candidate.pattern.ReplaceAllStringFunc(text, redactToken)
+	}
+	sort.Strings(rules)
+	return text, rules
+}
+
+// recordMetaSecrets registra un gfFinding crítico con la evidencia ya
+// redactada por redactMetaSecrets si alguna regla coincidió.
+func recordMetaSecrets(ctx *Context, evidence string, rules []string, isActive bool, tool string) {
+	if len(rules) == 0 {
+		return
+	}
+	ctx.Store.Record(tool, artifacts.Artifact{
+		Type:   "gfFinding",
+		Types:  rules,
+		Value:  buildGFFindingValue("meta", 0, evidence),
+		Active: isActive,
+		Up:     true,
+		Metadata: map[string]any{
+			"evidence": evidence,
+			"resource": "meta",
+			"rules":    rules,
+			"severity": "critical",
+		},
+	})
+}
+
+// redactToken conserva los 4 primeros y últimos caracteres del secreto para
+// poder identificarlo y oculta el resto.
+func redactToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", len(token)-8) + token[len(token)-4:]
+}
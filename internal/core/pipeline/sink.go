@@ -2,11 +2,15 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"passive-rec/internal/platform/logx"
 	"passive-rec/internal/platform/netutil"
 )
 
@@ -20,6 +24,10 @@ const (
 	lineBufferPerWorker = 256
 )
 
+// flushRetryBackoff es la espera antes del primer reintento de un flush
+// fallido; se duplica en cada reintento posterior.
+var flushRetryBackoff = 200 * time.Millisecond
+
 const (
 	toolMarker    = "\x00tool:"
 	toolSeparator = "\x00"
@@ -28,12 +36,25 @@ const (
 // LineBufferSize calcula un tamaño de búfer recomendado para el canal de líneas
 // en función del número de workers configurado.
 func LineBufferSize(workers int) int {
+	return LineBufferSizeWith(workers, 0, 0)
+}
+
+// LineBufferSizeWith funciona como LineBufferSize pero permite sustituir el
+// multiplicador por worker y el mínimo. Los valores <= 0 usan los por defecto
+// (lineBufferPerWorker y defaultLineBuffer).
+func LineBufferSizeWith(workers, perWorker, minimum int) int {
 	if workers < 1 {
 		workers = 1
 	}
-	size := workers * lineBufferPerWorker
-	if size < defaultLineBuffer {
-		size = defaultLineBuffer
+	if perWorker <= 0 {
+		perWorker = lineBufferPerWorker
+	}
+	if minimum <= 0 {
+		minimum = defaultLineBuffer
+	}
+	size := workers * perWorker
+	if size < minimum {
+		size = minimum
 	}
 	return size
 }
@@ -53,6 +74,27 @@ type Sink struct {
 	registry       *HandlerRegistry
 	ctx            *Context
 	recorder       StepRecorder
+	flushRetries   int
+	flushMu        sync.Mutex
+	lastErr        error
+	preprocessors  map[string]LinePreprocessor
+	preMu          sync.RWMutex
+	wildcardZones  map[string]struct{}
+	wildcardMu     sync.RWMutex
+	// preferWrapperTool atribuye los artefactos a la herramienta del wrapper
+	// en lugar de inferirla del mensaje (ver Context.AttributeTool).
+	preferWrapperTool bool
+	// processed cuenta las líneas consumidas por los workers; lo usa el
+	// informe de progreso (ver progress.go).
+	processed        atomic.Uint64
+	progressInterval time.Duration
+	progressStop     chan struct{}
+	progressDone     chan struct{}
+	// done es el canal del contexto de Start. Al cerrarse, los workers
+	// terminan la línea en curso y descartan las encoladas, y Flush deja de
+	// esperar a que la cola se vacíe.
+	done      <-chan struct{}
+	discarded atomic.Uint64
 }
 
 // StepRecorder recibe callbacks con la línea cruda emitida por cada herramienta.
@@ -68,6 +110,62 @@ type SinkConfig struct {
 	Target     string
 	ScopeMode  string
 	LineBuffer int
+	// LineBufferMin es el tamaño mínimo del canal de líneas; sustituye a
+	// defaultLineBuffer cuando es > 0.
+	LineBufferMin int
+	// FallbackOrder reordena los handlers sin prefijo (ver DefaultFallbackOrder).
+	// Los handlers no listados se prueban después, en el orden por defecto.
+	FallbackOrder []string
+	// MinConfidence descarta los artefactos cuya confianza (Metadata["confidence"]
+	// o la derivada de su estado activo) sea inferior a este valor (0-1). Con 0
+	// no se filtra nada.
+	MinConfidence float64
+	// Producer, si no es nil, recibe cada artefacto registrado (ver
+	// ArtifactProducer). El sink lo cierra en Close.
+	Producer ArtifactProducer
+	// FlushRetries es el número de reintentos (con backoff exponencial) cuando
+	// falla la escritura de artifacts.jsonl. El último error queda disponible
+	// en Sink.LastError.
+	FlushRetries int
+	// ScopeFile, si no está vacío, sustituye el scope derivado de Target por
+	// el definido en el fichero (dominios y CIDR, ver netutil.NewScopeFromFile).
+	ScopeFile string
+	// ExcludeScope resta del scope (de Target o de ScopeFile) estos dominios y
+	// sus subdominios.
+	ExcludeScope []string
+	// DedupWindow limita el número de claves que recuerda el deduplicador
+	// (LRU); al superarlo se olvidan las más antiguas. Con 0 no hay límite.
+	DedupWindow int
+	// Preprocessors normaliza la salida propia de cada herramienta (clave:
+	// nombre usado en WrapWithTool) antes de pasarla a los handlers. Ver
+	// Sink.RegisterPreprocessor.
+	Preprocessors map[string]LinePreprocessor
+	// Resume carga el artifacts.jsonl previo de Outdir al crear el sink para
+	// que los artefactos de ejecuciones anteriores se conserven al reescribirlo
+	// (ver Sink.LoadExisting).
+	Resume bool
+	// ArtifactTTL, con Resume, descarta al cargar los artefactos no vistos
+	// (LastSeen) en este intervalo. Con 0 no caducan.
+	ArtifactTTL time.Duration
+	// PreferWrapperTool atribuye los artefactos de las líneas envueltas con
+	// InWithTool/WrapWithTool a esa herramienta, en lugar de inferirla del
+	// contenido del mensaje (meta, rdap).
+	PreferWrapperTool bool
+	// ProgressInterval es la cadencia con la que el Sink informa de las líneas
+	// procesadas, el backlog y el ritmo de cada handler. Con 0 no informa.
+	ProgressInterval time.Duration
+}
+
+// DefaultFallbackOrder es el orden en que se prueban los handlers sin prefijo
+// cuando una línea no coincide con ningún handler registrado por prefijo.
+var DefaultFallbackOrder = []string{"relation", "meta", "route", "cert", "domain"}
+
+var fallbackHandlers = map[string]Handler{
+	"relation": WithMetrics("handleRelation", NewHandler("handleRelation", "", handleRelation)),
+	"meta":     WithMetrics("handleMetaFallback", NewHandler("handleMeta", "", handleMeta)),
+	"route":    WithMetrics("handleRoute", NewHandler("handleRoute", "", handleRoute)),
+	"cert":     WithMetrics("handleCertFallback", NewHandler("handleCert", "", handleCert)),
+	"domain":   WithMetrics("handleDomain", NewHandler("handleDomain", "", handleDomain)),
 }
 
 func NewSink(outdir string, active bool, target string, scopeMode string, lineBuffer int) (*Sink, error) {
@@ -81,37 +179,110 @@ func NewSink(outdir string, active bool, target string, scopeMode string, lineBu
 }
 
 func NewSinkWithConfig(cfg SinkConfig) (*Sink, error) {
+	minBuffer := defaultLineBuffer
+	if cfg.LineBufferMin > 0 {
+		minBuffer = cfg.LineBufferMin
+	}
 	if cfg.LineBuffer <= 0 {
-		cfg.LineBuffer = defaultLineBuffer
+		cfg.LineBuffer = minBuffer
+	}
+	if cfg.LineBufferMin > 0 && cfg.LineBuffer < cfg.LineBufferMin {
+		cfg.LineBuffer = cfg.LineBufferMin
+	}
+	if cfg.FlushRetries < 0 {
+		cfg.FlushRetries = 0
 	}
 	if err := os.MkdirAll(cfg.Outdir, 0o755); err != nil {
 		return nil, err
 	}
 
-	artifactsPath := filepath.Join(cfg.Outdir, "artifacts.jsonl")
-	store := NewOptimizedStore(artifactsPath, cfg.Target)
+	// El scope y los handlers se resuelven antes de crear el store, que arranca
+	// un worker y tendría que cerrarse si alguno fallara.
+	scope := netutil.NewScope(cfg.Target, cfg.ScopeMode, cfg.ExcludeScope...)
+	if cfg.ScopeFile != "" {
+		fileScope, err := netutil.NewScopeFromFile(cfg.ScopeFile, cfg.ScopeMode, cfg.ExcludeScope...)
+		if err != nil {
+			return nil, err
+		}
+		fileScope.ResolveHostnames(cfg.Active)
+		scope = fileScope
+	}
 
-	dedup := NewDedupe()
+	registry, err := buildHandlerRegistry(cfg.FallbackOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactsPath := filepath.Join(cfg.Outdir, "artifacts.jsonl")
+	var store ArtifactStore = NewOptimizedStore(artifactsPath, cfg.Target)
+	dedup := NewDedupeWithWindow(cfg.DedupWindow)
+	if cfg.Resume {
+		if _, _, err := preloadArtifacts(store, dedup, artifactsPath, cfg.ArtifactTTL, time.Now().UTC()); err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+	}
+	if cfg.Producer != nil {
+		store = newProducerStore(store, cfg.Producer)
+	}
+	if cfg.MinConfidence > 0 {
+		store = newConfidenceStore(store, cfg.MinConfidence)
+	}
 
 	s := &Sink{
-		artifacts:      store,
-		dedup:          dedup,
-		scope:          netutil.NewScope(cfg.Target, cfg.ScopeMode),
-		activeMode:     cfg.Active,
-		lines:          make(chan string, cfg.LineBuffer),
-		handlerMetrics: make(map[string]*handlerStats),
+		artifacts:         store,
+		dedup:             dedup,
+		scope:             scope,
+		activeMode:        cfg.Active,
+		lines:             make(chan string, cfg.LineBuffer),
+		handlerMetrics:    make(map[string]*handlerStats),
+		flushRetries:      cfg.FlushRetries,
+		preferWrapperTool: cfg.PreferWrapperTool,
+		progressInterval:  cfg.ProgressInterval,
+	}
+	for tool, fn := range cfg.Preprocessors {
+		s.RegisterPreprocessor(tool, fn)
 	}
 	s.cond = sync.NewCond(&s.procMu)
 	s.ctx = &Context{S: s, Store: store, Dedup: dedup}
-	s.registry = buildHandlerRegistry()
+	s.registry = registry
 	return s, nil
 }
 
-func buildHandlerRegistry() *HandlerRegistry {
+// resolveFallbackOrder valida el orden solicitado y lo completa con los
+// handlers restantes en el orden por defecto.
+func resolveFallbackOrder(order []string) ([]string, error) {
+	resolved := make([]string, 0, len(DefaultFallbackOrder))
+	seen := make(map[string]bool, len(DefaultFallbackOrder))
+	for _, name := range order {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := fallbackHandlers[name]; !ok {
+			return nil, fmt.Errorf("pipeline: handler fallback desconocido %q (valores permitidos: %s)", name, strings.Join(DefaultFallbackOrder, ", "))
+		}
+		seen[name] = true
+		resolved = append(resolved, name)
+	}
+	for _, name := range DefaultFallbackOrder {
+		if !seen[name] {
+			resolved = append(resolved, name)
+		}
+	}
+	return resolved, nil
+}
+
+func buildHandlerRegistry(fallbackOrder []string) (*HandlerRegistry, error) {
+	order, err := resolveFallbackOrder(fallbackOrder)
+	if err != nil {
+		return nil, err
+	}
 	registry := NewHandlerRegistry()
 	registry.Register(WithMetrics("handleDNS", NewHandler("handleDNS", "dns:", handleDNS)))
 	registry.Register(WithMetrics("handleMeta", NewHandler("handleMeta", "meta:", handleMeta)))
 	registry.Register(WithMetrics("handleGFFinding", NewHandler("handleGFFinding", "gffinding:", handleGFFinding)))
+	registry.Register(WithMetrics("handleFinding", NewHandler("handleFinding", "finding:", handleFinding)))
 	registry.Register(WithMetrics("handleRDAP", NewHandler("handleRDAP", "rdap:", handleRDAP)))
 	registry.Register(WithMetrics("handleJS", NewHandler("handleJS", "js:", handleJS)))
 	registry.Register(WithMetrics("handleHTML", NewHandler("handleHTML", "html:", handleHTML)))
@@ -122,31 +293,109 @@ func buildHandlerRegistry() *HandlerRegistry {
 	registry.Register(WithMetrics("handleSVGCategory", NewHandler("handleSVGCategory", "svg:", handleSVGCategory)))
 	registry.Register(WithMetrics("handleCrawlCategory", NewHandler("handleCrawlCategory", "crawl:", handleCrawlCategory)))
 	registry.Register(WithMetrics("handleMetaCategory", NewHandler("handleMetaCategory", "meta-route:", handleMetaCategory)))
+	registry.Register(WithMetrics("handleServerStatusCategory", NewHandler("handleServerStatusCategory", "server-status:", handleServerStatusCategory)))
+	registry.Register(WithMetrics("handleSearchClusterCategory", NewHandler("handleSearchClusterCategory", "search-cluster:", handleSearchClusterCategory)))
+	registry.Register(WithMetrics("handleIaCStateCategory", NewHandler("handleIaCStateCategory", "iac-state:", handleIaCStateCategory)))
+	registry.Register(WithMetrics("handleMailCategory", NewHandler("handleMailCategory", "mail:", handleMailCategory)))
+	registry.Register(WithMetrics("handleSourceCodeCategory", NewHandler("handleSourceCodeCategory", "source-code:", handleSourceCodeCategory)))
+	registry.Register(WithMetrics("handleWebSocketCategory", NewHandler("handleWebSocketCategory", "ws:", handleWebSocketCategory)))
 	registry.Register(WithMetrics("handleCert", NewHandler("handleCert", "cert:", handleCert)))
-
-	registry.Register(WithMetrics("handleRelation", NewHandler("handleRelation", "", handleRelation)))
-	registry.Register(WithMetrics("handleMetaFallback", NewHandler("handleMeta", "", handleMeta)))
-	registry.Register(WithMetrics("handleRoute", NewHandler("handleRoute", "", handleRoute)))
-	registry.Register(WithMetrics("handleCertFallback", NewHandler("handleCert", "", handleCert)))
-	registry.Register(WithMetrics("handleDomain", NewHandler("handleDomain", "", handleDomain)))
-	return registry
+	registry.Register(WithMetrics("handleTLS", NewHandler("handleTLS", "tls:", handleTLS)))
+	registry.Register(WithMetrics("handleOpenAPI", NewHandler("handleOpenAPI", "openapi:", handleOpenAPI)))
+	registry.Register(WithMetrics("handleCookie", NewHandler("handleCookie", "cookie:", handleCookie)))
+	registry.Register(WithMetrics("handleFraming", NewHandler("handleFraming", "framing:", handleFraming)))
+	registry.Register(WithMetrics("handleBackup", NewHandler("handleBackup", "backup:", handleBackup)))
+	registry.Register(WithMetrics("handleReflection", NewHandler("handleReflection", "reflection:", handleReflection)))
+	registry.Register(WithMetrics("handleOIDC", NewHandler("handleOIDC", "oidc:", handleOIDC)))
+	registry.Register(WithMetrics("handlePHPInfo", NewHandler("handlePHPInfo", "phpinfo:", handlePHPInfo)))
+	registry.Register(WithMetrics("handleDependency", NewHandler("handleDependency", "dependency:", handleDependency)))
+	registry.Register(WithMetrics("handleUnauthAPI", NewHandler("handleUnauthAPI", "unauthapi:", handleUnauthAPI)))
+	registry.Register(WithMetrics("handleMetrics", NewHandler("handleMetrics", "metrics:", handleMetrics)))
+	registry.Register(WithMetrics("handleFavicon", NewHandler("handleFavicon", "favicon:", handleFavicon)))
+	registry.Register(WithMetrics("handleAPISchema", NewHandler("handleAPISchema", "apischema:", handleAPISchema)))
+	registry.Register(WithMetrics("handleDNSWildcard", NewHandler("handleDNSWildcard", "dnswildcard:", handleDNSWildcard)))
+	registry.Register(WithMetrics("handleIP", NewHandler("handleIP", "ip:", handleIP)))
+	registry.Register(WithMetrics("handleEmail", NewHandler("handleEmail", "email:", handleEmail)))
+	registry.Register(WithMetrics("handleProtocol", NewHandler("handleProtocol", "protocol:", handleProtocol)))
+	registry.Register(WithMetrics("handlePort", NewHandler("handlePort", "port:", handlePort)))
+
+	for _, name := range order {
+		registry.Register(fallbackHandlers[name])
+	}
+	return registry, nil
 }
 
-func (s *Sink) Start(workers int) {
+// Start lanza los workers que procesan las líneas de In. Si ctx se cancela,
+// cada worker termina la línea en curso y a partir de ahí descarta las que
+// sigan llegando hasta Close, para que los productores no se bloqueen y
+// Flush/Close vuelvan enseguida con los artefactos ya registrados.
+func (s *Sink) Start(ctx context.Context, workers int) {
 	if workers < 1 {
 		workers = 1
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s.done = ctx.Done()
 	for i := 0; i < workers; i++ {
 		s.wg.Add(1)
 		go func() {
 			defer s.wg.Done()
-			for ln := range s.lines {
-				s.beginLine()
-				s.processLine(ln)
-				s.finishLine()
+			for {
+				select {
+				case <-s.done:
+					s.wakeIdle()
+					for range s.lines {
+						s.discardLine()
+					}
+					return
+				case ln, ok := <-s.lines:
+					if !ok {
+						return
+					}
+					if s.cancelled() {
+						s.discardLine()
+						continue
+					}
+					s.beginLine()
+					s.processLine(ln)
+					s.finishLine()
+					s.processed.Add(1)
+				}
 			}
 		}()
 	}
+	s.startProgress()
+}
+
+// cancelled indica si el contexto de Start se ha cancelado.
+func (s *Sink) cancelled() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// idleLocked indica si Flush puede volver: no hay líneas en proceso y la
+// cola está vacía o se descarta por cancelación. Requiere s.procMu.
+func (s *Sink) idleLocked() bool {
+	return s.processing == 0 && (len(s.lines) == 0 || s.cancelled())
+}
+
+// wakeIdle despierta a los Flush en espera si el sink ha quedado inactivo.
+func (s *Sink) wakeIdle() {
+	s.procMu.Lock()
+	if s.idleLocked() {
+		s.cond.Broadcast()
+	}
+	s.procMu.Unlock()
+}
+
+func (s *Sink) discardLine() {
+	s.discarded.Add(1)
+	s.wakeIdle()
 }
 
 func (s *Sink) In() chan<- string { return s.lines }
@@ -181,7 +430,7 @@ func (s *Sink) beginLine() {
 func (s *Sink) finishLine() {
 	s.procMu.Lock()
 	s.processing--
-	if s.processing == 0 && len(s.lines) == 0 {
+	if s.idleLocked() {
 		s.cond.Broadcast()
 	}
 	s.procMu.Unlock()
@@ -220,6 +469,17 @@ func (s *Sink) processLine(ln string) {
 	if s.recorder != nil && tool != "" {
 		s.recorder.RecordOutput(tool, "", line)
 	}
+	if line == "" {
+		return
+	}
+	for _, normalized := range s.preprocess(tool, line) {
+		s.dispatchLine(tool, strings.TrimSpace(normalized))
+	}
+}
+
+// dispatchLine entrega una línea ya normalizada al handler de su prefijo o, si
+// ninguno la acepta, a los handlers de fallback.
+func (s *Sink) dispatchLine(tool, line string) {
 	if line == "" {
 		return
 	}
@@ -262,22 +522,61 @@ func extractPrefix(line string) string {
 
 func (s *Sink) Flush() {
 	s.procMu.Lock()
-	for len(s.lines) > 0 || s.processing > 0 {
+	for !s.idleLocked() {
 		s.cond.Wait()
 	}
 	s.procMu.Unlock()
-	_ = s.artifacts.Flush()
+	_ = s.flushArtifacts()
 }
 
 func (s *Sink) Close() error {
+	s.stopProgress()
 	close(s.lines)
 	s.wg.Wait()
-	if err := s.artifacts.Flush(); err != nil {
+	if n := s.discarded.Load(); n > 0 {
+		logx.Warn("Líneas descartadas por cancelación", logx.Fields{"lines": n})
+	}
+	if filtered, ok := s.artifacts.(*confidenceStore); ok {
+		filtered.recordDropSummary()
+	}
+	if err := s.flushArtifacts(); err != nil {
 		return err
 	}
 	return s.artifacts.Close()
 }
 
+// flushArtifacts vuelca el store reintentando hasta flushRetries veces con
+// backoff exponencial. El resultado del último intento se guarda para
+// LastError; un flush correcto lo limpia porque reescribe el fichero completo.
+func (s *Sink) flushArtifacts() error {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	backoff := flushRetryBackoff
+	err := s.artifacts.Flush()
+	for attempt := 0; err != nil && attempt < s.flushRetries; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+		err = s.artifacts.Flush()
+	}
+	if err != nil {
+		err = fmt.Errorf("pipeline: flush de artefactos fallido tras %d intentos: %w", s.flushRetries+1, err)
+	}
+	s.lastErr = err
+	return err
+}
+
+// LastError devuelve el error del último flush de artefactos, o nil si se
+// completó correctamente.
+func (s *Sink) LastError() error {
+	if s == nil {
+		return nil
+	}
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+	return s.lastErr
+}
+
 // Helper para ejecutar una fuente con contexto y volcar al sink
 type SourceFunc func(ctx context.Context, target string, out chan<- string) error
 
@@ -291,6 +590,35 @@ func (s *Sink) SetStepRecorder(rec StepRecorder) {
 	s.recorder = rec
 }
 
+// markWildcardZone registra una zona con registro DNS comodín.
+func (s *Sink) markWildcardZone(zone string) {
+	if s == nil {
+		return
+	}
+	s.wildcardMu.Lock()
+	defer s.wildcardMu.Unlock()
+	if s.wildcardZones == nil {
+		s.wildcardZones = make(map[string]struct{})
+	}
+	s.wildcardZones[zone] = struct{}{}
+}
+
+// underWildcardZone indica si domain es un subdominio (no el apex) de alguna
+// zona marcada como comodín, por lo que su resolución no prueba que exista.
+func (s *Sink) underWildcardZone(domain string) bool {
+	if s == nil {
+		return false
+	}
+	s.wildcardMu.RLock()
+	defer s.wildcardMu.RUnlock()
+	for zone := range s.wildcardZones {
+		if strings.HasSuffix(domain, "."+zone) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Sink) scopeAllowsDomain(domain string) bool {
 	if s == nil || s.scope == nil {
 		return true
@@ -298,6 +626,13 @@ func (s *Sink) scopeAllowsDomain(domain string) bool {
 	return s.scope.AllowsDomain(domain)
 }
 
+func (s *Sink) scopeAllowsIP(ip string) bool {
+	if s == nil || s.scope == nil {
+		return true
+	}
+	return s.scope.AllowsIP(ip)
+}
+
 func (s *Sink) scopeAllowsRoute(route string) bool {
 	if s == nil || s.scope == nil {
 		return true
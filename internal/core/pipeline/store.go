@@ -35,6 +35,9 @@ type artifactRecord struct {
 	Artifact    artifacts.Artifact
 	Tools       map[string]struct{}
 	Occurrences int
+	// seq es el orden de primera aparición, compartido entre shards para
+	// que el flush reproduzca el orden de inserción global.
+	seq uint64
 }
 
 func (rec *artifactRecord) addTool(tool string) {
@@ -62,6 +65,9 @@ type jsonlStore struct {
 	dirty     bool
 	target    string    // Target domain/IP para header v2
 	lastFlush time.Time // Último flush exitoso para batch flush inteligente
+	// seq numera los records nuevos; los shards de un shardedStore comparten
+	// el mismo contador.
+	seq *atomic.Uint64
 }
 
 func newJSONLStore(path string, target string) *jsonlStore {
@@ -69,9 +75,17 @@ func newJSONLStore(path string, target string) *jsonlStore {
 		path:   path,
 		target: target,
 		index:  make(map[artifacts.Key]*artifactRecord),
+		seq:    new(atomic.Uint64),
 	}
 }
 
+// sequencedArtifact es un artifact listo para escribir junto con su orden de
+// primera aparición.
+type sequencedArtifact struct {
+	seq      uint64
+	artifact artifacts.Artifact
+}
+
 func (s *jsonlStore) Record(tool string, artifact artifacts.Artifact) {
 	if s == nil {
 		return
@@ -80,12 +94,17 @@ func (s *jsonlStore) Record(tool string, artifact artifacts.Artifact) {
 	if !ok {
 		return
 	}
-	key := artifacts.KeyFor(normalized)
+	s.recordNormalized(tool, normalized, artifacts.KeyFor(normalized))
+}
+
+// recordNormalized registra un artifact ya normalizado bajo key, fusionando
+// metadatos, tipos y herramientas si la clave existe.
+func (s *jsonlStore) recordNormalized(tool string, normalized artifacts.Artifact, key artifacts.Key) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	rec, exists := s.index[key]
 	if !exists {
-		rec = &artifactRecord{Artifact: normalized, Tools: make(map[string]struct{})}
+		rec = &artifactRecord{Artifact: normalized, Tools: make(map[string]struct{}), seq: s.seq.Add(1)}
 		s.index[key] = rec
 		s.order = append(s.order, key)
 	} else {
@@ -127,7 +146,37 @@ func (s *jsonlStore) Flush() error {
 		return nil
 	}
 
-	records := make([]artifacts.Artifact, 0, len(s.order))
+	snapshot := s.snapshotLocked()
+	records := make([]artifacts.Artifact, len(snapshot))
+	for i, entry := range snapshot {
+		records[i] = entry.artifact
+	}
+	s.dirty = false
+	path := s.path
+	target := s.target
+	s.mu.Unlock()
+
+	// Escribir en formato v2.0 (único formato)
+	if path == "" {
+		return nil
+	}
+	writer := artifacts.NewWriterV2(path, target)
+	err := writer.WriteArtifacts(records)
+
+	if err == nil {
+		// Solo actualizar lastFlush si la escritura fue exitosa
+		s.mu.Lock()
+		s.lastFlush = time.Now()
+		s.mu.Unlock()
+	}
+
+	return err
+}
+
+// snapshotLocked devuelve los artifacts en orden de inserción, con Tools
+// ordenadas y Occurrences consolidadas. Requiere s.mu.
+func (s *jsonlStore) snapshotLocked() []sequencedArtifact {
+	snapshot := make([]sequencedArtifact, 0, len(s.order))
 	for _, key := range s.order {
 		rec := s.index[key]
 		if rec == nil {
@@ -155,28 +204,9 @@ func (s *jsonlStore) Flush() error {
 		} else {
 			art.Occurrences = rec.Occurrences
 		}
-		records = append(records, art)
+		snapshot = append(snapshot, sequencedArtifact{seq: rec.seq, artifact: art})
 	}
-	s.dirty = false
-	path := s.path
-	target := s.target
-	s.mu.Unlock()
-
-	// Escribir en formato v2.0 (único formato)
-	if path == "" {
-		return nil
-	}
-	writer := artifacts.NewWriterV2(path, target)
-	err := writer.WriteArtifacts(records)
-
-	if err == nil {
-		// Solo actualizar lastFlush si la escritura fue exitosa
-		s.mu.Lock()
-		s.lastFlush = time.Now()
-		s.mu.Unlock()
-	}
-
-	return err
+	return snapshot
 }
 
 // forceFlush realiza un flush inmediato ignorando el intervalo de tiempo.
@@ -215,9 +245,11 @@ func newShardedStore(path string, target string, shardCount int) *shardedStore {
 	if shardCount <= 0 {
 		shardCount = defaultShardCount
 	}
+	seq := new(atomic.Uint64)
 	shards := make([]*jsonlStore, shardCount)
 	for i := 0; i < shardCount; i++ {
 		shards[i] = newJSONLStore(path, target)
+		shards[i].seq = seq
 	}
 	return &shardedStore{
 		shards: shards,
@@ -251,9 +283,8 @@ func (s *shardedStore) Record(tool string, artifact artifacts.Artifact) {
 	}
 	key := artifacts.KeyFor(normalized)
 
-	// Delegar al shard apropiado
-	shard := s.getShard(key)
-	shard.Record(tool, artifact)
+	// Delegar al shard apropiado sin volver a normalizar
+	s.getShard(key).recordNormalized(tool, normalized, key)
 }
 
 func (s *shardedStore) Flush() error {
@@ -261,83 +292,35 @@ func (s *shardedStore) Flush() error {
 		return nil
 	}
 
-	// Recolectar todos los artifacts de todos los shards
-	allRecords := make([]artifacts.Artifact, 0)
-	var mu sync.Mutex
-
-	// Recolectar de cada shard en paralelo
+	// Cada shard aporta sus artifacts en orden de inserción; la posición en
+	// perShard es fija para que la fusión no dependa del scheduling.
+	perShard := make([][]sequencedArtifact, s.count)
 	var wg sync.WaitGroup
-	errors := make([]error, s.count)
-
 	for i := uint32(0); i < s.count; i++ {
 		wg.Add(1)
 		go func(idx uint32) {
 			defer wg.Done()
 			shard := s.shards[idx]
 			shard.mu.Lock()
+			defer shard.mu.Unlock()
 
 			// Verificar si hay algo que escribir
 			if !shard.dirty && len(shard.order) == 0 {
-				shard.mu.Unlock()
 				return
 			}
 
 			// Batch flush inteligente
 			if !shard.lastFlush.IsZero() && time.Since(shard.lastFlush) < flushInterval {
-				shard.mu.Unlock()
 				return
 			}
 
-			// Recolectar records del shard
-			records := make([]artifacts.Artifact, 0, len(shard.order))
-			for _, key := range shard.order {
-				rec := shard.index[key]
-				if rec == nil {
-					continue
-				}
-				art := rec.Artifact
-				if rec.Tools != nil {
-					tools := make([]string, 0, len(rec.Tools))
-					for tool := range rec.Tools {
-						if tool == "" {
-							continue
-						}
-						tools = append(tools, tool)
-					}
-					sort.Strings(tools)
-					if len(tools) > 0 {
-						art.Tools = tools
-						if art.Tool == "" {
-							art.Tool = tools[0]
-						}
-					}
-				}
-				if rec.Occurrences <= 0 {
-					art.Occurrences = 1
-				} else {
-					art.Occurrences = rec.Occurrences
-				}
-				records = append(records, art)
-			}
-
+			perShard[idx] = shard.snapshotLocked()
 			shard.dirty = false
-			shard.mu.Unlock()
-
-			// Agregar a la colección global
-			mu.Lock()
-			allRecords = append(allRecords, records...)
-			mu.Unlock()
 		}(i)
 	}
-
 	wg.Wait()
 
-	// Verificar errores en la recolección
-	for _, err := range errors {
-		if err != nil {
-			return err
-		}
-	}
+	allRecords := mergeBySeq(perShard)
 
 	// Si no hay records, no hacer nada
 	if len(allRecords) == 0 {
@@ -369,6 +352,31 @@ func (s *shardedStore) Flush() error {
 	return err
 }
 
+// mergeBySeq fusiona las listas de cada shard, ya ordenadas por seq, en una
+// sola ordenada por primera aparición.
+func mergeBySeq(lists [][]sequencedArtifact) []artifacts.Artifact {
+	total := 0
+	for _, list := range lists {
+		total += len(list)
+	}
+	merged := make([]artifacts.Artifact, 0, total)
+	heads := make([]int, len(lists))
+	for len(merged) < total {
+		best := -1
+		for i, list := range lists {
+			if heads[i] >= len(list) {
+				continue
+			}
+			if best < 0 || list[heads[i]].seq < lists[best][heads[best]].seq {
+				best = i
+			}
+		}
+		merged = append(merged, lists[best][heads[best]].artifact)
+		heads[best]++
+	}
+	return merged
+}
+
 func (s *shardedStore) Close() error {
 	if s == nil {
 		return nil
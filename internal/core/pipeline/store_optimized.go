@@ -105,9 +105,11 @@ func newOptimizedShardedStore(path string, target string, shardCount int) *optim
 	if shardCount <= 0 {
 		shardCount = defaultShardCount
 	}
+	seq := new(atomic.Uint64)
 	shards := make([]*optimizedJSONLStore, shardCount)
 	for i := 0; i < shardCount; i++ {
 		shards[i] = newOptimizedJSONLStore(path, target)
+		shards[i].seq = seq
 	}
 	return &optimizedShardedStore{
 		shards: shards,
@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"passive-rec/internal/adapters/artifacts"
+)
+
+func TestShardedStoreFlushPreservesInsertionOrder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "artifacts.jsonl")
+	store := newShardedStore(path, "example.com", 4)
+
+	var want []string
+	for i := 0; i < 50; i++ {
+		value := fmt.Sprintf("host%02d.example.com", i)
+		want = append(want, value)
+		store.Record("subfinder", artifacts.Artifact{Type: "domain", Value: value, Up: true})
+	}
+	// Las repeticiones fusionan metadatos y herramientas sin mover el artifact.
+	store.Record("amass", artifacts.Artifact{Type: "domain", Value: "host07.example.com", Up: true, Metadata: map[string]any{"asn": "AS64500"}})
+	if err := store.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	got := readArtifactsFile(t, path)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d artifacts, got %d", len(want), len(got))
+	}
+	for i, art := range got {
+		if art.Value != want[i] {
+			t.Fatalf("artifact %d = %q, want %q", i, art.Value, want[i])
+		}
+	}
+	merged := got[7]
+	if merged.Occurrences != 2 || len(merged.Tools) != 2 || merged.Metadata["asn"] != "AS64500" {
+		t.Fatalf("expected host07 to merge both records, got %+v", merged)
+	}
+}
+
+func TestMergeBySeq(t *testing.T) {
+	t.Parallel()
+
+	entry := func(seq uint64) sequencedArtifact {
+		return sequencedArtifact{seq: seq, artifact: artifacts.Artifact{Value: fmt.Sprint(seq)}}
+	}
+	got := mergeBySeq([][]sequencedArtifact{
+		{entry(2), entry(5)},
+		nil,
+		{entry(1), entry(3), entry(4)},
+		{entry(6)},
+	})
+	for i, art := range got {
+		if want := fmt.Sprint(i + 1); art.Value != want {
+			t.Fatalf("position %d = %q, want %q", i, art.Value, want)
+		}
+	}
+	if len(got) != 6 {
+		t.Fatalf("expected 6 artifacts, got %d", len(got))
+	}
+}
+
+const (
+	benchStoreWorkers   = 16
+	benchStoreArtifacts = 1_000_000
+	benchStoreUnique    = 250_000
+)
+
+// benchmarkStoreRecord reparte benchStoreArtifacts Records entre
+// benchStoreWorkers goroutines; cada valor se repite para ejercitar la fusión.
+func benchmarkStoreRecord(b *testing.B, newStore func() ArtifactStore) {
+	inputs := make([]artifacts.Artifact, benchStoreUnique)
+	for i := range inputs {
+		inputs[i] = artifacts.Artifact{Type: "route", Value: fmt.Sprintf("https://app.example.com/api/v1/items/%d", i), Up: true}
+	}
+	perWorker := benchStoreArtifacts / benchStoreWorkers
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		store := newStore()
+		var wg sync.WaitGroup
+		for w := 0; w < benchStoreWorkers; w++ {
+			wg.Add(1)
+			go func(offset int) {
+				defer wg.Done()
+				for i := 0; i < perWorker; i++ {
+					store.Record("httpx", inputs[(offset+i)%len(inputs)])
+				}
+			}(w * perWorker)
+		}
+		wg.Wait()
+		if err := store.Close(); err != nil {
+			b.Fatalf("close: %v", err)
+		}
+	}
+}
+
+func BenchmarkStoreRecordSingleMutex(b *testing.B) {
+	benchmarkStoreRecord(b, func() ArtifactStore { return newJSONLStore("", "example.com") })
+}
+
+func BenchmarkStoreRecordSharded(b *testing.B) {
+	benchmarkStoreRecord(b, func() ArtifactStore { return newShardedStore("", "example.com", defaultShardCount) })
+}
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// gzipNDJSONProducer escribe cada artefacto como una línea JSON (NDJSON) en un
+// flujo gzip, pensado para volcar el stream a stdout y encadenarlo con
+// consumidores que entienden gzip.
+type gzipNDJSONProducer struct {
+	mu sync.Mutex
+	gz *gzip.Writer
+}
+
+// NewGzipNDJSONProducer devuelve un ArtifactProducer que comprime los
+// artefactos en w. Close cierra el flujo gzip pero no w.
+func NewGzipNDJSONProducer(w io.Writer) ArtifactProducer {
+	return &gzipNDJSONProducer{gz: gzip.NewWriter(w)}
+}
+
+func (p *gzipNDJSONProducer) Produce(_ context.Context, _, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.gz.Write(value); err != nil {
+		return err
+	}
+	_, err := p.gz.Write([]byte{'\n'})
+	return err
+}
+
+func (p *gzipNDJSONProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.gz.Close()
+}
+
+// multiProducer reenvía cada artefacto a varios productores.
+type multiProducer []ArtifactProducer
+
+// JoinProducers combina varios productores en uno; ignora los nil y devuelve
+// nil si no queda ninguno.
+func JoinProducers(producers ...ArtifactProducer) ArtifactProducer {
+	var joined multiProducer
+	for _, producer := range producers {
+		if producer != nil {
+			joined = append(joined, producer)
+		}
+	}
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return joined
+}
+
+func (m multiProducer) Produce(ctx context.Context, key, value []byte) error {
+	var errs []error
+	for _, producer := range m {
+		errs = append(errs, producer.Produce(ctx, key, value))
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiProducer) Close() error {
+	var errs []error
+	for _, producer := range m {
+		errs = append(errs, producer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter lo consultan las fuentes activas antes de cada petición o comando
+// externo. Wait bloquea hasta que se puede continuar o ctx se cancela. Es una
+// interfaz para que los tests puedan inyectar un limitador que cuente las
+// llamadas.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimiter es un token bucket compartido por todas las fuentes activas:
+// admite ráfagas de hasta perSecond operaciones y repone un token cada
+// 1/perSecond segundos. Es seguro para uso concurrente.
+type RateLimiter struct {
+	interval time.Duration // tiempo para reponer un token; 0 = sin límite
+
+	mu     sync.Mutex
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter crea un limitador de perSecond operaciones por segundo.
+// perSecond <= 0 devuelve un limitador sin límite cuyo Wait no espera nunca.
+func NewRateLimiter(perSecond int) *RateLimiter {
+	if perSecond <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{
+		interval: time.Second / time.Duration(perSecond),
+		burst:    float64(perSecond),
+		tokens:   float64(perSecond),
+		last:     time.Now(),
+	}
+}
+
+// Wait reserva un token y espera a que esté disponible. Si ctx se cancela
+// antes, el token se devuelve y se retorna ctx.Err(). Un limitador nil o sin
+// límite no espera.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.interval <= 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release()
+		return ctx.Err()
+	}
+}
+
+// reserve repone los tokens acumulados desde la última llamada, consume uno y
+// devuelve cuánto hay que esperar hasta que ese token exista.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+float64(elapsed)/float64(l.interval))
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// release devuelve un token reservado que no llegó a usarse.
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	l.tokens = min(l.burst, l.tokens+1)
+	l.mu.Unlock()
+}
@@ -0,0 +1,76 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterUnlimitedNeverWaits(t *testing.T) {
+	limiter := NewRateLimiter(0)
+
+	start := time.Now()
+	for i := 0; i < 10000; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected an unlimited limiter not to wait, took %s", elapsed)
+	}
+
+	var nilLimiter *RateLimiter
+	if err := nilLimiter.Wait(context.Background()); err != nil {
+		t.Fatalf("nil limiter Wait: %v", err)
+	}
+}
+
+func TestRateLimiterSpacesConcurrentCallers(t *testing.T) {
+	const perSecond = 50
+	limiter := NewRateLimiter(perSecond)
+
+	// La ráfaga inicial (perSecond tokens) pasa sin esperar; las 10 llamadas
+	// siguientes necesitan al menos 10 intervalos de 20ms.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < perSecond+10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Errorf("Wait: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Fatalf("expected callers beyond the burst to be spaced out, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterHonorsCancellation(t *testing.T) {
+	limiter := NewRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected Wait to return on cancellation, took %s", elapsed)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := NewRateLimiter(10).Wait(cancelled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled context to be reported, got %v", err)
+	}
+}
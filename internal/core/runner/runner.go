@@ -93,6 +93,57 @@ func RunCommandWithDir(ctx context.Context, dir string, name string, args []stri
 	return runCommand(ctx, name, args, out, dir)
 }
 
+// RunCommandWithRetry executes the command like RunCommandWithDir, retrying it up to
+// attempts times while it exits with a non-zero status. The wait between attempts
+// starts at backoff and doubles after each failure. Output from every attempt is
+// streamed to out. Context cancellation is honoured between attempts: if the context
+// is done, or its deadline would expire before the next attempt, the context error
+// is returned immediately without sleeping. When every attempt fails, the returned
+// error wraps the last failure together with the number of attempts made.
+func RunCommandWithRetry(ctx context.Context, dir string, name string, args []string, out chan<- string, attempts int, backoff time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	wait := backoff
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		lastErr = runCommand(ctx, name, args, out, dir)
+		if lastErr == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(lastErr, &exitErr) {
+			return lastErr
+		}
+		if attempt == attempts {
+			break
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+			return fmt.Errorf("%s: %w (attempt %d/%d): %v", name, context.DeadlineExceeded, attempt, attempts, lastErr)
+		}
+		logx.Debug("Reintentando comando", logx.Fields{
+			"command": name,
+			"attempt": attempt + 1,
+			"backoff": logx.FormatDuration(wait),
+			"error":   lastErr.Error(),
+		})
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		wait *= 2
+	}
+	return fmt.Errorf("%s failed after %d attempts: %w", name, attempts, lastErr)
+}
+
 func runCommand(ctx context.Context, name string, args []string, out chan<- string, dir string) error {
 	resolvedPath, lookErr := exec.LookPath(name)
 	if lookErr != nil {
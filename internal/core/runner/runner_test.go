@@ -3,8 +3,12 @@ package runner
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -108,6 +112,83 @@ func TestRunCommandCancellation(t *testing.T) {
 	}
 }
 
+// writeFlakyScript crea un script que falla las primeras failures ejecuciones
+// (contadas en un fichero junto al script) y después imprime "ok".
+func writeFlakyScript(t *testing.T, failures int) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	scriptPath := filepath.Join(tmpDir, "flaky.sh")
+	counter := filepath.Join(tmpDir, "count")
+	script := fmt.Sprintf("#!/bin/sh\nn=$(cat %[1]s 2>/dev/null || echo 0)\nn=$((n+1))\necho $n > %[1]s\necho attempt-$n\nif [ $n -le %[2]d ]; then exit 1; fi\necho ok\n", counter, failures)
+	if err := os.WriteFile(scriptPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to create script: %v", err)
+	}
+	return scriptPath
+}
+
+func drain(out chan string) []string {
+	close(out)
+	var lines []string
+	for line := range out {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRunCommandWithRetrySucceedsAfterFailures(t *testing.T) {
+	script := writeFlakyScript(t, 2)
+	out := make(chan string, 16)
+
+	if err := RunCommandWithRetry(context.Background(), "", script, nil, out, 3, time.Millisecond); err != nil {
+		t.Fatalf("RunCommandWithRetry: %v", err)
+	}
+
+	got := drain(out)
+	want := []string{"attempt-1", "attempt-2", "attempt-3", "ok"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected output: got %v, want %v", got, want)
+	}
+}
+
+func TestRunCommandWithRetryWrapsLastFailure(t *testing.T) {
+	script := writeFlakyScript(t, 5)
+	out := make(chan string, 16)
+
+	err := RunCommandWithRetry(context.Background(), "", script, nil, out, 2, time.Millisecond)
+	if err == nil {
+		t.Fatal("expected error after exhausting attempts")
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected wrapped exit error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 attempts") {
+		t.Fatalf("expected attempt count in error, got %v", err)
+	}
+	if got := drain(out); len(got) != 2 {
+		t.Fatalf("expected 2 attempts, got output %v", got)
+	}
+}
+
+func TestRunCommandWithRetryDeadlineShortCircuits(t *testing.T) {
+	script := writeFlakyScript(t, 5)
+	out := make(chan string, 16)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := RunCommandWithRetry(ctx, "", script, nil, out, 3, time.Minute)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected immediate return, took %v", elapsed)
+	}
+	if got := drain(out); len(got) != 1 {
+		t.Fatalf("expected a single attempt, got output %v", got)
+	}
+}
+
 func absDuration(d time.Duration) time.Duration {
 	if d < 0 {
 		return -d
@@ -8,36 +8,130 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	apperrors "passive-rec/internal/platform/errors"
+)
+
+var (
+	// printConfigOutput y printConfigExit permiten capturar -print-config en tests.
+	printConfigOutput io.Writer = os.Stdout
+	printConfigExit             = os.Exit
 )
 
 type Config struct {
 	Target             string
+	TargetsFile        string // Fichero con un target por línea; cada uno se escanea en <outdir>/<target>
 	OutDir             string
 	Workers            int
 	Active             bool
 	Tools              []string
 	TimeoutS           int
-	ToolTimeouts       map[string]int // Timeouts específicos por herramienta (segundos)
+	ToolTimeouts       map[string]int    // Timeouts específicos por herramienta (segundos)
+	TypeDirs           map[string]string // Directorio de salida por tipo de artefacto (sustituye al por defecto)
 	Verbosity          int
 	Report             bool
+	ReportLang         string            // Idioma del informe HTML: "es" (por defecto) o "en"
+	ReportRowLimits    map[string]int    // Filas máximas por tipo de artefacto en las tablas del informe
+	HighlightRules     map[string]string // Severidad por regla de highlight del informe ("off" la suprime)
 	Proxy              string
+	ProxyAuth          string // Credenciales user:pass del proxy; sustituyen a las de la URL
 	ProxyCACert        string
 	CensysAPIID        string
 	CensysAPISecret    string
 	Scope              string
 	Resume             bool // Reanudar desde checkpoint
 	CheckpointInterval int  // Intervalo de checkpoint en segundos
+	WriteBOM           bool // Prefijar un BOM UTF-8 en los ficheros de salida
+	// Tamaño del canal de líneas del sink: LineBufferPerWorker * Workers con
+	// mínimo LineBufferMin (0 = valores por defecto, 256 y 1024).
+	LineBufferPerWorker int
+	LineBufferMin       int
+	// OutDirTemplate sustituye el subdirectorio por target dentro de OutDir.
+	// Admite los placeholders {target}, {date} (2006-01-02) y {time} (150405).
+	OutDirTemplate string
+	MinConfidence  float64 // Confianza mínima (0-1), declarada o derivada del estado activo
+	// Streaming opcional de artefactos a Kafka (requiere brokers y topic)
+	KafkaBrokers []string
+	KafkaTopic   string
+	// StdoutStream emite los artefactos como NDJSON comprimido con gzip por
+	// stdout en lugar de materializarlos en ficheros por tipo.
+	StdoutStream bool
+	// SplitByType escribe además <outdir>/artifacts/<tipo>.jsonl con los
+	// artefactos de cada tipo (principal o de Types) al terminar.
+	SplitByType bool
+	// CollapseTrailingSlash deduplica las rutas que solo difieren en la barra
+	// final del path (https://x/path y https://x/path/). La raíz no cambia.
+	CollapseTrailingSlash bool
+	// StripTracking elimina parámetros de tracking (utm_*, fbclid, ...)
+	// de las rutas para deduplicarlas. TrackingParams sustituye la lista por
+	// defecto; admite "*" final como prefijo.
+	StripTracking  bool
+	TrackingParams []string
+	FlushRetries   int    // Reintentos al escribir artifacts.jsonl antes de reportar error
+	ScopeFile      string // Fichero con un dominio, IP o CIDR por línea que define el scope
+	// ExcludeScope son dominios que se restan del scope junto con sus
+	// subdominios (flag -exclude-scope repetible).
+	ExcludeScope []string
+	DedupWindow  int // Máximo de claves recordadas al deduplicar (LRU); 0 = sin límite
+	// PreferWrapperTool atribuye cada artefacto a la herramienta que emitió la
+	// línea en lugar de inferirla del contenido del mensaje.
+	PreferWrapperTool bool
+	// Límites compartidos por las comprobaciones activas HTTP: peticiones
+	// simultáneas (0 = sin límite) y espera mínima entre peticiones a un host.
+	ActiveConcurrency int
+	ActiveHostDelay   time.Duration
+	// RateLimit limita las peticiones y comandos externos de las fuentes
+	// activas a este número por segundo, entre todas ellas. 0 = sin límite.
+	RateLimit int
+	// Retries reintenta hasta ese número de veces cada ejecución de httpx y
+	// GoLinkfinderEVO que termina con error, con espera exponencial desde
+	// RetryBackoff. 0 = sin reintentos.
+	Retries      int
+	RetryBackoff time.Duration
+	// ArtifactTTL, con Resume, descarta del manifiesto previo los artefactos no
+	// vistos en este intervalo. 0 = sin caducidad.
+	ArtifactTTL time.Duration
+	// Since limita el informe a los artefactos descubiertos (first_seen) desde
+	// ese instante. Cero = sin filtro.
+	Since time.Time
+	// CertExpiryWindowDays son los días antes del vencimiento en que el
+	// informe considera un certificado "por expirar" (por defecto 30).
+	CertExpiryWindowDays int
+	// ProgressInterval es la cadencia con la que el sink informa de las líneas
+	// procesadas, el backlog y el ritmo de cada handler. 0 = sin informes.
+	ProgressInterval time.Duration
+	// NucleiImport es un JSONL de Nuclei (-jsonl) cuyos resultados se importan
+	// como hallazgos de seguridad. Vacío = no importar nada.
+	NucleiImport string
+	// AnalysisCache guarda la detección de tecnologías del informe en
+	// reports/analysis-cache.json y la reutiliza en ejecuciones posteriores.
+	AnalysisCache bool
+	// UnauthAPIProbe habilita el sondeo sin autenticación de las rutas de API
+	// descubiertas (paso unauthapi, requiere Active).
+	UnauthAPIProbe bool
+	// Upload es un destino s3://bucket/prefijo al que se sube el directorio de
+	// salida al terminar. Vacío = no subir nada.
+	Upload string
+	// DryRun muestra las herramientas que se ejecutarían y si sus binarios
+	// están en el PATH, y termina sin ejecutar nada ni escribir artefactos.
+	// Falla si falta algún binario salvo con DryRunAllowMissing.
+	DryRun             bool
+	DryRunAllowMissing bool
 	// Logging options
 	NoColor  bool
 	Compact  bool
@@ -45,26 +139,77 @@ type Config struct {
 }
 
 type fileConfig struct {
-	Target             *string        `json:"target" yaml:"target"`
-	OutDir             *string        `json:"outdir" yaml:"outdir"`
-	Workers            *int           `json:"workers" yaml:"workers"`
-	Active             *bool          `json:"active" yaml:"active"`
-	Tools              *stringList    `json:"tools" yaml:"tools"`
-	TimeoutS           *int           `json:"timeout" yaml:"timeout"`
-	ToolTimeouts       map[string]int `json:"tool_timeouts" yaml:"tool_timeouts"`
-	Verbosity          *int           `json:"verbosity" yaml:"verbosity"`
-	Report             *bool          `json:"report" yaml:"report"`
-	Proxy              *string        `json:"proxy" yaml:"proxy"`
-	ProxyCACert        *string        `json:"proxy_ca" yaml:"proxy_ca"`
-	CensysAPIID        *string        `json:"censys_api_id" yaml:"censys_api_id"`
-	CensysAPISecret    *string        `json:"censys_api_secret" yaml:"censys_api_secret"`
-	Scope              *string        `json:"scope" yaml:"scope"`
-	Resume             *bool          `json:"resume" yaml:"resume"`
-	CheckpointInterval *int           `json:"checkpoint_interval" yaml:"checkpoint_interval"`
+	Target             *string           `json:"target" yaml:"target"`
+	TargetsFile        *string           `json:"targets_file" yaml:"targets_file"`
+	OutDir             *string           `json:"outdir" yaml:"outdir"`
+	Workers            *int              `json:"workers" yaml:"workers"`
+	LineBufferWorker   *int              `json:"line_buffer_per_worker" yaml:"line_buffer_per_worker"`
+	LineBufferMin      *int              `json:"line_buffer_min" yaml:"line_buffer_min"`
+	Active             *bool             `json:"active" yaml:"active"`
+	Tools              *stringList       `json:"tools" yaml:"tools"`
+	TimeoutS           *int              `json:"timeout" yaml:"timeout"`
+	ToolTimeouts       map[string]int    `json:"tool_timeouts" yaml:"tool_timeouts"`
+	TypeDirs           map[string]string `json:"type_dirs" yaml:"type_dirs"`
+	Verbosity          *int              `json:"verbosity" yaml:"verbosity"`
+	Report             *bool             `json:"report" yaml:"report"`
+	ReportLang         *string           `json:"report_lang" yaml:"report_lang"`
+	ReportRowLimits    map[string]int    `json:"report_row_limits" yaml:"report_row_limits"`
+	HighlightRules     map[string]string `json:"highlight_rules" yaml:"highlight_rules"`
+	CertExpiryDays     *int              `json:"cert_expiry_days" yaml:"cert_expiry_days"`
+	ProgressInterval   *string           `json:"progress_interval" yaml:"progress_interval"`
+	NucleiImport       *string           `json:"nuclei_import" yaml:"nuclei_import"`
+	Proxy              *string           `json:"proxy" yaml:"proxy"`
+	ProxyAuth          *string           `json:"proxy_auth" yaml:"proxy_auth"`
+	ProxyCACert        *string           `json:"proxy_ca" yaml:"proxy_ca"`
+	CensysAPIID        *string           `json:"censys_api_id" yaml:"censys_api_id"`
+	CensysAPISecret    *string           `json:"censys_api_secret" yaml:"censys_api_secret"`
+	Scope              *string           `json:"scope" yaml:"scope"`
+	ScopeFile          *string           `json:"scope_file" yaml:"scope_file"`
+	ExcludeScope       *stringList       `json:"exclude_scope" yaml:"exclude_scope"`
+	Resume             *bool             `json:"resume" yaml:"resume"`
+	CheckpointInterval *int              `json:"checkpoint_interval" yaml:"checkpoint_interval"`
+	WriteBOM           *bool             `json:"write_bom" yaml:"write_bom"`
+	OutDirTemplate     *string           `json:"outdir_template" yaml:"outdir_template"`
+	MinConfidence      *float64          `json:"min_confidence" yaml:"min_confidence"`
+	KafkaBrokers       *stringList       `json:"kafka_brokers" yaml:"kafka_brokers"`
+	KafkaTopic         *string           `json:"kafka_topic" yaml:"kafka_topic"`
+	StdoutStream       *bool             `json:"stdout_stream" yaml:"stdout_stream"`
+	SplitByType        *bool             `json:"split_by_type" yaml:"split_by_type"`
+	StripTracking      *bool             `json:"strip_tracking_params" yaml:"strip_tracking_params"`
+	TrackingParams     *stringList       `json:"tracking_params" yaml:"tracking_params"`
+	CollapseSlash      *bool             `json:"collapse_trailing_slash" yaml:"collapse_trailing_slash"`
+	FlushRetries       *int              `json:"flush_retries" yaml:"flush_retries"`
+	DedupWindow        *int              `json:"dedup_window" yaml:"dedup_window"`
+	ArtifactTTL        *string           `json:"artifact_ttl" yaml:"artifact_ttl"`
+	Since              *string           `json:"since" yaml:"since"`
+	Upload             *string           `json:"upload" yaml:"upload"`
+	PreferWrapperTool  *bool             `json:"prefer_wrapper_tool" yaml:"prefer_wrapper_tool"`
+	ActiveConcurrency  *int              `json:"active_concurrency" yaml:"active_concurrency"`
+	ActiveHostDelay    *string           `json:"active_host_delay" yaml:"active_host_delay"`
+	RateLimit          *int              `json:"rate_limit" yaml:"rate_limit"`
+	Retries            *int              `json:"retries" yaml:"retries"`
+	RetryBackoff       *string           `json:"retry_backoff" yaml:"retry_backoff"`
+	AnalysisCache      *bool             `json:"analysis_cache" yaml:"analysis_cache"`
+	UnauthAPIProbe     *bool             `json:"unauth_api_probe" yaml:"unauth_api_probe"`
 }
 
 type stringList []string
 
+// String implementa flag.Value para los flags repetibles.
+func (s *stringList) String() string {
+	if s == nil {
+		return ""
+	}
+	return strings.Join(*s, ",")
+}
+
+// Set implementa flag.Value: cada aparición del flag añade sus valores (se
+// admite también CSV).
+func (s *stringList) Set(value string) error {
+	*s = append(*s, cleanStringSlice(strings.Split(value, ","))...)
+	return nil
+}
+
 func (s *stringList) UnmarshalJSON(data []byte) error {
 	trimmed := bytes.TrimSpace(data)
 	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
@@ -112,27 +257,122 @@ func (s *stringList) UnmarshalYAML(value *yaml.Node) error {
 	}
 }
 
+// defaultCertExpiryWindowDays es el valor por defecto de -cert-expiry-days.
+const defaultCertExpiryWindowDays = 30
+
+// defaultProgressInterval es el valor por defecto de -progress-interval.
+const defaultProgressInterval = 30 * time.Second
+
+// defaultRetryBackoff es el valor por defecto de -retry-backoff.
+const defaultRetryBackoff = 2 * time.Second
+
+// defaultTools son las herramientas que se ejecutan si no se indica -tools.
+var defaultTools = []string{"amass", "subfinder", "assetfinder", "rdap", "crtsh", "dedupe", "dnsx", "waybackurls", "gau", "httpx", "subjs", "linkfinderevo"}
+
+// defaultConfig devuelve los valores por defecto de los flags. Es la base sobre
+// la que se aplican el archivo de configuración y, después, los flags
+// explícitos.
+func defaultConfig() *Config {
+	return &Config{
+		OutDir:               ".",
+		Workers:              6,
+		Tools:                append([]string(nil), defaultTools...),
+		TimeoutS:             120,
+		ToolTimeouts:         make(map[string]int),
+		ReportLang:           "es",
+		CertExpiryWindowDays: defaultCertExpiryWindowDays,
+		CensysAPIID:          os.Getenv("CENSYS_API_ID"),
+		CensysAPISecret:      os.Getenv("CENSYS_API_SECRET"),
+		Scope:                "subdomains",
+		CheckpointInterval:   30,
+		ProgressInterval:     defaultProgressInterval,
+		FlushRetries:         3,
+		ActiveConcurrency:    20,
+		RetryBackoff:         defaultRetryBackoff,
+		LogWidth:             120,
+	}
+}
+
+// LoadFile lee un archivo de configuración YAML o JSON y lo aplica sobre los
+// valores por defecto, con la misma precedencia que -config sin flags. Las
+// claves desconocidas se avisan en el log pero no abortan la carga.
+func LoadFile(path string) (*Config, error) {
+	fc, err := loadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := defaultConfig()
+	if err := fc.applyTo(cfg, nil); err != nil {
+		return nil, fmt.Errorf("configuración inválida en %s: %w", path, err)
+	}
+	if err := cfg.finalize(); err != nil {
+		return nil, fmt.Errorf("configuración inválida en %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
 func ParseFlags() *Config {
+	defaults := defaultConfig()
 	configPath := flag.String("config", "", "Ruta a un archivo de configuración (YAML o JSON)")
+	printConfig := flag.Bool("print-config", false, "Mostrar la configuración efectiva (flags + entorno + archivo, con secretos redactados) y salir")
 	target := flag.String("target", "", "Target domain (ej: example.com)")
-	outdir := flag.String("outdir", ".", "Directorio de salida (default: .)")
-	workers := flag.Int("workers", 6, "Número de workers")
+	targetsFile := flag.String("targets-file", "", "Fichero con un target por línea (# para comentarios); escanea cada uno en <outdir>/<target>")
+	outdir := flag.String("outdir", defaults.OutDir, "Directorio de salida (default: .)")
+	workers := flag.Int("workers", defaults.Workers, "Número de workers")
+	lineBufferPerWorker := flag.Int("line-buffer-per-worker", 0, "Tamaño del búfer de líneas por worker (0 = 256)")
+	lineBufferMin := flag.Int("line-buffer-min", 0, "Tamaño mínimo del búfer de líneas (0 = 1024)")
 	active := flag.Bool("active", false, "Comprobaciones activas adicionales (amass/httpx)")
-	tools := flag.String("tools", "amass,subfinder,assetfinder,rdap,crtsh,dedupe,dnsx,waybackurls,gau,httpx,subjs,linkfinderevo", "Herramientas, CSV")
-	timeout := flag.Int("timeout", 120, "Timeout por herramienta (segundos)")
+	tools := flag.String("tools", strings.Join(defaults.Tools, ","), "Herramientas, CSV")
+	timeout := flag.Int("timeout", defaults.TimeoutS, "Timeout por herramienta (segundos)")
 	verbosity := flag.Int("v", 0, "Verbosity (0=silent,1=info,2=debug,3=trace)")
 	report := flag.Bool("report", false, "Generar un informe HTML al finalizar")
+	reportLang := flag.String("report-lang", defaults.ReportLang, "Idioma del informe HTML: es o en")
+	highlightRules := flag.String("highlight-rules", "", "Severidad de las reglas de highlight del informe, CSV regla=severidad|off (ej: insecure-http=high,expiring-certs=off)")
+	certExpiryDays := flag.Int("cert-expiry-days", defaults.CertExpiryWindowDays, "Días antes del vencimiento en que el informe marca un certificado como por expirar")
+	reportRowLimits := flag.String("report-row-limits", "", "Filas máximas por tipo en las tablas del informe, CSV tipo=n (ej: domain=50,route=10)")
 	proxy := flag.String("proxy", "", "Proxy HTTP/HTTPS (ej: http://127.0.0.1:8080)")
+	proxyAuth := flag.String("proxy-auth", "", "Credenciales del proxy user:pass (sustituyen a las embebidas en -proxy)")
 	proxyCA := flag.String("proxy-ca", "", "Ruta a un certificado CA adicional para mitm proxies")
-	censysID := flag.String("censys-api-id", os.Getenv("CENSYS_API_ID"), "Censys API ID (o exporta CENSYS_API_ID)")
-	censysSecret := flag.String("censys-api-secret", os.Getenv("CENSYS_API_SECRET"), "Censys API secret (o exporta CENSYS_API_SECRET)")
-	scope := flag.String("scope", "subdomains", "Modo de scope: 'subdomains' (incluye subdominios) o 'domain' (solo dominio exacto)")
+	censysID := flag.String("censys-api-id", defaults.CensysAPIID, "Censys API ID (o exporta CENSYS_API_ID)")
+	censysSecret := flag.String("censys-api-secret", defaults.CensysAPISecret, "Censys API secret (o exporta CENSYS_API_SECRET)")
+	scope := flag.String("scope", defaults.Scope, "Modo de scope: 'subdomains' (incluye subdominios) o 'domain' (solo dominio exacto)")
+	typeDirs := flag.String("type-dirs", "", "Directorio de salida por tipo de artefacto, CSV tipo=dir (ej: certificate=/data/certs,js=js-files)")
+	scopeFile := flag.String("scope-file", "", "Fichero con un dominio, IP o CIDR por línea que define el scope (sustituye al derivado de -target)")
+	var excludeScope stringList
+	flag.Var(&excludeScope, "exclude-scope", "Dominio a excluir del scope junto con sus subdominios (repetible, ej: -exclude-scope legacy.example.com)")
 	resume := flag.Bool("resume", false, "Reanudar desde último checkpoint")
-	checkpointInterval := flag.Int("checkpoint-interval", 30, "Intervalo de checkpoint en segundos")
+	checkpointInterval := flag.Int("checkpoint-interval", defaults.CheckpointInterval, "Intervalo de checkpoint en segundos")
+	writeBOM := flag.Bool("bom", false, "Escribir un BOM UTF-8 al inicio de los ficheros de salida (herramientas Windows)")
+	minConfidence := flag.Float64("min-confidence", 0, "Confianza mínima (0-1) para registrar artefactos (declarada o derivada: activo 1, pasivo 0.5, activo caído 0.25)")
+	kafkaBrokers := flag.String("kafka-brokers", "", "Brokers de Kafka (CSV) para publicar cada artefacto registrado")
+	kafkaTopic := flag.String("kafka-topic", "", "Topic de Kafka donde publicar los artefactos (requiere -kafka-brokers)")
+	splitByType := flag.Bool("split-by-type", false, "Escribir además un artifacts/<tipo>.jsonl por tipo de artefacto al terminar")
+	stdoutStream := flag.Bool("stdout-stream", false, "Emitir los artefactos como NDJSON gzip por stdout en lugar de ficheros por tipo")
+	stripTracking := flag.Bool("strip-tracking-params", false, "Eliminar parámetros de tracking (utm_*, fbclid, gclid...) de las rutas para deduplicarlas")
+	collapseSlash := flag.Bool("collapse-trailing-slash", false, "Deduplicar las rutas que solo difieren en la barra final del path (https://x/path y https://x/path/)")
+	trackingParams := flag.String("tracking-params", "", "Parámetros de tracking a eliminar, CSV (admite prefijos con *, ej: utm_*)")
+	flushRetries := flag.Int("flush-retries", defaults.FlushRetries, "Reintentos (con backoff exponencial) si falla la escritura de artifacts.jsonl")
+	artifactTTL := flag.Duration("artifact-ttl", 0, "Con -resume, descartar los artefactos previos no vistos en este intervalo (ej: 720h para 30 días); 0 = sin caducidad")
+	since := flag.String("since", "", "Incluir en el informe solo los artefactos descubiertos desde esta fecha (ej: 2024-01-01 o RFC3339)")
+	upload := flag.String("upload", "", "Subir el directorio de salida al terminar a un bucket S3 (ej: s3://bucket/prefijo; credenciales AWS_* del entorno)")
+	dryRun := flag.Bool("dry-run", false, "Mostrar las herramientas que se ejecutarían y si sus binarios están instalados, sin ejecutar nada (falla si falta alguno)")
+	dryRunAllowMissing := flag.Bool("dry-run-allow-missing", false, "Con -dry-run, no devolver error si faltan binarios")
+	activeConcurrency := flag.Int("active-concurrency", defaults.ActiveConcurrency, "Máximo de peticiones simultáneas de las comprobaciones activas (0 = sin límite)")
+	rateLimit := flag.Int("rate", 0, "Máximo de peticiones/comandos por segundo de las fuentes activas, entre todas ellas (0 = sin límite)")
+	activeHostDelay := flag.Duration("active-host-delay", 0, "Espera mínima entre peticiones activas al mismo host (ej: 200ms)")
+	retries := flag.Int("retries", 0, "Reintentos de cada ejecución de httpx y GoLinkfinderEVO que termina con error (0 = sin reintentos)")
+	retryBackoff := flag.Duration("retry-backoff", defaults.RetryBackoff, "Espera antes del primer reintento; se duplica en cada uno")
+	analysisCache := flag.Bool("analysis-cache", false, "Reutilizar entre ejecuciones la detección de tecnologías del informe (reports/analysis-cache.json)")
+	unauthAPIProbe := flag.Bool("unauth-api-probe", false, "Sondear sin autenticación las rutas de API descubiertas en busca de datos expuestos (requiere --active)")
+	preferWrapperTool := flag.Bool("prefer-wrapper-tool", false, "Atribuir los artefactos a la herramienta que emitió la línea en lugar de inferirla del mensaje")
+	dedupWindow := flag.Int("dedup-window", 0, "Máximo de claves recordadas al deduplicar (LRU, acota la memoria); 0 = sin límite")
+	outdirTemplate := flag.String("outdir-template", "", "Plantilla del directorio de salida dentro de -outdir (ej: {target}/{date}; placeholders {target},{date},{time})")
 	// Logging flags
 	noColor := flag.Bool("no-color", false, "Desactivar colores ANSI")
 	compact := flag.Bool("compact", false, "Modo de logs compacto")
-	logWidth := flag.Int("width", 120, "Ancho de salida para logs")
+	logWidth := flag.Int("width", defaults.LogWidth, "Ancho de salida para logs")
+	nucleiImport := flag.String("nuclei-import", "", "JSONL de Nuclei (-jsonl) cuyos resultados se importan como hallazgos de seguridad")
+	progressInterval := flag.Duration("progress-interval", defaults.ProgressInterval, "Cada cuánto informar del progreso del pipeline (líneas, backlog y ritmo por handler); 0 = desactivado")
 
 	flag.Parse()
 
@@ -142,28 +382,77 @@ func ParseFlags() *Config {
 	})
 
 	list := cleanStringSlice(strings.Split(*tools, ","))
+	typeDirMap, err := parseTypeDirs(cleanStringSlice(strings.Split(*typeDirs, ",")))
+	if err != nil {
+		log.Fatalf("configuración inválida: %v", err)
+	}
+	rowLimits, err := parseReportRowLimits(cleanStringSlice(strings.Split(*reportRowLimits, ",")))
+	if err != nil {
+		log.Fatalf("configuración inválida: %v", err)
+	}
+	highlightRuleMap, err := parseHighlightRules(cleanStringSlice(strings.Split(*highlightRules, ",")))
+	if err != nil {
+		log.Fatalf("configuración inválida: %v", err)
+	}
 
 	cfg := &Config{
-		Target:             strings.TrimSpace(*target),
-		OutDir:             strings.TrimSpace(*outdir),
-		Workers:            *workers,
-		Active:             *active,
-		Tools:              list,
-		TimeoutS:           *timeout,
-		ToolTimeouts:       make(map[string]int),
-		Verbosity:          *verbosity,
-		Report:             *report,
-		Proxy:              strings.TrimSpace(*proxy),
-		ProxyCACert:        strings.TrimSpace(*proxyCA),
-		CensysAPIID:        strings.TrimSpace(*censysID),
-		CensysAPISecret:    strings.TrimSpace(*censysSecret),
-		Scope:              strings.TrimSpace(*scope),
-		Resume:             *resume,
-		CheckpointInterval: *checkpointInterval,
-		NoColor:            *noColor,
-		Compact:            *compact,
-		LogWidth:           *logWidth,
+		Target:              strings.TrimSpace(*target),
+		TargetsFile:         strings.TrimSpace(*targetsFile),
+		OutDir:              strings.TrimSpace(*outdir),
+		Workers:             *workers,
+		LineBufferPerWorker: *lineBufferPerWorker,
+		LineBufferMin:       *lineBufferMin,
+		Active:              *active,
+		Tools:               list,
+		TimeoutS:            *timeout,
+		ToolTimeouts:        make(map[string]int),
+		TypeDirs:            typeDirMap,
+		Verbosity:           *verbosity,
+		Report:              *report,
+		ReportLang:          strings.ToLower(strings.TrimSpace(*reportLang)),
+		ReportRowLimits:     rowLimits,
+		HighlightRules:      highlightRuleMap,
+		Proxy:               strings.TrimSpace(*proxy),
+		ProxyAuth:           strings.TrimSpace(*proxyAuth),
+		ProxyCACert:         strings.TrimSpace(*proxyCA),
+		CensysAPIID:         strings.TrimSpace(*censysID),
+		CensysAPISecret:     strings.TrimSpace(*censysSecret),
+		Scope:               strings.TrimSpace(*scope),
+		ScopeFile:           strings.TrimSpace(*scopeFile),
+		ExcludeScope:        []string(excludeScope),
+		Resume:              *resume,
+		CheckpointInterval:  *checkpointInterval,
+		WriteBOM:            *writeBOM,
+		OutDirTemplate:      strings.TrimSpace(*outdirTemplate),
+		MinConfidence:       *minConfidence,
+		KafkaBrokers:        cleanStringSlice(strings.Split(*kafkaBrokers, ",")),
+		KafkaTopic:          strings.TrimSpace(*kafkaTopic),
+		StdoutStream:        *stdoutStream,
+		SplitByType:         *splitByType,
+		StripTracking:       *stripTracking,
+		TrackingParams:      cleanStringSlice(strings.Split(*trackingParams, ",")),
+		FlushRetries:        *flushRetries,
+		DedupWindow:         *dedupWindow,
+		ArtifactTTL:         *artifactTTL,
+		Upload:              strings.TrimSpace(*upload),
+		DryRun:              *dryRun,
+		DryRunAllowMissing:  *dryRunAllowMissing,
+		PreferWrapperTool:   *preferWrapperTool,
+		ActiveConcurrency:   *activeConcurrency,
+		ActiveHostDelay:     *activeHostDelay,
+		RateLimit:           *rateLimit,
+		NoColor:             *noColor,
+		Compact:             *compact,
+		LogWidth:            *logWidth,
 	}
+	cfg.CertExpiryWindowDays = *certExpiryDays
+	cfg.ProgressInterval = *progressInterval
+	cfg.CollapseTrailingSlash = *collapseSlash
+	cfg.NucleiImport = strings.TrimSpace(*nucleiImport)
+	cfg.Retries = *retries
+	cfg.RetryBackoff = *retryBackoff
+	cfg.AnalysisCache = *analysisCache
+	cfg.UnauthAPIProbe = *unauthAPIProbe
 
 	var fileCfg *fileConfig
 	if *configPath != "" {
@@ -185,66 +474,306 @@ func ParseFlags() *Config {
 	}
 
 	if fileCfg != nil {
-		if fileCfg.Target != nil && !setFlags["target"] {
-			cfg.Target = strings.TrimSpace(*fileCfg.Target)
-		}
-		if fileCfg.OutDir != nil && !setFlags["outdir"] {
-			cfg.OutDir = strings.TrimSpace(*fileCfg.OutDir)
-		}
-		if fileCfg.Workers != nil && !setFlags["workers"] {
-			cfg.Workers = *fileCfg.Workers
+		if err := fileCfg.applyTo(cfg, setFlags); err != nil {
+			log.Fatalf("configuración inválida: %v", err)
 		}
-		if fileCfg.Active != nil && !setFlags["active"] {
-			cfg.Active = *fileCfg.Active
+	}
+
+	if raw := strings.TrimSpace(*since); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			log.Fatalf("configuración inválida: since %q: debe ser una fecha 2006-01-02 o RFC3339", raw)
 		}
-		if fileCfg.Tools != nil && !setFlags["tools"] {
-			cfg.Tools = cleanStringSlice([]string(*fileCfg.Tools))
+		cfg.Since = parsed
+	}
+	if err := cfg.finalize(); err != nil {
+		log.Fatalf("configuración inválida: %v", err)
+	}
+
+	if *printConfig {
+		if err := cfg.Snapshot(printConfigOutput); err != nil {
+			log.Fatalf("no se pudo mostrar la configuración: %v", err)
 		}
-		if fileCfg.TimeoutS != nil && !setFlags["timeout"] {
-			cfg.TimeoutS = *fileCfg.TimeoutS
+		printConfigExit(0)
+	}
+
+	return cfg
+}
+
+// applyTo copia en cfg los valores presentes en el archivo de configuración,
+// salvo los de los flags indicados en setFlags, que tienen prioridad.
+func (fc *fileConfig) applyTo(cfg *Config, setFlags map[string]bool) error {
+	if fc.Target != nil && !setFlags["target"] {
+		cfg.Target = strings.TrimSpace(*fc.Target)
+	}
+	if fc.TargetsFile != nil && !setFlags["targets-file"] {
+		cfg.TargetsFile = strings.TrimSpace(*fc.TargetsFile)
+	}
+	if fc.OutDir != nil && !setFlags["outdir"] {
+		cfg.OutDir = strings.TrimSpace(*fc.OutDir)
+	}
+	if fc.Workers != nil && !setFlags["workers"] {
+		cfg.Workers = *fc.Workers
+	}
+	if fc.LineBufferWorker != nil && !setFlags["line-buffer-per-worker"] {
+		cfg.LineBufferPerWorker = *fc.LineBufferWorker
+	}
+	if fc.LineBufferMin != nil && !setFlags["line-buffer-min"] {
+		cfg.LineBufferMin = *fc.LineBufferMin
+	}
+	if fc.Active != nil && !setFlags["active"] {
+		cfg.Active = *fc.Active
+	}
+	if fc.Tools != nil && !setFlags["tools"] {
+		cfg.Tools = cleanStringSlice([]string(*fc.Tools))
+	}
+	if fc.TimeoutS != nil && !setFlags["timeout"] {
+		cfg.TimeoutS = *fc.TimeoutS
+	}
+	if len(fc.ToolTimeouts) > 0 {
+		cfg.ToolTimeouts = fc.ToolTimeouts
+	}
+	if len(fc.TypeDirs) > 0 && !setFlags["type-dirs"] {
+		entries := make([]string, 0, len(fc.TypeDirs))
+		for typ, dir := range fc.TypeDirs {
+			entries = append(entries, typ+"="+dir)
 		}
-		if len(fileCfg.ToolTimeouts) > 0 {
-			cfg.ToolTimeouts = fileCfg.ToolTimeouts
+		typeDirs, err := parseTypeDirs(entries)
+		if err != nil {
+			return err
 		}
-		if fileCfg.Verbosity != nil && !setFlags["v"] {
-			cfg.Verbosity = *fileCfg.Verbosity
+		cfg.TypeDirs = typeDirs
+	}
+	if fc.Verbosity != nil && !setFlags["v"] {
+		cfg.Verbosity = *fc.Verbosity
+	}
+	if fc.Report != nil && !setFlags["report"] {
+		cfg.Report = *fc.Report
+	}
+	if fc.ReportLang != nil && !setFlags["report-lang"] {
+		cfg.ReportLang = strings.ToLower(strings.TrimSpace(*fc.ReportLang))
+	}
+	if len(fc.ReportRowLimits) > 0 && !setFlags["report-row-limits"] {
+		entries := make([]string, 0, len(fc.ReportRowLimits))
+		for typ, limit := range fc.ReportRowLimits {
+			entries = append(entries, fmt.Sprintf("%s=%d", typ, limit))
 		}
-		if fileCfg.Report != nil && !setFlags["report"] {
-			cfg.Report = *fileCfg.Report
+		rowLimits, err := parseReportRowLimits(entries)
+		if err != nil {
+			return err
 		}
-		if fileCfg.Proxy != nil && !setFlags["proxy"] {
-			cfg.Proxy = strings.TrimSpace(*fileCfg.Proxy)
+		cfg.ReportRowLimits = rowLimits
+	}
+	if len(fc.HighlightRules) > 0 && !setFlags["highlight-rules"] {
+		entries := make([]string, 0, len(fc.HighlightRules))
+		for rule, severity := range fc.HighlightRules {
+			entries = append(entries, rule+"="+severity)
 		}
-		if fileCfg.ProxyCACert != nil && !setFlags["proxy-ca"] {
-			cfg.ProxyCACert = strings.TrimSpace(*fileCfg.ProxyCACert)
+		rules, err := parseHighlightRules(entries)
+		if err != nil {
+			return err
 		}
-		if fileCfg.CensysAPIID != nil && !setFlags["censys-api-id"] {
-			cfg.CensysAPIID = strings.TrimSpace(*fileCfg.CensysAPIID)
+		cfg.HighlightRules = rules
+	}
+	if fc.Proxy != nil && !setFlags["proxy"] {
+		cfg.Proxy = strings.TrimSpace(*fc.Proxy)
+	}
+	if fc.ProxyAuth != nil && !setFlags["proxy-auth"] {
+		cfg.ProxyAuth = strings.TrimSpace(*fc.ProxyAuth)
+	}
+	if fc.ProxyCACert != nil && !setFlags["proxy-ca"] {
+		cfg.ProxyCACert = strings.TrimSpace(*fc.ProxyCACert)
+	}
+	if fc.CensysAPIID != nil && !setFlags["censys-api-id"] {
+		cfg.CensysAPIID = strings.TrimSpace(*fc.CensysAPIID)
+	}
+	if fc.CensysAPISecret != nil && !setFlags["censys-api-secret"] {
+		cfg.CensysAPISecret = strings.TrimSpace(*fc.CensysAPISecret)
+	}
+	if fc.Scope != nil && !setFlags["scope"] {
+		cfg.Scope = strings.TrimSpace(*fc.Scope)
+	}
+	if fc.ScopeFile != nil && !setFlags["scope-file"] {
+		cfg.ScopeFile = strings.TrimSpace(*fc.ScopeFile)
+	}
+	if fc.ExcludeScope != nil && !setFlags["exclude-scope"] {
+		cfg.ExcludeScope = cleanStringSlice([]string(*fc.ExcludeScope))
+	}
+	if fc.Resume != nil && !setFlags["resume"] {
+		cfg.Resume = *fc.Resume
+	}
+	if fc.CheckpointInterval != nil && !setFlags["checkpoint-interval"] {
+		cfg.CheckpointInterval = *fc.CheckpointInterval
+	}
+	if fc.WriteBOM != nil && !setFlags["bom"] {
+		cfg.WriteBOM = *fc.WriteBOM
+	}
+	if fc.OutDirTemplate != nil && !setFlags["outdir-template"] {
+		cfg.OutDirTemplate = strings.TrimSpace(*fc.OutDirTemplate)
+	}
+	if fc.MinConfidence != nil && !setFlags["min-confidence"] {
+		cfg.MinConfidence = *fc.MinConfidence
+	}
+	if fc.KafkaBrokers != nil && !setFlags["kafka-brokers"] {
+		cfg.KafkaBrokers = cleanStringSlice([]string(*fc.KafkaBrokers))
+	}
+	if fc.KafkaTopic != nil && !setFlags["kafka-topic"] {
+		cfg.KafkaTopic = strings.TrimSpace(*fc.KafkaTopic)
+	}
+	if fc.StdoutStream != nil && !setFlags["stdout-stream"] {
+		cfg.StdoutStream = *fc.StdoutStream
+	}
+	if fc.SplitByType != nil && !setFlags["split-by-type"] {
+		cfg.SplitByType = *fc.SplitByType
+	}
+	if fc.StripTracking != nil && !setFlags["strip-tracking-params"] {
+		cfg.StripTracking = *fc.StripTracking
+	}
+	if fc.TrackingParams != nil && !setFlags["tracking-params"] {
+		cfg.TrackingParams = cleanStringSlice([]string(*fc.TrackingParams))
+	}
+	if fc.CollapseSlash != nil && !setFlags["collapse-trailing-slash"] {
+		cfg.CollapseTrailingSlash = *fc.CollapseSlash
+	}
+	if fc.FlushRetries != nil && !setFlags["flush-retries"] {
+		cfg.FlushRetries = *fc.FlushRetries
+	}
+	if fc.DedupWindow != nil && !setFlags["dedup-window"] {
+		cfg.DedupWindow = *fc.DedupWindow
+	}
+	if fc.ArtifactTTL != nil && !setFlags["artifact-ttl"] {
+		ttl, err := time.ParseDuration(strings.TrimSpace(*fc.ArtifactTTL))
+		if err != nil {
+			return fmt.Errorf("artifact_ttl %q: %v", *fc.ArtifactTTL, err)
 		}
-		if fileCfg.CensysAPISecret != nil && !setFlags["censys-api-secret"] {
-			cfg.CensysAPISecret = strings.TrimSpace(*fileCfg.CensysAPISecret)
+		cfg.ArtifactTTL = ttl
+	}
+	if fc.Since != nil && !setFlags["since"] {
+		raw := strings.TrimSpace(*fc.Since)
+		parsed, err := parseSince(raw)
+		if err != nil {
+			return fmt.Errorf("since %q: debe ser una fecha 2006-01-02 o RFC3339", raw)
 		}
-		if fileCfg.Scope != nil && !setFlags["scope"] {
-			cfg.Scope = strings.TrimSpace(*fileCfg.Scope)
+		cfg.Since = parsed
+	}
+	if fc.Upload != nil && !setFlags["upload"] {
+		cfg.Upload = strings.TrimSpace(*fc.Upload)
+	}
+	if fc.PreferWrapperTool != nil && !setFlags["prefer-wrapper-tool"] {
+		cfg.PreferWrapperTool = *fc.PreferWrapperTool
+	}
+	if fc.ActiveConcurrency != nil && !setFlags["active-concurrency"] {
+		cfg.ActiveConcurrency = *fc.ActiveConcurrency
+	}
+	if fc.ActiveHostDelay != nil && !setFlags["active-host-delay"] {
+		delay, err := time.ParseDuration(strings.TrimSpace(*fc.ActiveHostDelay))
+		if err != nil {
+			return fmt.Errorf("active_host_delay %q: %v", *fc.ActiveHostDelay, err)
 		}
-		if fileCfg.Resume != nil && !setFlags["resume"] {
-			cfg.Resume = *fileCfg.Resume
+		cfg.ActiveHostDelay = delay
+	}
+	if fc.RateLimit != nil && !setFlags["rate"] {
+		cfg.RateLimit = *fc.RateLimit
+	}
+	if fc.Retries != nil && !setFlags["retries"] {
+		cfg.Retries = *fc.Retries
+	}
+	if fc.RetryBackoff != nil && !setFlags["retry-backoff"] {
+		backoff, err := time.ParseDuration(strings.TrimSpace(*fc.RetryBackoff))
+		if err != nil {
+			return fmt.Errorf("retry_backoff %q: %v", *fc.RetryBackoff, err)
 		}
-		if fileCfg.CheckpointInterval != nil && !setFlags["checkpoint-interval"] {
-			cfg.CheckpointInterval = *fileCfg.CheckpointInterval
+		cfg.RetryBackoff = backoff
+	}
+	if fc.CertExpiryDays != nil && !setFlags["cert-expiry-days"] {
+		cfg.CertExpiryWindowDays = *fc.CertExpiryDays
+	}
+	if fc.ProgressInterval != nil && !setFlags["progress-interval"] {
+		interval, err := time.ParseDuration(strings.TrimSpace(*fc.ProgressInterval))
+		if err != nil {
+			return fmt.Errorf("progress_interval %q: %v", *fc.ProgressInterval, err)
 		}
+		cfg.ProgressInterval = interval
 	}
-
-	if cfg.OutDir == "" {
-		cfg.OutDir = "."
+	if fc.NucleiImport != nil && !setFlags["nuclei-import"] {
+		cfg.NucleiImport = strings.TrimSpace(*fc.NucleiImport)
+	}
+	if fc.AnalysisCache != nil && !setFlags["analysis-cache"] {
+		cfg.AnalysisCache = *fc.AnalysisCache
+	}
+	if fc.UnauthAPIProbe != nil && !setFlags["unauth-api-probe"] {
+		cfg.UnauthAPIProbe = *fc.UnauthAPIProbe
 	}
+	return nil
+}
 
-	// Validar scope
-	if err := validateScope(cfg.Scope); err != nil {
-		log.Fatalf("configuración inválida: %v", err)
+// finalize completa y valida la configuración ya combinada (defaults, archivo
+// y flags): valida rangos y combina ProxyAuth con Proxy.
+func (c *Config) finalize() error {
+	if c.OutDir == "" {
+		c.OutDir = "."
 	}
 
-	return cfg
+	if err := validateScope(c.Scope); err != nil {
+		return err
+	}
+	if c.Target != "" && c.TargetsFile != "" {
+		return errors.New("-target y -targets-file son incompatibles: indica un único target o un fichero de targets, no ambos")
+	}
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("min-confidence debe estar entre 0 y 1 (recibido %v)", c.MinConfidence)
+	}
+	if c.LineBufferPerWorker < 0 || c.LineBufferMin < 0 {
+		return errors.New("line-buffer-per-worker y line-buffer-min no pueden ser negativos")
+	}
+	if c.FlushRetries < 0 {
+		return fmt.Errorf("flush-retries no puede ser negativo (recibido %d)", c.FlushRetries)
+	}
+	if c.CertExpiryWindowDays <= 0 {
+		log.Printf("advertencia: cert-expiry-days debe ser positivo (recibido %d); se usan %d días", c.CertExpiryWindowDays, defaultCertExpiryWindowDays)
+		c.CertExpiryWindowDays = defaultCertExpiryWindowDays
+	}
+	if c.ReportLang != "es" && c.ReportLang != "en" {
+		return fmt.Errorf("report-lang debe ser \"es\" o \"en\" (recibido %q)", c.ReportLang)
+	}
+	if c.DedupWindow < 0 {
+		return fmt.Errorf("dedup-window no puede ser negativo (recibido %d)", c.DedupWindow)
+	}
+	if c.ActiveConcurrency < 0 {
+		return fmt.Errorf("active-concurrency no puede ser negativo (recibido %d)", c.ActiveConcurrency)
+	}
+	if c.ProgressInterval < 0 {
+		return fmt.Errorf("progress-interval no puede ser negativo (recibido %s)", c.ProgressInterval)
+	}
+	if c.ActiveHostDelay < 0 {
+		return fmt.Errorf("active-host-delay no puede ser negativo (recibido %s)", c.ActiveHostDelay)
+	}
+	if err := validateHighlightRules(c.HighlightRules); err != nil {
+		return err
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate no puede ser negativo (recibido %d)", c.RateLimit)
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("retries no puede ser negativo (recibido %d)", c.Retries)
+	}
+	if c.RetryBackoff < 0 {
+		return fmt.Errorf("retry-backoff no puede ser negativo (recibido %s)", c.RetryBackoff)
+	}
+	if c.ArtifactTTL < 0 {
+		return fmt.Errorf("artifact-ttl no puede ser negativo (recibido %s)", c.ArtifactTTL)
+	}
+	if err := validateUpload(c.Upload); err != nil {
+		return err
+	}
+	if c.ProxyAuth != "" {
+		merged, err := ProxyWithAuth(c.Proxy, c.ProxyAuth)
+		if err != nil {
+			return err
+		}
+		c.Proxy = merged
+	}
+	return nil
 }
 
 // validateScope verifica que el valor de scope sea válido
@@ -263,34 +792,180 @@ func validateScope(scope string) error {
 	return nil
 }
 
+// validateUpload comprueba que el destino de -upload tenga la forma
+// s3://bucket[/prefijo]. Vacío es válido (sin subida).
+func validateUpload(dst string) error {
+	if dst == "" {
+		return nil
+	}
+	rest, ok := strings.CutPrefix(dst, "s3://")
+	if !ok {
+		return fmt.Errorf("upload %q: solo se admiten destinos s3://bucket/prefijo", dst)
+	}
+	if bucket, _, _ := strings.Cut(rest, "/"); bucket == "" {
+		return fmt.Errorf("upload %q: falta el bucket", dst)
+	}
+	return nil
+}
+
 func loadConfigFile(path string) (*fileConfig, error) {
 	raw, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var cfg fileConfig
+	var (
+		cfg  fileConfig
+		keys map[string]any
+	)
 	ext := strings.ToLower(filepath.Ext(path))
 	switch ext {
 	case ".yaml", ".yml":
 		if err := yaml.Unmarshal(raw, &cfg); err != nil {
 			return nil, err
 		}
+		_ = yaml.Unmarshal(raw, &keys)
 	case ".json":
 		if err := json.Unmarshal(raw, &cfg); err != nil {
 			return nil, err
 		}
+		_ = json.Unmarshal(raw, &keys)
 	default:
 		if err := yaml.Unmarshal(raw, &cfg); err != nil {
 			if err := json.Unmarshal(raw, &cfg); err != nil {
 				return nil, err
 			}
+			_ = json.Unmarshal(raw, &keys)
+		} else {
+			_ = yaml.Unmarshal(raw, &keys)
 		}
 	}
 
+	for _, key := range unknownConfigKeys(keys) {
+		log.Printf("advertencia: clave desconocida %q en el archivo de configuración %s; se ignora", key, path)
+	}
+
 	return &cfg, nil
 }
 
+// unknownConfigKeys devuelve, ordenadas, las claves de primer nivel del
+// archivo que no corresponden a ningún campo de fileConfig.
+func unknownConfigKeys(keys map[string]any) []string {
+	known := make(map[string]struct{})
+	typ := reflect.TypeOf(fileConfig{})
+	for i := 0; i < typ.NumField(); i++ {
+		for _, tag := range []string{"yaml", "json"} {
+			if name, _, _ := strings.Cut(typ.Field(i).Tag.Get(tag), ","); name != "" {
+				known[name] = struct{}{}
+			}
+		}
+	}
+	var unknown []string
+	for key := range keys {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// parseTypeDirs convierte entradas "tipo=dir" en el mapa de directorios por
+// tipo de artefacto. Devuelve nil si no hay entradas.
+func parseTypeDirs(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	dirs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		typ, dir, ok := strings.Cut(entry, "=")
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		dir = strings.TrimSpace(dir)
+		if !ok || typ == "" || dir == "" {
+			return nil, fmt.Errorf("type-dirs: entrada inválida %q (formato tipo=dir)", entry)
+		}
+		dirs[typ] = dir
+	}
+	return dirs, nil
+}
+
+// parseReportRowLimits convierte entradas "tipo=n" en el mapa de filas
+// máximas por tipo de artefacto del informe. Devuelve nil si no hay entradas.
+func parseReportRowLimits(entries []string) (map[string]int, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	limits := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		typ, raw, ok := strings.Cut(entry, "=")
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		limit, err := strconv.Atoi(strings.TrimSpace(raw))
+		if !ok || typ == "" || err != nil || limit <= 0 {
+			return nil, fmt.Errorf("report-row-limits: entrada inválida %q (formato tipo=n con n > 0)", entry)
+		}
+		limits[typ] = limit
+	}
+	return limits, nil
+}
+
+// HighlightRuleNames son las reglas de highlight del informe que admite
+// highlight-rules. El informe valida con esta misma tabla.
+var HighlightRuleNames = map[string]struct{}{
+	"insecure-http":     {},
+	"nonstandard-ports": {},
+	"sensitive-paths":   {},
+	"sensitive-domains": {},
+	"expired-certs":     {},
+	"expiring-certs":    {},
+	"wildcard-certs":    {},
+	"risky-ports":       {},
+}
+
+// validateHighlightRules rechaza las reglas de highlight que el informe no
+// conoce, para fallar al arrancar en lugar de al generar el informe.
+func validateHighlightRules(rules map[string]string) error {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := HighlightRuleNames[name]; ok {
+			continue
+		}
+		known := make([]string, 0, len(HighlightRuleNames))
+		for rule := range HighlightRuleNames {
+			known = append(known, rule)
+		}
+		sort.Strings(known)
+		return apperrors.NewConfigurationError("highlight-rules", name, "regla de highlight desconocida", "reglas válidas: "+strings.Join(known, ", "))
+	}
+	return nil
+}
+
+// highlightSeverities son los valores admitidos en highlight-rules.
+var highlightSeverities = map[string]bool{"critical": true, "high": true, "medium": true, "low": true, "off": true}
+
+// parseHighlightRules convierte entradas "regla=severidad" en el mapa de reglas
+// de highlight del informe. Los nombres de regla los valida finalize.
+// Devuelve nil si no hay entradas.
+func parseHighlightRules(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	rules := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		rule, severity, ok := strings.Cut(entry, "=")
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if !ok || rule == "" || !highlightSeverities[severity] {
+			return nil, fmt.Errorf("highlight-rules: entrada inválida %q (formato regla=critical|high|medium|low|off)", entry)
+		}
+		rules[rule] = severity
+	}
+	return rules, nil
+}
+
 func cleanStringSlice(values []string) []string {
 	list := make([]string, 0, len(values))
 	for _, v := range values {
@@ -332,7 +1007,7 @@ func ApplyProxy(proxy string) error {
 
 	// Intentar verificar conectividad básica (sin bloquear si falla)
 	if err := validateProxyConnectivity(parsed); err != nil {
-		log.Printf("advertencia: no se pudo verificar conectividad del proxy %s: %v", proxy, err)
+		log.Printf("advertencia: no se pudo verificar conectividad del proxy %s: %v", RedactProxy(proxy), err)
 		log.Printf("continuando de todos modos, pero las peticiones pueden fallar si el proxy no está disponible")
 	}
 
@@ -342,9 +1017,56 @@ func ApplyProxy(proxy string) error {
 			return fmt.Errorf("no se pudo configurar %s: %w", key, err)
 		}
 	}
+	// http.ProxyFromEnvironment lee el entorno una sola vez, así que el
+	// transporte por defecto usa directamente la URL (con sus credenciales,
+	// que net/http envía en Proxy-Authorization).
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport.Proxy = http.ProxyURL(parsed)
+	}
 	return nil
 }
 
+// parseSince interpreta la fecha de -since como día (2006-01-02, medianoche
+// UTC) o como instante RFC3339.
+func parseSince(raw string) (time.Time, error) {
+	if day, err := time.Parse(time.DateOnly, raw); err == nil {
+		return day.UTC(), nil
+	}
+	ts, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.UTC(), nil
+}
+
+// ProxyWithAuth devuelve la URL del proxy con las credenciales auth
+// ("user:pass") embebidas y codificadas (los caracteres especiales de la
+// contraseña se escapan). Si la URL ya traía credenciales, auth las sustituye
+// y se avisa por el log.
+func ProxyWithAuth(proxy, auth string) (string, error) {
+	proxy = strings.TrimSpace(proxy)
+	auth = strings.TrimSpace(auth)
+	if auth == "" {
+		return proxy, nil
+	}
+	if proxy == "" {
+		return "", errors.New("proxy-auth requiere un proxy (-proxy)")
+	}
+	user, pass, ok := strings.Cut(auth, ":")
+	if !ok || user == "" {
+		return "", errors.New("proxy-auth inválido: formato esperado user:pass")
+	}
+	parsed, err := url.Parse(proxy)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("proxy inválido: %q (debe incluir esquema y host, ej: http://127.0.0.1:8080)", proxy)
+	}
+	if parsed.User != nil {
+		log.Printf("advertencia: el proxy %s ya incluye credenciales; se usan las de proxy-auth", RedactProxy(proxy))
+	}
+	parsed.User = url.UserPassword(user, pass)
+	return parsed.String(), nil
+}
+
 // validateProxyConnectivity performs a basic connectivity check to the proxy.
 // Returns an error if the proxy is unreachable, but this is non-fatal.
 func validateProxyConnectivity(proxyURL *url.URL) error {
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redactedValue sustituye a los valores sensibles en el snapshot.
+const redactedValue = "REDACTED"
+
+// configSnapshot es la representación serializable de la configuración
+// efectiva. Usa las mismas claves que el archivo de configuración para que el
+// snapshot pueda reutilizarse como punto de partida con -config.
+type configSnapshot struct {
+	Target             string            `json:"target"`
+	TargetsFile        string            `json:"targets_file,omitempty"`
+	OutDir             string            `json:"outdir"`
+	Workers            int               `json:"workers"`
+	LineBufferWorker   int               `json:"line_buffer_per_worker"`
+	LineBufferMin      int               `json:"line_buffer_min"`
+	Active             bool              `json:"active"`
+	Tools              []string          `json:"tools"`
+	TimeoutS           int               `json:"timeout"`
+	ToolTimeouts       map[string]int    `json:"tool_timeouts,omitempty"`
+	TypeDirs           map[string]string `json:"type_dirs,omitempty"`
+	Verbosity          int               `json:"verbosity"`
+	Report             bool              `json:"report"`
+	ReportLang         string            `json:"report_lang"`
+	ReportRowLimits    map[string]int    `json:"report_row_limits,omitempty"`
+	HighlightRules     map[string]string `json:"highlight_rules,omitempty"`
+	Proxy              string            `json:"proxy,omitempty"`
+	ProxyAuth          string            `json:"proxy_auth,omitempty"`
+	ProxyCACert        string            `json:"proxy_ca,omitempty"`
+	CensysAPIID        string            `json:"censys_api_id,omitempty"`
+	CensysAPISecret    string            `json:"censys_api_secret,omitempty"`
+	Scope              string            `json:"scope"`
+	ScopeFile          string            `json:"scope_file,omitempty"`
+	ExcludeScope       []string          `json:"exclude_scope,omitempty"`
+	Resume             bool              `json:"resume"`
+	CheckpointInterval int               `json:"checkpoint_interval"`
+	WriteBOM           bool              `json:"write_bom"`
+	OutDirTemplate     string            `json:"outdir_template,omitempty"`
+	MinConfidence      float64           `json:"min_confidence"`
+	KafkaBrokers       []string          `json:"kafka_brokers,omitempty"`
+	KafkaTopic         string            `json:"kafka_topic,omitempty"`
+	StdoutStream       bool              `json:"stdout_stream"`
+	SplitByType        bool              `json:"split_by_type"`
+	StripTracking      bool              `json:"strip_tracking_params"`
+	TrackingParams     []string          `json:"tracking_params,omitempty"`
+	CollapseSlash      bool              `json:"collapse_trailing_slash"`
+	FlushRetries       int               `json:"flush_retries"`
+	DedupWindow        int               `json:"dedup_window"`
+	ArtifactTTL        string            `json:"artifact_ttl"`
+	Since              string            `json:"since,omitempty"`
+	Upload             string            `json:"upload,omitempty"`
+	PreferWrapperTool  bool              `json:"prefer_wrapper_tool"`
+	ActiveConcurrency  int               `json:"active_concurrency"`
+	ActiveHostDelay    string            `json:"active_host_delay"`
+	RateLimit          int               `json:"rate_limit"`
+	Retries            int               `json:"retries"`
+	RetryBackoff       string            `json:"retry_backoff"`
+	CertExpiryDays     int               `json:"cert_expiry_days"`
+	ProgressInterval   string            `json:"progress_interval"`
+	NucleiImport       string            `json:"nuclei_import,omitempty"`
+	AnalysisCache      bool              `json:"analysis_cache"`
+	UnauthAPIProbe     bool              `json:"unauth_api_probe"`
+}
+
+// Snapshot escribe en w la configuración efectiva (flags + archivo) en formato
+// JSON. Los campos sensibles (credenciales del proxy y de Censys) se redactan.
+func (c *Config) Snapshot(w io.Writer) error {
+	if c == nil {
+		return nil
+	}
+	snap := configSnapshot{
+		Target:             c.Target,
+		TargetsFile:        c.TargetsFile,
+		OutDir:             c.OutDir,
+		Workers:            c.Workers,
+		LineBufferWorker:   c.LineBufferPerWorker,
+		LineBufferMin:      c.LineBufferMin,
+		Active:             c.Active,
+		Tools:              c.Tools,
+		TimeoutS:           c.TimeoutS,
+		ToolTimeouts:       c.ToolTimeouts,
+		TypeDirs:           c.TypeDirs,
+		Verbosity:          c.Verbosity,
+		Report:             c.Report,
+		ReportLang:         c.ReportLang,
+		ReportRowLimits:    c.ReportRowLimits,
+		HighlightRules:     c.HighlightRules,
+		Proxy:              RedactProxy(c.Proxy),
+		ProxyAuth:          redactSecret(c.ProxyAuth),
+		ProxyCACert:        c.ProxyCACert,
+		CensysAPIID:        redactSecret(c.CensysAPIID),
+		CensysAPISecret:    redactSecret(c.CensysAPISecret),
+		Scope:              c.Scope,
+		ScopeFile:          c.ScopeFile,
+		ExcludeScope:       c.ExcludeScope,
+		Resume:             c.Resume,
+		CheckpointInterval: c.CheckpointInterval,
+		WriteBOM:           c.WriteBOM,
+		OutDirTemplate:     c.OutDirTemplate,
+		MinConfidence:      c.MinConfidence,
+		KafkaBrokers:       c.KafkaBrokers,
+		KafkaTopic:         c.KafkaTopic,
+		StdoutStream:       c.StdoutStream,
+		SplitByType:        c.SplitByType,
+		StripTracking:      c.StripTracking,
+		TrackingParams:     c.TrackingParams,
+		CollapseSlash:      c.CollapseTrailingSlash,
+		FlushRetries:       c.FlushRetries,
+		DedupWindow:        c.DedupWindow,
+		ArtifactTTL:        c.ArtifactTTL.String(),
+		Since:              formatSince(c.Since),
+		Upload:             c.Upload,
+		PreferWrapperTool:  c.PreferWrapperTool,
+		ActiveConcurrency:  c.ActiveConcurrency,
+		ActiveHostDelay:    c.ActiveHostDelay.String(),
+		RateLimit:          c.RateLimit,
+		Retries:            c.Retries,
+		RetryBackoff:       c.RetryBackoff.String(),
+		CertExpiryDays:     c.CertExpiryWindowDays,
+		ProgressInterval:   c.ProgressInterval.String(),
+		NucleiImport:       c.NucleiImport,
+		AnalysisCache:      c.AnalysisCache,
+		UnauthAPIProbe:     c.UnauthAPIProbe,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+func redactSecret(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// RedactProxy elimina la contraseña embebida en la URL del proxy, conservando
+// esquema, usuario y host para que el snapshot (y los logs) sigan siendo útiles.
+func RedactProxy(proxy string) string {
+	proxy = strings.TrimSpace(proxy)
+	if proxy == "" {
+		return ""
+	}
+	parsed, err := url.Parse(proxy)
+	if err != nil {
+		return redactedValue
+	}
+	if parsed.User == nil {
+		return proxy
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), redactedValue)
+	}
+	return parsed.String()
+}
+
+func formatSince(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	return since.Format(time.RFC3339)
+}
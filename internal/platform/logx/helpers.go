@@ -2,6 +2,8 @@ package logx
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -129,6 +131,63 @@ func LogProgress(tool string, current, total int64, extra ...Fields) {
 	LogTool(LevelInfo, tool, "progress", fields)
 }
 
+// ProgressRate es el ritmo de un subcomponente (p. ej. un handler) desde el
+// informe de progreso anterior.
+type ProgressRate struct {
+	Name string
+	// PerSecond son las unidades procesadas por segundo en el intervalo.
+	PerSecond float64
+}
+
+// Progress loggea el progreso periódico de un componente de larga duración:
+// los contadores recibidos y el ritmo de sus subcomponentes, de mayor a menor.
+// No escribe nada si el nivel es inferior a info. En modo compacto solo se
+// incluye el subcomponente más activo; los colores siguen la configuración
+// del logger.
+func Progress(component string, counters Fields, rates []ProgressRate) {
+	cfg.mu.RLock()
+	level := cfg.level
+	compact := cfg.outputCfg.Compact
+	cfg.mu.RUnlock()
+	if level < LevelInfo {
+		return
+	}
+
+	fields := Fields{}
+	for k, v := range counters {
+		fields[k] = v
+	}
+	if throughput := formatProgressRates(rates, compact); throughput != "" {
+		fields["throughput"] = throughput
+	}
+	LogTool(LevelInfo, component, "progress", fields)
+}
+
+// formatProgressRates compone "handleRoute=120.0/s, handleDomain=3.5/s"
+// omitiendo los subcomponentes sin actividad en el intervalo.
+func formatProgressRates(rates []ProgressRate, compact bool) string {
+	active := make([]ProgressRate, 0, len(rates))
+	for _, rate := range rates {
+		if rate.PerSecond > 0 {
+			active = append(active, rate)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].PerSecond == active[j].PerSecond {
+			return active[i].Name < active[j].Name
+		}
+		return active[i].PerSecond > active[j].PerSecond
+	})
+	if compact && len(active) > 1 {
+		active = active[:1]
+	}
+	parts := make([]string, 0, len(active))
+	for _, rate := range active {
+		parts = append(parts, fmt.Sprintf("%s=%.1f/s", rate.Name, rate.PerSecond))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // LogBinary loggea información sobre un binario encontrado o faltante.
 func LogBinary(tool, binary, status string, extra ...Fields) {
 	fields := Fields{
@@ -0,0 +1,76 @@
+package netutil
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// emailPattern valida una dirección completa ya normalizada: parte local
+	// con los caracteres habituales y dominio con al menos dos etiquetas y un
+	// TLD alfabético.
+	emailPattern = regexp.MustCompile(`^[a-z0-9!#$%&'*+/=?^_{|}~-]+(?:\.[a-z0-9!#$%&'*+/=?^_{|}~-]+)*@(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+	// emailCandidatePattern localiza posibles direcciones dentro de texto libre.
+	emailCandidatePattern = regexp.MustCompile(`(?i)(?:mailto:)?[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,63}`)
+)
+
+// emailFileExtensions son "TLDs" que en realidad delatan nombres de fichero
+// como logo@2x.png, frecuentes en HTML y JS.
+var emailFileExtensions = map[string]struct{}{
+	"png": {}, "jpg": {}, "jpeg": {}, "gif": {}, "svg": {}, "webp": {}, "ico": {},
+	"js": {}, "css": {}, "map": {}, "woff": {}, "woff2": {},
+}
+
+// maxEmailLength es la longitud máxima de una dirección según RFC 5321.
+const maxEmailLength = 254
+
+// NormalizeEmail limpia una dirección de correo: elimina el prefijo mailto:,
+// los parámetros (?subject=...) y los delimitadores habituales (<>, comillas)
+// y la pasa a minúsculas. Devuelve la dirección y su dominio, o ok=false si
+// no es una dirección válida.
+func NormalizeEmail(raw string) (email, domain string, ok bool) {
+	candidate := strings.Trim(strings.TrimSpace(raw), " \t<>\"'(),")
+	if len(candidate) >= len("mailto:") && strings.EqualFold(candidate[:len("mailto:")], "mailto:") {
+		candidate = candidate[len("mailto:"):]
+	}
+	if i := strings.IndexAny(candidate, "?#"); i >= 0 {
+		candidate = candidate[:i]
+	}
+	// Un punto o punto y coma final suele cerrar la frase que contiene la dirección.
+	candidate = strings.ToLower(strings.TrimRight(candidate, ".;"))
+	if candidate == "" || len(candidate) > maxEmailLength || strings.Count(candidate, "@") != 1 {
+		return "", "", false
+	}
+	if !emailPattern.MatchString(candidate) {
+		return "", "", false
+	}
+	domain = candidate[strings.LastIndexByte(candidate, '@')+1:]
+	tld := domain[strings.LastIndexByte(domain, '.')+1:]
+	if _, isFile := emailFileExtensions[tld]; isFile {
+		return "", "", false
+	}
+	return candidate, domain, true
+}
+
+// ExtractEmails devuelve las direcciones válidas encontradas en text,
+// normalizadas con NormalizeEmail y sin duplicados, en orden de aparición.
+func ExtractEmails(text string) []string {
+	matches := emailCandidatePattern.FindAllString(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(matches))
+	var emails []string
+	for _, match := range matches {
+		email, _, ok := NormalizeEmail(match)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[email]; dup {
+			continue
+		}
+		seen[email] = struct{}{}
+		emails = append(emails, email)
+	}
+	return emails
+}
@@ -0,0 +1,47 @@
+package netutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw    string
+		email  string
+		domain string
+		ok     bool
+	}{
+		{raw: "Admin@Example.COM", email: "admin@example.com", domain: "example.com", ok: true},
+		{raw: "mailto:Security@Example.com?subject=hi", email: "security@example.com", domain: "example.com", ok: true},
+		{raw: "MAILTO:ops@mail.example.co.uk", email: "ops@mail.example.co.uk", domain: "mail.example.co.uk", ok: true},
+		{raw: "<mailto:first.last+tag@example.org>", email: "first.last+tag@example.org", domain: "example.org", ok: true},
+		{raw: "no-at-sign.example.com"},
+		{raw: "two@@example.com"},
+		{raw: "user@localhost"},
+		{raw: ".user@example.com"},
+		{raw: "us..er@example.com"},
+		{raw: "user@-example.com"},
+		{raw: "logo@2x.png"},
+		{raw: "@example.com"},
+	}
+	for _, tt := range tests {
+		email, domain, ok := NormalizeEmail(tt.raw)
+		if ok != tt.ok || email != tt.email || domain != tt.domain {
+			t.Errorf("NormalizeEmail(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.raw, email, domain, ok, tt.email, tt.domain, tt.ok)
+		}
+	}
+}
+
+func TestExtractEmails(t *testing.T) {
+	t.Parallel()
+
+	text := `contacto: <a href="mailto:Info@Example.com">info@example.com</a>, soporte: support@example.org; img src="logo@2x.png"`
+	got := ExtractEmails(text)
+	want := []string{"info@example.com", "support@example.org"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExtractEmails = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package netutil
+
+import (
+	"net"
+	"strings"
+)
+
+// Entornos no productivos reconocidos por DetectEnvironment.
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentTest        = "test"
+	EnvironmentStaging     = "staging"
+	EnvironmentUAT         = "uat"
+	EnvironmentQA          = "qa"
+)
+
+// environmentTokens asocia los fragmentos habituales en subdominios con el
+// entorno canónico que representan.
+var environmentTokens = map[string]string{
+	"dev":         EnvironmentDevelopment,
+	"devel":       EnvironmentDevelopment,
+	"develop":     EnvironmentDevelopment,
+	"development": EnvironmentDevelopment,
+	"test":        EnvironmentTest,
+	"testing":     EnvironmentTest,
+	"tst":         EnvironmentTest,
+	"stage":       EnvironmentStaging,
+	"staging":     EnvironmentStaging,
+	"stg":         EnvironmentStaging,
+	"preprod":     EnvironmentStaging,
+	"uat":         EnvironmentUAT,
+	"qa":          EnvironmentQA,
+}
+
+// DetectEnvironment devuelve el entorno no productivo (development, test,
+// staging, uat o qa) que sugieren los subdominios del host, o "" si no hay
+// ninguno. Se examinan las etiquetas a la izquierda del dominio registrable
+// aproximado (las dos últimas) divididas por "-" y "_", tolerando sufijos
+// numéricos como "dev2" o "qa01". Se devuelve la coincidencia más a la
+// izquierda.
+func DetectEnvironment(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if host == "" || net.ParseIP(host) != nil {
+		return ""
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return ""
+	}
+	for _, label := range labels[:len(labels)-2] {
+		for _, token := range strings.FieldsFunc(label, func(r rune) bool { return r == '-' || r == '_' }) {
+			token = strings.TrimRight(token, "0123456789")
+			if env, ok := environmentTokens[token]; ok {
+				return env
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package netutil
+
+import "testing"
+
+func TestDetectEnvironment(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"dev.example.com":             EnvironmentDevelopment,
+		"api.dev2.example.com":        EnvironmentDevelopment,
+		"staging.example.com":         EnvironmentStaging,
+		"app-stg.example.com":         EnvironmentStaging,
+		"uat.example.com":             EnvironmentUAT,
+		"qa01.example.com":            EnvironmentQA,
+		"test_api.example.com":        EnvironmentTest,
+		"www.example.com":             "",
+		"prod.example.com":            "",
+		"developers.example.com":      "",
+		"dev.com":                     "",
+		"staging-corp.com":            "",
+		"10.0.0.1":                    "",
+		"":                            "",
+		"Portal.Staging.Example.com.": EnvironmentStaging,
+	}
+
+	for host, want := range tests {
+		if got := DetectEnvironment(host); got != want {
+			t.Fatalf("DetectEnvironment(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
@@ -1,24 +1,48 @@
 package netutil
 
 import (
+	"bufio"
+	"context"
+	"fmt"
 	"net"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
+	"time"
 )
 
+// lookupHost resuelve un hostname para compararlo con los CIDR de un scope
+// cargado desde fichero. Se sustituye en los tests.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// scopeLookupTimeout acota cada resolución de lookupHost; un host que no
+// responde a tiempo se considera fuera de scope.
+var scopeLookupTimeout = 2 * time.Second
+
 // Scope representa los límites canónicos de un escaneo.
 type Scope struct {
-	hostname     string // host normalizado tal cual lo dio el usuario (subdominios incluidos)
-	ip           net.IP // si el objetivo es una IP
-	mode         string // "domain" o "subdomains" (por defecto "subdomains")
-	strictDomain bool   // true si mode == "domain", rechaza subdominios
+	hostname     string   // host normalizado tal cual lo dio el usuario (subdominios incluidos)
+	ip           net.IP   // si el objetivo es una IP
+	mode         string   // "domain" o "subdomains" (por defecto "subdomains")
+	strictDomain bool     // true si mode == "domain", rechaza subdominios
+	exclude      []string // dominios excluidos (junto con sus subdominios)
+
+	// Scope cargado desde fichero (ver NewScopeFromFile).
+	fromFile bool
+	domains  []string
+	networks []*net.IPNet
+	resolve  bool     // resolver hostnames contra networks (ver ResolveHostnames)
+	resolved sync.Map // hostname -> bool (alguna IP resuelta cae en networks)
 }
 
 // NewScope construye un Scope desde el target dado. Si no se puede
 // normalizar como dominio/IP válido, devuelve nil (sin filtrado).
 // El parámetro mode puede ser "domain" (solo dominio exacto) o "subdomains" (incluye subdominios).
 // Si mode está vacío, se usa "subdomains" por defecto.
-func NewScope(target string, mode string) *Scope {
+// Los dominios de exclude (y sus subdominios) quedan fuera del scope aunque
+// caigan bajo el target; se admite el prefijo "*." (ver DeniesDomain).
+func NewScope(target string, mode string, exclude ...string) *Scope {
 	normalized := NormalizeDomain(target)
 	if normalized == "" {
 		return nil
@@ -37,7 +61,7 @@ func NewScope(target string, mode string) *Scope {
 
 	// Caso IP
 	if ip := net.ParseIP(normalized); ip != nil {
-		return &Scope{hostname: normalized, ip: ip, mode: mode, strictDomain: strictDomain}
+		return &Scope{hostname: normalized, ip: ip, mode: mode, strictDomain: strictDomain, exclude: normalizeExclusions(exclude)}
 	}
 
 	// Caso dominio
@@ -45,9 +69,86 @@ func NewScope(target string, mode string) *Scope {
 		hostname:     normalized,
 		mode:         mode,
 		strictDomain: strictDomain,
+		exclude:      normalizeExclusions(exclude),
 	}
 }
 
+// normalizeExclusions normaliza los patrones de exclusión descartando los
+// vacíos, los inválidos y los repetidos.
+func normalizeExclusions(patterns []string) []string {
+	var out []string
+	seen := make(map[string]struct{}, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "*.")
+		normalized := NormalizeDomain(pattern)
+		if normalized == "" {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		out = append(out, normalized)
+	}
+	return out
+}
+
+// NewScopeFromFile construye un Scope a partir de un fichero con un dominio,
+// una IP o un CIDR por línea. Las líneas vacías y las que empiezan por "#" se
+// ignoran. Los dominios se comparan según mode (igual que NewScope) y las IPs
+// con los CIDR; los hostnames que no coinciden con ningún dominio solo se
+// resuelven contra los CIDR si se activa con ResolveHostnames. exclude se
+// aplica igual que en NewScope.
+func NewScopeFromFile(path string, mode string, exclude ...string) (*Scope, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mode = strings.TrimSpace(strings.ToLower(mode))
+	if mode == "" {
+		mode = "subdomains"
+	}
+	scope := &Scope{mode: mode, strictDomain: mode == "domain", fromFile: true, exclude: normalizeExclusions(exclude)}
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, "/") && !strings.Contains(line, "://") {
+			if _, network, err := net.ParseCIDR(line); err == nil {
+				scope.networks = append(scope.networks, network)
+				continue
+			}
+		}
+		normalized := NormalizeDomain(line)
+		if normalized == "" {
+			return nil, fmt.Errorf("scope %s:%d: entrada inválida %q", path, lineNo, line)
+		}
+		if ip := net.ParseIP(normalized); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			scope.networks = append(scope.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		scope.domains = append(scope.domains, normalized)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(scope.domains) == 0 && len(scope.networks) == 0 {
+		return nil, fmt.Errorf("scope %s: el fichero no contiene dominios ni CIDR", path)
+	}
+	return scope, nil
+}
+
 // AllowsDomain indica si el dominio proporcionado cae dentro del scope.
 func (s *Scope) AllowsDomain(candidate string) bool {
 	if s == nil {
@@ -59,6 +160,14 @@ func (s *Scope) AllowsDomain(candidate string) bool {
 		return false
 	}
 
+	if s.deniesNormalized(normalized) {
+		return false
+	}
+
+	if s.fromFile {
+		return s.allowsFromFile(normalized)
+	}
+
 	// Si el scope es IP, solo aceptamos esa misma IP exacta.
 	if s.ip != nil {
 		// El candidato debe ser IP y coincidir exactamente.
@@ -87,6 +196,70 @@ func (s *Scope) AllowsDomain(candidate string) bool {
 	return strings.HasSuffix(normalized, "."+s.hostname)
 }
 
+// DeniesDomain indica si el dominio coincide con alguna exclusión del scope o
+// es un subdominio de ella.
+func (s *Scope) DeniesDomain(domain string) bool {
+	if s == nil {
+		return false
+	}
+	normalized := NormalizeDomain(domain)
+	if normalized == "" {
+		return false
+	}
+	return s.deniesNormalized(normalized)
+}
+
+func (s *Scope) deniesNormalized(normalized string) bool {
+	for _, excluded := range s.exclude {
+		if normalized == excluded || strings.HasSuffix(normalized, "."+excluded) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty indica si las exclusiones cubren todo el scope: el target (o todos
+// los dominios del fichero, sin CIDR) está excluido y nada puede permitirse.
+func (s *Scope) Empty() bool {
+	if s == nil {
+		return false
+	}
+	if s.fromFile {
+		if len(s.networks) > 0 {
+			return false
+		}
+		for _, domain := range s.domains {
+			if !s.deniesNormalized(domain) {
+				return false
+			}
+		}
+		return true
+	}
+	return s.deniesNormalized(s.hostname)
+}
+
+// AllowsIP indica si una dirección IP cae dentro del scope. Un scope de IP solo
+// admite esa misma IP y uno cargado desde fichero, las IPs de sus CIDR (si
+// declara alguno). Un scope de dominio no puede decidirlo sin resolución
+// inversa, así que admite cualquier IP: las herramientas las obtienen al
+// resolver hosts que ya están en scope.
+func (s *Scope) AllowsIP(candidate string) bool {
+	if s == nil {
+		return true
+	}
+	ip := net.ParseIP(NormalizeDomain(candidate))
+	if ip == nil {
+		return false
+	}
+	if s.fromFile {
+		return len(s.networks) == 0 || s.containsIP(ip)
+	}
+	if s.ip != nil {
+		return s.ip.Equal(ip)
+	}
+	return true
+}
+
 // AllowsRoute indica si una ruta/URL pertenece al scope.
 // Las rutas relativas (sin host) siempre están permitidas.
 func (s *Scope) AllowsRoute(route string) bool {
@@ -136,3 +309,60 @@ func (s *Scope) AllowsRoute(route string) bool {
 	}
 	return s.AllowsDomain(host)
 }
+
+// ResolveHostnames activa la resolución DNS de los hostnames que no coinciden
+// con ningún dominio de un scope cargado desde fichero, para admitirlos si
+// alguna de sus IPs cae en los CIDR. Está desactivada por defecto porque
+// resolver hosts (incluidos los que quedan fuera de scope) es una acción
+// activa: el sink solo la habilita con --active. Cada resolución está acotada
+// por scopeLookupTimeout y su resultado se cachea por hostname.
+func (s *Scope) ResolveHostnames(enabled bool) {
+	if s != nil {
+		s.resolve = enabled
+	}
+}
+
+// allowsFromFile aplica un scope cargado desde fichero: las IPs se comparan con
+// los CIDR y los hostnames con los dominios o, si no coinciden y la resolución
+// está activa, por sus IPs resueltas.
+func (s *Scope) allowsFromFile(normalized string) bool {
+	if ip := net.ParseIP(normalized); ip != nil {
+		return s.containsIP(ip)
+	}
+	for _, domain := range s.domains {
+		if normalized == domain {
+			return true
+		}
+		if !s.strictDomain && strings.HasSuffix(normalized, "."+domain) {
+			return true
+		}
+	}
+	if len(s.networks) == 0 || !s.resolve {
+		return false
+	}
+	if cached, ok := s.resolved.Load(normalized); ok {
+		return cached.(bool)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), scopeLookupTimeout)
+	defer cancel()
+	allowed := false
+	if addrs, err := lookupHost(ctx, normalized); err == nil {
+		for _, addr := range addrs {
+			if ip := net.ParseIP(addr); ip != nil && s.containsIP(ip) {
+				allowed = true
+				break
+			}
+		}
+	}
+	s.resolved.Store(normalized, allowed)
+	return allowed
+}
+
+func (s *Scope) containsIP(ip net.IP) bool {
+	for _, network := range s.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeScopeFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scope.txt")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write scope file: %v", err)
+	}
+	return path
+}
+
+func TestNewScopeFromFileDomainsAndCIDRs(t *testing.T) {
+	original := lookupHost
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		switch host {
+		case "vpn.corp-hosting.net":
+			return []string{"10.20.30.40"}, nil
+		case "cdn.other.org":
+			return []string{"203.0.113.9"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	t.Cleanup(func() { lookupHost = original })
+
+	path := writeScopeFile(t, "# alcance del engagement\nexample.com\n\n10.20.0.0/16\n192.0.2.7\n")
+	scope, err := NewScopeFromFile(path, "subdomains")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	scope.ResolveHostnames(true)
+
+	cases := map[string]bool{
+		"example.com":                  true,
+		"api.example.com":              true,
+		"notexample.com":               false,
+		"10.20.1.1":                    true,
+		"10.21.0.1":                    false,
+		"192.0.2.7":                    true,
+		"192.0.2.8":                    false,
+		"vpn.corp-hosting.net":         true,
+		"cdn.other.org":                false,
+		"unresolvable.invalid":         false,
+		"https://10.20.5.5:8443/admin": true,
+	}
+	for candidate, want := range cases {
+		if got := scope.AllowsDomain(candidate); got != want {
+			t.Errorf("AllowsDomain(%q) = %v, want %v", candidate, got, want)
+		}
+	}
+	if !scope.AllowsRoute("https://vpn.corp-hosting.net/login") {
+		t.Errorf("expected route on a host resolving into the CIDR to be allowed")
+	}
+	if scope.AllowsRoute("https://cdn.other.org/app.js") {
+		t.Errorf("expected route on a host outside the scope to be denied")
+	}
+}
+
+func TestNewScopeFromFileDoesNotResolveByDefault(t *testing.T) {
+	original := lookupHost
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		t.Errorf("unexpected lookup of %q without ResolveHostnames", host)
+		return []string{"10.20.30.40"}, nil
+	}
+	t.Cleanup(func() { lookupHost = original })
+
+	scope, err := NewScopeFromFile(writeScopeFile(t, "example.com\n10.20.0.0/16\n"), "subdomains")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	if scope.AllowsDomain("vpn.corp-hosting.net") {
+		t.Fatalf("expected unresolved hostname to stay out of scope")
+	}
+	if !scope.AllowsDomain("api.example.com") || !scope.AllowsDomain("10.20.1.1") {
+		t.Fatalf("expected domains and IPs to match without resolving")
+	}
+}
+
+func TestNewScopeFromFileLookupTimeout(t *testing.T) {
+	originalLookup, originalTimeout := lookupHost, scopeLookupTimeout
+	lookupHost = func(ctx context.Context, _ string) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	scopeLookupTimeout = 10 * time.Millisecond
+	t.Cleanup(func() { lookupHost, scopeLookupTimeout = originalLookup, originalTimeout })
+
+	scope, err := NewScopeFromFile(writeScopeFile(t, "10.20.0.0/16\n"), "subdomains")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	scope.ResolveHostnames(true)
+	if scope.AllowsDomain("slow.example.net") {
+		t.Fatalf("expected a lookup that times out to be out of scope")
+	}
+}
+
+func TestNewScopeFromFileDomainMode(t *testing.T) {
+	path := writeScopeFile(t, "example.com\n")
+	scope, err := NewScopeFromFile(path, "domain")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	if !scope.AllowsDomain("example.com") || scope.AllowsDomain("www.example.com") {
+		t.Fatalf("domain mode should only allow the exact domain")
+	}
+}
+
+func TestNewScopeFromFileRejectsInvalidEntries(t *testing.T) {
+	if _, err := NewScopeFromFile(writeScopeFile(t, "example.com\nNo results found\n"), ""); err == nil {
+		t.Fatalf("expected error for invalid entry")
+	}
+	if _, err := NewScopeFromFile(writeScopeFile(t, "# vacío\n\n"), ""); err == nil {
+		t.Fatalf("expected error for empty scope file")
+	}
+}
+
+func TestScopeExclusions(t *testing.T) {
+	t.Parallel()
+
+	scope := NewScope("example.com", "subdomains", "legacy.example.com", "*.sandbox.example.com", "")
+
+	cases := map[string]bool{
+		"example.com":                   true,
+		"app.example.com":               true,
+		"legacy.example.com":            false,
+		"api.legacy.example.com":        false,
+		"sandbox.example.com":           false,
+		"dev.sandbox.example.com":       false,
+		"notlegacy.example.com":         true,
+		"https://legacy.example.com/x":  false,
+		"https://app.example.com/login": true,
+	}
+	for candidate, want := range cases {
+		if got := scope.AllowsRoute(candidate); got != want {
+			t.Errorf("AllowsRoute(%q) = %v, want %v", candidate, got, want)
+		}
+	}
+	if !scope.DeniesDomain("API.Legacy.Example.com") {
+		t.Fatalf("expected DeniesDomain to match subdomains of an exclusion case-insensitively")
+	}
+	if scope.DeniesDomain("app.example.com") {
+		t.Fatalf("app.example.com should not be denied")
+	}
+	if scope.Empty() {
+		t.Fatalf("scope with partial exclusions should not be empty")
+	}
+
+	if !NewScope("example.com", "subdomains", "example.com").Empty() {
+		t.Fatalf("excluding the target should produce an empty scope")
+	}
+}
+
+func TestNewScopeFromFileExclusions(t *testing.T) {
+	t.Parallel()
+
+	path := writeScopeFile(t, "example.com\nexample.org\n")
+	scope, err := NewScopeFromFile(path, "subdomains", "legacy.example.com")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	if scope.AllowsDomain("a.legacy.example.com") {
+		t.Fatalf("excluded subdomain should not be allowed")
+	}
+	if !scope.AllowsDomain("www.example.org") {
+		t.Fatalf("www.example.org should be allowed")
+	}
+}
+
+func TestScopeAllowsIP(t *testing.T) {
+	t.Parallel()
+
+	if !NewScope("example.com", "subdomains").AllowsIP("93.184.216.34") {
+		t.Fatalf("domain scope should accept resolved IPs")
+	}
+	if NewScope("example.com", "subdomains").AllowsIP("not-an-ip") {
+		t.Fatalf("invalid IPs should be rejected")
+	}
+	ipScope := NewScope("2001:db8::1", "subdomains")
+	if !ipScope.AllowsIP("[2001:DB8:0::1]") {
+		t.Fatalf("IP scope should accept the same IP in another notation")
+	}
+	if ipScope.AllowsIP("2001:db8::2") {
+		t.Fatalf("IP scope should reject other IPs")
+	}
+
+	path := writeScopeFile(t, "example.com\n10.0.0.0/24\n")
+	fileScope, err := NewScopeFromFile(path, "subdomains")
+	if err != nil {
+		t.Fatalf("NewScopeFromFile: %v", err)
+	}
+	if !fileScope.AllowsIP("10.0.0.77") || fileScope.AllowsIP("10.0.1.1") {
+		t.Fatalf("file scope should only accept IPs inside its CIDRs")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"passive-rec/internal/platform/netutil"
 )
@@ -19,6 +20,18 @@ type Writer struct {
 	closed bool
 }
 
+// utf8BOM es la marca de orden de bytes que algunas herramientas de Windows
+// esperan al inicio de los ficheros de texto UTF-8.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+var writeBOM atomic.Bool
+
+// SetWriteBOM activa o desactiva el BOM UTF-8 en los ficheros creados por New.
+// Por defecto está desactivado.
+func SetWriteBOM(enabled bool) {
+	writeBOM.Store(enabled)
+}
+
 func New(outdir, name string) (*Writer, error) {
 	if err := os.MkdirAll(outdir, 0755); err != nil {
 		return nil, err
@@ -28,6 +41,12 @@ func New(outdir, name string) (*Writer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if writeBOM.Load() {
+		if _, err := f.Write(utf8BOM); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
 	return &Writer{
 		file: f,
 		buf:  bufio.NewWriterSize(f, 64*1024),
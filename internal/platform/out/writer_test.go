@@ -1,6 +1,7 @@
 package out
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -172,3 +173,74 @@ func TestWriteRaw(t *testing.T) {
 		t.Fatalf("unexpected raw lines (-want +got):\n%s", diff)
 	}
 }
+
+func TestNewWritesBOMWhenEnabled(t *testing.T) {
+	// No paralelo: SetWriteBOM modifica estado global del paquete.
+	SetWriteBOM(true)
+	t.Cleanup(func() { SetWriteBOM(false) })
+
+	dir := t.TempDir()
+	w, err := New(dir, "domains.passive")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := w.WriteDomain("example.com"); err != nil {
+		t.Fatalf("WriteDomain: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "domains.passive"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := append(append([]byte{}, utf8BOM...), "example.com\n"...)
+	if !bytes.Equal(data, want) {
+		t.Fatalf("unexpected contents: %q, want %q", data, want)
+	}
+}
+
+func TestNewOmitsBOMByDefault(t *testing.T) {
+	dir := t.TempDir()
+	w, err := New(dir, "domains.passive")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := w.WriteDomain("example.com"); err != nil {
+		t.Fatalf("WriteDomain: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "domains.passive"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if bytes.HasPrefix(data, utf8BOM) {
+		t.Fatalf("unexpected BOM at start of file: %q", data)
+	}
+}
+
+func TestWriterFlushesEachWriteBeforeClose(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	w, err := New(dir, "routes.passive")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer w.Close()
+
+	if err := w.WriteURL("https://example.com/login"); err != nil {
+		t.Fatalf("WriteURL: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "routes.passive"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "https://example.com/login\n" {
+		t.Fatalf("expected write to be on disk before Close, got %q", data)
+	}
+}
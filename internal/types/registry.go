@@ -290,6 +290,16 @@ var Registry = map[string]TypeDef{
 		CheckScope:     false,
 		UseRawMetadata: false,
 	},
+	"finding.nuclei": {
+		Type:           "finding",
+		Subtype:        "nuclei",
+		KeyspacePrefix: "finding:nuclei",
+		OutputDir:      "findings",
+		OutputFile:     "nuclei",
+		Category:       CategorySecurity,
+		CheckScope:     true,
+		UseRawMetadata: false,
+	},
 
 	// ========================================================================
 	// SPECIAL